@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,11 +29,21 @@ type ICalendarAccess interface {
 	WriteDayFocus(day DayFocus) error
 
 	// GetRoutineCompletions returns every date on which routineID was
-	// checked, scanning all year files under calendar/. Dates are
+	// checked, across all year files under calendar/. Dates are
 	// returned in YYYY-MM-DD form, sorted ascending. Used by the
-	// PlanningManager to feed ScheduleEngine.Plan's overdue-priority
-	// rule with real cross-day completion history.
+	// PlanningManager to feed overdue, progress and stats computations
+	// and ScheduleEngine.Plan's overdue-priority rule with real
+	// cross-day completion history.
 	GetRoutineCompletions(routineID string) ([]string, error)
+
+	// RebuildRoutineCompletionIndex discards the completion index behind
+	// GetRoutineCompletions and rebuilds it from the year files, picking
+	// up edits made to them outside CalendarAccess.
+	RebuildRoutineCompletionIndex() error
+
+	// GetFocusYears returns the years that have a calendar file, sorted
+	// ascending.
+	GetFocusYears() ([]int, error)
 }
 
 // CalendarAccess implements ICalendarAccess with file-based storage and git versioning.
@@ -49,10 +60,15 @@ type ICalendarAccess interface {
 // Lock-ordering invariant: acquire CalendarAccess.mu before invoking
 // commitFiles (which internally takes the repository transaction lock).
 // Never invert this order.
+//
+// completions indexes routine ID to the sorted dates the routine was checked
+// on, across all year files. It is built from disk on first use, kept up to
+// date by writeDayFocusLocked, and guarded by mu like the files it mirrors.
 type CalendarAccess struct {
-	dataPath string
-	repo     utilities.IRepository
-	mu       sync.Mutex
+	dataPath    string
+	repo        utilities.IRepository
+	mu          sync.Mutex
+	completions map[string][]string
 }
 
 // NewCalendarAccess creates a new CalendarAccess instance.
@@ -212,6 +228,7 @@ func (ca *CalendarAccess) writeDayFocusLocked(day DayFocus, year int) (string, b
 	if err := writeJSON(filePath, YearFocusFile{Year: year, Entries: entries}); err != nil {
 		return "", found, err
 	}
+	ca.indexDayLocked(day)
 
 	return filePath, found, nil
 }
@@ -244,13 +261,14 @@ func (ca *CalendarAccess) getYearFocusLocked(year int) ([]DayFocus, error) {
 }
 
 // GetRoutineCompletions returns every date on which routineID appears in
-// DayFocus.RoutineChecks, scanning every <year>.json file under calendar/.
-// Result is YYYY-MM-DD strings sorted ascending. Missing calendar/ directory
-// yields an empty slice without error. Malformed year files are logged and
-// skipped — a single corrupt file must not poison the whole query.
+// DayFocus.RoutineChecks, as YYYY-MM-DD strings sorted ascending. Dates come
+// from the completion index, built on first use by scanning every
+// <year>.json file under calendar/. A missing calendar/ directory yields an
+// empty result without error. Malformed year files are logged and skipped
+// — a single corrupt file must not poison the whole query.
 //
-// Read-only path: takes ca.mu to keep the file-level snapshot consistent
-// with concurrent writers (RMW invariant).
+// Takes ca.mu to keep the index consistent with concurrent writers (RMW
+// invariant).
 func (ca *CalendarAccess) GetRoutineCompletions(routineID string) ([]string, error) {
 	if routineID == "" {
 		return nil, fmt.Errorf("CalendarAccess.GetRoutineCompletions: routineID cannot be empty")
@@ -259,47 +277,120 @@ func (ca *CalendarAccess) GetRoutineCompletions(routineID string) ([]string, err
 	ca.mu.Lock()
 	defer ca.mu.Unlock()
 
-	calendarDir := filepath.Join(ca.dataPath, "calendar")
-	dirEntries, err := os.ReadDir(calendarDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil
+	if ca.completions == nil {
+		if err := ca.buildCompletionIndexLocked(); err != nil {
+			return nil, fmt.Errorf("CalendarAccess.GetRoutineCompletions: %w", err)
 		}
-		return nil, fmt.Errorf("CalendarAccess.GetRoutineCompletions: failed to read calendar directory: %w", err)
 	}
+	return slices.Clone(ca.completions[routineID]), nil
+}
 
-	var dates []string
-	for _, entry := range dirEntries {
-		if entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		if !strings.HasSuffix(name, ".json") {
-			continue
-		}
-		yearStr := strings.TrimSuffix(name, ".json")
-		year, err := strconv.Atoi(yearStr)
-		if err != nil {
-			// Not a year file (e.g. a stray file). Skip silently.
-			continue
-		}
+// RebuildRoutineCompletionIndex rebuilds the completion index from the year
+// files.
+func (ca *CalendarAccess) RebuildRoutineCompletionIndex() error {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	if err := ca.buildCompletionIndexLocked(); err != nil {
+		return fmt.Errorf("CalendarAccess.RebuildRoutineCompletionIndex: %w", err)
+	}
+	return nil
+}
 
+// buildCompletionIndexLocked scans every year file into a fresh completion
+// index. Caller must hold ca.mu.
+func (ca *CalendarAccess) buildCompletionIndexLocked() error {
+	years, err := ca.focusYearsLocked()
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string][]string)
+	for _, year := range years {
 		entries, err := ca.getYearFocusLocked(year)
 		if err != nil {
-			slog.Warn("CalendarAccess.GetRoutineCompletions: skipping malformed year file",
+			slog.Warn("CalendarAccess: skipping malformed year file in completion index",
 				"year", year, "error", err)
 			continue
 		}
 		for _, df := range entries {
-			for _, rid := range df.RoutineChecks {
-				if rid == routineID {
-					dates = append(dates, df.Date.String())
-					break
-				}
+			for _, rid := range uniqueChecks(df.RoutineChecks) {
+				index[rid] = append(index[rid], df.Date.String())
+			}
+		}
+	}
+	for _, dates := range index {
+		sort.Strings(dates)
+	}
+	ca.completions = index
+	return nil
+}
+
+// indexDayLocked brings the completion index in line with a day just
+// written: the day's date is dropped from every routine, then added to the
+// routines checked on it. A no-op until the index is built. Caller must
+// hold ca.mu.
+func (ca *CalendarAccess) indexDayLocked(day DayFocus) {
+	if ca.completions == nil {
+		return
+	}
+	date := day.Date.String()
+	for rid, dates := range ca.completions {
+		if i, ok := slices.BinarySearch(dates, date); ok {
+			if dates = slices.Delete(dates, i, i+1); len(dates) == 0 {
+				delete(ca.completions, rid)
+				continue
 			}
+			ca.completions[rid] = dates
 		}
 	}
+	for _, rid := range uniqueChecks(day.RoutineChecks) {
+		dates := ca.completions[rid]
+		i, _ := slices.BinarySearch(dates, date)
+		ca.completions[rid] = slices.Insert(dates, i, date)
+	}
+}
+
+// uniqueChecks returns the routine IDs in checks without duplicates.
+func uniqueChecks(checks []string) []string {
+	var out []string
+	for _, rid := range checks {
+		if !slices.Contains(out, rid) {
+			out = append(out, rid)
+		}
+	}
+	return out
+}
+
+// GetFocusYears returns every year with a calendar/<year>.json file, sorted
+// ascending. Missing calendar/ directory yields an empty slice without error;
+// files whose name is not a year are ignored.
+func (ca *CalendarAccess) GetFocusYears() ([]int, error) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	return ca.focusYearsLocked()
+}
 
-	sort.Strings(dates)
-	return dates, nil
+// focusYearsLocked lists the year files under calendar/. Caller must hold ca.mu.
+func (ca *CalendarAccess) focusYearsLocked() ([]int, error) {
+	dirEntries, err := os.ReadDir(filepath.Join(ca.dataPath, "calendar"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []int{}, nil
+		}
+		return nil, fmt.Errorf("CalendarAccess.GetFocusYears: failed to read calendar directory: %w", err)
+	}
+	years := []int{}
+	for _, entry := range dirEntries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		year, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years, nil
 }
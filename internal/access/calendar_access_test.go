@@ -226,6 +226,102 @@ func TestUnit_CalendarAccess_GetRoutineCompletions_ScansAllYearFiles(t *testing.
 	}
 }
 
+// TestUnit_CalendarAccess_RoutineCheckDetails_RoundTrip verifies that year
+// files written before check-in details existed still load, and that details
+// are stored next to the plain routine IDs.
+func TestUnit_CalendarAccess_RoutineCheckDetails_RoundTrip(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	legacy := `{"year":2026,"entries":[{"date":"2026-05-01","notes":"","text":"","routineChecks":["R1","R2"]}]}`
+	if err := os.WriteFile(filepath.Join(env.dataDir, "calendar", "2026.json"), []byte(legacy), 0644); err != nil {
+		t.Fatalf("write legacy year file: %v", err)
+	}
+	day, err := env.calendar.GetDayFocus("2026-05-01")
+	if err != nil || day == nil {
+		t.Fatalf("GetDayFocus = %v, %v", day, err)
+	}
+	if !slicesEqual(day.RoutineChecks, []string{"R1", "R2"}) || day.RoutineCheckDetails != nil {
+		t.Fatalf("legacy day = %+v, want plain checks R1 and R2", day)
+	}
+
+	day.RoutineCheckDetails = []RoutineCheckDetail{{RoutineID: "R1", Amount: 5, Unit: "km", DurationMinutes: 31, Note: "Windy"}}
+	if err := env.calendar.SaveDayFocus(*day); err != nil {
+		t.Fatalf("SaveDayFocus: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(env.dataDir, "calendar", "2026.json"))
+	if err != nil {
+		t.Fatalf("read year file: %v", err)
+	}
+	var file struct {
+		Entries []map[string]json.RawMessage `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("parse year file: %v", err)
+	}
+	var checks []string
+	if err := json.Unmarshal(file.Entries[0]["routineChecks"], &checks); err != nil || !slicesEqual(checks, []string{"R1", "R2"}) {
+		t.Errorf("routineChecks = %s, want plain IDs", file.Entries[0]["routineChecks"])
+	}
+	var details []RoutineCheckDetail
+	if err := json.Unmarshal(file.Entries[0]["routineCheckDetails"], &details); err != nil || len(details) != 1 || details[0] != day.RoutineCheckDetails[0] {
+		t.Errorf("routineCheckDetails = %s, want %+v", file.Entries[0]["routineCheckDetails"], day.RoutineCheckDetails)
+	}
+}
+
+// TestUnit_CalendarAccess_CompletionIndex_FollowsWrites verifies that the
+// completion index tracks checks and unchecks written through CalendarAccess
+// and picks up edits made behind its back only once rebuilt.
+func TestUnit_CalendarAccess_CompletionIndex_FollowsWrites(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	write := func(date string, checks ...string) {
+		t.Helper()
+		if err := env.calendar.WriteDayFocus(DayFocus{Date: utilities.MustParseCalendarDate(date), RoutineChecks: checks}); err != nil {
+			t.Fatalf("WriteDayFocus %s: %v", date, err)
+		}
+	}
+	completions := func(routineID string) []string {
+		t.Helper()
+		dates, err := env.calendar.GetRoutineCompletions(routineID)
+		if err != nil {
+			t.Fatalf("GetRoutineCompletions(%s): %v", routineID, err)
+		}
+		return dates
+	}
+
+	write("2025-12-31", "R1")
+	if got := completions("R1"); !slicesEqual(got, []string{"2025-12-31"}) {
+		t.Fatalf("R1 = %v, want [2025-12-31]", got)
+	}
+
+	// Writes after the index is built update it in place.
+	write("2026-01-02", "R1", "R2", "R1")
+	write("2025-12-31", "R2")
+	if got := completions("R1"); !slicesEqual(got, []string{"2026-01-02"}) {
+		t.Errorf("R1 = %v, want [2026-01-02]", got)
+	}
+	if got := completions("R2"); !slicesEqual(got, []string{"2025-12-31", "2026-01-02"}) {
+		t.Errorf("R2 = %v, want both dates", got)
+	}
+
+	// An edit to a year file outside CalendarAccess shows after a rebuild.
+	edited := `{"year":2024,"entries":[{"date":"2024-06-01","notes":"","text":"","routineChecks":["R1"]}]}`
+	if err := os.WriteFile(filepath.Join(env.dataDir, "calendar", "2024.json"), []byte(edited), 0644); err != nil {
+		t.Fatalf("write year file: %v", err)
+	}
+	if got := completions("R1"); !slicesEqual(got, []string{"2026-01-02"}) {
+		t.Errorf("R1 before rebuild = %v, want [2026-01-02]", got)
+	}
+	if err := env.calendar.RebuildRoutineCompletionIndex(); err != nil {
+		t.Fatalf("RebuildRoutineCompletionIndex: %v", err)
+	}
+	if got := completions("R1"); !slicesEqual(got, []string{"2024-06-01", "2026-01-02"}) {
+		t.Errorf("R1 after rebuild = %v, want [2024-06-01 2026-01-02]", got)
+	}
+}
+
 // TestUnit_CalendarAccess_GetRoutineCompletions_NoCalendarDir verifies the
 // missing-directory edge case: a fresh CalendarAccess on a path without
 // calendar/ must yield an empty slice and no error. The constructor
@@ -258,6 +354,36 @@ func TestUnit_CalendarAccess_GetRoutineCompletions_EmptyRoutineID(t *testing.T)
 	}
 }
 
+// TestUnit_CalendarAccess_GetFocusYears lists year files in ascending order
+// and ignores files that are not named after a year.
+func TestUnit_CalendarAccess_GetFocusYears(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	for _, date := range []string{"2026-01-02", "2024-05-06", "2025-07-15"} {
+		if err := env.calendar.WriteDayFocus(DayFocus{Date: utilities.MustParseCalendarDate(date)}); err != nil {
+			t.Fatalf("seed %s: %v", date, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(env.dataDir, "calendar", "notes.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("write stray file: %v", err)
+	}
+
+	years, err := env.calendar.GetFocusYears()
+	if err != nil {
+		t.Fatalf("GetFocusYears: %v", err)
+	}
+	want := []int{2024, 2025, 2026}
+	if len(years) != len(want) {
+		t.Fatalf("GetFocusYears: got %v, want %v", years, want)
+	}
+	for i := range want {
+		if years[i] != want[i] {
+			t.Errorf("GetFocusYears[%d] = %d, want %d", i, years[i], want[i])
+		}
+	}
+}
+
 // slicesEqual is a tiny string-slice equality helper local to this test
 // file (the standard library's slices.Equal would also work; using a
 // local helper keeps imports minimal here).
@@ -0,0 +1,200 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// ICycleAccess defines the interface for OKR cycle data access operations.
+// All write operations use git versioning through transactions.
+type ICycleAccess interface {
+	GetCycles() ([]Cycle, error)
+	SaveCycle(cycle Cycle) error
+	DeleteCycle(id string) error
+
+	// WriteCycle persists a cycle without git-committing. Intended for use
+	// inside a manager-orchestrated utilities.RunTransaction so a single
+	// terminal commit covers writes spanning multiple Access components.
+	WriteCycle(cycle Cycle) error
+}
+
+// CycleAccess implements ICycleAccess with file-based storage and git versioning.
+//
+// mu serialises the full read-modify-write cycle on cycles.json and is always
+// acquired before the repository lock taken inside commitFiles.
+type CycleAccess struct {
+	dataPath string
+	repo     utilities.IRepository
+	mu       sync.Mutex
+}
+
+// NewCycleAccess creates a new CycleAccess instance.
+func NewCycleAccess(dataPath string, repo utilities.IRepository) (*CycleAccess, error) {
+	if dataPath == "" {
+		return nil, fmt.Errorf("CycleAccess.New: dataPath cannot be empty")
+	}
+	if repo == nil {
+		return nil, fmt.Errorf("CycleAccess.New: repo cannot be nil")
+	}
+
+	return &CycleAccess{
+		dataPath: dataPath,
+		repo:     repo,
+	}, nil
+}
+
+// cyclesFilePath returns the path to the cycles.json file.
+func (ca *CycleAccess) cyclesFilePath() string {
+	return filepath.Join(ca.dataPath, "cycles.json")
+}
+
+// GetCycles returns all cycles in stored order.
+func (ca *CycleAccess) GetCycles() ([]Cycle, error) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	return ca.getCyclesLocked()
+}
+
+// getCyclesLocked reads and parses cycles.json. The caller must hold ca.mu.
+func (ca *CycleAccess) getCyclesLocked() ([]Cycle, error) {
+	data, err := os.ReadFile(ca.cyclesFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Cycle{}, nil
+		}
+		return nil, fmt.Errorf("CycleAccess.GetCycles: failed to read cycles file: %w", err)
+	}
+
+	var cyclesFile CyclesFile
+	if err := json.Unmarshal(data, &cyclesFile); err != nil {
+		return nil, fmt.Errorf("CycleAccess.GetCycles: failed to parse cycles file: %w", err)
+	}
+
+	return cyclesFile.Cycles, nil
+}
+
+// SaveCycle saves or updates a single cycle.
+// The cycle ID must be set by the caller.
+func (ca *CycleAccess) SaveCycle(cycle Cycle) error {
+	if cycle.ID == "" {
+		return fmt.Errorf("CycleAccess.SaveCycle: cycle ID cannot be empty")
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	filePath, found, err := ca.writeCycleLocked(cycle)
+	if err != nil {
+		return fmt.Errorf("CycleAccess.SaveCycle: %w", err)
+	}
+
+	action := "Update"
+	if !found {
+		action = "Add"
+	}
+	if err := commitFiles(ca.repo, []string{filePath}, fmt.Sprintf("%s cycle: %s", action, cycle.Name)); err != nil {
+		return fmt.Errorf("CycleAccess.SaveCycle: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCycle writes a cycle to disk without git-committing. The caller is
+// expected to coordinate the terminal commit (typically via
+// utilities.RunTransaction at the manager layer).
+func (ca *CycleAccess) WriteCycle(cycle Cycle) error {
+	if cycle.ID == "" {
+		return fmt.Errorf("CycleAccess.WriteCycle: cycle ID cannot be empty")
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	if _, _, err := ca.writeCycleLocked(cycle); err != nil {
+		return fmt.Errorf("CycleAccess.WriteCycle: %w", err)
+	}
+	return nil
+}
+
+// writeCycleLocked performs the in-memory upsert and disk write without
+// committing. Caller must hold ca.mu. Returns the file path written and
+// whether the cycle already existed (false = newly added).
+func (ca *CycleAccess) writeCycleLocked(cycle Cycle) (string, bool, error) {
+	cycles, err := ca.getCyclesLocked()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get existing cycles: %w", err)
+	}
+
+	found := false
+	for i, c := range cycles {
+		if c.ID == cycle.ID {
+			cycles[i] = cycle
+			found = true
+			break
+		}
+	}
+	if !found {
+		cycles = append(cycles, cycle)
+	}
+
+	filePath := ca.cyclesFilePath()
+	if err := writeJSON(filePath, CyclesFile{Cycles: cycles}); err != nil {
+		return "", found, err
+	}
+
+	return filePath, found, nil
+}
+
+// DeleteCycle deletes a cycle by ID.
+func (ca *CycleAccess) DeleteCycle(id string) error {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	cycles, err := ca.getCyclesLocked()
+	if err != nil {
+		return fmt.Errorf("CycleAccess.DeleteCycle: %w", err)
+	}
+
+	var deleted *Cycle
+	remaining := make([]Cycle, 0, len(cycles))
+	for i := range cycles {
+		if cycles[i].ID == id {
+			deleted = &cycles[i]
+		} else {
+			remaining = append(remaining, cycles[i])
+		}
+	}
+	if deleted == nil {
+		return fmt.Errorf("CycleAccess.DeleteCycle: cycle with ID %s not found", id)
+	}
+
+	filePath := ca.cyclesFilePath()
+	if err := writeJSON(filePath, CyclesFile{Cycles: remaining}); err != nil {
+		return fmt.Errorf("CycleAccess.DeleteCycle: %w", err)
+	}
+	if err := commitFiles(ca.repo, []string{filePath}, fmt.Sprintf("Delete cycle: %s", deleted.Name)); err != nil {
+		return fmt.Errorf("CycleAccess.DeleteCycle: %w", err)
+	}
+
+	return nil
+}
+
+// NextCycleID scans existing cycle IDs for the CY{n} pattern and returns CY{max+1}.
+func NextCycleID(cycles []Cycle) string {
+	maxNum := 0
+	for _, c := range cycles {
+		if strings.HasPrefix(c.ID, "CY") {
+			if n, err := strconv.Atoi(c.ID[2:]); err == nil && n > maxNum {
+				maxNum = n
+			}
+		}
+	}
+	return fmt.Sprintf("CY%d", maxNum+1)
+}
@@ -0,0 +1,149 @@
+package access
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewCycleAccess_InvalidArguments(t *testing.T) {
+	if _, err := NewCycleAccess("", nil); err == nil {
+		t.Error("Expected error for empty dataPath")
+	}
+	if _, err := NewCycleAccess("/tmp/test", nil); err == nil {
+		t.Error("Expected error for nil repo")
+	}
+}
+
+func TestGetCycles_EmptyRepository(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cycles, err := env.cycles.GetCycles()
+	if err != nil {
+		t.Fatalf("GetCycles failed: %v", err)
+	}
+	if len(cycles) != 0 {
+		t.Errorf("Expected 0 cycles, got %d", len(cycles))
+	}
+}
+
+func TestSaveCycle_AddUpdateDelete(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	q1 := Cycle{ID: "CY1", Name: "2026 Q1", StartDate: "2026-01-01", EndDate: "2026-03-31"}
+	q2 := Cycle{ID: "CY2", Name: "2026 Q2", StartDate: "2026-04-01", EndDate: "2026-06-30"}
+	for _, c := range []Cycle{q1, q2} {
+		if err := env.cycles.SaveCycle(c); err != nil {
+			t.Fatalf("SaveCycle %s failed: %v", c.ID, err)
+		}
+	}
+
+	q1.ClosedAt = "2026-04-01T08:00:00Z"
+	if err := env.cycles.SaveCycle(q1); err != nil {
+		t.Fatalf("SaveCycle update failed: %v", err)
+	}
+
+	cycles, err := env.cycles.GetCycles()
+	if err != nil {
+		t.Fatalf("GetCycles failed: %v", err)
+	}
+	if len(cycles) != 2 || cycles[0] != q1 || cycles[1] != q2 {
+		t.Fatalf("Unexpected cycles: %+v", cycles)
+	}
+
+	if err := env.cycles.DeleteCycle("CY1"); err != nil {
+		t.Fatalf("DeleteCycle failed: %v", err)
+	}
+	cycles, _ = env.cycles.GetCycles()
+	if len(cycles) != 1 || cycles[0].ID != "CY2" {
+		t.Errorf("Expected only CY2 after delete, got %+v", cycles)
+	}
+}
+
+func TestSaveCycle_EmptyID(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	if err := env.cycles.SaveCycle(Cycle{Name: "No ID"}); err == nil {
+		t.Error("Expected error for empty cycle ID")
+	}
+}
+
+func TestDeleteCycle_NotFound(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	if err := env.cycles.DeleteCycle("CY9"); err == nil {
+		t.Error("Expected error when deleting a non-existent cycle")
+	}
+}
+
+func TestGetCycles_FileFormat(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	if err := env.cycles.SaveCycle(Cycle{ID: "CY1", Name: "2026 Q1", StartDate: "2026-01-01", EndDate: "2026-03-31"}); err != nil {
+		t.Fatalf("SaveCycle failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(env.dataDir, "cycles.json"))
+	if err != nil {
+		t.Fatalf("Failed to read cycles.json: %v", err)
+	}
+	var raw map[string][]map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Failed to parse cycles.json: %v", err)
+	}
+	if len(raw["cycles"]) != 1 || raw["cycles"][0]["startDate"] != "2026-01-01" {
+		t.Errorf("Unexpected file content: %s", data)
+	}
+	if _, ok := raw["cycles"][0]["closedAt"]; ok {
+		t.Error("Expected closedAt to be omitted for an open cycle")
+	}
+}
+
+func TestNextCycleID(t *testing.T) {
+	tests := []struct {
+		cycles []Cycle
+		want   string
+	}{
+		{nil, "CY1"},
+		{[]Cycle{{ID: "CY1"}, {ID: "CY2"}}, "CY3"},
+		{[]Cycle{{ID: "CY1"}, {ID: "CY7"}}, "CY8"},
+		{[]Cycle{{ID: "Q1"}, {ID: "CYx"}}, "CY1"},
+	}
+	for _, tt := range tests {
+		if got := NextCycleID(tt.cycles); got != tt.want {
+			t.Errorf("NextCycleID(%v) = %s, want %s", tt.cycles, got, tt.want)
+		}
+	}
+}
+
+// TestUnit_CycleAccess_WriteCycle_PersistsWithoutCommit verifies that
+// WriteCycle writes the cycle to disk but produces no git commit.
+func TestUnit_CycleAccess_WriteCycle_PersistsWithoutCommit(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	beforeHead := headCommitID(t, env.repo)
+
+	if err := env.cycles.WriteCycle(Cycle{ID: "CY1", Name: "2026 Q1"}); err != nil {
+		t.Fatalf("WriteCycle failed: %v", err)
+	}
+
+	saved, err := env.cycles.GetCycles()
+	if err != nil {
+		t.Fatalf("GetCycles failed: %v", err)
+	}
+	if len(saved) != 1 || saved[0].ID != "CY1" {
+		t.Fatalf("WriteCycle did not persist correctly: got %#v", saved)
+	}
+
+	afterHead := headCommitID(t, env.repo)
+	if beforeHead != afterHead {
+		t.Errorf("WriteCycle produced an unexpected commit: HEAD %q -> %q", beforeHead, afterHead)
+	}
+}
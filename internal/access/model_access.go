@@ -13,6 +13,25 @@ import (
 // defaultModelTimeout is the default timeout for model CLI invocations.
 const defaultModelTimeout = 60 * time.Second
 
+// ModelProviderClaudeCLI selects ClaudeCLIModelAccess.
+const ModelProviderClaudeCLI = "claude-cli"
+
+// ModelProviders lists the provider names accepted by NewModelAccess.
+func ModelProviders() []string {
+	return []string{ModelProviderClaudeCLI}
+}
+
+// NewModelAccess creates the IModelAccess implementation for provider.
+// If timeout is 0, the provider's default timeout is used.
+func NewModelAccess(provider string, timeout time.Duration) (IModelAccess, error) {
+	switch provider {
+	case ModelProviderClaudeCLI:
+		return NewClaudeCLIModelAccess(timeout), nil
+	default:
+		return nil, fmt.Errorf("ModelAccess.New: unknown provider %q", provider)
+	}
+}
+
 // ModelMessage is the minimal message type for model communication.
 type ModelMessage struct {
 	Role    string `json:"role"`    // "system", "user", "assistant"
@@ -16,6 +16,7 @@ type LifeTheme struct {
 	ID         string      `json:"id"`              // 1-3 uppercase letter abbreviation: H, CF, LRN
 	Name       string      `json:"name"`            // Human-readable theme name
 	Color      string      `json:"color"`           // Hex color code for UI display
+	Status     string      `json:"status,omitempty"` // Lifecycle status: paused, archived (empty = active)
 	Objectives []Objective `json:"objectives"`      // Associated objectives for this theme
 }
 
@@ -31,45 +32,138 @@ type Objective struct {
 	ClosingStatus string      `json:"closingStatus,omitempty"` // achieved, partially-achieved, missed, postponed, canceled
 	ClosingNotes  string      `json:"closingNotes,omitempty"`  // Reflection notes
 	ClosedAt      utilities.Timestamp `json:"closedAt,omitempty"`       // ISO 8601 timestamp
+	StartDate     utilities.CalendarDate `json:"startDate,omitempty"` // Start of the time window (optional)
+	EndDate       utilities.CalendarDate `json:"endDate,omitempty"`   // End of the time window (optional)
+	Weight        float64     `json:"weight,omitempty"`        // Relative weight in the parent's progress (0 = 1)
+	CycleID       string      `json:"cycleId,omitempty"`       // OKR cycle of a top-level objective (empty = none)
+	CreatedAt     utilities.Timestamp `json:"createdAt,omitempty"` // ISO 8601 creation timestamp (empty for older objectives)
 	KeyResults    []KeyResult `json:"keyResults"`              // Measurable key results
 	Objectives    []Objective `json:"objectives,omitempty"`    // Nested child objectives
+	Retrospective *Retrospective `json:"retrospective,omitempty"` // Record produced when the objective was closed
+}
+
+// Retrospective is the record stored on an objective when it is closed: how
+// its key results ended, the check-ins that got them there, and the tasks and
+// focus days that went into it.
+type Retrospective struct {
+	Since      utilities.Timestamp      `json:"since,omitempty"`     // Start of the objective's life (empty = unknown)
+	ClosedAt   utilities.Timestamp      `json:"closedAt"`            // When the objective was closed
+	KeyResults []RetrospectiveKeyResult `json:"keyResults"`          // Final state of the direct key results
+	Tasks      []RetrospectiveTask      `json:"tasks,omitempty"`     // Linked tasks completed during the objective's life
+	FocusDays  []utilities.CalendarDate `json:"focusDays,omitempty"` // Days whose focus referenced the objective or its key results
+}
+
+// RetrospectiveKeyResult is the final state of a key result in a retrospective.
+type RetrospectiveKeyResult struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	Type        string    `json:"type,omitempty"`
+	Direction   string    `json:"direction,omitempty"`
+	Unit        string    `json:"unit,omitempty"`
+	StartValue  float64   `json:"startValue"`
+	FinalValue  float64   `json:"finalValue"`
+	TargetValue float64   `json:"targetValue"`
+	Reached     bool      `json:"reached"`
+	CheckIns    []CheckIn `json:"checkIns,omitempty"` // Value timeline, oldest first
+}
+
+// RetrospectiveTask is a completed task counted towards an objective, linked
+// through the objective's theme or one of its tags.
+type RetrospectiveTask struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	ThemeID     string              `json:"themeId"`
+	Tags        []string            `json:"tags,omitempty"`
+	CompletedAt utilities.Timestamp `json:"completedAt"` // Last update of the done task
 }
 
 // KeyResult represents a measurable outcome for an objective.
 // Key results define how progress toward an objective is measured.
 type KeyResult struct {
-	ID           string `json:"id"`                     // Theme-scoped ID: H-KR1, CF-KR2
-	ParentID     string `json:"parentId"`               // ID of owning objective
-	Description  string `json:"description"`            // Description of the measurable result
-	Type         string `json:"type,omitempty"`         // KR type: "" or "metric" (default), "binary"
-	Status       string `json:"status,omitempty"`       // Lifecycle status: active, completed, archived (empty = active)
-	StartValue   int    `json:"startValue,omitempty"`   // Starting value (default 0)
-	CurrentValue int    `json:"currentValue,omitempty"` // Current progress value
-	TargetValue  int    `json:"targetValue,omitempty"`  // Target value (0 = untracked, 1 = binary)
+	ID           string  `json:"id"`                     // Theme-scoped ID: H-KR1, CF-KR2
+	ParentID     string  `json:"parentId"`               // ID of owning objective
+	Description  string  `json:"description"`            // Description of the measurable result
+	Type         string  `json:"type,omitempty"`         // KR type: "" or "metric" (default), "binary", "percentage", "milestone"
+	Status       string  `json:"status,omitempty"`       // Lifecycle status: active, completed, archived (empty = active)
+	Direction    string  `json:"direction,omitempty"`    // "increase" (default) or "decrease"
+	Unit         string  `json:"unit,omitempty"`         // Display unit, e.g. "h", "kg", "%"
+	Weight       float64 `json:"weight,omitempty"`       // Relative weight in the objective's progress (0 = 1)
+	StartValue   float64 `json:"startValue,omitempty"`   // Starting value (default 0)
+	CurrentValue float64 `json:"currentValue,omitempty"` // Value of the latest check-in (StartValue when none)
+	TargetValue  float64 `json:"targetValue,omitempty"`  // Target value (0 = untracked unless decreasing, 1 = binary)
+	CheckIns     []CheckIn `json:"checkIns,omitempty"`  // Dated progress history, oldest first
+	Milestones   []Milestone `json:"milestones,omitempty"` // Ordered steps of a milestone KR
+}
+
+// CheckIn is a single dated progress update on a key result.
+type CheckIn struct {
+	ID         string              `json:"id"`                   // KR-scoped ID: C1, C2
+	Value      float64             `json:"value"`                // Value recorded at this check-in
+	Timestamp  utilities.Timestamp `json:"timestamp"`            // When the value was observed
+	Note       string              `json:"note,omitempty"`       // Optional free-text note
+	Confidence int                 `json:"confidence,omitempty"` // Optional 1-10 confidence (0 = unset)
+}
+
+// Milestone is one named step of a milestone key result.
+type Milestone struct {
+	ID          string                 `json:"id"`                    // KR-scoped ID: M1, M2
+	Name        string                 `json:"name"`                  // Short description of the step
+	Done        bool                   `json:"done,omitempty"`        // Whether the step is complete
+	DueDate     utilities.CalendarDate `json:"dueDate,omitempty"`     // Optional planned completion date
+	CompletedAt utilities.Timestamp    `json:"completedAt,omitempty"` // When the step was marked done
+	Weight      float64                `json:"weight,omitempty"`      // Relative weight (0 = unweighted)
 }
 
 // RepeatPattern defines a recurrence schedule for a routine.
 type RepeatPattern struct {
-	Frequency  string `json:"frequency"`            // "daily", "weekly", "monthly", "yearly"
-	Interval   int    `json:"interval"`             // every N (default 1)
-	Weekdays   []int  `json:"weekdays,omitempty"`   // for weekly: 0=Sun..6=Sat
-	DayOfMonth int    `json:"dayOfMonth,omitempty"` // for monthly
-	StartDate  utilities.CalendarDate `json:"startDate"`  // YYYY-MM-DD
+	Frequency       string                 `json:"frequency"`                 // "daily", "weekly", "monthly", "yearly"
+	Interval        int                    `json:"interval"`                  // every N (default 1)
+	Weekdays        []int                  `json:"weekdays,omitempty"`        // for weekly, and monthly/yearly with WeekOfMonth: 0=Sun..6=Sat
+	DayOfMonth      int                    `json:"dayOfMonth,omitempty"`      // for monthly/yearly; negative counts from the end (-1 = last day)
+	WeekOfMonth     int                    `json:"weekOfMonth,omitempty"`     // for monthly/yearly: 1-5 = n-th of Weekdays in the month, -1 = last
+	Month           int                    `json:"month,omitempty"`           // for yearly: 1-12
+	RRule           string                 `json:"rrule,omitempty"`           // RFC 5545 RRULE; replaces the fields above when set
+	AfterCompletion bool                   `json:"afterCompletion,omitempty"` // due Interval Frequency-units after the latest completion; no fixed grid
+	StartDate       utilities.CalendarDate `json:"startDate"`                 // YYYY-MM-DD (DTSTART for RRule)
+	EndDate         utilities.CalendarDate `json:"endDate,omitempty"`         // last possible occurrence; empty = no end
+	Count           int                    `json:"count,omitempty"`           // number of occurrences from StartDate; 0 = unbounded
+	Pauses          []SchedulePause        `json:"pauses,omitempty"`          // windows without occurrences
+}
+
+// SchedulePause is an inclusive date range during which a routine does not recur.
+type SchedulePause struct {
+	From utilities.CalendarDate `json:"from"` // first paused date
+	To   utilities.CalendarDate `json:"to"`   // last paused date
 }
 
-// ScheduleException represents a single date override in a routine's schedule.
+// ScheduleException represents a single date override in a routine's schedule:
+// a reschedule, or a skip when NewDate is empty.
 type ScheduleException struct {
 	OriginalDate utilities.CalendarDate `json:"originalDate"` // suppressed occurrence date
-	NewDate      utilities.CalendarDate `json:"newDate"`      // replacement date
+	NewDate      utilities.CalendarDate `json:"newDate"`      // replacement date; empty = skipped
 }
 
 // Routine represents an ongoing activity tracked per occurrence for a life theme.
 // Periodic routines have a RepeatPattern; sporadic routines have none.
 type Routine struct {
-	ID            string              `json:"id"`                      // Unique routine ID: R{n}
-	Description   string              `json:"description"`             // What is being tracked
-	RepeatPattern *RepeatPattern      `json:"repeatPattern,omitempty"` // Recurrence schedule (nil = sporadic)
-	Exceptions    []ScheduleException `json:"exceptions,omitempty"`    // Date overrides for the schedule
+	ID              string                  `json:"id"`                        // Unique routine ID: R{n}
+	Description     string                  `json:"description"`               // What is being tracked
+	RepeatPattern   *RepeatPattern          `json:"repeatPattern,omitempty"`   // Recurrence schedule (nil = sporadic)
+	Exceptions      []ScheduleException     `json:"exceptions,omitempty"`      // Date overrides for the schedule
+	ThemeID         string                  `json:"themeId,omitempty"`         // Optional LifeTheme.ID the routine serves
+	KeyResultID     string                  `json:"keyResultId,omitempty"`     // Optional KeyResult.ID the routine serves, within ThemeID
+	FeedKeyResult   bool                    `json:"feedKeyResult,omitempty"`   // Record the routine's adherence as check-ins on KeyResultID
+	Materialization *RoutineMaterialization `json:"materialization,omitempty"` // How the routine turns into tasks; nil = on check, into todo
+}
+
+// RoutineMaterialization configures the tasks a routine turns into. Mode is
+// "on-check" (the default: a task when the routine is checked), "never" or
+// "ahead" (a task once an occurrence falls due).
+type RoutineMaterialization struct {
+	Mode          string   `json:"mode,omitempty"`          // "on-check", "never" or "ahead"; empty = "on-check"
+	Column        string   `json:"column,omitempty"`        // board column slug for new tasks; empty = todo
+	Tags          []string `json:"tags,omitempty"`          // replace the Routine tag when set
+	TitleTemplate string   `json:"titleTemplate,omitempty"` // task title; {description} and {date} are substituted
 }
 
 // ClosingStatus constants for objective closing workflow
@@ -87,6 +181,18 @@ const (
 	KRTypeMetric = "metric"
 	// KRTypeBinary is a binary KR type (done/not done) with fixed start=0, target=1
 	KRTypeBinary = "binary"
+	// KRTypePercentage is a metric KR whose values are percentages in 0-100
+	KRTypePercentage = "percentage"
+	// KRTypeMilestone is a KR measured by the share of its milestones completed (0-100)
+	KRTypeMilestone = "milestone"
+)
+
+// KRDirection constants for whether a key result's value should rise or fall
+const (
+	// KRDirectionIncrease means progress is made by raising the value (default)
+	KRDirectionIncrease = "increase"
+	// KRDirectionDecrease means progress is made by lowering the value
+	KRDirectionDecrease = "decrease"
 )
 
 // DayFocus represents the daily focus on one or more life themes.
@@ -99,6 +205,19 @@ type DayFocus struct {
 	OkrIDs         []string `json:"okrIds,omitempty"`          // Optional OKR item references (Objective/KR IDs)
 	Tags           []string `json:"tags,omitempty"`            // Optional day-level tags
 	RoutineChecks  []string `json:"routineChecks,omitempty"`   // IDs of routines checked off on this date
+	RoutineCheckDetails []RoutineCheckDetail `json:"routineCheckDetails,omitempty"` // Optional quantities for entries in RoutineChecks
+}
+
+// RoutineCheckDetail quantifies a routine check-in: how much was done, for
+// how long, and a short note. It belongs to a routine listed in the day's
+// RoutineChecks, which alone records that the routine was done; checks
+// without details have no entry.
+type RoutineCheckDetail struct {
+	RoutineID       string  `json:"routineId"`
+	Amount          float64 `json:"amount,omitempty"`          // Quantity done, in Unit
+	Unit            string  `json:"unit,omitempty"`            // Unit of Amount, e.g. "km"
+	DurationMinutes int     `json:"durationMinutes,omitempty"` // Time spent
+	Note            string  `json:"note,omitempty"`
 }
 
 // Task represents a single actionable item linked to a life theme.
@@ -220,6 +339,18 @@ const (
 	OKRStatusArchived OKRStatus = "archived"
 )
 
+// ThemeStatus represents the lifecycle status of a life theme
+type ThemeStatus string
+
+const (
+	// ThemeStatusActive represents a theme in use (stored as an empty status)
+	ThemeStatusActive ThemeStatus = "active"
+	// ThemeStatusPaused represents a theme that accepts no new tasks
+	ThemeStatusPaused ThemeStatus = "paused"
+	// ThemeStatusArchived represents a retired theme (hidden by default)
+	ThemeStatusArchived ThemeStatus = "archived"
+)
+
 // Slugify delegates to utilities.Slugify.
 // Deprecated: Use utilities.Slugify directly.
 func Slugify(title string) string {
@@ -273,6 +404,34 @@ type ThemesFile struct {
 	Themes []LifeTheme `json:"themes"`
 }
 
+// Cycle is a time-boxed OKR period, such as a quarter, that top-level
+// objectives belong to.
+type Cycle struct {
+	ID        string                 `json:"id"`                 // Global ID: CY1, CY2
+	Name      string                 `json:"name"`               // Display name, e.g. "2026 Q2"
+	StartDate utilities.CalendarDate `json:"startDate"`          // First day of the cycle
+	EndDate   utilities.CalendarDate `json:"endDate"`            // Last day of the cycle
+	ClosedAt  utilities.Timestamp    `json:"closedAt,omitempty"` // Set by rollover
+}
+
+// CyclesFile represents the structure of the cycles.json file
+type CyclesFile struct {
+	Cycles []Cycle `json:"cycles"`
+}
+
+// WeekPlan is the plan for one ISO week, between the day calendar and the
+// board: what the week is for, the goals and themes it works on and the
+// tasks committed to it. It is stored in weeks/{week}.json.
+type WeekPlan struct {
+	Week             string              `json:"week"`                       // ISO week: 2026-W12
+	Intentions       []string            `json:"intentions,omitempty"`       // Free-text weekly intentions
+	ObjectiveIDs     []string            `json:"objectiveIds,omitempty"`     // Selected objectives
+	KeyResultIDs     []string            `json:"keyResultIds,omitempty"`     // Selected key results
+	ThemeIDs         []string            `json:"themeIds,omitempty"`         // Themes to emphasise
+	CommittedTaskIDs []string            `json:"committedTaskIds,omitempty"` // Tasks committed to for the week
+	UpdatedAt        utilities.Timestamp `json:"updatedAt,omitempty"`        // Last save
+}
+
 // RoutinesFile represents the structure of the routines.json file
 type RoutinesFile struct {
 	Routines []Routine `json:"routines"`
@@ -313,3 +472,69 @@ type NavigationContext struct {
 	RoutinesCollapsed            *bool    `json:"routinesCollapsed,omitempty"`
 	SelectedTag                  string   `json:"selectedTag,omitempty"`
 }
+
+// Profile is a named data directory the user can switch between at runtime
+// (e.g. "Personal" and "Side project"). Each profile's data directory is a
+// complete, independently versioned Bearing data directory.
+type Profile struct {
+	ID        string              `json:"id"`                  // Stable slug, e.g. "side-project"
+	Name      string              `json:"name"`                // Display name
+	DataDir   string              `json:"dataDir"`             // Absolute path of the profile's data directory
+	CreatedAt utilities.Timestamp `json:"createdAt,omitempty"` // ISO 8601 creation timestamp
+}
+
+// ProfileRegistry is the on-disk shape of the profile registry file.
+type ProfileRegistry struct {
+	Profiles []Profile `json:"profiles"`
+	LastUsed string    `json:"lastUsed,omitempty"` // ID of the most recently opened profile
+}
+
+// Week start days accepted in Settings.WeekStart.
+const (
+	WeekStartMonday = "monday"
+	WeekStartSunday = "sunday"
+)
+
+// AutoArchiveMode selects how done tasks reach the archive.
+type AutoArchiveMode string
+
+const (
+	// AutoArchiveManual leaves done tasks on the board until archived by hand.
+	AutoArchiveManual AutoArchiveMode = "manual"
+	// AutoArchiveAfterDays archives done tasks that have not been touched for
+	// AutoArchiveSettings.Days days when a profile is opened.
+	AutoArchiveAfterDays AutoArchiveMode = "after-days"
+)
+
+// Settings is the on-disk shape of settings.json: application preferences
+// that belong to a data directory and travel with its git history.
+type Settings struct {
+	Version        int                 `json:"version"`                  // Layout version, see SettingsVersion
+	GitAuthor      GitAuthorSettings   `json:"gitAuthor"`                // Identity used for commits made by the app
+	WeekStart      string              `json:"weekStart"`                // WeekStartMonday or WeekStartSunday
+	Timezone       string              `json:"timezone,omitempty"`       // IANA zone name; empty means the system zone
+	DayStartHour   int                 `json:"dayStartHour,omitempty"`   // Hour at which a new day begins, 0 = midnight
+	Locale         string              `json:"locale,omitempty"`         // BCP 47 tag; empty means detect from the system
+	DefaultThemeID string              `json:"defaultThemeId,omitempty"` // Theme assigned to tasks created without one
+	Advisor        AdvisorSettings     `json:"advisor"`
+	AutoArchive    AutoArchiveSettings `json:"autoArchive"`
+}
+
+// GitAuthorSettings is the git identity for app commits.
+type GitAuthorSettings struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// AdvisorSettings configures the AI advisor and its model provider.
+type AdvisorSettings struct {
+	Enabled        bool   `json:"enabled"`
+	Provider       string `json:"provider"`       // One of the ModelProvider* constants
+	TimeoutSeconds int    `json:"timeoutSeconds"` // Per-request model timeout
+}
+
+// AutoArchiveSettings is the policy for archiving done tasks.
+type AutoArchiveSettings struct {
+	Mode AutoArchiveMode `json:"mode"`
+	Days int             `json:"days,omitempty"` // Only used by AutoArchiveAfterDays
+}
@@ -16,6 +16,8 @@ type testEnv struct {
 	calendar *CalendarAccess
 	vision   *VisionAccess
 	routines *RoutineAccess
+	cycles   *CycleAccess
+	weeks    *WeekAccess
 	repo     utilities.IRepository
 	dataDir  string
 }
@@ -81,12 +83,26 @@ func setupTestEnv(t *testing.T) (*testEnv, string, func()) {
 		t.Fatalf("Failed to create RoutineAccess: %v", err)
 	}
 
+	cyc, err := NewCycleAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		t.Fatalf("Failed to create CycleAccess: %v", err)
+	}
+
+	wk, err := NewWeekAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		t.Fatalf("Failed to create WeekAccess: %v", err)
+	}
+
 	cleanup := func() {
 		repo.Close()
 		os.RemoveAll(tmpDir)
 	}
 
-	return &testEnv{themes: themes, tasks: tasks, calendar: cal, vision: vis, routines: rtn, repo: repo, dataDir: dataDir}, tmpDir, cleanup
+	return &testEnv{themes: themes, tasks: tasks, calendar: cal, vision: vis, routines: rtn, cycles: cyc, weeks: wk, repo: repo, dataDir: dataDir}, tmpDir, cleanup
 }
 
 // setupTestPlanAccess is a backward-compatible helper that returns a TaskAccess
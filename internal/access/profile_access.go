@@ -0,0 +1,61 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// IProfileAccess defines the interface for persisting the profile registry.
+// The registry lives outside every profile's data directory and is not
+// git-versioned: it describes which repositories exist, not data within one.
+type IProfileAccess interface {
+	LoadProfiles() (*ProfileRegistry, error)
+	SaveProfiles(registry ProfileRegistry) error
+}
+
+// ProfileAccess implements IProfileAccess with a single JSON file.
+type ProfileAccess struct {
+	registryPath string
+}
+
+// NewProfileAccess creates a new ProfileAccess backed by registryPath.
+func NewProfileAccess(registryPath string) (*ProfileAccess, error) {
+	if registryPath == "" {
+		return nil, fmt.Errorf("ProfileAccess.New: registryPath cannot be empty")
+	}
+	return &ProfileAccess{registryPath: registryPath}, nil
+}
+
+// LoadProfiles reads the registry. A missing file yields an empty registry.
+func (pa *ProfileAccess) LoadProfiles() (*ProfileRegistry, error) {
+	data, err := os.ReadFile(pa.registryPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ProfileRegistry{Profiles: []Profile{}}, nil
+		}
+		return nil, fmt.Errorf("ProfileAccess.LoadProfiles: failed to read file: %w", err)
+	}
+
+	var registry ProfileRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("ProfileAccess.LoadProfiles: failed to parse file: %w", err)
+	}
+	if registry.Profiles == nil {
+		registry.Profiles = []Profile{}
+	}
+	return &registry, nil
+}
+
+// SaveProfiles persists the registry.
+// Note: This is application configuration, not versioned with git.
+func (pa *ProfileAccess) SaveProfiles(registry ProfileRegistry) error {
+	if err := ensureDir(filepath.Dir(pa.registryPath)); err != nil {
+		return fmt.Errorf("ProfileAccess.SaveProfiles: %w", err)
+	}
+	if err := writeJSON(pa.registryPath, registry); err != nil {
+		return fmt.Errorf("ProfileAccess.SaveProfiles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package access
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestUnit_ProfileAccess_LoadMissingRegistry(t *testing.T) {
+	pa, err := NewProfileAccess(filepath.Join(t.TempDir(), "profiles.json"))
+	if err != nil {
+		t.Fatalf("NewProfileAccess: %v", err)
+	}
+
+	registry, err := pa.LoadProfiles()
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if len(registry.Profiles) != 0 || registry.LastUsed != "" {
+		t.Errorf("expected empty registry, got %+v", registry)
+	}
+}
+
+func TestUnit_ProfileAccess_SaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "profiles.json")
+	pa, err := NewProfileAccess(path)
+	if err != nil {
+		t.Fatalf("NewProfileAccess: %v", err)
+	}
+
+	saved := ProfileRegistry{
+		Profiles: []Profile{
+			{ID: "default", Name: "Default", DataDir: "/data/default"},
+			{ID: "side", Name: "Side project", DataDir: "/data/side"},
+		},
+		LastUsed: "side",
+	}
+	if err := pa.SaveProfiles(saved); err != nil {
+		t.Fatalf("SaveProfiles: %v", err)
+	}
+
+	loaded, err := pa.LoadProfiles()
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if loaded.LastUsed != "side" || len(loaded.Profiles) != 2 || loaded.Profiles[1].DataDir != "/data/side" {
+		t.Errorf("round trip mismatch: %+v", loaded)
+	}
+}
+
+func TestUnit_ProfileAccess_EmptyPathRejected(t *testing.T) {
+	if _, err := NewProfileAccess(""); err == nil {
+		t.Error("expected error for empty registry path")
+	}
+}
@@ -0,0 +1,155 @@
+package access
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// SettingsVersion is the current layout version of settings.json.
+const SettingsVersion = 1
+
+// ErrSettingsTooNew is returned when settings.json was written by a newer
+// build. Loading it anyway would silently drop the fields this build does
+// not know about on the next save.
+var ErrSettingsTooNew = errors.New("settings file is newer than this build supports")
+
+// DefaultSettings returns the settings used for any field missing from
+// settings.json, and for the whole file when it does not exist.
+func DefaultSettings() Settings {
+	return Settings{
+		Version: SettingsVersion,
+		GitAuthor: GitAuthorSettings{
+			Name:  "Bearing App",
+			Email: "bearing@localhost",
+		},
+		WeekStart: WeekStartMonday,
+		Advisor: AdvisorSettings{
+			Provider:       ModelProviderClaudeCLI,
+			TimeoutSeconds: int(defaultModelTimeout.Seconds()),
+		},
+		AutoArchive: AutoArchiveSettings{Mode: AutoArchiveManual},
+	}
+}
+
+// ISettingsAccess defines the interface for settings persistence.
+// Writes use git versioning so preference changes are part of the history.
+type ISettingsAccess interface {
+	LoadSettings() (*Settings, error)
+	SaveSettings(settings Settings) error
+
+	// WriteSettings persists the settings without git-committing. Intended
+	// for use inside a manager-orchestrated utilities.RunTransaction so a
+	// single terminal commit covers writes spanning multiple Access
+	// components.
+	WriteSettings(settings Settings) error
+}
+
+// SettingsAccess implements ISettingsAccess with a single settings.json file.
+type SettingsAccess struct {
+	dataPath string
+	repo     utilities.IRepository
+	mu       sync.Mutex
+}
+
+// NewSettingsAccess creates a new SettingsAccess instance.
+func NewSettingsAccess(dataPath string, repo utilities.IRepository) (*SettingsAccess, error) {
+	if dataPath == "" {
+		return nil, fmt.Errorf("SettingsAccess.New: dataPath cannot be empty")
+	}
+	if repo == nil {
+		return nil, fmt.Errorf("SettingsAccess.New: repo cannot be nil")
+	}
+
+	return &SettingsAccess{
+		dataPath: dataPath,
+		repo:     repo,
+	}, nil
+}
+
+// settingsFilePath returns the path to settings.json inside dataPath.
+func settingsFilePath(dataPath string) string {
+	return filepath.Join(dataPath, "settings.json")
+}
+
+// ReadSettingsFile reads settings.json from dataPath without a repository.
+// Bootstrap uses it to pick the git author before the repository is opened.
+// Fields absent from the file take their DefaultSettings value.
+func ReadSettingsFile(dataPath string) (*Settings, error) {
+	settings := DefaultSettings()
+
+	data, err := os.ReadFile(settingsFilePath(dataPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &settings, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	if settings.Version > SettingsVersion {
+		return nil, fmt.Errorf("%w (file is v%d, build supports up to v%d)", ErrSettingsTooNew, settings.Version, SettingsVersion)
+	}
+	settings.Version = SettingsVersion
+	return &settings, nil
+}
+
+// LoadSettings retrieves the settings, filled in with defaults.
+func (sa *SettingsAccess) LoadSettings() (*Settings, error) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	settings, err := ReadSettingsFile(sa.dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("SettingsAccess.LoadSettings: %w", err)
+	}
+	return settings, nil
+}
+
+// SaveSettings persists the settings at the current layout version and
+// commits via git. Validation is the caller's responsibility.
+func (sa *SettingsAccess) SaveSettings(settings Settings) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	filePath, err := sa.writeSettingsLocked(settings)
+	if err != nil {
+		return fmt.Errorf("SettingsAccess.SaveSettings: %w", err)
+	}
+
+	if err := commitFiles(sa.repo, []string{filePath}, "Update settings"); err != nil {
+		return fmt.Errorf("SettingsAccess.SaveSettings: %w", err)
+	}
+
+	return nil
+}
+
+// WriteSettings writes the settings to disk without git-committing. The
+// caller is expected to coordinate the terminal commit (typically via
+// utilities.RunTransaction at the manager layer).
+func (sa *SettingsAccess) WriteSettings(settings Settings) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	if _, err := sa.writeSettingsLocked(settings); err != nil {
+		return fmt.Errorf("SettingsAccess.WriteSettings: %w", err)
+	}
+	return nil
+}
+
+// writeSettingsLocked writes the settings at the current layout version and
+// returns the path written. The caller must hold sa.mu.
+func (sa *SettingsAccess) writeSettingsLocked(settings Settings) (string, error) {
+	settings.Version = SettingsVersion
+	filePath := settingsFilePath(sa.dataPath)
+	if err := writeJSON(filePath, settings); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
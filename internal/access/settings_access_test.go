@@ -0,0 +1,96 @@
+package access
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func setupTestSettingsAccess(t *testing.T) (*SettingsAccess, string, utilities.IRepository) {
+	t.Helper()
+	dataDir := t.TempDir()
+	repo, err := utilities.InitializeRepositoryWithConfig(dataDir, &utilities.AuthorConfiguration{User: "Test User", Email: "test@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	sa, err := NewSettingsAccess(dataDir, repo)
+	if err != nil {
+		t.Fatalf("NewSettingsAccess failed: %v", err)
+	}
+	return sa, dataDir, repo
+}
+
+func TestUnit_SettingsAccess_MissingFileYieldsDefaults(t *testing.T) {
+	sa, _, _ := setupTestSettingsAccess(t)
+
+	settings, err := sa.LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if *settings != DefaultSettings() {
+		t.Errorf("expected defaults, got %+v", settings)
+	}
+}
+
+func TestUnit_SettingsAccess_PartialFileKeepsDefaults(t *testing.T) {
+	sa, dataDir, _ := setupTestSettingsAccess(t)
+	partial := `{"version":1,"weekStart":"sunday","advisor":{"enabled":true}}`
+	if err := os.WriteFile(filepath.Join(dataDir, "settings.json"), []byte(partial), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := sa.LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.WeekStart != WeekStartSunday || !settings.Advisor.Enabled {
+		t.Errorf("file values not applied: %+v", settings)
+	}
+	if settings.Advisor.Provider != ModelProviderClaudeCLI || settings.Advisor.TimeoutSeconds != 60 {
+		t.Errorf("missing advisor fields should keep defaults, got %+v", settings.Advisor)
+	}
+	if settings.GitAuthor.Name != "Bearing App" {
+		t.Errorf("GitAuthor.Name = %q, want default", settings.GitAuthor.Name)
+	}
+}
+
+func TestUnit_SettingsAccess_SaveCommitsAndRoundTrips(t *testing.T) {
+	sa, _, repo := setupTestSettingsAccess(t)
+	before := commitCount(t, repo)
+
+	settings := DefaultSettings()
+	settings.Version = 0
+	settings.Timezone = "Europe/Zurich"
+	settings.AutoArchive = AutoArchiveSettings{Mode: AutoArchiveAfterDays, Days: 14}
+	if err := sa.SaveSettings(settings); err != nil {
+		t.Fatalf("SaveSettings failed: %v", err)
+	}
+	if got := commitCount(t, repo) - before; got != 1 {
+		t.Errorf("expected 1 commit, got %d", got)
+	}
+
+	loaded, err := sa.LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	settings.Version = SettingsVersion
+	if *loaded != settings {
+		t.Errorf("round trip mismatch: got %+v, want %+v", loaded, settings)
+	}
+}
+
+func TestUnit_SettingsAccess_RefusesNewerVersion(t *testing.T) {
+	sa, dataDir, _ := setupTestSettingsAccess(t)
+	if err := os.WriteFile(filepath.Join(dataDir, "settings.json"), []byte(`{"version":99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sa.LoadSettings(); !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("expected ErrSettingsTooNew, got %v", err)
+	}
+}
@@ -40,8 +40,19 @@ type ITaskAccess interface {
 	FindTasksByTag(tag string) ([]TaggedTask, error)
 	LoadTaskOrder() (map[string][]string, error)
 	SaveTaskOrder(order map[string][]string) error
+	// WriteTaskOrder persists the order map without git-committing, for use
+	// inside a manager-orchestrated utilities.RunTransaction.
+	WriteTaskOrder(order map[string][]string) error
 	LoadArchivedOrder() ([]string, error)
+	// WriteArchivedOrder persists the archived order without
+	// git-committing, for use inside a manager-orchestrated
+	// utilities.RunTransaction.
+	WriteArchivedOrder(order []string) error
 	GetBoardConfiguration() (*BoardConfiguration, error)
+	// WriteRenameTheme moves every task of a theme to a new theme
+	// abbreviation without git-committing, for use inside a
+	// manager-orchestrated utilities.RunTransaction.
+	WriteRenameTheme(oldThemeID, newThemeID string) (map[string]string, error)
 }
 
 // TaskAccess implements ITaskAccess with file-based storage and git versioning.
@@ -285,10 +296,16 @@ func (ta *TaskAccess) GetTasksByStatus(status string) ([]Task, error) {
 	return tasks, nil
 }
 
-// saveTaskFile writes a task to disk without committing.
-// Returns the file path and whether the task is new.
+// saveTaskFile writes a task to disk without committing; a new task goes
+// into the todo column. Returns the file path and whether the task is new.
 func (ta *TaskAccess) saveTaskFile(task *Task) ([]string, bool, error) {
-	if task.ThemeID == "" && !slices.Contains(task.Tags, "Routine") {
+	return ta.saveTaskFileIn(task, string(TaskStatusTodo))
+}
+
+// saveTaskFileIn is saveTaskFile placing a new task in the newStatus column.
+// A task may lack a theme only when it materialises a routine.
+func (ta *TaskAccess) saveTaskFileIn(task *Task, newStatus string) ([]string, bool, error) {
+	if task.ThemeID == "" && task.RoutineRef == nil && !slices.Contains(task.Tags, "Routine") {
 		return nil, false, fmt.Errorf("TaskAccess.saveTaskFile: themeID cannot be empty")
 	}
 
@@ -307,7 +324,7 @@ func (ta *TaskAccess) saveTaskFile(task *Task) ([]string, bool, error) {
 	task.UpdatedAt = now
 
 	// Determine status
-	status := string(TaskStatusTodo)
+	status := newStatus
 	var affectedPaths []string
 	if !isNew {
 		existing, existingStatus, _, err := ta.findTaskInPlan(task.ID)
@@ -381,6 +398,17 @@ func (ta *TaskAccess) SaveTaskOrder(order map[string][]string) error {
 	return nil
 }
 
+// WriteTaskOrder writes the order map to task_order.json without committing.
+// The caller is expected to commit via utilities.RunTransaction.
+func (ta *TaskAccess) WriteTaskOrder(order map[string][]string) error {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	if err := ta.writeTaskOrder(order); err != nil {
+		return fmt.Errorf("TaskAccess.WriteTaskOrder: %w", err)
+	}
+	return nil
+}
+
 // archivedOrderFilePath returns the path to the archived_order.json file.
 func (ta *TaskAccess) archivedOrderFilePath() string {
 	return filepath.Join(ta.dataPath, "archived_order.json")
@@ -420,6 +448,17 @@ func (ta *TaskAccess) writeArchivedOrder(order []string) error {
 	return nil
 }
 
+// WriteArchivedOrder writes the order slice to archived_order.json without
+// committing. The caller is expected to commit via utilities.RunTransaction.
+func (ta *TaskAccess) WriteArchivedOrder(order []string) error {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	if err := ta.writeArchivedOrder(order); err != nil {
+		return fmt.Errorf("TaskAccess.WriteArchivedOrder: %w", err)
+	}
+	return nil
+}
+
 // boardConfigFilePath returns the path to the board configuration file.
 func (ta *TaskAccess) boardConfigFilePath() string {
 	return filepath.Join(ta.dataPath, "board_config.json")
@@ -525,6 +564,118 @@ func (ta *TaskAccess) generateTaskID(themeAbbr string) string {
 	return fmt.Sprintf("%s-T%d", themeAbbr, maxNum+1)
 }
 
+// WriteRenameTheme rewrites the tasks of theme oldThemeID to belong to
+// newThemeID, without committing. Task IDs carrying the old abbreviation
+// ("H-T12") are renamed to the new one ("WB-T12") in every status
+// directory, including archived, and in task_order.json and
+// archived_order.json. Returns the old-to-new mapping of renamed task IDs.
+//
+// All renames are checked before anything is written: if a target file
+// already exists the call fails without touching the disk. A failed write
+// removes the files already created so the old ones stay authoritative.
+func (ta *TaskAccess) WriteRenameTheme(oldThemeID, newThemeID string) (map[string]string, error) {
+	if oldThemeID == "" || newThemeID == "" {
+		return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: theme IDs cannot be empty")
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	type rename struct {
+		task    Task
+		oldPath string
+		newPath string
+	}
+	mapping := make(map[string]string)
+	var renames []rename
+	for _, status := range ta.allStatusSlugs() {
+		tasks, err := ta.GetTasksByStatus(status)
+		if err != nil {
+			return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: %w", err)
+		}
+		for _, task := range tasks {
+			newID := task.ID
+			if rest, ok := strings.CutPrefix(task.ID, oldThemeID+"-T"); ok {
+				if _, err := strconv.Atoi(rest); err == nil {
+					newID = newThemeID + "-T" + rest
+				}
+			}
+			if newID == task.ID && task.ThemeID != oldThemeID {
+				continue
+			}
+			r := rename{task: task, oldPath: ta.taskFilePath(status, task.ID), newPath: ta.taskFilePath(status, newID)}
+			if newID != task.ID {
+				if _, err := os.Stat(r.newPath); err == nil {
+					return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: task %s already exists", newID)
+				}
+				mapping[task.ID] = newID
+			}
+			if r.task.ThemeID == oldThemeID {
+				r.task.ThemeID = newThemeID
+			}
+			r.task.ID = newID
+			renames = append(renames, r)
+		}
+	}
+	if len(renames) == 0 {
+		return mapping, nil
+	}
+
+	for i, r := range renames {
+		if err := writeJSON(r.newPath, r.task); err != nil {
+			for _, done := range renames[:i] {
+				if done.newPath != done.oldPath {
+					_ = os.Remove(done.newPath)
+				}
+			}
+			return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: %w", err)
+		}
+	}
+	for _, r := range renames {
+		if r.newPath == r.oldPath {
+			continue
+		}
+		if err := os.Remove(r.oldPath); err != nil {
+			return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: failed to remove %s: %w", r.oldPath, err)
+		}
+	}
+
+	if len(mapping) == 0 {
+		return mapping, nil
+	}
+	orderMap, err := ta.LoadTaskOrder()
+	if err != nil {
+		return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: %w", err)
+	}
+	for zone, ids := range orderMap {
+		orderMap[zone] = renameOrderIDs(ids, mapping)
+	}
+	if err := ta.writeTaskOrder(orderMap); err != nil {
+		return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: %w", err)
+	}
+	archived, err := ta.LoadArchivedOrder()
+	if err != nil {
+		return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: %w", err)
+	}
+	if err := ta.writeArchivedOrder(renameOrderIDs(archived, mapping)); err != nil {
+		return nil, fmt.Errorf("TaskAccess.WriteRenameTheme: %w", err)
+	}
+	return mapping, nil
+}
+
+// renameOrderIDs returns ids with every entry found in mapping replaced.
+func renameOrderIDs(ids []string, mapping map[string]string) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		if newID, ok := mapping[id]; ok {
+			out[i] = newID
+		} else {
+			out[i] = id
+		}
+	}
+	return out
+}
+
 // =============================================================================
 // ITask facet implementation
 // =============================================================================
@@ -3,6 +3,7 @@ package access
 import (
 	"fmt"
 	"os"
+	"slices"
 )
 
 // =============================================================================
@@ -295,7 +296,15 @@ func (ta *TaskAccess) commitLocked(req BatchRequest) (BatchOutcome, []string, st
 		create := req.Creates[i]
 		taskCopy := create.Task
 		taskCopy.ID = "" // force ID allocation under the lock
-		paths, _, err := ta.saveTaskFile(&taskCopy)
+		status := create.Status
+		if status == "" {
+			status = string(TaskStatusTodo)
+		}
+		if status == string(TaskStatusArchived) || !slices.Contains(ta.allStatusSlugs(), status) {
+			rollback()
+			return BatchOutcome{}, nil, "", nil, fmt.Errorf("TaskAccess.Commit: create failed: unknown column %q", status)
+		}
+		paths, _, err := ta.saveTaskFileIn(&taskCopy, status)
 		if err != nil {
 			rollback()
 			return BatchOutcome{}, nil, "", nil, fmt.Errorf("TaskAccess.Commit: create failed: %w", err)
@@ -339,3 +339,33 @@ func sameOrderMap(a, b map[string][]string) bool {
 	}
 	return true
 }
+
+func TestUnit_Commit_CreateInColumn(t *testing.T) {
+	t.Parallel()
+	env, _, cleanup := setupTestPlanAccess(t)
+	defer cleanup()
+
+	routineTask := Task{Title: "Stretch", RoutineRef: &RoutineRef{RoutineID: "R1", Date: "2026-05-01"}}
+	outcome, err := env.tasks.Commit(BatchRequest{
+		Creates: []TaskCreate{{Task: routineTask, DropZone: "doing", Status: "doing"}},
+	})
+	if err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+	if len(outcome.CreatedIDs) != 1 {
+		t.Fatalf("expected 1 created ID, got %v", outcome.CreatedIDs)
+	}
+	path := filepath.Join(env.tasks.taskDirPath("doing"), outcome.CreatedIDs[0]+".json")
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected created task file %s, got err=%v", path, err)
+	}
+
+	for _, status := range []string{"nope", string(TaskStatusArchived)} {
+		_, err := env.tasks.Commit(BatchRequest{
+			Creates: []TaskCreate{{Task: routineTask, DropZone: status, Status: status}},
+		})
+		if err == nil {
+			t.Errorf("Commit into %q: expected error, got nil", status)
+		}
+	}
+}
@@ -623,6 +623,123 @@ func TestUnit_ITask_Reorder_PreservesAbsentZones(t *testing.T) {
 	}
 }
 
+// TestUnit_TaskAccess_WriteTaskOrder_NoCommit verifies the non-committing
+// order write persists the map but leaves HEAD untouched.
+func TestUnit_TaskAccess_WriteTaskOrder_NoCommit(t *testing.T) {
+	env, _, cleanup := setupTestPlanAccess(t)
+	defer cleanup()
+
+	a := seedTaskInTodo(t, env, "H", "A", nil)
+	beforeHead := headCommitID(t, env.repo)
+
+	if err := env.tasks.WriteTaskOrder(map[string][]string{"todo": {a.ID}, "doing": {}}); err != nil {
+		t.Fatalf("WriteTaskOrder failed: %v", err)
+	}
+
+	order, _ := env.tasks.LoadTaskOrder()
+	if got := order["todo"]; len(got) != 1 || got[0] != a.ID {
+		t.Errorf("Expected todo = [%s], got %v", a.ID, got)
+	}
+	if afterHead := headCommitID(t, env.repo); afterHead != beforeHead {
+		t.Errorf("WriteTaskOrder produced an unexpected commit: HEAD %q -> %q", beforeHead, afterHead)
+	}
+}
+
+// TestUnit_TaskAccess_WriteArchivedOrder_NoCommit verifies the
+// non-committing archived order write persists the slice but leaves HEAD
+// untouched.
+func TestUnit_TaskAccess_WriteArchivedOrder_NoCommit(t *testing.T) {
+	env, _, cleanup := setupTestPlanAccess(t)
+	defer cleanup()
+
+	a := seedTaskInTodo(t, env, "H", "A", nil)
+	if err := env.tasks.Archive(a.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	beforeHead := headCommitID(t, env.repo)
+
+	if err := env.tasks.WriteArchivedOrder([]string{}); err != nil {
+		t.Fatalf("WriteArchivedOrder failed: %v", err)
+	}
+
+	if order, _ := env.tasks.LoadArchivedOrder(); len(order) != 0 {
+		t.Errorf("Expected empty archived order, got %v", order)
+	}
+	if afterHead := headCommitID(t, env.repo); afterHead != beforeHead {
+		t.Errorf("WriteArchivedOrder produced an unexpected commit: HEAD %q -> %q", beforeHead, afterHead)
+	}
+}
+
+// TestUnit_TaskAccess_WriteRenameTheme_RenamesAcrossStatuses verifies that
+// renaming a theme moves its task files in active and archived directories,
+// rewrites both order files, leaves other themes alone, and does not commit.
+func TestUnit_TaskAccess_WriteRenameTheme_RenamesAcrossStatuses(t *testing.T) {
+	env, _, cleanup := setupTestPlanAccess(t)
+	defer cleanup()
+
+	a := seedTaskInTodo(t, env, "H", "A", nil)
+	b := seedTaskInTodo(t, env, "H", "B", nil)
+	other := seedTaskInTodo(t, env, "W", "Other", nil)
+	if err := env.tasks.Archive(b.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	beforeHead := headCommitID(t, env.repo)
+
+	mapping, err := env.tasks.WriteRenameTheme("H", "HB")
+	if err != nil {
+		t.Fatalf("WriteRenameTheme failed: %v", err)
+	}
+	if len(mapping) != 2 || mapping[a.ID] != "HB-T1" || mapping[b.ID] != "HB-T2" {
+		t.Errorf("mapping = %v, want H-T1/H-T2 -> HB-T1/HB-T2", mapping)
+	}
+
+	todo, _ := env.tasks.GetTasksByStatus("todo")
+	ids := map[string]string{}
+	for _, task := range todo {
+		ids[task.ID] = task.ThemeID
+	}
+	if ids["HB-T1"] != "HB" || ids[other.ID] != "W" || len(ids) != 2 {
+		t.Errorf("todo tasks = %v, want HB-T1 in HB and %s untouched", ids, other.ID)
+	}
+	archived, _ := env.tasks.GetTasksByStatus(string(TaskStatusArchived))
+	if len(archived) != 1 || archived[0].ID != "HB-T2" || archived[0].ThemeID != "HB" {
+		t.Errorf("archived tasks = %+v, want HB-T2", archived)
+	}
+	if _, err := os.Stat(filepath.Join(env.dataDir, "tasks", "todo", a.ID+".json")); !os.IsNotExist(err) {
+		t.Errorf("old task file %s still exists", a.ID)
+	}
+
+	order, _ := env.tasks.LoadTaskOrder()
+	if got := order["todo"]; len(got) != 2 || got[0] != "HB-T1" || got[1] != other.ID {
+		t.Errorf("task order = %v, want [HB-T1 %s]", got, other.ID)
+	}
+	archivedOrder, _ := env.tasks.LoadArchivedOrder()
+	if len(archivedOrder) != 1 || archivedOrder[0] != "HB-T2" {
+		t.Errorf("archived order = %v, want [HB-T2]", archivedOrder)
+	}
+	if afterHead := headCommitID(t, env.repo); afterHead != beforeHead {
+		t.Errorf("WriteRenameTheme produced an unexpected commit: HEAD %q -> %q", beforeHead, afterHead)
+	}
+}
+
+// TestUnit_TaskAccess_WriteRenameTheme_RefusesExistingTarget verifies that a
+// rename colliding with an existing task file changes nothing.
+func TestUnit_TaskAccess_WriteRenameTheme_RefusesExistingTarget(t *testing.T) {
+	env, _, cleanup := setupTestPlanAccess(t)
+	defer cleanup()
+
+	a := seedTaskInTodo(t, env, "H", "A", nil)
+	_ = seedTaskInTodo(t, env, "W", "Leftover", nil)
+
+	if _, err := env.tasks.WriteRenameTheme("H", "W"); err == nil || !strings.Contains(err.Error(), "W-T1 already exists") {
+		t.Fatalf("expected collision error, got %v", err)
+	}
+	tasks, _ := env.tasks.GetTasksByTheme("H")
+	if len(tasks) != 1 || tasks[0].ID != a.ID {
+		t.Errorf("tasks of H = %+v, want %s unchanged", tasks, a.ID)
+	}
+}
+
 // TestUnit_ITask_ConcurrentMoveVsArchive_NoRace runs Move and Archive
 // concurrently against the same TaskAccess. -race must report nothing.
 func TestUnit_ITask_ConcurrentMoveVsArchive_NoRace(t *testing.T) {
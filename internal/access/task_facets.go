@@ -155,10 +155,12 @@ type PromoteOutcome struct {
 }
 
 // TaskCreate describes one task to be created as part of an
-// IBatch.Commit call.
+// IBatch.Commit call. Status names the board column the task lands in;
+// empty means todo.
 type TaskCreate struct {
 	Task     Task   `json:"task"`
 	DropZone string `json:"dropZone"`
+	Status   string `json:"status,omitempty"`
 }
 
 // BatchRequest is the input to IBatch.Commit. Creates and Deletes are
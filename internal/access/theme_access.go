@@ -26,6 +26,10 @@ type IThemeAccess interface {
 	// WriteDeleteTheme removes a theme without git-committing. Same usage
 	// rationale as WriteTheme.
 	WriteDeleteTheme(id string) error
+	// WriteRenameTheme replaces the theme stored under oldID with theme,
+	// which carries the new ID, keeping its position. Same usage rationale
+	// as WriteTheme.
+	WriteRenameTheme(oldID string, theme LifeTheme) error
 }
 
 // ThemeAccess implements IThemeAccess with file-based storage and git versioning.
@@ -225,6 +229,42 @@ func (ta *ThemeAccess) WriteDeleteTheme(id string) error {
 	return nil
 }
 
+// WriteRenameTheme stores theme in place of the theme with ID oldID without
+// git-committing. The caller rewrites the objective and key result IDs; IDs
+// left empty are assigned in the new theme's namespace and parent links are
+// re-derived. Fails if oldID is unknown or theme.ID belongs to another theme.
+func (ta *ThemeAccess) WriteRenameTheme(oldID string, theme LifeTheme) error {
+	if theme.ID == "" {
+		return fmt.Errorf("ThemeAccess.WriteRenameTheme: theme ID cannot be empty")
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	themes, err := ta.getThemesLocked()
+	if err != nil {
+		return fmt.Errorf("ThemeAccess.WriteRenameTheme: failed to get existing themes: %w", err)
+	}
+	idx := -1
+	for i, t := range themes {
+		switch t.ID {
+		case oldID:
+			idx = i
+		case theme.ID:
+			return fmt.Errorf("ThemeAccess.WriteRenameTheme: theme with ID %s already exists", theme.ID)
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("ThemeAccess.WriteRenameTheme: theme with ID %s not found", oldID)
+	}
+
+	themes[idx] = ta.ensureThemeIDs(theme, themes)
+	if err := writeJSON(ta.themesFilePath(), ThemesFile{Themes: themes}); err != nil {
+		return fmt.Errorf("ThemeAccess.WriteRenameTheme: %w", err)
+	}
+	return nil
+}
+
 // deleteThemeLocked performs the in-memory removal and disk write of a theme
 // deletion without committing. Caller must hold ta.mu. Returns the file path
 // written and the deleted theme (for use in commit messages).
@@ -223,6 +223,49 @@ func TestUnit_ThemeAccess_WriteDeleteTheme_RemovesWithoutCommit(t *testing.T) {
 	}
 }
 
+// TestUnit_ThemeAccess_WriteRenameTheme_KeepsPosition verifies that a renamed
+// theme stays in place, gets re-derived parent links, and is not committed.
+func TestUnit_ThemeAccess_WriteRenameTheme_KeepsPosition(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	for _, theme := range []LifeTheme{
+		{ID: "H", Name: "Health", Color: "#000", Objectives: []Objective{{Title: "Run", KeyResults: []KeyResult{{Description: "10k"}}}}},
+		{ID: "W", Name: "Work", Color: "#111"},
+	} {
+		if err := env.themes.SaveTheme(theme); err != nil {
+			t.Fatalf("seed SaveTheme failed: %v", err)
+		}
+	}
+	beforeHead := headCommitID(t, env.repo)
+
+	renamed := LifeTheme{ID: "HB", Name: "Health", Color: "#000", Objectives: []Objective{
+		{ID: "HB-O1", ParentID: "H", Title: "Run", KeyResults: []KeyResult{{ID: "HB-KR1", ParentID: "H-O1", Description: "10k"}}},
+	}}
+	if err := env.themes.WriteRenameTheme("H", renamed); err != nil {
+		t.Fatalf("WriteRenameTheme failed: %v", err)
+	}
+
+	saved, _ := env.themes.GetThemes()
+	if len(saved) != 2 || saved[0].ID != "HB" || saved[1].ID != "W" {
+		t.Fatalf("themes = %+v, want [HB W]", saved)
+	}
+	obj := saved[0].Objectives[0]
+	if obj.ParentID != "HB" || obj.KeyResults[0].ParentID != "HB-O1" {
+		t.Errorf("objective = %+v, want parent links re-derived", obj)
+	}
+	if afterHead := headCommitID(t, env.repo); afterHead != beforeHead {
+		t.Errorf("WriteRenameTheme produced an unexpected commit: HEAD %q -> %q", beforeHead, afterHead)
+	}
+
+	if err := env.themes.WriteRenameTheme("HB", LifeTheme{ID: "W", Name: "Clash"}); err == nil {
+		t.Error("expected error when renaming onto an existing theme ID")
+	}
+	if err := env.themes.WriteRenameTheme("X", LifeTheme{ID: "Y", Name: "Missing"}); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+}
+
 // TestUnit_ThemeAccess_SaveTheme_ProducesExactlyOneCommit guards against a
 // regression where the Write*/Save* refactor accidentally commits twice or
 // not at all.
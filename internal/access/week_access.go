@@ -0,0 +1,210 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// IWeekAccess defines the interface for weekly plan data access operations.
+// All write operations use git versioning through transactions.
+type IWeekAccess interface {
+	// GetWeekPlan returns the plan of an ISO week ("2026-W12"), or nil when
+	// none has been saved.
+	GetWeekPlan(week string) (*WeekPlan, error)
+	// GetWeekPlans returns every saved plan, oldest week first.
+	GetWeekPlans() ([]WeekPlan, error)
+	SaveWeekPlan(plan WeekPlan) error
+	DeleteWeekPlan(week string) error
+
+	// WriteWeekPlan persists a plan without git-committing. Intended for use
+	// inside a manager-orchestrated utilities.RunTransaction so a single
+	// terminal commit covers writes spanning multiple Access components.
+	WriteWeekPlan(plan WeekPlan) error
+}
+
+// WeekAccess implements IWeekAccess with one JSON file per week under
+// weeks/ and git versioning.
+//
+// mu serialises reads and writes of the week files and is always acquired
+// before the repository lock taken inside commitFiles.
+type WeekAccess struct {
+	dataPath string
+	repo     utilities.IRepository
+	mu       sync.Mutex
+}
+
+// NewWeekAccess creates a new WeekAccess instance.
+func NewWeekAccess(dataPath string, repo utilities.IRepository) (*WeekAccess, error) {
+	if dataPath == "" {
+		return nil, fmt.Errorf("WeekAccess.New: dataPath cannot be empty")
+	}
+	if repo == nil {
+		return nil, fmt.Errorf("WeekAccess.New: repo cannot be nil")
+	}
+
+	return &WeekAccess{
+		dataPath: dataPath,
+		repo:     repo,
+	}, nil
+}
+
+// weeksDirPath returns the path to the weeks directory.
+func (wa *WeekAccess) weeksDirPath() string {
+	return filepath.Join(wa.dataPath, "weeks")
+}
+
+// weekFilePath returns the path to the file of an ISO week. The week must
+// have been validated, as it becomes part of the path.
+func (wa *WeekAccess) weekFilePath(week string) string {
+	return filepath.Join(wa.weeksDirPath(), week+".json")
+}
+
+// GetWeekPlan returns the plan of an ISO week, or nil when none is saved.
+func (wa *WeekAccess) GetWeekPlan(week string) (*WeekPlan, error) {
+	if _, _, err := utilities.ParseISOWeek(week); err != nil {
+		return nil, fmt.Errorf("WeekAccess.GetWeekPlan: %w", err)
+	}
+
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+
+	plan, err := wa.readWeekPlanLocked(wa.weekFilePath(week))
+	if err != nil {
+		return nil, fmt.Errorf("WeekAccess.GetWeekPlan: %w", err)
+	}
+	return plan, nil
+}
+
+// readWeekPlanLocked reads and parses one week file, returning nil when it
+// does not exist. The caller must hold wa.mu.
+func (wa *WeekAccess) readWeekPlanLocked(filePath string) (*WeekPlan, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read week file %s: %w", filePath, err)
+	}
+
+	var plan WeekPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse week file %s: %w", filePath, err)
+	}
+	return &plan, nil
+}
+
+// GetWeekPlans returns every saved plan, oldest week first. Files whose
+// name is not an ISO week are ignored.
+func (wa *WeekAccess) GetWeekPlans() ([]WeekPlan, error) {
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+
+	entries, err := os.ReadDir(wa.weeksDirPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []WeekPlan{}, nil
+		}
+		return nil, fmt.Errorf("WeekAccess.GetWeekPlans: failed to read weeks directory: %w", err)
+	}
+
+	plans := []WeekPlan{}
+	for _, entry := range entries {
+		week, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if _, _, err := utilities.ParseISOWeek(week); err != nil {
+			continue
+		}
+		plan, err := wa.readWeekPlanLocked(wa.weekFilePath(week))
+		if err != nil {
+			return nil, fmt.Errorf("WeekAccess.GetWeekPlans: %w", err)
+		}
+		if plan != nil {
+			plans = append(plans, *plan)
+		}
+	}
+	// "YYYY-Www" sorts chronologically.
+	slices.SortFunc(plans, func(a, b WeekPlan) int { return strings.Compare(a.Week, b.Week) })
+	return plans, nil
+}
+
+// SaveWeekPlan saves or replaces the plan of its week.
+func (wa *WeekAccess) SaveWeekPlan(plan WeekPlan) error {
+	if _, _, err := utilities.ParseISOWeek(plan.Week); err != nil {
+		return fmt.Errorf("WeekAccess.SaveWeekPlan: %w", err)
+	}
+
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+
+	filePath, err := wa.writeWeekPlanLocked(plan)
+	if err != nil {
+		return fmt.Errorf("WeekAccess.SaveWeekPlan: %w", err)
+	}
+	if err := commitFiles(wa.repo, []string{filePath}, fmt.Sprintf("Save week plan: %s", plan.Week)); err != nil {
+		return fmt.Errorf("WeekAccess.SaveWeekPlan: %w", err)
+	}
+
+	return nil
+}
+
+// WriteWeekPlan writes a plan to disk without git-committing. The caller is
+// expected to coordinate the terminal commit (typically via
+// utilities.RunTransaction at the manager layer).
+func (wa *WeekAccess) WriteWeekPlan(plan WeekPlan) error {
+	if _, _, err := utilities.ParseISOWeek(plan.Week); err != nil {
+		return fmt.Errorf("WeekAccess.WriteWeekPlan: %w", err)
+	}
+
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+
+	if _, err := wa.writeWeekPlanLocked(plan); err != nil {
+		return fmt.Errorf("WeekAccess.WriteWeekPlan: %w", err)
+	}
+	return nil
+}
+
+// writeWeekPlanLocked writes a plan to its week file without committing and
+// returns the path written. The caller must hold wa.mu.
+func (wa *WeekAccess) writeWeekPlanLocked(plan WeekPlan) (string, error) {
+	if err := os.MkdirAll(wa.weeksDirPath(), 0755); err != nil {
+		return "", fmt.Errorf("failed to create weeks directory: %w", err)
+	}
+	filePath := wa.weekFilePath(plan.Week)
+	if err := writeJSON(filePath, plan); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// DeleteWeekPlan deletes the plan of an ISO week.
+func (wa *WeekAccess) DeleteWeekPlan(week string) error {
+	if _, _, err := utilities.ParseISOWeek(week); err != nil {
+		return fmt.Errorf("WeekAccess.DeleteWeekPlan: %w", err)
+	}
+
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+
+	filePath := wa.weekFilePath(week)
+	if err := os.Remove(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("WeekAccess.DeleteWeekPlan: no plan for week %s", week)
+		}
+		return fmt.Errorf("WeekAccess.DeleteWeekPlan: %w", err)
+	}
+	if err := commitFiles(wa.repo, []string{filePath}, fmt.Sprintf("Delete week plan: %s", week)); err != nil {
+		return fmt.Errorf("WeekAccess.DeleteWeekPlan: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package access
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNewWeekAccess_InvalidArguments(t *testing.T) {
+	if _, err := NewWeekAccess("", nil); err == nil {
+		t.Error("Expected error for empty dataPath")
+	}
+	if _, err := NewWeekAccess("/tmp/test", nil); err == nil {
+		t.Error("Expected error for nil repo")
+	}
+}
+
+func TestGetWeekPlan_NotSaved(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	plan, err := env.weeks.GetWeekPlan("2026-W12")
+	if err != nil {
+		t.Fatalf("GetWeekPlan failed: %v", err)
+	}
+	if plan != nil {
+		t.Errorf("Expected no plan, got %+v", plan)
+	}
+	plans, err := env.weeks.GetWeekPlans()
+	if err != nil {
+		t.Fatalf("GetWeekPlans failed: %v", err)
+	}
+	if len(plans) != 0 {
+		t.Errorf("Expected 0 plans, got %d", len(plans))
+	}
+}
+
+func TestSaveWeekPlan_SaveListDelete(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	w12 := WeekPlan{Week: "2026-W12", Intentions: []string{"Ship the release"}, ThemeIDs: []string{"H"}, CommittedTaskIDs: []string{"H-T1"}}
+	w01 := WeekPlan{Week: "2026-W01", KeyResultIDs: []string{"H-KR1"}}
+	before := commitCount(t, env.repo)
+	for _, plan := range []WeekPlan{w12, w01} {
+		if err := env.weeks.SaveWeekPlan(plan); err != nil {
+			t.Fatalf("SaveWeekPlan %s failed: %v", plan.Week, err)
+		}
+	}
+	if got := commitCount(t, env.repo) - before; got != 2 {
+		t.Errorf("Expected 2 commits, got %d", got)
+	}
+	if _, err := os.Stat(filepath.Join(env.dataDir, "weeks", "2026-W12.json")); err != nil {
+		t.Errorf("Expected week file: %v", err)
+	}
+
+	plan, err := env.weeks.GetWeekPlan("2026-W12")
+	if err != nil {
+		t.Fatalf("GetWeekPlan failed: %v", err)
+	}
+	if plan == nil || !slices.Equal(plan.Intentions, w12.Intentions) || !slices.Equal(plan.CommittedTaskIDs, w12.CommittedTaskIDs) {
+		t.Errorf("GetWeekPlan = %+v, want %+v", plan, w12)
+	}
+
+	plans, err := env.weeks.GetWeekPlans()
+	if err != nil {
+		t.Fatalf("GetWeekPlans failed: %v", err)
+	}
+	if len(plans) != 2 || plans[0].Week != "2026-W01" || plans[1].Week != "2026-W12" {
+		t.Errorf("GetWeekPlans = %+v, want W01 then W12", plans)
+	}
+
+	if err := env.weeks.DeleteWeekPlan("2026-W12"); err != nil {
+		t.Fatalf("DeleteWeekPlan failed: %v", err)
+	}
+	if plan, _ := env.weeks.GetWeekPlan("2026-W12"); plan != nil {
+		t.Errorf("Expected plan deleted, got %+v", plan)
+	}
+	if err := env.weeks.DeleteWeekPlan("2026-W12"); err == nil {
+		t.Error("Expected error deleting a missing plan")
+	}
+}
+
+func TestSaveWeekPlan_InvalidWeek(t *testing.T) {
+	env, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	for _, week := range []string{"", "2025-W53", "../2026-W12"} {
+		if err := env.weeks.SaveWeekPlan(WeekPlan{Week: week}); err == nil {
+			t.Errorf("SaveWeekPlan(%q): expected error", week)
+		}
+		if _, err := env.weeks.GetWeekPlan(week); err == nil {
+			t.Errorf("GetWeekPlan(%q): expected error", week)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 // Package bootstrap provides startup orchestration for the Bearing application.
-// It resolves the data directory, initializes logging, the git repository,
-// all resource access components, and both managers.
+// It resolves the data directory and profile, initializes logging, the git
+// repository, all resource access components, and the managers.
 package bootstrap
 
 import (
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/rkn/bearing/internal/access"
 	"github.com/rkn/bearing/internal/engines/chat_engine"
@@ -15,17 +16,39 @@ import (
 	"github.com/rkn/bearing/internal/utilities"
 )
 
-// Result holds the initialized components returned by Initialize.
+// Result holds the initialized components returned by Initialize and
+// OpenProfile. Everything except ProfileManager is bound to the opened
+// profile's data directory and is released by Close.
 type Result struct {
 	PlanningManager  *managers.PlanningManager
 	WorkspaceManager *managers.WorkspaceManager
 	AdviceManager    *managers.AdviceManager
+	SettingsManager  *managers.SettingsManager
+	ProfileManager   *managers.ProfileManager
+	Profile          *managers.Profile
 	LogFile          *os.File
+	repo             utilities.IRepository
 }
 
-// Initialize performs all startup orchestration: resolves the data directory,
-// sets up logging, initializes the git repository, creates all resource access
-// components, and wires both managers. It returns an error on any failure (fail-fast).
+// Close releases the repository handle and log file of the opened profile.
+// It is called on the previous Result after switching profiles.
+func (r *Result) Close() error {
+	var firstErr error
+	if r.repo != nil {
+		firstErr = r.repo.Close()
+	}
+	if r.LogFile != nil {
+		if err := r.LogFile.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Initialize performs all startup orchestration: resolves the default data
+// directory, loads the profile registry, and opens the last-used profile
+// (the default profile on first run). It returns an error on any failure
+// (fail-fast).
 func Initialize() (*Result, error) {
 	// Resolve data directory (BEARING_DATA_DIR overrides default ~/.bearing/)
 	bearingDir := os.Getenv("BEARING_DATA_DIR")
@@ -36,6 +59,61 @@ func Initialize() (*Result, error) {
 		}
 		bearingDir = filepath.Join(homeDir, ".bearing")
 	}
+
+	// The profile registry sits next to the default data directory rather
+	// than inside it, so it is never committed to any profile's history.
+	profileAccess, err := access.NewProfileAccess(bearingDir + "-profiles.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ProfileAccess: %w", err)
+	}
+	profileManager, err := managers.NewProfileManager(profileAccess, bearingDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ProfileManager: %w", err)
+	}
+	current, err := profileManager.CurrentProfile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve current profile: %w", err)
+	}
+
+	return OpenProfile(profileManager, current.ID)
+}
+
+// OpenProfile initializes logging, the git repository, every resource access
+// component and all managers against the data directory of profile id, then
+// records it as the last-used profile. Nothing is shared with a previously
+// opened profile, so switching is a clean re-initialisation; the caller
+// swaps in the returned managers and closes the old Result. On failure the
+// previous logger is restored and the last-used profile is unchanged.
+func OpenProfile(profiles *managers.ProfileManager, id string) (*Result, error) {
+	profile, err := profiles.GetProfile(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open profile: %w", err)
+	}
+
+	previousLogger := slog.Default()
+	result, err := initializeDataDir(profile.DataDir)
+	if err == nil {
+		err = profiles.MarkUsed(id)
+		if err != nil {
+			_ = result.Close()
+			err = fmt.Errorf("failed to record last-used profile: %w", err)
+		}
+	}
+	if err != nil {
+		slog.SetDefault(previousLogger)
+		return nil, err
+	}
+
+	profile.Active = true
+	result.ProfileManager = profiles
+	result.Profile = profile
+	slog.Info("Profile opened", "profile", profile.ID, "dataDir", profile.DataDir)
+	return result, nil
+}
+
+// initializeDataDir wires logging, the repository, migrations, access
+// components and managers for a single data directory.
+func initializeDataDir(bearingDir string) (result *Result, err error) {
 	if err := os.MkdirAll(bearingDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
@@ -47,23 +125,39 @@ func Initialize() (*Result, error) {
 		handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo, AddSource: true})
 		slog.SetDefault(slog.New(handler))
 		slog.Warn("Failed to open log file, falling back to stderr", "path", logPath, "error", err)
+		logFile = nil
 	} else {
 		handler := slog.NewTextHandler(logFile, &slog.HandlerOptions{Level: slog.LevelInfo, AddSource: true})
 		slog.SetDefault(slog.New(handler))
+		defer func() {
+			if err != nil {
+				_ = logFile.Close()
+			}
+		}()
 	}
 
 	slog.Info("Bearing starting up", "dataDir", bearingDir, "mode", "init")
 
-	// Initialize git repository for versioning
-	gitConfig := &utilities.AuthorConfiguration{
-		User:  "Bearing App",
-		Email: "bearing@localhost",
+	// Settings are read before the repository is opened because they carry
+	// the git author. Missing fields fall back to access.DefaultSettings.
+	settings, err := access.ReadSettingsFile(bearingDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
 	}
-	repo, err := utilities.InitializeRepositoryWithConfig(bearingDir, gitConfig)
+
+	// Initialize git repository for versioning
+	repo, err := utilities.InitializeRepositoryWithConfig(bearingDir, gitAuthor(settings))
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize repository: %w", err)
 	}
 
+	// Bring the data directory up to the current schema before any access
+	// component reads it. Each migration that changes data commits on its
+	// own; a data directory written by a newer build is refused outright.
+	if _, err := runMigrations(migrationEnv{dataPath: bearingDir, logger: slog.Default()}, repo, registeredMigrations, false); err != nil {
+		return nil, fmt.Errorf("failed to migrate data directory: %w", err)
+	}
+
 	// Initialize Resource Access components
 	themeAccess, err := access.NewThemeAccess(bearingDir, repo)
 	if err != nil {
@@ -81,14 +175,6 @@ func Initialize() (*Result, error) {
 	if err := taskAccess.SeedDefaultBoard(); err != nil {
 		return nil, fmt.Errorf("failed to seed default board configuration: %w", err)
 	}
-	// One-time migration: backfill Task.RoutineRef on tasks materialised
-	// under the legacy "routine:<id>:<date>" Description + "Routine" tag
-	// convention. Idempotent; produces no commit on a fully-migrated repo.
-	// Runs BEFORE any manager construction so PlanningManager never sees
-	// a half-migrated state.
-	if err := migrateRoutineRefs(taskAccess, repo, bearingDir, slog.Default()); err != nil {
-		return nil, fmt.Errorf("failed to migrate routine refs: %w", err)
-	}
 	calendarAccess, err := access.NewCalendarAccess(bearingDir, repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize CalendarAccess: %w", err)
@@ -101,10 +187,28 @@ func Initialize() (*Result, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize RoutineAccess: %w", err)
 	}
+	cycleAccess, err := access.NewCycleAccess(bearingDir, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize CycleAccess: %w", err)
+	}
+	weekAccess, err := access.NewWeekAccess(bearingDir, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize WeekAccess: %w", err)
+	}
 	uiStateAccess := access.NewUIStateAccess(bearingDir)
+	settingsAccess, err := access.NewSettingsAccess(bearingDir, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize SettingsAccess: %w", err)
+	}
+	// Re-read through the access component: the migrations above may have
+	// moved legacy settings into settings.json.
+	settings, err = settingsAccess.LoadSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
 
 	// Initialize Managers
-	planningManager, err := managers.NewPlanningManager(themeAccess, taskAccess, calendarAccess, routineAccess, visionAccess, uiStateAccess, repo)
+	planningManager, err := managers.NewPlanningManager(themeAccess, taskAccess, calendarAccess, routineAccess, cycleAccess, weekAccess, settingsAccess, visionAccess, uiStateAccess, repo, newClock(settings))
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize PlanningManager: %w", err)
 	}
@@ -112,15 +216,23 @@ func Initialize() (*Result, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize WorkspaceManager: %w", err)
 	}
+	settingsManager, err := managers.NewSettingsManager(settingsAccess, themeAccess)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize SettingsManager: %w", err)
+	}
+	applyAutoArchive(planningManager, settings.AutoArchive)
 
 	// Initialize ChatEngine
 	chatEngine := chat_engine.NewChatEngine()
 
 	// Initialize ModelAccess
-	modelAccess := access.NewClaudeCLIModelAccess(0)
+	modelAccess, err := access.NewModelAccess(settings.Advisor.Provider, time.Duration(settings.Advisor.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ModelAccess: %w", err)
+	}
 
 	// Initialize AdviceManager
-	adviceManager, err := managers.NewAdviceManager(themeAccess, routineAccess, chatEngine, modelAccess, uiStateAccess, planningManager)
+	adviceManager, err := managers.NewAdviceManager(themeAccess, routineAccess, chatEngine, modelAccess, settingsAccess, planningManager)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize AdviceManager: %w", err)
 	}
@@ -131,6 +243,59 @@ func Initialize() (*Result, error) {
 		PlanningManager:  planningManager,
 		WorkspaceManager: workspaceManager,
 		AdviceManager:    adviceManager,
+		SettingsManager:  settingsManager,
 		LogFile:          logFile,
+		repo:             repo,
 	}, nil
 }
+
+// gitAuthor returns the git identity used for commits made by the app. An
+// incomplete identity in settings.json falls back to the default one.
+func gitAuthor(settings *access.Settings) *utilities.AuthorConfiguration {
+	author := settings.GitAuthor
+	if author.Name == "" || author.Email == "" {
+		author = access.DefaultSettings().GitAuthor
+	}
+	return &utilities.AuthorConfiguration{
+		User:  author.Name,
+		Email: author.Email,
+	}
+}
+
+// newClock builds the managers' clock from the timezone and day boundary in
+// settings. An unknown zone or out-of-range hour (e.g. from a hand-edited
+// settings.json) falls back to the system zone and midnight with a warning.
+func newClock(settings *access.Settings) utilities.Clock {
+	location := time.Local
+	if settings.Timezone != "" {
+		loc, err := time.LoadLocation(settings.Timezone)
+		if err != nil {
+			slog.Warn("Unknown timezone in settings, using system timezone", "timezone", settings.Timezone, "error", err)
+		} else {
+			location = loc
+		}
+	}
+	clock, err := utilities.NewSystemClock(location, settings.DayStartHour)
+	if err != nil {
+		slog.Warn("Invalid day start in settings, using midnight", "dayStartHour", settings.DayStartHour, "error", err)
+		clock, _ = utilities.NewSystemClock(location, 0)
+	}
+	return clock
+}
+
+// applyAutoArchive runs the auto-archive policy when a profile is opened.
+// Failures are logged rather than returned: a stale board is no reason to
+// refuse startup.
+func applyAutoArchive(planningManager *managers.PlanningManager, policy access.AutoArchiveSettings) {
+	if policy.Mode != access.AutoArchiveAfterDays || policy.Days < 1 {
+		return
+	}
+	archived, err := planningManager.ArchiveDoneTasksOlderThan(policy.Days)
+	if err != nil {
+		slog.Warn("Auto-archive failed", "days", policy.Days, "archived", archived, "error", err)
+		return
+	}
+	if archived > 0 {
+		slog.Info("Auto-archived done tasks", "days", policy.Days, "count", archived)
+	}
+}
@@ -0,0 +1,73 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnit_OpenProfile_SwitchesDataDirAndRemembersLastUsed(t *testing.T) {
+	defaultDir := filepath.Join(t.TempDir(), "bearing")
+	t.Setenv("BEARING_DATA_DIR", defaultDir)
+
+	first, err := Initialize()
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if first.Profile.ID != "default" || first.Profile.DataDir != defaultDir {
+		t.Fatalf("initial profile = %+v", first.Profile)
+	}
+
+	created, err := first.ProfileManager.CreateProfile("Side Project", "")
+	if err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+	second, err := OpenProfile(first.ProfileManager, created.ID)
+	if err != nil {
+		t.Fatalf("OpenProfile: %v", err)
+	}
+	defer first.Close()
+	defer second.Close()
+
+	if _, err := os.Stat(filepath.Join(created.DataDir, ".git")); err != nil {
+		t.Errorf("expected repository in %s: %v", created.DataDir, err)
+	}
+	if err := second.PlanningManager.SavePersonalVision("Side mission", ""); err != nil {
+		t.Fatalf("SavePersonalVision: %v", err)
+	}
+	vision, err := first.PlanningManager.GetPersonalVision()
+	if err != nil {
+		t.Fatalf("GetPersonalVision on default profile: %v", err)
+	}
+	if vision.Mission != "" {
+		t.Errorf("vision leaked into the default profile: %q", vision.Mission)
+	}
+
+	// Next startup resumes the last-used profile.
+	third, err := Initialize()
+	if err != nil {
+		t.Fatalf("re-Initialize: %v", err)
+	}
+	defer third.Close()
+	if third.Profile.ID != created.ID {
+		t.Errorf("resumed profile = %q, want %q", third.Profile.ID, created.ID)
+	}
+}
+
+func TestUnit_OpenProfile_UnknownProfileKeepsLastUsed(t *testing.T) {
+	t.Setenv("BEARING_DATA_DIR", filepath.Join(t.TempDir(), "bearing"))
+
+	result, err := Initialize()
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer result.Close()
+
+	if _, err := OpenProfile(result.ProfileManager, "missing"); err == nil {
+		t.Fatal("expected error opening an unknown profile")
+	}
+	current, _ := result.ProfileManager.CurrentProfile()
+	if current.ID != "default" {
+		t.Errorf("CurrentProfile = %q, want default", current.ID)
+	}
+}
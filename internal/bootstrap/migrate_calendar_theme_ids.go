@@ -0,0 +1,77 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// planCalendarThemeIDs converts calendar DayFocus entries from the legacy
+// single "themeId" string to the "themeIds" array. An entry that already has
+// themeIds keeps it and only loses the stale themeId key; an empty themeId
+// is dropped without creating an empty array.
+func planCalendarThemeIDs(env migrationEnv) ([]migrationChange, error) {
+	calendarDir := filepath.Join(env.dataPath, "calendar")
+	entries, err := os.ReadDir(calendarDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read calendar dir: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var changes []migrationChange
+	for _, name := range names {
+		path := filepath.Join(calendarDir, name)
+		yearFile, err := readRawJSON(path)
+		if err != nil {
+			return nil, err
+		}
+		days, _ := yearFile["entries"].([]any)
+		migrated := 0
+		for _, raw := range days {
+			if day, ok := raw.(map[string]any); ok && migrateDayThemeID(day) {
+				migrated++
+			}
+		}
+		if migrated == 0 {
+			continue
+		}
+		changes = append(changes, migrationChange{
+			summary: fmt.Sprintf("convert themeId to themeIds on %d entries in calendar/%s", migrated, name),
+			apply: func() error {
+				return utilities.AtomicWriteJSON(path, yearFile)
+			},
+		})
+	}
+	return changes, nil
+}
+
+// migrateDayThemeID rewrites a single raw DayFocus entry in place and
+// reports whether it changed.
+func migrateDayThemeID(day map[string]any) bool {
+	themeID, hasThemeID := day["themeId"]
+	if !hasThemeID {
+		return false
+	}
+	delete(day, "themeId")
+	if _, hasThemeIDs := day["themeIds"]; hasThemeIDs {
+		return true
+	}
+	if id, ok := themeID.(string); ok && id != "" {
+		day["themeIds"] = []any{id}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// rawCheckIn is one reconstructed progress value and when it was committed.
+type rawCheckIn struct {
+	value float64
+	at    time.Time
+}
+
+// planKeyResultCheckIns gives every key result without check-ins a history
+// rebuilt from the git commits of themes/themes.json: each commit in which a
+// key result's currentValue differs from its previous value (initially its
+// startValue) becomes a check-in dated at that commit. When the working copy
+// holds a value no commit recorded, a final check-in dated at the file's
+// modification time is added so that currentValue stays the latest value.
+func planKeyResultCheckIns(env migrationEnv) ([]migrationChange, error) {
+	themesPath := filepath.Join(env.dataPath, "themes", "themes.json")
+	themesFile, err := readRawJSON(themesPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []map[string]any
+	for _, kr := range rawKeyResults(themesFile) {
+		if checkIns, _ := kr["checkIns"].([]any); len(checkIns) == 0 {
+			pending = append(pending, kr)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	history, err := keyResultValueHistory(env, themesPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(themesPath)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", themesPath, err)
+	}
+
+	var ids []string
+	total := 0
+	for _, kr := range pending {
+		id, _ := kr["id"].(string)
+		points := history[id]
+		current := rawNumber(kr["currentValue"])
+		last := rawNumber(kr["startValue"])
+		if len(points) > 0 {
+			last = points[len(points)-1].value
+		}
+		if current != last {
+			points = append(points, rawCheckIn{value: current, at: info.ModTime()})
+		}
+		if len(points) == 0 {
+			continue
+		}
+
+		checkIns := make([]any, len(points))
+		for i, p := range points {
+			checkIns[i] = map[string]any{
+				"id":        fmt.Sprintf("C%d", i+1),
+				"value":     p.value,
+				"timestamp": utilities.NewTimestamp(p.at.UTC()).String(),
+			}
+		}
+		kr["checkIns"] = checkIns
+		ids = append(ids, id)
+		total += len(checkIns)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return []migrationChange{{
+		summary: fmt.Sprintf("backfill %d check-ins on %d key results in themes/themes.json (%s)", total, len(ids), strings.Join(ids, ", ")),
+		apply: func() error {
+			return utilities.AtomicWriteJSON(themesPath, themesFile)
+		},
+	}}, nil
+}
+
+// keyResultValueHistory replays the committed versions of themesPath, oldest
+// first, and returns the value changes of every key result by ID. Versions
+// that cannot be read or parsed are skipped.
+func keyResultValueHistory(env migrationEnv, themesPath string) (map[string][]rawCheckIn, error) {
+	history := map[string][]rawCheckIn{}
+	if env.repo == nil {
+		return history, nil
+	}
+	relPath, err := filepath.Rel(env.repo.Path(), themesPath)
+	if err != nil {
+		return nil, fmt.Errorf("locate %s in repository: %w", themesPath, err)
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	commits, err := env.repo.GetFileHistory(relPath, 0)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(commits)
+
+	previous := map[string]float64{}
+	for _, commit := range commits {
+		data, err := env.repo.GetFileAtCommit(relPath, commit.ID)
+		if err != nil {
+			env.logger.Warn("skipping unreadable themes.json version", "commit", commit.ID, "error", err)
+			continue
+		}
+		var version map[string]any
+		if err := json.Unmarshal(data, &version); err != nil {
+			env.logger.Warn("skipping unparseable themes.json version", "commit", commit.ID, "error", err)
+			continue
+		}
+		for _, kr := range rawKeyResults(version) {
+			id, _ := kr["id"].(string)
+			if id == "" {
+				continue
+			}
+			last, seen := previous[id]
+			if !seen {
+				last = rawNumber(kr["startValue"])
+			}
+			value := rawNumber(kr["currentValue"])
+			if value != last {
+				history[id] = append(history[id], rawCheckIn{value: value, at: commit.Timestamp})
+			}
+			previous[id] = value
+		}
+	}
+	return history, nil
+}
+
+// rawKeyResults returns every key result in a raw themes.json document,
+// walking nested objectives depth-first. The maps alias the document.
+func rawKeyResults(themesFile map[string]any) []map[string]any {
+	var out []map[string]any
+	var walk func(objectives []any)
+	walk = func(objectives []any) {
+		for _, rawObj := range objectives {
+			obj, ok := rawObj.(map[string]any)
+			if !ok {
+				continue
+			}
+			krs, _ := obj["keyResults"].([]any)
+			for _, rawKR := range krs {
+				if kr, ok := rawKR.(map[string]any); ok {
+					out = append(out, kr)
+				}
+			}
+			children, _ := obj["objectives"].([]any)
+			walk(children)
+		}
+	}
+	themes, _ := themesFile["themes"].([]any)
+	for _, rawTheme := range themes {
+		if theme, ok := rawTheme.(map[string]any); ok {
+			objectives, _ := theme["objectives"].([]any)
+			walk(objectives)
+		}
+	}
+	return out
+}
+
+// rawNumber returns a decoded JSON number, treating a missing value as 0
+// (the fields are written with omitempty).
+func rawNumber(v any) float64 {
+	n, _ := v.(float64)
+	return n
+}
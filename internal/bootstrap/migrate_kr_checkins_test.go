@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// writeKRThemes writes a themes.json with two key results: H-KR1 at the
+// given current value (start 2) and H-KR2, which already has a check-in.
+func writeKRThemes(t *testing.T, dataDir string, current int) {
+	t.Helper()
+	doc := fmt.Sprintf(`{"themes":[{"id":"H","name":"Health","color":"#22c55e","objectives":[
+		{"id":"H-O1","parentId":"H","title":"Get fit","keyResults":[
+			{"id":"H-KR1","parentId":"H-O1","description":"Run","startValue":2,"currentValue":%d,"targetValue":20},
+			{"id":"H-KR2","parentId":"H-O1","description":"Swim","currentValue":4,"targetValue":8,
+			 "checkIns":[{"id":"C1","value":4,"timestamp":"2025-01-01T00:00:00Z"}]}
+		]}]}]}`, current)
+	if err := os.MkdirAll(filepath.Join(dataDir, "themes"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "themes", "themes.json"), []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnit_PlanKeyResultCheckIns_ReplaysHistory(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "")
+	// Committed values 2 (start), 5, 5, 7; the working copy then holds 9.
+	for i, value := range []int{2, 5, 5, 7} {
+		writeKRThemes(t, dataDir, value)
+		if err := utilities.RunTransaction(repo, fmt.Sprintf("Update themes %d", i), func() error { return nil }); err != nil {
+			t.Fatalf("commit %d: %v", i, err)
+		}
+	}
+	writeKRThemes(t, dataDir, 9)
+
+	m := migration{version: 6, name: "Backfill key result check-ins from history", plan: planKeyResultCheckIns}
+	result, err := applyMigration(testMigrationEnv(dataDir), repo, m)
+	if err != nil {
+		t.Fatalf("applyMigration: %v", err)
+	}
+	if !result.Committed || len(result.Changes) != 1 {
+		t.Fatalf("result = %+v, want one committed change", result)
+	}
+
+	themeAccess, err := access.NewThemeAccess(dataDir, repo)
+	if err != nil {
+		t.Fatalf("NewThemeAccess: %v", err)
+	}
+	themes, err := themeAccess.GetThemes()
+	if err != nil {
+		t.Fatalf("GetThemes: %v", err)
+	}
+	krs := themes[0].Objectives[0].KeyResults
+
+	want := []float64{5, 7, 9}
+	if len(krs[0].CheckIns) != len(want) {
+		t.Fatalf("H-KR1 check-ins = %+v, want values %v", krs[0].CheckIns, want)
+	}
+	for i, c := range krs[0].CheckIns {
+		if c.ID != fmt.Sprintf("C%d", i+1) || c.Value != want[i] {
+			t.Errorf("check-in %d = %+v, want C%d with value %g", i, c, i+1, want[i])
+		}
+		if i > 0 && c.Timestamp.Time().Before(krs[0].CheckIns[i-1].Timestamp.Time()) {
+			t.Errorf("check-in %d is older than its predecessor", i)
+		}
+	}
+	if krs[0].CurrentValue != 9 {
+		t.Errorf("H-KR1 CurrentValue = %g, want 9", krs[0].CurrentValue)
+	}
+	if len(krs[1].CheckIns) != 1 || krs[1].CheckIns[0].Timestamp != "2025-01-01T00:00:00Z" {
+		t.Errorf("existing H-KR2 history should be kept, got %+v", krs[1].CheckIns)
+	}
+
+	// A second run finds nothing left to backfill.
+	env := testMigrationEnv(dataDir)
+	env.repo = repo
+	changes, err := planKeyResultCheckIns(env)
+	if err != nil {
+		t.Fatalf("re-plan: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes on re-run, got %d", len(changes))
+	}
+}
@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// planKeyResultDirections writes an explicit "direction" onto every key
+// result that lacks one. Values are left untouched: integers stored by
+// earlier versions are valid decimal values as they are. Recording the
+// schema step keeps builds that only understand integer values from opening
+// data that may now contain decimals.
+func planKeyResultDirections(env migrationEnv) ([]migrationChange, error) {
+	themesPath := filepath.Join(env.dataPath, "themes", "themes.json")
+	themesFile, err := readRawJSON(themesPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, kr := range rawKeyResults(themesFile) {
+		if direction, _ := kr["direction"].(string); direction != "" {
+			continue
+		}
+		kr["direction"] = rawKeyResultDirection(kr)
+		id, _ := kr["id"].(string)
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return []migrationChange{{
+		summary: fmt.Sprintf("set direction on %d key results in themes/themes.json (%s)", len(ids), strings.Join(ids, ", ")),
+		apply: func() error {
+			return utilities.AtomicWriteJSON(themesPath, themesFile)
+		},
+	}}, nil
+}
+
+// rawKeyResultDirection infers the direction of a legacy key result. Earlier
+// versions only accepted increasing key results; a tracked key result whose
+// target lies below its start can only come from a hand edit and is taken to
+// decrease.
+func rawKeyResultDirection(kr map[string]any) string {
+	start, target := rawNumber(kr["startValue"]), rawNumber(kr["targetValue"])
+	if target != 0 && target < start {
+		return access.KRDirectionDecrease
+	}
+	return access.KRDirectionIncrease
+}
@@ -0,0 +1,70 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnit_PlanKeyResultDirections(t *testing.T) {
+	dataDir := t.TempDir()
+	doc := `{"themes":[{"id":"H","name":"Health","color":"#22c55e","objectives":[
+		{"id":"H-O1","parentId":"H","title":"Get fit","keyResults":[
+			{"id":"H-KR1","parentId":"H-O1","description":"Run","startValue":2,"currentValue":5,"targetValue":20},
+			{"id":"H-KR2","parentId":"H-O1","description":"Weight","startValue":90,"currentValue":88,"targetValue":80},
+			{"id":"H-KR3","parentId":"H-O1","description":"Untracked","currentValue":4},
+			{"id":"H-KR4","parentId":"H-O1","description":"Done","direction":"decrease","startValue":5,"targetValue":1}
+		],"objectives":[
+			{"id":"H-O2","parentId":"H-O1","title":"Nested","keyResults":[
+				{"id":"H-KR5","parentId":"H-O2","description":"Swim","currentValue":1,"targetValue":3}
+			]}
+		]}]}]}`
+	if err := os.MkdirAll(filepath.Join(dataDir, "themes"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	themesPath := filepath.Join(dataDir, "themes", "themes.json")
+	if err := os.WriteFile(themesPath, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := testMigrationEnv(dataDir)
+	changes, err := planKeyResultDirections(env)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+	if err := changes[0].apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	themesFile, err := readRawJSON(themesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"H-KR1": "increase",
+		"H-KR2": "decrease",
+		"H-KR3": "increase",
+		"H-KR4": "decrease",
+		"H-KR5": "increase",
+	}
+	krs := rawKeyResults(themesFile)
+	if len(krs) != len(want) {
+		t.Fatalf("expected %d key results, got %d", len(want), len(krs))
+	}
+	for _, kr := range krs {
+		id, _ := kr["id"].(string)
+		if kr["direction"] != want[id] {
+			t.Errorf("%s direction = %v, want %s", id, kr["direction"], want[id])
+		}
+		if id == "H-KR1" && kr["startValue"] != 2.0 {
+			t.Errorf("H-KR1 startValue = %v, want 2 unchanged", kr["startValue"])
+		}
+	}
+
+	if changes, err := planKeyResultDirections(env); err != nil || len(changes) != 0 {
+		t.Errorf("re-plan = %d changes, %v; want none", len(changes), err)
+	}
+}
@@ -2,10 +2,10 @@ package bootstrap
 
 import (
 	"fmt"
-	"log/slog"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/rkn/bearing/internal/access"
@@ -23,96 +23,65 @@ var routineDescPattern = regexp.MustCompile(`^routine:([^:]+):(\d{4}-\d{2}-\d{2}
 // only ever a discoverability hack.
 const routineMigrationTag = "Routine"
 
-// migrateRoutineRefs is a one-time migration that backfills the typed
-// Task.RoutineRef field on tasks that were materialised under the legacy
-// convention of stuffing "routine:<id>:<date>" into Description and tagging
-// "Routine".
+// planRoutineRefs backfills the typed Task.RoutineRef field on tasks that
+// were materialised under the legacy convention of stuffing
+// "routine:<id>:<date>" into Description and tagging "Routine". The
+// "Routine" tag is dropped from migrated tasks; user-added tags are kept.
 //
-// All migrated writes are bundled into a single git commit titled
-// "Migrate Routine tag to typed routineRef" via utilities.RunTransaction.
-// The migration is idempotent: re-running on a fully-migrated repo finds
-// no tagged-but-unparsed tasks and produces no commit. Tasks tagged
-// "Routine" with non-matching descriptions (user-edited) are preserved
-// as-is and a warning is logged.
-//
-// The helper does NOT route writes through TaskAccess.Save because that
-// verb produces one commit per call (and would also re-acquire the
-// per-repo lock that RunTransaction already holds, causing a deadlock).
-// Writing the on-disk task files directly via utilities.AtomicWriteJSON
-// inside the transaction is the simplest path to a single migration
-// commit. The on-disk schema (one JSON file per task under
+// Tasks tagged "Routine" with non-matching descriptions (user-edited) are
+// preserved as-is and a warning is logged. Tasks that already carry a
+// RoutineRef are left alone, so re-planning a fully-migrated directory
+// yields no changes. The on-disk schema (one JSON file per task under
 // <dataPath>/tasks/<status>/<id>.json) is stable; the migration only
 // updates the file contents, never the path.
-//
-// The helper is invoked from bootstrap.Initialize after access components
-// are wired and before any manager construction, so managers never see a
-// half-migrated state.
-func migrateRoutineRefs(taskAccess access.ITask, repo utilities.IRepository, dataPath string, logger *slog.Logger) error {
-	routineTag := routineMigrationTag
-	tasks, err := taskAccess.Find(access.TaskFilter{Tag: &routineTag})
+func planRoutineRefs(env migrationEnv) ([]migrationChange, error) {
+	files, err := listTaskFiles(env.dataPath)
 	if err != nil {
-		return fmt.Errorf("migrateRoutineRefs: find tagged tasks: %w", err)
+		return nil, fmt.Errorf("list task files: %w", err)
 	}
 
-	// Pre-compute the migration plan so we can short-circuit (no commit)
-	// when nothing needs to change. Each entry pairs the updated task with
-	// the on-disk file path the writer will overwrite.
-	type plan struct {
-		task     access.Task
-		filePath string
-	}
-	var plans []plan
-	for _, task := range tasks {
-		if task.RoutineRef != nil {
+	var changes []migrationChange
+	for _, filePath := range files {
+		task, err := readRawJSON(filePath)
+		if err != nil {
+			return nil, err
+		}
+		tags := rawStrings(task["tags"])
+		if !containsTag(tags, routineMigrationTag) {
+			continue
+		}
+		if ref, ok := task["routineRef"]; ok && ref != nil {
 			// Already migrated by a future-flow source; skip.
 			continue
 		}
-		m := routineDescPattern.FindStringSubmatch(task.Description)
+		taskID, _ := task["id"].(string)
+		description, _ := task["description"].(string)
+		m := routineDescPattern.FindStringSubmatch(description)
 		if m == nil {
-			logger.Warn("routine-tagged task with non-matching description; preserving",
-				"taskID", task.ID, "description", task.Description)
+			env.logger.Warn("routine-tagged task with non-matching description; preserving",
+				"taskID", taskID, "description", description)
 			continue
 		}
 		date, parseErr := utilities.ParseCalendarDate(m[2])
 		if parseErr != nil {
-			logger.Warn("routine-tagged task with unparseable date; preserving",
-				"taskID", task.ID, "description", task.Description, "error", parseErr)
+			env.logger.Warn("routine-tagged task with unparseable date; preserving",
+				"taskID", taskID, "description", description, "error", parseErr)
 			continue
 		}
 
-		filePath, locateErr := locateTaskFile(dataPath, task.ID)
-		if locateErr != nil {
-			return fmt.Errorf("migrateRoutineRefs: locate task %s: %w", task.ID, locateErr)
-		}
-
-		updated := task
-		updated.RoutineRef = &access.RoutineRef{RoutineID: m[1], Date: date}
-		updated.Tags = removeTag(updated.Tags, routineMigrationTag)
-		updated.UpdatedAt = utilities.Now()
-
-		plans = append(plans, plan{task: updated, filePath: filePath})
-	}
+		task["routineRef"] = access.RoutineRef{RoutineID: m[1], Date: date}
+		task["tags"] = removeTag(tags, routineMigrationTag)
+		task["updatedAt"] = utilities.Now()
 
-	if len(plans) == 0 {
-		// Idempotent no-op: fully migrated already, or no Routine-tagged
-		// tasks ever existed. Produce no commit.
-		return nil
-	}
-
-	if err := utilities.RunTransaction(repo, "Migrate Routine tag to typed routineRef", func() error {
-		for _, p := range plans {
-			if writeErr := utilities.AtomicWriteJSON(p.filePath, p.task); writeErr != nil {
-				return fmt.Errorf("write task %s: %w", p.task.ID, writeErr)
-			}
-		}
-		return nil
-	}); err != nil {
-		return fmt.Errorf("migrateRoutineRefs: %w", err)
+		path := filePath
+		changes = append(changes, migrationChange{
+			summary: fmt.Sprintf("set routineRef %s/%s on task %s", m[1], m[2], taskID),
+			apply: func() error {
+				return utilities.AtomicWriteJSON(path, task)
+			},
+		})
 	}
-
-	logger.Info("Routine→RoutineRef migration applied",
-		"migratedCount", len(plans))
-	return nil
+	return changes, nil
 }
 
 // removeTag returns a copy of tags with every occurrence of target stripped.
@@ -127,30 +96,58 @@ func removeTag(tags []string, target string) []string {
 	return out
 }
 
-// locateTaskFile scans <dataPath>/tasks/* for a file named <taskID>.json and
-// returns its absolute path. The migration cannot derive status from the
-// Task DTO alone (Task has no Status field), so we discover the file by
-// scanning each status subdirectory. The on-disk filename is always
-// "<taskID>.json".
-func locateTaskFile(dataPath, taskID string) (string, error) {
+// containsTag reports whether tags includes target.
+func containsTag(tags []string, target string) bool {
+	for _, t := range tags {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// rawStrings converts a decoded JSON array into a string slice, skipping
+// non-string elements.
+func rawStrings(v any) []string {
+	list, _ := v.([]any)
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// listTaskFiles returns every <dataPath>/tasks/<status>/<id>.json file in
+// sorted order. Status is derived from the directory, so custom board columns
+// are picked up along with the built-in ones. Dot-directories are skipped.
+func listTaskFiles(dataPath string) ([]string, error) {
 	tasksDir := filepath.Join(dataPath, "tasks")
 	entries, err := os.ReadDir(tasksDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
 	if err != nil {
-		return "", fmt.Errorf("read tasks dir: %w", err)
+		return nil, fmt.Errorf("read tasks dir: %w", err)
 	}
-	target := taskID + ".json"
+	var files []string
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
-		// Defensive: skip dotfiles (e.g. .gitkeep) accidentally surfacing as dirs.
-		if strings.HasPrefix(entry.Name(), ".") {
-			continue
+		statusDir := filepath.Join(tasksDir, entry.Name())
+		taskEntries, err := os.ReadDir(statusDir)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", statusDir, err)
 		}
-		candidate := filepath.Join(tasksDir, entry.Name(), target)
-		if _, statErr := os.Stat(candidate); statErr == nil {
-			return candidate, nil
+		for _, te := range taskEntries {
+			if te.IsDir() || !strings.HasSuffix(te.Name(), ".json") {
+				continue
+			}
+			files = append(files, filepath.Join(statusDir, te.Name()))
 		}
 	}
-	return "", fmt.Errorf("task file %s not found under %s", target, tasksDir)
+	sort.Strings(files)
+	return files, nil
 }
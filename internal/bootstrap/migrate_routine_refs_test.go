@@ -20,6 +20,7 @@ type migrateTestEnv struct {
 	dataDir    string
 	logger     *slog.Logger
 	logBuf     *bytes.Buffer
+	step       migration
 }
 
 func setupMigrateTestEnv(t *testing.T) *migrateTestEnv {
@@ -52,6 +53,7 @@ func setupMigrateTestEnv(t *testing.T) *migrateTestEnv {
 		dataDir:    dataDir,
 		logger:     logger,
 		logBuf:     logBuf,
+		step:       routineRefsMigration(t),
 	}
 }
 
@@ -69,6 +71,24 @@ func seedRoutineTaskFile(t *testing.T, dataDir string, task access.Task) {
 	}
 }
 
+// routineRefsMigration returns the registered routineRef migration step.
+func routineRefsMigration(t *testing.T) migration {
+	t.Helper()
+	for _, m := range registeredMigrations {
+		if m.name == "Migrate Routine tag to typed routineRef" {
+			return m
+		}
+	}
+	t.Fatal("routineRef migration not registered")
+	return migration{}
+}
+
+// migrate applies the routineRef migration step on its own.
+func (e *migrateTestEnv) migrate() error {
+	_, err := applyMigration(migrationEnv{dataPath: e.dataDir, logger: e.logger}, e.repo, e.step)
+	return err
+}
+
 func commitCount(t *testing.T, repo utilities.IRepository) int {
 	t.Helper()
 	hist, err := repo.GetHistory(1000)
@@ -135,8 +155,8 @@ func TestMigrateRoutineRefs_BackfillsAllMatchingTasks(t *testing.T) {
 
 	preCommits := commitCount(t, env.repo)
 
-	if err := migrateRoutineRefs(env.taskAccess, env.repo, env.dataDir, env.logger); err != nil {
-		t.Fatalf("migrateRoutineRefs: %v", err)
+	if err := env.migrate(); err != nil {
+		t.Fatalf("migrate: %v", err)
 	}
 
 	postCommits := commitCount(t, env.repo)
@@ -195,14 +215,14 @@ func TestMigrateRoutineRefs_IdempotentSecondRun(t *testing.T) {
 		Tags:        []string{"Routine"},
 	})
 
-	if err := migrateRoutineRefs(env.taskAccess, env.repo, env.dataDir, env.logger); err != nil {
-		t.Fatalf("first migrateRoutineRefs: %v", err)
+	if err := env.migrate(); err != nil {
+		t.Fatalf("first migrate: %v", err)
 	}
 	afterFirst := commitCount(t, env.repo)
 
 	// Second run on a fully-migrated repo must produce no commit.
-	if err := migrateRoutineRefs(env.taskAccess, env.repo, env.dataDir, env.logger); err != nil {
-		t.Fatalf("second migrateRoutineRefs: %v", err)
+	if err := env.migrate(); err != nil {
+		t.Fatalf("second migrate: %v", err)
 	}
 	afterSecond := commitCount(t, env.repo)
 
@@ -215,8 +235,8 @@ func TestMigrateRoutineRefs_NoTaggedTasks_NoCommit(t *testing.T) {
 	env := setupMigrateTestEnv(t)
 
 	preCommits := commitCount(t, env.repo)
-	if err := migrateRoutineRefs(env.taskAccess, env.repo, env.dataDir, env.logger); err != nil {
-		t.Fatalf("migrateRoutineRefs: %v", err)
+	if err := env.migrate(); err != nil {
+		t.Fatalf("migrate: %v", err)
 	}
 	postCommits := commitCount(t, env.repo)
 
@@ -238,8 +258,8 @@ func TestMigrateRoutineRefs_MalformedDescription_PreservesTag(t *testing.T) {
 	})
 
 	preCommits := commitCount(t, env.repo)
-	if err := migrateRoutineRefs(env.taskAccess, env.repo, env.dataDir, env.logger); err != nil {
-		t.Fatalf("migrateRoutineRefs: %v", err)
+	if err := env.migrate(); err != nil {
+		t.Fatalf("migrate: %v", err)
 	}
 	postCommits := commitCount(t, env.repo)
 
@@ -288,8 +308,8 @@ func TestMigrateRoutineRefs_AlreadyHasRoutineRef_Skipped(t *testing.T) {
 	seedRoutineTaskFile(t, env.dataDir, preExisting)
 
 	preCommits := commitCount(t, env.repo)
-	if err := migrateRoutineRefs(env.taskAccess, env.repo, env.dataDir, env.logger); err != nil {
-		t.Fatalf("migrateRoutineRefs: %v", err)
+	if err := env.migrate(); err != nil {
+		t.Fatalf("migrate: %v", err)
 	}
 	postCommits := commitCount(t, env.repo)
 
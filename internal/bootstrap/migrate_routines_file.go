@@ -0,0 +1,144 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// planExtractRoutines moves routines that were embedded in each theme's
+// "routines" array into the standalone routines.json, renumbering them R1,
+// R2, ... in theme order and remapping calendar routineChecks to the new
+// IDs. The step is skipped once routines.json exists: at that point the
+// standalone file is authoritative and any embedded arrays are stale.
+func planExtractRoutines(env migrationEnv) ([]migrationChange, error) {
+	routinesPath := filepath.Join(env.dataPath, "routines.json")
+	if _, err := os.Stat(routinesPath); err == nil {
+		return nil, nil
+	}
+	themesPath := filepath.Join(env.dataPath, "themes", "themes.json")
+	themesFile, err := readRawJSON(themesPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	idMap := make(map[string]string)
+	routines := make([]any, 0)
+	hasEmbedded := false
+	themes, _ := themesFile["themes"].([]any)
+	for _, raw := range themes {
+		theme, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		embedded, present := theme["routines"]
+		if !present {
+			continue
+		}
+		hasEmbedded = true
+		delete(theme, "routines")
+		list, _ := embedded.([]any)
+		for _, r := range list {
+			routine, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+			newID := fmt.Sprintf("R%d", len(routines)+1)
+			if oldID, ok := routine["id"].(string); ok {
+				idMap[oldID] = newID
+			}
+			routine["id"] = newID
+			routines = append(routines, routine)
+		}
+	}
+	if !hasEmbedded {
+		return nil, nil
+	}
+
+	changes := []migrationChange{
+		{
+			summary: fmt.Sprintf("write routines.json with %d routines", len(routines)),
+			apply: func() error {
+				return utilities.AtomicWriteJSON(routinesPath, map[string]any{"routines": routines})
+			},
+		},
+		{
+			summary: "remove embedded routines from themes/themes.json",
+			apply: func() error {
+				return utilities.AtomicWriteJSON(themesPath, themesFile)
+			},
+		},
+	}
+
+	calendarChanges, err := planRemapRoutineChecks(env.dataPath, idMap)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, calendarChanges...), nil
+}
+
+// planRemapRoutineChecks rewrites calendar routineChecks entries through
+// idMap. IDs not present in idMap are kept unchanged.
+func planRemapRoutineChecks(dataPath string, idMap map[string]string) ([]migrationChange, error) {
+	if len(idMap) == 0 {
+		return nil, nil
+	}
+	calendarDir := filepath.Join(dataPath, "calendar")
+	entries, err := os.ReadDir(calendarDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read calendar dir: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var changes []migrationChange
+	for _, name := range names {
+		path := filepath.Join(calendarDir, name)
+		yearFile, err := readRawJSON(path)
+		if err != nil {
+			return nil, err
+		}
+		changed := false
+		days, _ := yearFile["entries"].([]any)
+		for _, raw := range days {
+			day, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			checks, _ := day["routineChecks"].([]any)
+			for i, c := range checks {
+				if id, ok := c.(string); ok {
+					if newID, mapped := idMap[id]; mapped && newID != id {
+						checks[i] = newID
+						changed = true
+					}
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+		changes = append(changes, migrationChange{
+			summary: fmt.Sprintf("remap routineChecks in calendar/%s", name),
+			apply: func() error {
+				return utilities.AtomicWriteJSON(path, yearFile)
+			},
+		})
+	}
+	return changes, nil
+}
@@ -0,0 +1,49 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// planAdvisorSettings folds the legacy advisor_settings.json toggle into
+// settings.json under "advisor.enabled" and removes the legacy file. Other
+// settings keep whatever value settings.json already has, or their defaults
+// when it does not exist yet.
+func planAdvisorSettings(env migrationEnv) ([]migrationChange, error) {
+	legacyPath := filepath.Join(env.dataPath, "advisor_settings.json")
+	legacy, err := readRawJSON(legacyPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	enabled, _ := legacy["enabled"].(bool)
+
+	settingsPath := filepath.Join(env.dataPath, "settings.json")
+	settings, err := readRawJSON(settingsPath)
+	if os.IsNotExist(err) {
+		settings = map[string]any{"version": float64(1)}
+	} else if err != nil {
+		return nil, err
+	}
+	advisor, _ := settings["advisor"].(map[string]any)
+	if advisor == nil {
+		advisor = map[string]any{}
+	}
+	advisor["enabled"] = enabled
+	settings["advisor"] = advisor
+
+	return []migrationChange{{
+		summary: fmt.Sprintf("move advisor enabled=%t from advisor_settings.json to settings.json", enabled),
+		apply: func() error {
+			if err := utilities.AtomicWriteJSON(settingsPath, settings); err != nil {
+				return err
+			}
+			return os.Remove(legacyPath)
+		},
+	}}, nil
+}
@@ -0,0 +1,114 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// legacyTaskStatuses are the status directories that existed under the
+// theme-scoped task layout tasks/<themeID>/<status>/.
+var legacyTaskStatuses = []string{"todo", "doing", "done", "archived"}
+
+// planFlattenTaskDirs moves task files from the legacy theme-scoped layout
+// tasks/<themeID>/<status>/<id>.json to tasks/<status>/<id>.json.
+//
+// Custom board columns also live as directories directly under tasks/, so a
+// directory is only treated as a legacy theme directory when it contains one
+// of the legacy status subdirectories. Filename collisions abort the plan
+// rather than overwrite a task.
+func planFlattenTaskDirs(env migrationEnv) ([]migrationChange, error) {
+	tasksDir := filepath.Join(env.dataPath, "tasks")
+	entries, err := os.ReadDir(tasksDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read tasks dir: %w", err)
+	}
+
+	var changes []migrationChange
+	var themeDirs []string
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		themeDir := filepath.Join(tasksDir, entry.Name())
+		isLegacy := false
+		for _, status := range legacyTaskStatuses {
+			srcDir := filepath.Join(themeDir, status)
+			files, err := os.ReadDir(srcDir)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", srcDir, err)
+			}
+			isLegacy = true
+			for _, f := range files {
+				if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+					continue
+				}
+				src := filepath.Join(srcDir, f.Name())
+				dst := filepath.Join(tasksDir, status, f.Name())
+				key := status + "/" + f.Name()
+				if prev, dup := seen[key]; dup {
+					return nil, fmt.Errorf("task file collision: %s and %s both map to tasks/%s", prev, src, key)
+				}
+				if _, err := os.Stat(dst); err == nil {
+					return nil, fmt.Errorf("task file collision: %s already exists (source %s)", dst, src)
+				}
+				seen[key] = src
+				changes = append(changes, migrationChange{
+					summary: fmt.Sprintf("move tasks/%s/%s/%s to tasks/%s", entry.Name(), status, f.Name(), key),
+					apply: func() error {
+						if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+							return fmt.Errorf("create %s: %w", filepath.Dir(dst), err)
+						}
+						if err := os.Rename(src, dst); err != nil {
+							return fmt.Errorf("move %s: %w", src, err)
+						}
+						return nil
+					},
+				})
+			}
+		}
+		if isLegacy {
+			themeDirs = append(themeDirs, themeDir)
+		}
+	}
+
+	sort.Strings(themeDirs)
+	for _, dir := range themeDirs {
+		changes = append(changes, migrationChange{
+			summary: fmt.Sprintf("remove empty theme directory tasks/%s", filepath.Base(dir)),
+			apply: func() error {
+				return removeEmptyDirs(dir)
+			},
+		})
+	}
+	return changes, nil
+}
+
+// removeEmptyDirs removes dir and its subdirectories bottom-up, stopping
+// silently at any directory that still contains files.
+func removeEmptyDirs(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if err := removeEmptyDirs(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	if remaining, err := os.ReadDir(dir); err == nil && len(remaining) == 0 {
+		return os.Remove(dir)
+	}
+	return nil
+}
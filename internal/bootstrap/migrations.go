@@ -0,0 +1,276 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// schemaVersionFile records the schema version of a data directory. It is
+// versioned in git alongside the data it describes so that checking out an
+// older commit also restores the matching version number.
+const schemaVersionFile = "schema_version.json"
+
+// ErrSchemaTooNew is returned when the data directory was written by a newer
+// build than the running binary. Starting anyway would risk an older build
+// silently dropping fields it does not understand, so startup is refused.
+var ErrSchemaTooNew = errors.New("data directory schema is newer than this build supports")
+
+// schemaVersion is the on-disk shape of schema_version.json.
+type schemaVersion struct {
+	Version int `json:"version"`
+}
+
+// migrationEnv carries what a migration needs to inspect the data directory.
+// Migrations deliberately read and write the raw on-disk files rather than
+// going through the access components: the access layer always speaks the
+// latest schema, while a migration has to understand the schema it is
+// migrating from.
+//
+// repo is the data directory's repository, for migrations that reconstruct
+// data from history. runMigrations and applyMigration fill it in.
+type migrationEnv struct {
+	dataPath string
+	logger   *slog.Logger
+	repo     utilities.IRepository
+}
+
+// migrationChange is a single planned write. summary is reported in dry-run
+// mode; apply performs the write and is only invoked inside the migration's
+// transaction.
+type migrationChange struct {
+	summary string
+	apply   func() error
+}
+
+// migration is one registered schema step. plan must be side-effect free: it
+// inspects the data directory and returns the changes the migration would
+// make, so the same function serves both dry-run and real runs. An empty plan
+// means the data is already in the target shape.
+type migration struct {
+	version int
+	name    string
+	plan    func(env migrationEnv) ([]migrationChange, error)
+}
+
+// MigrationResult describes the outcome of one migration step.
+type MigrationResult struct {
+	Version   int      `json:"version"`
+	Name      string   `json:"name"`
+	Changes   []string `json:"changes"`
+	Committed bool     `json:"committed"`
+}
+
+// registeredMigrations is the ordered list of schema migrations. Versions
+// must be strictly increasing; append new steps at the end and never
+// renumber or remove a shipped one.
+var registeredMigrations = []migration{
+	{version: 1, name: "Flatten task directory structure", plan: planFlattenTaskDirs},
+	{version: 2, name: "Migrate calendar themeId to themeIds", plan: planCalendarThemeIDs},
+	{version: 3, name: "Extract routines from themes", plan: planExtractRoutines},
+	{version: 4, name: "Migrate Routine tag to typed routineRef", plan: planRoutineRefs},
+	{version: 5, name: "Move advisor setting into settings.json", plan: planAdvisorSettings},
+	{version: 6, name: "Backfill key result check-ins from history", plan: planKeyResultCheckIns},
+	{version: 7, name: "Make key result direction explicit", plan: planKeyResultDirections},
+}
+
+// LatestSchemaVersion returns the schema version this build writes.
+func LatestSchemaVersion() int {
+	return latestVersion(registeredMigrations)
+}
+
+// MigrateDataDir runs the registered migrations against dataDir. With dryRun
+// set, it only reports what each pending migration would change and leaves
+// the directory and its history untouched: an existing repository is opened
+// for reading and a missing one is not created. Note that in dry-run mode
+// every pending step is planned against the current data, so a step whose
+// input is produced by an earlier pending step may under-report.
+func MigrateDataDir(dataDir string, dryRun bool) ([]MigrationResult, error) {
+	repo, err := openMigrationRepo(dataDir, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("MigrateDataDir: %w", err)
+	}
+	if repo != nil {
+		defer func() { _ = repo.Close() }()
+	}
+
+	env := migrationEnv{dataPath: dataDir, logger: slog.Default()}
+	return runMigrations(env, repo, registeredMigrations, dryRun)
+}
+
+// openMigrationRepo returns the repository of dataDir. A real run
+// initialises it if needed; a dry run only opens an existing one and returns
+// nil when there is none, which migrations reading history must tolerate.
+func openMigrationRepo(dataDir string, dryRun bool) (utilities.IRepository, error) {
+	if dryRun {
+		repo, err := utilities.OpenRepository(dataDir)
+		if errors.Is(err, utilities.ErrNoRepository) {
+			return nil, nil
+		}
+		return repo, err
+	}
+	settings, err := access.ReadSettingsFile(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	return utilities.InitializeRepositoryWithConfig(dataDir, gitAuthor(settings))
+}
+
+// runMigrations applies every migration in registry whose version is above
+// the data directory's current version, in order. Each migration that changes
+// data runs in its own git commit, with the schema version bump included in
+// the same commit. Migrations whose plan is empty do not get a commit of
+// their own; if only such steps were pending, the final version is recorded
+// in a single trailing commit.
+func runMigrations(env migrationEnv, repo utilities.IRepository, registry []migration, dryRun bool) ([]MigrationResult, error) {
+	env.repo = repo
+	current, err := readSchemaVersion(env.dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("runMigrations: %w", err)
+	}
+	latest := latestVersion(registry)
+	if current > latest {
+		return nil, fmt.Errorf("runMigrations: %w (data is v%d, build supports up to v%d)", ErrSchemaTooNew, current, latest)
+	}
+
+	recorded := current
+	var results []MigrationResult
+	for _, m := range registry {
+		if m.version <= current {
+			continue
+		}
+
+		if dryRun {
+			result, err := planMigration(env, m)
+			if err != nil {
+				return results, fmt.Errorf("runMigrations: %w", err)
+			}
+			results = append(results, result)
+			continue
+		}
+
+		result, err := applyMigration(env, repo, m)
+		if err != nil {
+			return results, fmt.Errorf("runMigrations: %w", err)
+		}
+		if result.Committed {
+			recorded = m.version
+		}
+		current = m.version
+		results = append(results, result)
+	}
+
+	if !dryRun && current != recorded {
+		version := current
+		if err := utilities.RunTransaction(repo, fmt.Sprintf("Record data schema version %d", version), func() error {
+			return writeSchemaVersion(env.dataPath, version)
+		}); err != nil {
+			return results, fmt.Errorf("runMigrations: record version %d: %w", version, err)
+		}
+	}
+
+	return results, nil
+}
+
+// planMigration evaluates m's plan without writing anything.
+func planMigration(env migrationEnv, m migration) (MigrationResult, error) {
+	_, result, err := planWithResult(env, m)
+	return result, err
+}
+
+// applyMigration plans m and, when the plan is non-empty, applies it together
+// with the schema version bump in a single commit titled with m's name.
+// An empty plan produces no commit and leaves the version file untouched.
+// If a change fails, the working tree is restored to HEAD so that no
+// half-applied step is left behind.
+func applyMigration(env migrationEnv, repo utilities.IRepository, m migration) (MigrationResult, error) {
+	env.repo = repo
+	changes, result, err := planWithResult(env, m)
+	if err != nil || len(changes) == 0 {
+		return result, err
+	}
+	if err := utilities.RunTransaction(repo, m.name, func() error {
+		for _, c := range changes {
+			if err := c.apply(); err != nil {
+				return err
+			}
+		}
+		return writeSchemaVersion(env.dataPath, m.version)
+	}); err != nil {
+		if restoreErr := repo.RestoreWorkTree(); restoreErr != nil {
+			env.logger.Error("Schema migration left a partial working tree",
+				"version", m.version, "name", m.name, "error", restoreErr)
+		}
+		return result, fmt.Errorf("apply v%d (%s): %w", m.version, m.name, err)
+	}
+	result.Committed = true
+	env.logger.Info("Schema migration applied",
+		"version", m.version, "name", m.name, "changeCount", len(changes))
+	return result, nil
+}
+
+// planWithResult runs m.plan and summarises the changes as a MigrationResult.
+func planWithResult(env migrationEnv, m migration) ([]migrationChange, MigrationResult, error) {
+	result := MigrationResult{Version: m.version, Name: m.name, Changes: []string{}}
+	changes, err := m.plan(env)
+	if err != nil {
+		return nil, result, fmt.Errorf("plan v%d (%s): %w", m.version, m.name, err)
+	}
+	for _, c := range changes {
+		result.Changes = append(result.Changes, c.summary)
+	}
+	return changes, result, nil
+}
+
+// latestVersion returns the highest version in registry (0 when empty).
+func latestVersion(registry []migration) int {
+	if len(registry) == 0 {
+		return 0
+	}
+	return registry[len(registry)-1].version
+}
+
+// readSchemaVersion returns the version recorded in dataPath, or 0 when the
+// file does not exist (a data directory that predates schema versioning).
+func readSchemaVersion(dataPath string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(dataPath, schemaVersionFile))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", schemaVersionFile, err)
+	}
+	var sv schemaVersion
+	if err := json.Unmarshal(data, &sv); err != nil {
+		return 0, fmt.Errorf("parse %s: %w", schemaVersionFile, err)
+	}
+	return sv.Version, nil
+}
+
+// writeSchemaVersion records version in dataPath.
+func writeSchemaVersion(dataPath string, version int) error {
+	if err := utilities.AtomicWriteJSON(filepath.Join(dataPath, schemaVersionFile), schemaVersion{Version: version}); err != nil {
+		return fmt.Errorf("write %s: %w", schemaVersionFile, err)
+	}
+	return nil
+}
+
+// readRawJSON decodes path into a generic map so that migrations can rewrite
+// a file without dropping fields they do not know about.
+func readRawJSON(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return out, nil
+}
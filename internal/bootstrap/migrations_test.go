@@ -0,0 +1,359 @@
+package bootstrap
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// setupFixtureDataDir copies testdata/<fixture> into a fresh git-backed data
+// directory (the repository root is the data directory, as in production)
+// and commits the copied files so migration commits can be counted.
+func setupFixtureDataDir(t *testing.T, fixture string) (string, utilities.IRepository) {
+	t.Helper()
+
+	dataDir := t.TempDir()
+	if fixture != "" {
+		src := filepath.Join("testdata", fixture)
+		err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, _ := filepath.Rel(src, path)
+			dst := filepath.Join(dataDir, rel)
+			if d.IsDir() {
+				return os.MkdirAll(dst, 0755)
+			}
+			in, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+			out, err := os.Create(dst)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+			_, err = io.Copy(out, in)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("copy fixture %s: %v", fixture, err)
+		}
+	}
+
+	gitConfig := &utilities.AuthorConfiguration{User: "Test", Email: "test@example.com"}
+	repo, err := utilities.InitializeRepositoryWithConfig(dataDir, gitConfig)
+	if err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	if fixture != "" {
+		if err := utilities.RunTransaction(repo, "Seed fixture", func() error { return nil }); err != nil {
+			t.Fatalf("seed commit: %v", err)
+		}
+	}
+	return dataDir, repo
+}
+
+func testMigrationEnv(dataDir string) migrationEnv {
+	return migrationEnv{dataPath: dataDir, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+func readFixtureJSON(t *testing.T, path string) map[string]any {
+	t.Helper()
+	raw, err := readRawJSON(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return raw
+}
+
+func TestUnit_RunMigrations_LegacyFixture(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "legacy_v0")
+	pre := commitCount(t, repo)
+
+	results, err := runMigrations(testMigrationEnv(dataDir), repo, registeredMigrations, false)
+	if err != nil {
+		t.Fatalf("runMigrations: %v", err)
+	}
+
+	if got := commitCount(t, repo) - pre; got != len(registeredMigrations) {
+		t.Errorf("expected one commit per migration (%d), got %d", len(registeredMigrations), got)
+	}
+	for _, r := range results {
+		if !r.Committed {
+			t.Errorf("migration v%d (%s) not committed", r.Version, r.Name)
+		}
+	}
+	if v, _ := readSchemaVersion(dataDir); v != LatestSchemaVersion() {
+		t.Errorf("schema version = %d, want %d", v, LatestSchemaVersion())
+	}
+
+	// v1: theme-scoped task files flattened, custom column untouched.
+	for _, rel := range []string{"tasks/todo/H-T1.json", "tasks/done/H-T2.json", "tasks/waiting/T4.json"} {
+		if _, err := os.Stat(filepath.Join(dataDir, rel)); err != nil {
+			t.Errorf("expected %s after migration: %v", rel, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "tasks", "H")); !os.IsNotExist(err) {
+		t.Errorf("legacy theme directory tasks/H should be removed, stat err = %v", err)
+	}
+
+	// v2 + v3: themeIds converted, routineChecks remapped.
+	cal := readFixtureJSON(t, filepath.Join(dataDir, "calendar", "2025.json"))
+	entries := cal["entries"].([]any)
+	wantThemeIDs := [][]any{{"H"}, nil, {"CF"}, {"H"}}
+	wantChecks := [][]any{{"R1", "R3"}, nil, nil, {"R2"}}
+	for i, raw := range entries {
+		day := raw.(map[string]any)
+		if _, ok := day["themeId"]; ok {
+			t.Errorf("entry %d still has themeId", i)
+		}
+		gotIDs, _ := day["themeIds"].([]any)
+		if wantThemeIDs[i] == nil && gotIDs != nil || wantThemeIDs[i] != nil && !reflect.DeepEqual(gotIDs, wantThemeIDs[i]) {
+			t.Errorf("entry %d themeIds = %v, want %v", i, gotIDs, wantThemeIDs[i])
+		}
+		gotChecks, _ := day["routineChecks"].([]any)
+		if wantChecks[i] != nil && !reflect.DeepEqual(gotChecks, wantChecks[i]) {
+			t.Errorf("entry %d routineChecks = %v, want %v", i, gotChecks, wantChecks[i])
+		}
+	}
+
+	routines := readFixtureJSON(t, filepath.Join(dataDir, "routines.json"))["routines"].([]any)
+	if len(routines) != 3 {
+		t.Fatalf("expected 3 extracted routines, got %d", len(routines))
+	}
+	if d := routines[2].(map[string]any)["description"]; d != "Read a paper" {
+		t.Errorf("R3 description = %v, want %q", d, "Read a paper")
+	}
+	themes := readFixtureJSON(t, filepath.Join(dataDir, "themes", "themes.json"))["themes"].([]any)
+	for _, raw := range themes {
+		if _, ok := raw.(map[string]any)["routines"]; ok {
+			t.Errorf("theme %v still has embedded routines", raw.(map[string]any)["id"])
+		}
+	}
+
+	// v4: routine-tagged task backfilled.
+	task := loadTaskFromDisk(t, dataDir, "T3")
+	if task.RoutineRef == nil || task.RoutineRef.RoutineID != "R1" {
+		t.Errorf("T3 RoutineRef = %+v, want R1", task.RoutineRef)
+	}
+
+	// v5: advisor toggle moved into settings.json.
+	settings, err := access.ReadSettingsFile(dataDir)
+	if err != nil {
+		t.Fatalf("ReadSettingsFile: %v", err)
+	}
+	if !settings.Advisor.Enabled {
+		t.Error("advisor should stay enabled after moving into settings.json")
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "advisor_settings.json")); !os.IsNotExist(err) {
+		t.Errorf("advisor_settings.json should be removed, stat err = %v", err)
+	}
+
+	// v6: the seeded progress value becomes the key result's first check-in.
+	themeAccess, err := access.NewThemeAccess(dataDir, repo)
+	if err != nil {
+		t.Fatalf("NewThemeAccess: %v", err)
+	}
+	loaded, err := themeAccess.GetThemes()
+	if err != nil {
+		t.Fatalf("GetThemes: %v", err)
+	}
+	kr := loaded[0].Objectives[0].KeyResults[0]
+	if len(kr.CheckIns) != 1 || kr.CheckIns[0].ID != "C1" || kr.CheckIns[0].Value != 3 || kr.CheckIns[0].Timestamp.IsZero() {
+		t.Errorf("H-KR1 check-ins = %+v, want one check-in with value 3", kr.CheckIns)
+	}
+
+	// v7: the legacy key result gains an explicit direction.
+	if kr.Direction != access.KRDirectionIncrease {
+		t.Errorf("H-KR1 direction = %q, want %q", kr.Direction, access.KRDirectionIncrease)
+	}
+}
+
+func TestUnit_RunMigrations_IdempotentSecondRun(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "legacy_v0")
+	env := testMigrationEnv(dataDir)
+
+	if _, err := runMigrations(env, repo, registeredMigrations, false); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	afterFirst := commitCount(t, repo)
+
+	results, err := runMigrations(env, repo, registeredMigrations, false)
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no pending migrations on second run, got %d", len(results))
+	}
+	if got := commitCount(t, repo); got != afterFirst {
+		t.Errorf("commit count changed from %d to %d on re-run", afterFirst, got)
+	}
+}
+
+func TestUnit_RunMigrations_DryRunWritesNothing(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "legacy_v0")
+	pre := commitCount(t, repo)
+	calPath := filepath.Join(dataDir, "calendar", "2025.json")
+	before, _ := os.ReadFile(calPath)
+
+	results, err := runMigrations(testMigrationEnv(dataDir), repo, registeredMigrations, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+
+	if len(results) != len(registeredMigrations) {
+		t.Fatalf("expected %d planned migrations, got %d", len(registeredMigrations), len(results))
+	}
+	for _, r := range results {
+		if r.Committed {
+			t.Errorf("dry run committed v%d", r.Version)
+		}
+		if len(r.Changes) == 0 {
+			t.Errorf("dry run reported no changes for v%d (%s)", r.Version, r.Name)
+		}
+	}
+	if got := commitCount(t, repo); got != pre {
+		t.Errorf("dry run created commits: %d -> %d", pre, got)
+	}
+	after, _ := os.ReadFile(calPath)
+	if string(before) != string(after) {
+		t.Error("dry run modified calendar file")
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, schemaVersionFile)); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote %s", schemaVersionFile)
+	}
+}
+
+func TestUnit_MigrateDataDir_DryRunCreatesNoRepository(t *testing.T) {
+	dataDir := t.TempDir()
+
+	if _, err := MigrateDataDir(dataDir, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, ".git")); !os.IsNotExist(err) {
+		t.Error("dry run initialised a repository")
+	}
+}
+
+func TestUnit_RunMigrations_RefusesNewerSchema(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "")
+	if err := writeSchemaVersion(dataDir, LatestSchemaVersion()+1); err != nil {
+		t.Fatalf("write version: %v", err)
+	}
+
+	_, err := runMigrations(testMigrationEnv(dataDir), repo, registeredMigrations, false)
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("expected ErrSchemaTooNew, got %v", err)
+	}
+}
+
+func TestUnit_RunMigrations_FreshDirectoryRecordsVersionOnce(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "")
+	pre := commitCount(t, repo)
+
+	if _, err := runMigrations(testMigrationEnv(dataDir), repo, registeredMigrations, false); err != nil {
+		t.Fatalf("runMigrations: %v", err)
+	}
+
+	if got := commitCount(t, repo) - pre; got != 1 {
+		t.Errorf("expected a single version commit on a fresh directory, got %d", got)
+	}
+	if v, _ := readSchemaVersion(dataDir); v != LatestSchemaVersion() {
+		t.Errorf("schema version = %d, want %d", v, LatestSchemaVersion())
+	}
+}
+
+func TestUnit_RunMigrations_SkipsAppliedAndStopsOnError(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "")
+	if err := writeSchemaVersion(dataDir, 1); err != nil {
+		t.Fatalf("write version: %v", err)
+	}
+
+	var ran []int
+	step := func(v int, fail bool) migration {
+		return migration{version: v, name: "step", plan: func(migrationEnv) ([]migrationChange, error) {
+			ran = append(ran, v)
+			if fail {
+				return nil, errors.New("boom")
+			}
+			return nil, nil
+		}}
+	}
+	registry := []migration{step(1, false), step(2, false), step(3, true), step(4, false)}
+
+	if _, err := runMigrations(testMigrationEnv(dataDir), repo, registry, false); err == nil {
+		t.Fatal("expected error from failing step")
+	}
+	if !reflect.DeepEqual(ran, []int{2, 3}) {
+		t.Errorf("ran steps %v, want [2 3]", ran)
+	}
+	if v, _ := readSchemaVersion(dataDir); v != 1 {
+		t.Errorf("schema version = %d, want unchanged 1", v)
+	}
+}
+
+func TestUnit_ApplyMigration_FailureRestoresWorkTree(t *testing.T) {
+	dataDir, repo := setupFixtureDataDir(t, "legacy_v0")
+	calPath := filepath.Join(dataDir, "calendar", "2025.json")
+	newPath := filepath.Join(dataDir, "new.json")
+	before, _ := os.ReadFile(calPath)
+
+	m := migration{version: 1, name: "step", plan: func(migrationEnv) ([]migrationChange, error) {
+		return []migrationChange{
+			{summary: "rewrite calendar", apply: func() error { return os.WriteFile(calPath, []byte("{}"), 0644) }},
+			{summary: "add file", apply: func() error { return os.WriteFile(newPath, []byte("{}"), 0644) }},
+			{summary: "fail", apply: func() error { return errors.New("boom") }},
+		}, nil
+	}}
+
+	if _, err := applyMigration(testMigrationEnv(dataDir), repo, m); err == nil {
+		t.Fatal("expected error from failing change")
+	}
+	if after, _ := os.ReadFile(calPath); string(after) != string(before) {
+		t.Error("failed step left the calendar file rewritten")
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Error("failed step left new.json behind")
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, schemaVersionFile)); !os.IsNotExist(err) {
+		t.Errorf("failed step left %s behind", schemaVersionFile)
+	}
+}
+
+func TestUnit_PlanFlattenTaskDirs_CollisionAborts(t *testing.T) {
+	dataDir := t.TempDir()
+	for _, rel := range []string{"tasks/H/todo/T1.json", "tasks/todo/T1.json"} {
+		path := filepath.Join(dataDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(`{"id":"T1"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := planFlattenTaskDirs(testMigrationEnv(dataDir)); err == nil {
+		t.Fatal("expected collision error")
+	}
+}
+
+func TestUnit_RegisteredMigrations_StrictlyIncreasing(t *testing.T) {
+	for i := 1; i < len(registeredMigrations); i++ {
+		if registeredMigrations[i].version <= registeredMigrations[i-1].version {
+			t.Errorf("migration %q (v%d) is not after v%d",
+				registeredMigrations[i].name, registeredMigrations[i].version, registeredMigrations[i-1].version)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
 )
 
@@ -71,14 +72,15 @@ type rawSuggestion struct {
 	Title    string `json:"title,omitempty"`
 	ParentID string `json:"parentId,omitempty"`
 	// Key result fields
-	Description       string `json:"description,omitempty"`
-	StartValue        int    `json:"startValue,omitempty"`
-	CurrentValue      int    `json:"currentValue,omitempty"`
-	TargetValue       int    `json:"targetValue,omitempty"`
-	ParentObjectiveID string `json:"parentObjectiveId,omitempty"`
+	Description       string  `json:"description,omitempty"`
+	StartValue        float64 `json:"startValue,omitempty"`
+	CurrentValue      float64 `json:"currentValue,omitempty"`
+	TargetValue       float64 `json:"targetValue,omitempty"`
+	Unit              string  `json:"unit,omitempty"`
+	Direction         string  `json:"direction,omitempty"`
+	ParentObjectiveID string  `json:"parentObjectiveId,omitempty"`
 	// Legacy fields — parsed but not propagated to typed DTOs.
 	TargetType string `json:"targetType,omitempty"`
-	Unit       string `json:"unit,omitempty"`
 	ThemeID    string `json:"themeId,omitempty"`
 }
 
@@ -179,6 +181,8 @@ func convertRawSuggestion(raw rawSuggestion) (Suggestion, string, bool) {
 			StartValue:        raw.StartValue,
 			CurrentValue:      raw.CurrentValue,
 			TargetValue:       raw.TargetValue,
+			Unit:              raw.Unit,
+			Direction:         raw.Direction,
 			ParentObjectiveID: raw.ParentObjectiveID,
 		}
 		if isEdit {
@@ -220,14 +224,16 @@ func (ce *ChatEngine) buildSystemPrompt(okrData []OKRContext) string {
 	b.WriteString("Fields by type:\n")
 	b.WriteString("- theme: name, color (optional), id (required for edit)\n")
 	b.WriteString("- objective: title, parentId (theme or parent objective ID), id (required for edit)\n")
-	b.WriteString("- key_result: description, startValue, currentValue, targetValue, parentObjectiveId, id (required for edit)\n")
+	b.WriteString("- key_result: description, startValue, currentValue, targetValue, parentObjectiveId, unit (optional), direction (optional: \"increase\" or \"decrease\"), id (required for edit)\n")
 	b.WriteString("- routine: description, themeId, id (required for edit)\n\n")
 	b.WriteString("Reference existing items by their actual IDs shown in the OKR context above.\n\n")
 
 	// Auto-extraction instructions.
 	b.WriteString("When the user describes goals in natural language, automatically extract ")
 	b.WriteString("start and target values for key results. For example, ")
-	b.WriteString("\"I want to read 12 books this year\" implies startValue=0 and targetValue=12.\n\n")
+	b.WriteString("\"I want to read 12 books this year\" implies startValue=0 and targetValue=12. ")
+	b.WriteString("Values may be decimals. \"Reduce screen time from 4h to 2h\" implies startValue=4, ")
+	b.WriteString("targetValue=2, unit=\"h\" and direction=\"decrease\".\n\n")
 
 	if len(okrData) == 0 {
 		ce.writeEmptyContextPrompt(&b)
@@ -262,7 +268,11 @@ func (ce *ChatEngine) writeOKRContextPrompt(b *strings.Builder, okrData []OKRCon
 		if len(ctx.Routines) > 0 {
 			b.WriteString("\nRoutines:\n")
 			for _, r := range ctx.Routines {
-				fmt.Fprintf(b, "  - %s (ID: %s)\n", r.Description, r.ID)
+				fmt.Fprintf(b, "  - %s (ID: %s", r.Description, r.ID)
+				if r.KeyResultID != "" {
+					fmt.Fprintf(b, ", key result: %s", r.KeyResultID)
+				}
+				b.WriteString(")\n")
 			}
 		}
 
@@ -279,11 +289,26 @@ func (ce *ChatEngine) writeObjectives(b *strings.Builder, objectives []OKRObject
 		if status == "" {
 			status = "active"
 		}
-		fmt.Fprintf(b, "%sObjective: %s (ID: %s, status: %s)\n", indent, obj.Title, obj.ID, status)
+		fmt.Fprintf(b, "%sObjective: %s (ID: %s, status: %s)", indent, obj.Title, obj.ID, status)
+		if obj.Weight > 0 {
+			fmt.Fprintf(b, " weight=%s", formatKRValue(obj.Weight, ""))
+		}
+		b.WriteString("\n")
 
 		for _, kr := range obj.KeyResults {
-			fmt.Fprintf(b, "%s  KR: %s (ID: %s) start=%d current=%d target=%d\n",
-				indent, kr.Description, kr.ID, kr.StartValue, kr.CurrentValue, kr.TargetValue)
+			fmt.Fprintf(b, "%s  KR: %s (ID: %s) start=%s current=%s target=%s",
+				indent, kr.Description, kr.ID,
+				formatKRValue(kr.StartValue, kr.Unit), formatKRValue(kr.CurrentValue, kr.Unit), formatKRValue(kr.TargetValue, kr.Unit))
+			if kr.Direction == "decrease" {
+				b.WriteString(" (decreasing)")
+			}
+			if kr.Type != "" && kr.Type != "metric" {
+				fmt.Fprintf(b, " type=%s", kr.Type)
+			}
+			if kr.Weight > 0 {
+				fmt.Fprintf(b, " weight=%s", formatKRValue(kr.Weight, ""))
+			}
+			b.WriteString("\n")
 		}
 
 		if len(obj.Children) > 0 {
@@ -292,6 +317,19 @@ func (ce *ChatEngine) writeObjectives(b *strings.Builder, objectives []OKRObject
 	}
 }
 
+// formatKRValue renders a key result value without trailing zeros, followed
+// by its unit when there is one.
+func formatKRValue(v float64, unit string) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if unit == "" {
+		return s
+	}
+	if unit == "%" {
+		return s + unit
+	}
+	return s + " " + unit
+}
+
 // sanitizeChatML strips ChatML role markers from user content to prevent
 // prompt injection.
 func sanitizeChatML(content string) string {
@@ -47,6 +47,7 @@ func TestUnit_ChatEngine_AssembleConversation_WithOKRContext(t *testing.T) {
 				{
 					ID:          "routine-1",
 					Description: "Daily exercise",
+					KeyResultID: "kr-1",
 				},
 			},
 		},
@@ -71,7 +72,7 @@ func TestUnit_ChatEngine_AssembleConversation_WithOKRContext(t *testing.T) {
 	assertContains(t, system.Content, "Run 100 miles")
 	assertContains(t, system.Content, "kr-1")
 	assertContains(t, system.Content, "Daily exercise")
-	assertContains(t, system.Content, "routine-1")
+	assertContains(t, system.Content, "Daily exercise (ID: routine-1, key result: kr-1)")
 
 	user := messages[1]
 	if user.Role != "user" {
@@ -82,6 +83,46 @@ func TestUnit_ChatEngine_AssembleConversation_WithOKRContext(t *testing.T) {
 	}
 }
 
+func TestUnit_ChatEngine_AssembleConversation_DecimalAndDecreasingKeyResults(t *testing.T) {
+	engine := NewChatEngine()
+
+	okrData := []OKRContext{{
+		ThemeID:   "theme-1",
+		ThemeName: "Health",
+		Objectives: []OKRObjective{{
+			ID:    "obj-1",
+			Title: "Get lighter",
+			KeyResults: []OKRKeyResult{
+				{ID: "kr-1", Description: "Body weight", Direction: "decrease", Unit: "kg", StartValue: 92.5, CurrentValue: 90.25, TargetValue: 85, Weight: 2},
+				{ID: "kr-2", Description: "Body fat", Type: "percentage", Unit: "%", StartValue: 24, CurrentValue: 22.5, TargetValue: 18, Direction: "decrease"},
+			},
+		}},
+	}}
+
+	system := engine.AssembleConversation(okrData, nil, "How am I doing?")[0].Content
+
+	assertContains(t, system, "start=92.5 kg current=90.25 kg target=85 kg (decreasing) weight=2")
+	assertContains(t, system, "start=24% current=22.5% target=18% (decreasing) type=percentage")
+}
+
+func TestUnit_ChatEngine_ParseSuggestions_UnitAndDirection(t *testing.T) {
+	engine := NewChatEngine()
+
+	input := "```bearing-suggestion\n" +
+		`{"type": "key_result", "action": "create", "description": "Reach 80 kg", "startValue": 92.5, "targetValue": 80, "unit": "kg", "direction": "decrease", "parentObjectiveId": "obj-1"}` + "\n" +
+		"```"
+
+	_, suggestions := engine.ParseSuggestions(input)
+
+	if len(suggestions) != 1 || suggestions[0].KeyResultData == nil {
+		t.Fatalf("expected 1 key result suggestion, got %+v", suggestions)
+	}
+	kr := suggestions[0].KeyResultData
+	if kr.StartValue != 92.5 || kr.TargetValue != 80 || kr.Unit != "kg" || kr.Direction != "decrease" {
+		t.Errorf("unexpected key result data: %+v", kr)
+	}
+}
+
 func TestUnit_ChatEngine_AssembleConversation_WithNestedObjectives(t *testing.T) {
 	engine := NewChatEngine()
 
@@ -486,7 +527,7 @@ func TestUnit_ChatEngine_ParseSuggestions_AllTypes(t *testing.T) {
 		t.Errorf("expected KR description 'Run 500 miles', got %q", suggestions[2].KeyResultData.Description)
 	}
 	if suggestions[2].KeyResultData.TargetValue != 500 {
-		t.Errorf("expected KR targetValue 500, got %d", suggestions[2].KeyResultData.TargetValue)
+		t.Errorf("expected KR targetValue 500, got %g", suggestions[2].KeyResultData.TargetValue)
 	}
 	if suggestions[2].KeyResultData.ParentObjectiveID != "obj-1" {
 		t.Errorf("expected KR parentObjectiveId 'obj-1', got %q", suggestions[2].KeyResultData.ParentObjectiveID)
@@ -547,13 +588,13 @@ func TestUnit_ChatEngine_ParseSuggestions_AutoExtractedValues(t *testing.T) {
 		t.Fatal("expected KeyResultData to be populated")
 	}
 	if kr.StartValue != 0 {
-		t.Errorf("expected KR startValue 0, got %d", kr.StartValue)
+		t.Errorf("expected KR startValue 0, got %g", kr.StartValue)
 	}
 	if kr.CurrentValue != 0 {
-		t.Errorf("expected KR currentValue 0, got %d", kr.CurrentValue)
+		t.Errorf("expected KR currentValue 0, got %g", kr.CurrentValue)
 	}
 	if kr.TargetValue != 3 {
-		t.Errorf("expected KR targetValue 3, got %d", kr.TargetValue)
+		t.Errorf("expected KR targetValue 3, got %g", kr.TargetValue)
 	}
 	if kr.ParentObjectiveID != "obj-fitness" {
 		t.Errorf("expected KR parentObjectiveId 'obj-fitness', got %q", kr.ParentObjectiveID)
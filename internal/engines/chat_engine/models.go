@@ -23,24 +23,32 @@ type OKRObjective struct {
 	ID         string         `json:"id"`
 	Title      string         `json:"title"`
 	Status     string         `json:"status,omitempty"`
+	Weight     float64        `json:"weight,omitempty"` // 0 = default weight of 1
 	KeyResults []OKRKeyResult `json:"keyResults,omitempty"`
 	Children   []OKRObjective `json:"children,omitempty"`
 }
 
 // OKRKeyResult represents a key result with start, current, and target values.
+// Type, Direction and Unit are empty for plain increasing metrics.
 type OKRKeyResult struct {
-	ID           string `json:"id"`
-	Description  string `json:"description"`
-	StartValue   int    `json:"startValue"`
-	CurrentValue int    `json:"currentValue"`
-	TargetValue  int    `json:"targetValue"`
+	ID           string  `json:"id"`
+	Description  string  `json:"description"`
+	Type         string  `json:"type,omitempty"`
+	Direction    string  `json:"direction,omitempty"`
+	Unit         string  `json:"unit,omitempty"`
+	Weight       float64 `json:"weight,omitempty"` // 0 = default weight of 1
+	StartValue   float64 `json:"startValue"`
+	CurrentValue float64 `json:"currentValue"`
+	TargetValue  float64 `json:"targetValue"`
 }
 
 // OKRRoutine represents a routine. Periodic routines have a repeat pattern;
 // sporadic routines have none. Numeric tracking is no longer part of the model.
+// KeyResultID names the key result the routine supports, if any.
 type OKRRoutine struct {
 	ID          string `json:"id"`
 	Description string `json:"description"`
+	KeyResultID string `json:"keyResultId,omitempty"`
 }
 
 // AdviceResponse is the response from the advisor containing text and
@@ -77,12 +85,14 @@ type ObjectiveSuggestion struct {
 
 // KeyResultSuggestion holds data for a suggested key result creation or edit.
 type KeyResultSuggestion struct {
-	ID                string `json:"id,omitempty"`
-	Description       string `json:"description"`
-	StartValue        int    `json:"startValue"`
-	CurrentValue      int    `json:"currentValue"`
-	TargetValue       int    `json:"targetValue"`
-	ParentObjectiveID string `json:"parentObjectiveId,omitempty"`
+	ID                string  `json:"id,omitempty"`
+	Description       string  `json:"description"`
+	StartValue        float64 `json:"startValue"`
+	CurrentValue      float64 `json:"currentValue"`
+	TargetValue       float64 `json:"targetValue"`
+	Unit              string  `json:"unit,omitempty"`
+	Direction         string  `json:"direction,omitempty"`
+	ParentObjectiveID string  `json:"parentObjectiveId,omitempty"`
 }
 
 // RoutineSuggestion holds data for a suggested routine creation or edit.
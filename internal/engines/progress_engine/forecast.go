@@ -0,0 +1,241 @@
+package progress_engine
+
+import "time"
+
+// Forecast tuning. A key result whose forecast reaches atRiskForecastProgress
+// is at risk rather than off track; regression needs minRegressionPoints
+// observations to be more informative than the average pace.
+const (
+	atRiskForecastProgress = 70.0
+	minRegressionPoints    = 3
+	// binaryAtRiskElapsed is the share of the window after which an
+	// unfinished binary key result is at risk.
+	binaryAtRiskElapsed = 0.75
+)
+
+// window is the time span a goal is measured over. end is exclusive: the
+// day after the goal's last day.
+type window struct {
+	start, end time.Time
+}
+
+// goalWindow returns the window of a goal running from start through its
+// last day inclusive, or the zero window when either date is missing.
+func goalWindow(start, last time.Time) window {
+	if start.IsZero() || last.IsZero() {
+		return window{}
+	}
+	return window{start: start, end: last.AddDate(0, 0, 1)}
+}
+
+func (w window) valid() bool {
+	return !w.start.IsZero() && !w.end.IsZero() && w.end.After(w.start)
+}
+
+// elapsed returns the share of the window that has passed at t, clamped to 0-1.
+func (w window) elapsed(t time.Time) float64 {
+	f := days(w.start, t) / days(w.start, w.end)
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// days returns the number of days from a to b.
+func days(a, b time.Time) float64 {
+	return b.Sub(a).Hours() / 24
+}
+
+// ComputeForecasts classifies every active key result and objective as of
+// today. Key results take their window from the nearest enclosing objective
+// that has both a start and an end date.
+func (pe *ProgressEngine) ComputeForecasts(themes []ThemeData, today time.Time) []ThemeForecast {
+	result := make([]ThemeForecast, 0, len(themes))
+	for _, theme := range themes {
+		tf := ThemeForecast{
+			ThemeID:    theme.ID,
+			Objectives: []ObjectiveForecast{},
+			KeyResults: []KeyResultForecast{},
+		}
+		for _, obj := range theme.Objectives {
+			if !isActiveOKRStatus(obj.Status) {
+				continue
+			}
+			pe.forecastObjective(obj, window{}, today, &tf)
+		}
+		result = append(result, tf)
+	}
+	return result
+}
+
+// forecastObjective appends the forecasts of obj, its key results and its
+// descendants to tf and returns the objective's own forecast.
+func (pe *ProgressEngine) forecastObjective(obj ObjectiveData, inherited window, today time.Time, tf *ThemeForecast) ObjectiveForecast {
+	w := inherited
+	if own := goalWindow(obj.StartDate, obj.EndDate); own.valid() {
+		w = own
+	}
+
+	var known []ObjectiveForecast
+	var weights []float64
+	for _, kr := range obj.KeyResults {
+		if !isActiveOKRStatus(kr.Status) {
+			continue
+		}
+		f := forecastKeyResult(kr, w, today)
+		tf.KeyResults = append(tf.KeyResults, f)
+		if f.Status != ForecastUnknown {
+			known = append(known, ObjectiveForecast{
+				Progress:         f.Progress,
+				ExpectedProgress: f.ExpectedProgress,
+				ForecastProgress: f.ForecastProgress,
+				Status:           f.Status,
+			})
+			weights = append(weights, effectiveWeight(kr.Weight))
+		}
+	}
+	for _, child := range obj.Objectives {
+		if !isActiveOKRStatus(child.Status) {
+			continue
+		}
+		if f := pe.forecastObjective(child, w, today, tf); f.Status != ForecastUnknown {
+			known = append(known, f)
+			weights = append(weights, effectiveWeight(child.Weight))
+		}
+	}
+
+	of := ObjectiveForecast{ObjectiveID: obj.ID, Progress: -1, ExpectedProgress: -1, ForecastProgress: -1, Status: ForecastUnknown}
+	if len(known) > 0 {
+		var progress, expected, forecast, total float64
+		for i, k := range known {
+			progress += weights[i] * k.Progress
+			expected += weights[i] * k.ExpectedProgress
+			forecast += weights[i] * k.ForecastProgress
+			total += weights[i]
+		}
+		of.Progress = progress / total
+		of.ExpectedProgress = expected / total
+		of.ForecastProgress = forecast / total
+		of.Status = classify(of.Progress, of.ExpectedProgress, of.ForecastProgress, w.valid() && !today.Before(w.end))
+	}
+	tf.Objectives = append(tf.Objectives, of)
+	return of
+}
+
+// forecastKeyResult computes the forecast of a single key result within w.
+func forecastKeyResult(kr KeyResultData, w window, today time.Time) KeyResultForecast {
+	f := KeyResultForecast{
+		KeyResultID:      kr.ID,
+		Progress:         -1,
+		ExpectedProgress: -1,
+		ForecastValue:    kr.CurrentValue,
+		ForecastProgress: -1,
+		Status:           ForecastUnknown,
+	}
+	progress := computeKRProgress(kr)
+	if progress < 0 || !w.valid() {
+		return f
+	}
+
+	elapsed := w.elapsed(today)
+	ended := elapsed >= 1
+	f.Progress = progress
+	f.ExpectedProgress = elapsed * 100
+
+	if kr.Type == "binary" {
+		f.ForecastProgress = progress
+		switch {
+		case progress >= 100:
+			f.Status = ForecastOnTrack
+		case ended:
+			f.Status = ForecastOffTrack
+		case elapsed >= binaryAtRiskElapsed:
+			f.Status = ForecastAtRisk
+		default:
+			f.Status = ForecastOnTrack
+		}
+		return f
+	}
+
+	f.ForecastValue, f.Method = forecastValue(kr, w, today)
+	f.ForecastProgress = krProgressAt(kr, f.ForecastValue)
+	f.Status = classify(f.Progress, f.ExpectedProgress, f.ForecastProgress, ended)
+	return f
+}
+
+// forecastValue projects the key result's value at the end of w. With enough
+// history it fits a least-squares line through the observations (anchored at
+// the start value when no observation predates the window); otherwise it
+// extrapolates the average pace from the start date to today. Before the
+// window opens and after it closes the current value is the forecast.
+func forecastValue(kr KeyResultData, w window, today time.Time) (float64, string) {
+	current := kr.CurrentValue
+	if !today.After(w.start) || !today.Before(w.end) {
+		return current, ForecastMethodLinear
+	}
+
+	if len(kr.History) >= minRegressionPoints {
+		xs := make([]float64, 0, len(kr.History)+1)
+		ys := make([]float64, 0, len(kr.History)+1)
+		if kr.History[0].At.After(w.start) {
+			xs = append(xs, 0)
+			ys = append(ys, kr.StartValue)
+		}
+		for _, p := range kr.History {
+			xs = append(xs, days(w.start, p.At))
+			ys = append(ys, p.Value)
+		}
+		if slope, intercept, ok := linearRegression(xs, ys); ok {
+			return intercept + slope*days(w.start, w.end), ForecastMethodRegression
+		}
+	}
+
+	pace := (current - kr.StartValue) / days(w.start, today)
+	return kr.StartValue + pace*days(w.start, w.end), ForecastMethodLinear
+}
+
+// linearRegression fits y = intercept + slope*x by least squares. ok is false
+// when the xs have no spread.
+func linearRegression(xs, ys []float64) (slope, intercept float64, ok bool) {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		sxx += dx * dx
+		sxy += dx * (ys[i] - meanY)
+	}
+	if sxx == 0 {
+		return 0, 0, false
+	}
+	slope = sxy / sxx
+	return slope, meanY - slope*meanX, true
+}
+
+// classify maps progress figures to a status. A goal is on track when it is
+// at or ahead of the expected progress or forecast to reach its target, at
+// risk when the forecast falls only a little short, and off track otherwise.
+// Once the window has ended only completed goals count as on track.
+func classify(progress, expected, forecast float64, ended bool) ForecastStatus {
+	switch {
+	case progress >= 100:
+		return ForecastOnTrack
+	case ended:
+		return ForecastOffTrack
+	case progress >= expected || forecast >= 100:
+		return ForecastOnTrack
+	case forecast >= atRiskForecastProgress:
+		return ForecastAtRisk
+	default:
+		return ForecastOffTrack
+	}
+}
@@ -0,0 +1,201 @@
+package progress_engine
+
+import (
+	"testing"
+	"time"
+)
+
+// Fixed forecast window: 30 days, 1 to 30 January inclusive, with "today"
+// exactly half way through. fcEnd is the exclusive window end, fcLast the
+// objective's last day.
+var (
+	fcStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fcLast  = time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC)
+	fcEnd   = time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	fcToday = time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)
+)
+
+func fcDay(n int) time.Time {
+	return fcStart.AddDate(0, 0, n)
+}
+
+func TestUnit_ForecastKeyResult(t *testing.T) {
+	w := window{start: fcStart, end: fcEnd}
+	tests := []struct {
+		name         string
+		kr           KeyResultData
+		today        time.Time
+		wantValue    float64
+		wantProgress float64
+		wantMethod   string
+		wantStatus   ForecastStatus
+	}{
+		{"ahead of pace", KeyResultData{StartValue: 0, CurrentValue: 60, TargetValue: 100}, fcToday, 120, 100, ForecastMethodLinear, ForecastOnTrack},
+		{"slightly behind", KeyResultData{StartValue: 0, CurrentValue: 40, TargetValue: 100}, fcToday, 80, 80, ForecastMethodLinear, ForecastAtRisk},
+		{"far behind", KeyResultData{StartValue: 0, CurrentValue: 20, TargetValue: 100}, fcToday, 40, 40, ForecastMethodLinear, ForecastOffTrack},
+		{"decreasing target", KeyResultData{StartValue: 100, CurrentValue: 80, TargetValue: 50}, fcToday, 60, 80, ForecastMethodLinear, ForecastAtRisk},
+		{"regression over history", KeyResultData{StartValue: 0, CurrentValue: 30, TargetValue: 100, History: []ValuePoint{
+			{At: fcDay(5), Value: 10}, {At: fcDay(10), Value: 20}, {At: fcDay(15), Value: 30},
+		}}, fcToday, 60, 60, ForecastMethodRegression, ForecastOffTrack},
+		{"before window", KeyResultData{StartValue: 0, CurrentValue: 0, TargetValue: 100}, fcDay(-3), 0, 0, ForecastMethodLinear, ForecastOnTrack},
+		{"window ended short", KeyResultData{StartValue: 0, CurrentValue: 90, TargetValue: 100}, fcDay(40), 90, 90, ForecastMethodLinear, ForecastOffTrack},
+		{"window ended complete", KeyResultData{StartValue: 0, CurrentValue: 100, TargetValue: 100}, fcDay(40), 100, 100, ForecastMethodLinear, ForecastOnTrack},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := forecastKeyResult(tt.kr, w, tt.today)
+			if !floatEqual(got.ForecastValue, tt.wantValue) || !floatEqual(got.ForecastProgress, tt.wantProgress) {
+				t.Errorf("forecast = %.2f (%.2f%%), want %.2f (%.2f%%)", got.ForecastValue, got.ForecastProgress, tt.wantValue, tt.wantProgress)
+			}
+			if got.Method != tt.wantMethod || got.Status != tt.wantStatus {
+				t.Errorf("method/status = %s/%s, want %s/%s", got.Method, got.Status, tt.wantMethod, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestUnit_ForecastKeyResult_ExpectedProgress(t *testing.T) {
+	got := forecastKeyResult(KeyResultData{CurrentValue: 5, TargetValue: 10}, window{start: fcStart, end: fcEnd}, fcDay(6))
+	if !floatEqual(got.ExpectedProgress, 20) || !floatEqual(got.Progress, 50) {
+		t.Errorf("expected/progress = %.2f/%.2f, want 20/50", got.ExpectedProgress, got.Progress)
+	}
+}
+
+func TestUnit_ForecastKeyResult_Binary(t *testing.T) {
+	w := window{start: fcStart, end: fcEnd}
+	open := KeyResultData{Type: "binary", TargetValue: 1}
+	done := KeyResultData{Type: "binary", CurrentValue: 1, TargetValue: 1}
+
+	if s := forecastKeyResult(open, w, fcToday).Status; s != ForecastOnTrack {
+		t.Errorf("open binary at 50%% = %s, want on-track", s)
+	}
+	if s := forecastKeyResult(open, w, fcDay(25)).Status; s != ForecastAtRisk {
+		t.Errorf("open binary at 83%% = %s, want at-risk", s)
+	}
+	if s := forecastKeyResult(open, w, fcDay(31)).Status; s != ForecastOffTrack {
+		t.Errorf("open binary after end = %s, want off-track", s)
+	}
+	if s := forecastKeyResult(done, w, fcDay(31)).Status; s != ForecastOnTrack {
+		t.Errorf("done binary = %s, want on-track", s)
+	}
+}
+
+func TestUnit_ForecastKeyResult_Unknown(t *testing.T) {
+	untracked := forecastKeyResult(KeyResultData{CurrentValue: 3}, window{start: fcStart, end: fcEnd}, fcToday)
+	noWindow := forecastKeyResult(KeyResultData{CurrentValue: 3, TargetValue: 10}, window{}, fcToday)
+	for name, f := range map[string]KeyResultForecast{"untracked": untracked, "no window": noWindow} {
+		if f.Status != ForecastUnknown || f.Progress != -1 || f.Method != "" {
+			t.Errorf("%s: got %+v, want unknown", name, f)
+		}
+	}
+}
+
+func TestUnit_ComputeForecasts_InheritsWindowAndAggregates(t *testing.T) {
+	pe := NewProgressEngine()
+	result := pe.ComputeForecasts([]ThemeData{
+		{ID: "T", Objectives: []ObjectiveData{
+			{ID: "O1", StartDate: fcStart, EndDate: fcLast, Objectives: []ObjectiveData{
+				{ID: "O2", KeyResults: []KeyResultData{
+					{ID: "KR1", CurrentValue: 60, TargetValue: 100},
+					{ID: "KR2", CurrentValue: 20, TargetValue: 100},
+					{ID: "KR3", CurrentValue: 1}, // untracked, ignored in aggregates
+					{ID: "KR4", Status: "archived", TargetValue: 100},
+				}},
+			}},
+			{ID: "O3", KeyResults: []KeyResultData{{ID: "KR5", CurrentValue: 1, TargetValue: 10}}},
+		}},
+	}, fcToday)
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 theme, got %d", len(result))
+	}
+	krs := map[string]KeyResultForecast{}
+	for _, f := range result[0].KeyResults {
+		krs[f.KeyResultID] = f
+	}
+	if _, ok := krs["KR4"]; ok {
+		t.Error("archived KR should be skipped")
+	}
+	if krs["KR1"].Status != ForecastOnTrack || krs["KR2"].Status != ForecastOffTrack {
+		t.Errorf("KR1/KR2 = %s/%s, want on-track/off-track (window inherited from O1)", krs["KR1"].Status, krs["KR2"].Status)
+	}
+	if krs["KR5"].Status != ForecastUnknown {
+		t.Errorf("KR5 without window = %s, want unknown", krs["KR5"].Status)
+	}
+
+	objs := map[string]ObjectiveForecast{}
+	for _, f := range result[0].Objectives {
+		objs[f.ObjectiveID] = f
+	}
+	// O2 averages KR1 (60%, forecast 100%) and KR2 (20%, forecast 40%).
+	o2 := objs["O2"]
+	if !floatEqual(o2.Progress, 40) || !floatEqual(o2.ExpectedProgress, 50) || !floatEqual(o2.ForecastProgress, 70) || o2.Status != ForecastAtRisk {
+		t.Errorf("O2 = %+v, want 40/50/70 at-risk", o2)
+	}
+	if objs["O1"].Status != ForecastAtRisk {
+		t.Errorf("O1 = %s, want at-risk from its only child", objs["O1"].Status)
+	}
+	if objs["O3"].Status != ForecastUnknown || objs["O3"].Progress != -1 {
+		t.Errorf("O3 = %+v, want unknown", objs["O3"])
+	}
+}
+
+func TestUnit_ComputeForecasts_LastDayIsInsideWindow(t *testing.T) {
+	pe := NewProgressEngine()
+	themes := []ThemeData{{ID: "T", Objectives: []ObjectiveData{
+		{ID: "O1", StartDate: fcStart, EndDate: fcLast, KeyResults: []KeyResultData{
+			{ID: "KR1", Type: "binary", TargetValue: 1},
+		}},
+		{ID: "O2", StartDate: fcLast, EndDate: fcLast, KeyResults: []KeyResultData{
+			{ID: "KR2", CurrentValue: 5, TargetValue: 10},
+		}},
+	}}}
+
+	// On the last day a full day remains: an open binary KR is at risk, not
+	// off track, and a one-day window is known.
+	krs := map[string]KeyResultForecast{}
+	for _, f := range pe.ComputeForecasts(themes, fcLast)[0].KeyResults {
+		krs[f.KeyResultID] = f
+	}
+	if krs["KR1"].Status != ForecastAtRisk {
+		t.Errorf("KR1 on last day = %s, want at-risk", krs["KR1"].Status)
+	}
+	if krs["KR2"].Status == ForecastUnknown || !floatEqual(krs["KR2"].ExpectedProgress, 0) {
+		t.Errorf("KR2 in one-day window = %+v, want known with 0%% expected", krs["KR2"])
+	}
+
+	// The day after, the window has ended.
+	for _, f := range pe.ComputeForecasts(themes, fcEnd)[0].KeyResults {
+		if f.KeyResultID == "KR1" && f.Status != ForecastOffTrack {
+			t.Errorf("KR1 after last day = %s, want off-track", f.Status)
+		}
+	}
+}
+
+func TestUnit_ComputeForecasts_WeightedAggregate(t *testing.T) {
+	pe := NewProgressEngine()
+	result := pe.ComputeForecasts([]ThemeData{
+		{ID: "T", Objectives: []ObjectiveData{
+			{ID: "O1", StartDate: fcStart, EndDate: fcLast, KeyResults: []KeyResultData{
+				{ID: "KR1", CurrentValue: 60, TargetValue: 100, Weight: 3},
+				{ID: "KR2", CurrentValue: 20, TargetValue: 100},
+			}},
+		}},
+	}, fcToday)
+
+	// Progress (3*60 + 20) / 4 = 50, forecast (3*100 + 40) / 4 = 85.
+	o1 := result[0].Objectives[0]
+	if !floatEqual(o1.Progress, 50) || !floatEqual(o1.ForecastProgress, 85) || o1.Status != ForecastOnTrack {
+		t.Errorf("O1 = %+v, want 50/85 on-track", o1)
+	}
+}
+
+func TestUnit_LinearRegression(t *testing.T) {
+	slope, intercept, ok := linearRegression([]float64{0, 1, 2, 3}, []float64{1, 3, 5, 7})
+	if !ok || !floatEqual(slope, 2) || !floatEqual(intercept, 1) {
+		t.Errorf("got slope %.2f intercept %.2f ok %v, want 2, 1, true", slope, intercept, ok)
+	}
+	if _, _, ok := linearRegression([]float64{4, 4, 4}, []float64{1, 2, 3}); ok {
+		t.Error("expected ok=false for xs without spread")
+	}
+}
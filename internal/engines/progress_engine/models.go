@@ -3,19 +3,34 @@
 // without importing access layer types.
 package progress_engine
 
+import "time"
+
 // KeyResultData contains the key result fields needed for progress computation.
 type KeyResultData struct {
 	ID           string
+	Type         string // "" or "metric", "binary", "percentage", "milestone"
 	Status       string
-	StartValue   int
-	CurrentValue int
-	TargetValue  int
+	Direction    string  // "" or "increase", "decrease"
+	Weight       float64 // Relative weight in the objective's progress (0 = 1)
+	StartValue   float64
+	CurrentValue float64
+	TargetValue  float64
+	History      []ValuePoint // Observed values, oldest first
+}
+
+// ValuePoint is a key result value observed at a point in time.
+type ValuePoint struct {
+	At    time.Time
+	Value float64
 }
 
 // ObjectiveData contains the objective fields needed for progress computation.
 type ObjectiveData struct {
 	ID         string
 	Status     string
+	Weight     float64   // Relative weight in the parent's progress (0 = 1)
+	StartDate  time.Time // Zero when the objective has no time window
+	EndDate    time.Time // Zero when the objective has no time window
 	KeyResults []KeyResultData
 	Objectives []ObjectiveData
 }
@@ -26,15 +41,85 @@ type ThemeData struct {
 	Objectives []ObjectiveData
 }
 
+// Contribution kinds reported in Contribution.Kind.
+const (
+	ContributionKeyResult = "key-result"
+	ContributionObjective = "objective"
+)
+
+// Contribution is the part a child goal plays in its parent's progress. Only
+// active, tracked children contribute; their Points sum to the parent's
+// progress.
+type Contribution struct {
+	ID       string
+	Kind     string  // ContributionKeyResult or ContributionObjective
+	Progress float64 // The child's own progress, 0-100
+	Weight   float64 // Effective weight (an unset weight counts as 1)
+	Share    float64 // Weight relative to all contributing siblings, 0-1
+	Points   float64 // Share * Progress
+}
+
 // ObjectiveProgress represents the computed progress of an objective.
 type ObjectiveProgress struct {
-	ObjectiveID string
-	Progress    float64 // 0-100, or -1 if no data
+	ObjectiveID   string
+	Progress      float64 // 0-100, or -1 if no data
+	Contributions []Contribution
 }
 
 // ThemeProgress represents computed progress for a theme and its objectives.
 type ThemeProgress struct {
+	ThemeID       string
+	Progress      float64 // 0-100, weighted mean of top-level objective progresses
+	Objectives    []ObjectiveProgress
+	Contributions []Contribution // Top-level objectives
+}
+
+// ForecastStatus classifies whether a goal is expected to reach its target
+// by the end of its time window.
+type ForecastStatus string
+
+const (
+	ForecastOnTrack  ForecastStatus = "on-track"
+	ForecastAtRisk   ForecastStatus = "at-risk"
+	ForecastOffTrack ForecastStatus = "off-track"
+	// ForecastUnknown marks goals without a target or a time window.
+	ForecastUnknown ForecastStatus = "unknown"
+)
+
+// Forecast methods reported in KeyResultForecast.Method.
+const (
+	// ForecastMethodLinear extrapolates the average pace since the start date.
+	ForecastMethodLinear = "linear"
+	// ForecastMethodRegression fits a least-squares line through the history.
+	ForecastMethodRegression = "regression"
+)
+
+// KeyResultForecast is the forecast for a single key result. Progress values
+// are 0-100; they are -1 when Status is ForecastUnknown.
+type KeyResultForecast struct {
+	KeyResultID      string
+	Progress         float64 // Progress at the current value
+	ExpectedProgress float64 // Progress a steady pace would have reached today
+	ForecastValue    float64 // Projected value at the end date
+	ForecastProgress float64 // Progress at ForecastValue
+	Method           string  // ForecastMethod*, empty for binary and unknown KRs
+	Status           ForecastStatus
+}
+
+// ObjectiveForecast aggregates the forecasts of an objective's active key
+// results and child objectives. Progress values are weighted means over
+// children whose status is known, or -1 when there are none.
+type ObjectiveForecast struct {
+	ObjectiveID      string
+	Progress         float64
+	ExpectedProgress float64
+	ForecastProgress float64
+	Status           ForecastStatus
+}
+
+// ThemeForecast holds the forecasts of all active goals in a theme.
+type ThemeForecast struct {
 	ThemeID    string
-	Progress   float64 // 0-100, average of objective progresses
-	Objectives []ObjectiveProgress
+	Objectives []ObjectiveForecast
+	KeyResults []KeyResultForecast
 }
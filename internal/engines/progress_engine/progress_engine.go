@@ -1,9 +1,14 @@
 package progress_engine
 
+import "time"
+
 // IProgressEngine defines the interface for progress computation operations.
 type IProgressEngine interface {
 	// ComputeAllThemeProgress computes progress for all themes and their objectives.
 	ComputeAllThemeProgress(themes []ThemeData) []ThemeProgress
+	// ComputeForecasts classifies every active key result and objective as
+	// on track, at risk or off track as of today.
+	ComputeForecasts(themes []ThemeData, today time.Time) []ThemeForecast
 }
 
 // ProgressEngine implements IProgressEngine. It is stateless and computes
@@ -20,7 +25,7 @@ func (pe *ProgressEngine) ComputeAllThemeProgress(themes []ThemeData) []ThemePro
 	result := make([]ThemeProgress, 0, len(themes))
 	for _, theme := range themes {
 		var themeObjProgress []ObjectiveProgress
-		var topLevelProgressValues []float64
+		var topLevel []Contribution
 
 		for _, obj := range theme.Objectives {
 			if !isActiveOKRStatus(obj.Status) {
@@ -29,46 +34,81 @@ func (pe *ProgressEngine) ComputeAllThemeProgress(themes []ThemeData) []ThemePro
 			objProgress, nested := pe.computeObjectiveProgress(obj)
 			themeObjProgress = append(themeObjProgress, nested...)
 			if objProgress >= 0 {
-				topLevelProgressValues = append(topLevelProgressValues, objProgress)
+				topLevel = append(topLevel, Contribution{ID: obj.ID, Kind: ContributionObjective, Progress: objProgress, Weight: effectiveWeight(obj.Weight)})
 			}
 		}
 
-		var themeProgress float64
-		if len(topLevelProgressValues) == 0 {
-			themeProgress = -1
-		} else {
-			var sum float64
-			for _, v := range topLevelProgressValues {
-				sum += v
-			}
-			themeProgress = sum / float64(len(topLevelProgressValues))
-		}
+		themeProgress, contributions := rollUp(topLevel)
 
 		if themeObjProgress == nil {
 			themeObjProgress = []ObjectiveProgress{}
 		}
 
 		result = append(result, ThemeProgress{
-			ThemeID:    theme.ID,
-			Progress:   themeProgress,
-			Objectives: themeObjProgress,
+			ThemeID:       theme.ID,
+			Progress:      themeProgress,
+			Objectives:    themeObjProgress,
+			Contributions: contributions,
 		})
 	}
 
 	return result
 }
 
+// effectiveWeight returns w, counting an unset (zero or negative) weight as 1.
+func effectiveWeight(w float64) float64 {
+	if w > 0 {
+		return w
+	}
+	return 1
+}
+
+// rollUp computes the weighted mean of the children's progress and fills in
+// each child's share and points. Returns -1 and no contributions when no
+// child is tracked.
+func rollUp(children []Contribution) (float64, []Contribution) {
+	if len(children) == 0 {
+		return -1, nil
+	}
+	var total float64
+	for _, c := range children {
+		total += c.Weight
+	}
+	var progress float64
+	for i := range children {
+		children[i].Share = children[i].Weight / total
+		children[i].Points = children[i].Share * children[i].Progress
+		progress += children[i].Points
+	}
+	return progress, children
+}
+
 // computeKRProgress computes the progress percentage of a single key result.
-// Returns -1 if the KR is untracked (targetValue == 0).
+// Returns -1 if the KR is untracked (see isUntracked).
 func computeKRProgress(kr KeyResultData) float64 {
-	if kr.TargetValue == 0 {
+	return krProgressAt(kr, kr.CurrentValue)
+}
+
+// isUntracked reports whether the key result has no target. A zero target
+// means "untracked" for increasing key results only; decreasing ones may
+// legitimately aim for zero.
+func isUntracked(kr KeyResultData) bool {
+	return kr.TargetValue == 0 && kr.Direction != "decrease"
+}
+
+// krProgressAt computes the progress percentage the key result would have at
+// value, clamped to 0-100. Returns -1 if the KR is untracked. The formula
+// works for both directions: for a decreasing key result the range and the
+// distance travelled are both negative.
+func krProgressAt(kr KeyResultData, value float64) float64 {
+	if isUntracked(kr) {
 		return -1
 	}
-	rangeVal := float64(kr.TargetValue - kr.StartValue)
+	rangeVal := kr.TargetValue - kr.StartValue
 	if rangeVal == 0 {
 		return 0
 	}
-	progress := float64(kr.CurrentValue-kr.StartValue) / rangeVal * 100
+	progress := (value - kr.StartValue) / rangeVal * 100
 	if progress < 0 {
 		return 0
 	}
@@ -84,10 +124,11 @@ func isActiveOKRStatus(status string) bool {
 }
 
 // computeObjectiveProgress recursively computes progress for an objective
-// and collects all nested objective progress entries.
+// as the weighted mean of its active, tracked key results and child
+// objectives, and collects all nested objective progress entries.
 func (pe *ProgressEngine) computeObjectiveProgress(obj ObjectiveData) (float64, []ObjectiveProgress) {
 	var allObjProgress []ObjectiveProgress
-	var progressValues []float64
+	var children []Contribution
 
 	// Collect progress from active, tracked KRs
 	for _, kr := range obj.KeyResults {
@@ -96,7 +137,7 @@ func (pe *ProgressEngine) computeObjectiveProgress(obj ObjectiveData) (float64,
 		}
 		p := computeKRProgress(kr)
 		if p >= 0 {
-			progressValues = append(progressValues, p)
+			children = append(children, Contribution{ID: kr.ID, Kind: ContributionKeyResult, Progress: p, Weight: effectiveWeight(kr.Weight)})
 		}
 	}
 
@@ -108,24 +149,16 @@ func (pe *ProgressEngine) computeObjectiveProgress(obj ObjectiveData) (float64,
 		childProgress, childObjProgress := pe.computeObjectiveProgress(child)
 		allObjProgress = append(allObjProgress, childObjProgress...)
 		if childProgress >= 0 {
-			progressValues = append(progressValues, childProgress)
+			children = append(children, Contribution{ID: child.ID, Kind: ContributionObjective, Progress: childProgress, Weight: effectiveWeight(child.Weight)})
 		}
 	}
 
-	var progress float64
-	if len(progressValues) == 0 {
-		progress = -1
-	} else {
-		var sum float64
-		for _, v := range progressValues {
-			sum += v
-		}
-		progress = sum / float64(len(progressValues))
-	}
+	progress, contributions := rollUp(children)
 
 	allObjProgress = append(allObjProgress, ObjectiveProgress{
-		ObjectiveID: obj.ID,
-		Progress:    progress,
+		ObjectiveID:   obj.ID,
+		Progress:      progress,
+		Contributions: contributions,
 	})
 
 	return progress, allObjProgress
@@ -18,6 +18,9 @@ func TestUnit_ComputeKRProgress(t *testing.T) {
 		{"over 100 clamped", KeyResultData{StartValue: 0, CurrentValue: 150, TargetValue: 100}, 100},
 		{"below zero clamped", KeyResultData{StartValue: 50, CurrentValue: 10, TargetValue: 100}, 0},
 		{"nonzero start", KeyResultData{StartValue: 20, CurrentValue: 60, TargetValue: 100}, 50},
+		{"decimal values", KeyResultData{StartValue: 22.5, CurrentValue: 20.25, TargetValue: 18, Direction: "decrease"}, 50},
+		{"decreasing to zero", KeyResultData{StartValue: 4, CurrentValue: 1, TargetValue: 0, Direction: "decrease"}, 75},
+		{"decreasing overshoot clamped", KeyResultData{StartValue: 4, CurrentValue: 5, TargetValue: 2, Direction: "decrease"}, 0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -187,6 +190,62 @@ func TestUnit_ComputeAllThemeProgress_UntrackedKR(t *testing.T) {
 	}
 }
 
+func TestUnit_ComputeAllThemeProgress_WeightedRollUp(t *testing.T) {
+	pe := NewProgressEngine()
+	result := pe.ComputeAllThemeProgress([]ThemeData{
+		{ID: "T1", Objectives: []ObjectiveData{
+			{ID: "O1", Weight: 3,
+				KeyResults: []KeyResultData{
+					{ID: "KR1", CurrentValue: 100, TargetValue: 100, Weight: 3},
+					{ID: "KR2", CurrentValue: 0, TargetValue: 100},
+					{ID: "KR3", CurrentValue: 5}, // untracked, does not contribute
+				},
+				Objectives: []ObjectiveData{
+					{ID: "O2", KeyResults: []KeyResultData{
+						{ID: "KR4", CurrentValue: 50, TargetValue: 100},
+					}},
+				},
+			},
+			{ID: "O3", KeyResults: []KeyResultData{
+				{ID: "KR5", CurrentValue: 0, TargetValue: 10},
+			}},
+		}},
+	})
+
+	// O1 = (3*100 + 1*0 + 1*50) / 5 = 70
+	var o1 ObjectiveProgress
+	for _, op := range result[0].Objectives {
+		if op.ObjectiveID == "O1" {
+			o1 = op
+		}
+	}
+	if !floatEqual(o1.Progress, 70) {
+		t.Errorf("O1 progress = %f, want 70", o1.Progress)
+	}
+	want := []Contribution{
+		{ID: "KR1", Kind: ContributionKeyResult, Progress: 100, Weight: 3, Share: 0.6, Points: 60},
+		{ID: "KR2", Kind: ContributionKeyResult, Progress: 0, Weight: 1, Share: 0.2, Points: 0},
+		{ID: "O2", Kind: ContributionObjective, Progress: 50, Weight: 1, Share: 0.2, Points: 10},
+	}
+	if len(o1.Contributions) != len(want) {
+		t.Fatalf("O1 contributions = %+v, want %d entries", o1.Contributions, len(want))
+	}
+	for i, c := range o1.Contributions {
+		w := want[i]
+		if c.ID != w.ID || c.Kind != w.Kind || !floatEqual(c.Progress, w.Progress) || !floatEqual(c.Weight, w.Weight) || !floatEqual(c.Share, w.Share) || !floatEqual(c.Points, w.Points) {
+			t.Errorf("contribution %d = %+v, want %+v", i, c, w)
+		}
+	}
+
+	// Theme = (3*70 + 1*0) / 4 = 52.5
+	if !floatEqual(result[0].Progress, 52.5) {
+		t.Errorf("Theme progress = %f, want 52.5", result[0].Progress)
+	}
+	if len(result[0].Contributions) != 2 || !floatEqual(result[0].Contributions[0].Share, 0.75) {
+		t.Errorf("theme contributions = %+v, want O1 with share 0.75", result[0].Contributions)
+	}
+}
+
 func floatEqual(a, b float64) bool {
 	return math.Abs(a-b) < 0.001
 }
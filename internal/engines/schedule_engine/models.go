@@ -6,24 +6,63 @@ package schedule_engine
 import "github.com/rkn/bearing/internal/utilities"
 
 // RepeatPattern defines when a routine recurs.
+//
+// Monthly and yearly patterns fall on a day of the month, or with
+// WeekOfMonth set on the n-th (or last) of the given weekdays of the month:
+// "2nd Tuesday" is {monthly, WeekOfMonth: 2, Weekdays: [2]}, "last day of
+// the month" is {monthly, DayOfMonth: -1} and "first Monday of March" is
+// {yearly, Month: 3, WeekOfMonth: 1, Weekdays: [1]}. "Every weekday" is
+// {weekly, Weekdays: [1, 2, 3, 4, 5]}.
+//
+// A pattern with RRule set recurs by that RFC 5545 rule from StartDate
+// instead, and its other fields are ignored. An AfterCompletion pattern has
+// no fixed grid: it falls due Interval days, weeks, months or years (by
+// Frequency) after its latest completion, first on StartDate (see NextDue).
+//
+// EndDate and Count bound any pattern: no occurrence falls after EndDate,
+// and only the first Count occurrences from StartDate are generated (for
+// after-completion patterns, until Count completions). No occurrence falls
+// within a pause, so paused dates are neither scheduled nor overdue.
 type RepeatPattern struct {
-	Frequency  string                 // "daily", "weekly", "monthly", "yearly"
-	Interval   int                    // every N periods (default 1)
-	Weekdays   []int                  // for weekly: 0=Sun..6=Sat (time.Weekday values)
-	DayOfMonth int                    // for monthly: which day
-	StartDate  utilities.CalendarDate // anchor date YYYY-MM-DD
+	Frequency       string                 // "daily", "weekly", "monthly", "yearly"
+	Interval        int                    // every N periods (default 1)
+	Weekdays        []int                  // for weekly, and monthly/yearly with WeekOfMonth: 0=Sun..6=Sat (time.Weekday values)
+	DayOfMonth      int                    // for monthly/yearly: which day; negative counts from the end (-1 = last day); 0 = the anchor's day
+	WeekOfMonth     int                    // for monthly/yearly: 1-5 = n-th of Weekdays in the month, -1 = the last; 0 = use DayOfMonth
+	Month           int                    // for yearly: 1-12; 0 = the anchor's month
+	RRule           string                 // RFC 5545 RRULE value; overrides the fields above when set
+	AfterCompletion bool                   // due Interval Frequency-units after the latest completion
+	StartDate       utilities.CalendarDate // anchor date YYYY-MM-DD (DTSTART for RRule)
+	EndDate         utilities.CalendarDate // last possible occurrence YYYY-MM-DD; zero = no end
+	Count           int                    // number of occurrences from StartDate; 0 = unbounded
+	Pauses          []Pause                // windows without occurrences
 }
 
-// Exception represents a rescheduled occurrence.
+// Pause is an inclusive date range during which a routine does not recur.
+type Pause struct {
+	From utilities.CalendarDate // first paused date YYYY-MM-DD
+	To   utilities.CalendarDate // last paused date YYYY-MM-DD
+}
+
+// contains reports whether date (YYYY-MM-DD) falls within the pause.
+func (p Pause) contains(date string) bool {
+	// Lexicographic compare on YYYY-MM-DD is equivalent to chronological order.
+	return date >= p.From.String() && date <= p.To.String()
+}
+
+// Exception represents a rescheduled or skipped occurrence. An exception
+// without a NewDate skips the occurrence without moving it.
 type Exception struct {
 	OriginalDate utilities.CalendarDate // suppressed date YYYY-MM-DD
-	NewDate      utilities.CalendarDate // replacement date YYYY-MM-DD
+	NewDate      utilities.CalendarDate // replacement date YYYY-MM-DD; zero = skipped
 }
 
 // PeriodCompletion shows how many occurrences were completed in the current period.
 type PeriodCompletion struct {
 	Completed int    // occurrences checked in current period
 	Expected  int    // occurrences scheduled in current period
-	Period    string // "day", "week", "month", "year"
+	Period    string // "day", "week", "month", "year", or "cycle" for after-completion patterns
 	OnTrack   bool   // Completed >= Expected (for period so far)
+	Start     string // first date of the period YYYY-MM-DD; for a cycle, the completion fulfilling it, if any
+	End       string // last date of the period YYYY-MM-DD; equals Start for a cycle
 }
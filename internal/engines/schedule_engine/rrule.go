@@ -0,0 +1,548 @@
+package schedule_engine
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// RRule is an RFC 5545 recurrence rule restricted to the date-level parts
+// routines use: FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, BYDAY with
+// optional ordinals, BYMONTHDAY, BYMONTH, BYSETPOS, COUNT, UNTIL and WKST.
+// DTSTART is the routine's start date.
+type RRule struct {
+	Freq       string // "DAILY", "WEEKLY", "MONTHLY", "YEARLY"
+	Interval   int    // every N periods (at least 1)
+	ByDay      []WeekdayNum
+	ByMonthDay []int // 1..31 or -31..-1 (counted from the end of the month)
+	ByMonth    []int // 1..12
+	BySetPos   []int // positions within the period's set, negative from the end
+	Count      int   // total occurrences from DTSTART; 0 = unbounded
+	Until      utilities.CalendarDate
+	WeekStart  time.Weekday // WKST, Monday by default
+}
+
+// WeekdayNum is a BYDAY entry such as "TU" or "-1FR". Ordinal 0 means every
+// such weekday in the period; otherwise the n-th (negative: from the end)
+// in the month, or in the year for YEARLY rules without BYMONTH.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ParseRRule parses an RRULE value such as "FREQ=MONTHLY;BYDAY=2TU", with
+// or without the "RRULE:" property name.
+func ParseRRule(s string) (RRule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	r := RRule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return RRule{}, fmt.Errorf("malformed rule part %q", part)
+		}
+		if seen[key] {
+			return RRule{}, fmt.Errorf("%s given more than once", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = value
+		case "INTERVAL":
+			r.Interval, err = parseRRuleInt(value, 1, 1<<16)
+		case "COUNT":
+			r.Count, err = parseRRuleInt(value, 1, 1<<20)
+		case "UNTIL":
+			r.Until, err = parseRRuleDate(value)
+		case "WKST":
+			r.WeekStart, err = parseRRuleWeekday(value)
+		case "BYMONTH":
+			r.ByMonth, err = parseRRuleInts(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseRRuleInts(value, -31, 31, true)
+		case "BYSETPOS":
+			r.BySetPos, err = parseRRuleInts(value, -366, 366, true)
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				wd, perr := parseRRuleWeekdayNum(item)
+				if perr != nil {
+					err = perr
+					break
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		default:
+			return RRule{}, fmt.Errorf("unsupported rule part %s", key)
+		}
+		if err != nil {
+			return RRule{}, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	if err := r.validate(); err != nil {
+		return RRule{}, err
+	}
+	return r, nil
+}
+
+// validate applies the RFC 5545 constraints between rule parts.
+func (r RRule) validate() error {
+	switch r.Freq {
+	case "":
+		return fmt.Errorf("FREQ is required")
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return fmt.Errorf("unsupported FREQ %s", r.Freq)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("COUNT and UNTIL cannot both be given")
+	}
+	if r.Freq == "WEEKLY" && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	for _, wd := range r.ByDay {
+		switch {
+		case wd.Ordinal == 0:
+		case r.Freq == "MONTHLY" || (r.Freq == "YEARLY" && len(r.ByMonth) > 0):
+			if wd.Ordinal < -5 || wd.Ordinal > 5 {
+				return fmt.Errorf("BYDAY ordinal %d is outside -5..5", wd.Ordinal)
+			}
+		case r.Freq == "YEARLY":
+			if wd.Ordinal < -53 || wd.Ordinal > 53 {
+				return fmt.Errorf("BYDAY ordinal %d is outside -53..53", wd.Ordinal)
+			}
+		default:
+			return fmt.Errorf("BYDAY ordinals need FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return fmt.Errorf("BYSETPOS needs BYDAY, BYMONTHDAY or BYMONTH")
+	}
+	return nil
+}
+
+// String formats the rule as an RRULE value, parts in a fixed order and
+// defaults (INTERVAL=1, WKST=MO) left out.
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinRRuleInts(r.ByMonth))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinRRuleInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			days[i] = rruleWeekdays[wd.Weekday]
+			if wd.Ordinal != 0 {
+				days[i] = strconv.Itoa(wd.Ordinal) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinRRuleInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+rruleWeekdays[r.WeekStart])
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Time().Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseRecurrence parses iCalendar recurrence lines as calendar apps export
+// them: an optional "DTSTART" line and an "RRULE" line, or a bare RRULE
+// value. dtstart is zero when no DTSTART line is given.
+func ParseRecurrence(s string) (rule RRule, dtstart utilities.CalendarDate, err error) {
+	var ruleLine string
+	for _, line := range strings.FieldsFunc(s, func(c rune) bool { return c == '\n' || c == '\r' }) {
+		line = strings.TrimSpace(line)
+		name, value, ok := strings.Cut(line, ":")
+		switch {
+		case line == "":
+		case ok && strings.EqualFold(strings.SplitN(name, ";", 2)[0], "DTSTART"):
+			if dtstart, err = parseRRuleDate(strings.ToUpper(strings.TrimSpace(value))); err != nil {
+				return RRule{}, "", fmt.Errorf("DTSTART: %w", err)
+			}
+		case ruleLine != "":
+			return RRule{}, "", fmt.Errorf("more than one recurrence rule")
+		default:
+			ruleLine = line
+		}
+	}
+	if ruleLine == "" {
+		return RRule{}, "", fmt.Errorf("no recurrence rule")
+	}
+	rule, err = ParseRRule(ruleLine)
+	return rule, dtstart, err
+}
+
+// FormatRecurrence formats rule as iCalendar lines, preceded by an all-day
+// DTSTART line when dtstart is set.
+func FormatRecurrence(rule RRule, dtstart utilities.CalendarDate) string {
+	if dtstart.IsZero() {
+		return "RRULE:" + rule.String()
+	}
+	return "DTSTART;VALUE=DATE:" + dtstart.Time().Format("20060102") + "\nRRULE:" + rule.String()
+}
+
+// PatternToRRule converts a repeat pattern to an equivalent rule, one that
+// yields the same occurrences from the same start date. Patterns carrying an
+// RRule return it parsed. Days clamped to the month's length become a
+// BYSETPOS=-1 choice among the candidate days ("day 31" is the last of days
+// 28-31), since RRULE skips months where a day does not exist. EndDate and
+// Count become UNTIL and COUNT; pauses, like exceptions, are not part of the
+// rule. After-completion patterns have no fixed grid and return an error.
+func PatternToRRule(p RepeatPattern) (RRule, error) {
+	if p.AfterCompletion {
+		return RRule{}, fmt.Errorf("after-completion schedules have no RRULE equivalent")
+	}
+	if p.RRule != "" {
+		return ParseRRule(p.RRule)
+	}
+	if p.Count > 0 && !p.EndDate.IsZero() {
+		return RRule{}, fmt.Errorf("a schedule with both an end date and a count has no RRULE equivalent")
+	}
+	r, err := gridRRule(p)
+	if err != nil {
+		return RRule{}, err
+	}
+	r.Count = p.Count
+	r.Until = p.EndDate
+	return r, nil
+}
+
+// gridRRule converts the structured fields of a pattern to a rule.
+func gridRRule(p RepeatPattern) (RRule, error) {
+	r := RRule{Interval: effectiveInterval(p.Interval), WeekStart: time.Monday}
+	anchor := p.StartDate.Time()
+
+	switch p.Frequency {
+	case "daily":
+		r.Freq = "DAILY"
+		return r, nil
+	case "weekly":
+		if len(p.Weekdays) == 0 {
+			return RRule{}, fmt.Errorf("weekly pattern without weekdays has no occurrences")
+		}
+		r.Freq = "WEEKLY"
+		for _, wd := range sortedWeekdays(p.Weekdays) {
+			r.ByDay = append(r.ByDay, WeekdayNum{Weekday: wd})
+		}
+		return r, nil
+	case "monthly":
+		r.Freq = "MONTHLY"
+	case "yearly":
+		r.Freq = "YEARLY"
+		month := int(anchor.Month())
+		if p.Month >= 1 && p.Month <= 12 {
+			month = p.Month
+		}
+		r.ByMonth = []int{month}
+	default:
+		return RRule{}, fmt.Errorf("unknown frequency %q", p.Frequency)
+	}
+	if p.WeekOfMonth != 0 {
+		weekdays := p.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []int{int(anchor.Weekday())}
+		}
+		for _, wd := range sortedWeekdays(weekdays) {
+			r.ByDay = append(r.ByDay, WeekdayNum{Ordinal: p.WeekOfMonth, Weekday: wd})
+		}
+		return r, nil
+	}
+
+	day := p.DayOfMonth
+	if day == 0 {
+		day = anchor.Day()
+	}
+	switch {
+	case day > 28:
+		// Clamped to the month's length: the last existing of 28..day.
+		for d := 28; d <= min(day, 31); d++ {
+			r.ByMonthDay = append(r.ByMonthDay, d)
+		}
+		r.BySetPos = []int{-1}
+	case day < -31:
+		r.ByMonthDay = []int{1}
+	case day < -28:
+		// Counted from the end but at least the 1st.
+		r.ByMonthDay = []int{1, day}
+		r.BySetPos = []int{-1}
+	default:
+		r.ByMonthDay = []int{day}
+	}
+	return r, nil
+}
+
+// sortedWeekdays returns the weekdays (0=Sun..6=Sat) in ascending order
+// without duplicates.
+func sortedWeekdays(weekdays []int) []time.Weekday {
+	sorted := slices.Clone(weekdays)
+	slices.Sort(sorted)
+	out := make([]time.Weekday, 0, len(sorted))
+	for _, wd := range slices.Compact(sorted) {
+		out = append(out, time.Weekday(wd))
+	}
+	return out
+}
+
+// expandRRule produces the rule's occurrences from dtstart within
+// [start, end]. COUNT counts from dtstart, so occurrences before start use
+// up the count. Like the other generators, dtstart itself is an occurrence
+// only when it matches the rule.
+func expandRRule(r RRule, dtstart, start, end time.Time) []time.Time {
+	limit := end
+	if !r.Until.IsZero() && r.Until.Time().Before(limit) {
+		limit = r.Until.Time()
+	}
+	var dates []time.Time
+	count := 0
+	for period := r.periodStart(dtstart); !period.After(limit); period = r.nextPeriod(period) {
+		for _, d := range r.periodSet(period, dtstart) {
+			if d.Before(dtstart) {
+				continue
+			}
+			if d.After(limit) {
+				return dates
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return dates
+			}
+			if !d.Before(start) {
+				dates = append(dates, d)
+			}
+		}
+	}
+	return dates
+}
+
+// periodStart returns the first day of the period containing dtstart.
+func (r RRule) periodStart(dtstart time.Time) time.Time {
+	switch r.Freq {
+	case "WEEKLY":
+		back := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		return dtstart.AddDate(0, 0, -back)
+	case "MONTHLY":
+		return time.Date(dtstart.Year(), dtstart.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "YEARLY":
+		return time.Date(dtstart.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return dtstart
+	}
+}
+
+// nextPeriod returns the first day of the interval-th period after period.
+func (r RRule) nextPeriod(period time.Time) time.Time {
+	interval := effectiveInterval(r.Interval)
+	switch r.Freq {
+	case "WEEKLY":
+		return period.AddDate(0, 0, 7*interval)
+	case "MONTHLY":
+		return period.AddDate(0, interval, 0)
+	case "YEARLY":
+		return period.AddDate(interval, 0, 0)
+	default:
+		return period.AddDate(0, 0, interval)
+	}
+}
+
+// periodSet returns, in order, the days of the period starting at period
+// that match the rule, after BYSETPOS.
+func (r RRule) periodSet(period, dtstart time.Time) []time.Time {
+	var last time.Time
+	switch r.Freq {
+	case "WEEKLY":
+		last = period.AddDate(0, 0, 6)
+	case "MONTHLY":
+		last = period.AddDate(0, 1, -1)
+	case "YEARLY":
+		last = period.AddDate(1, 0, -1)
+	default:
+		last = period
+	}
+	var set []time.Time
+	for d := period; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if r.matches(d, dtstart) {
+			set = append(set, d)
+		}
+	}
+	if len(r.BySetPos) == 0 || len(set) == 0 {
+		return set
+	}
+
+	var picked []int
+	for _, pos := range r.BySetPos {
+		idx := pos - 1
+		if pos < 0 {
+			idx = len(set) + pos
+		}
+		if idx >= 0 && idx < len(set) {
+			picked = append(picked, idx)
+		}
+	}
+	slices.Sort(picked)
+	out := make([]time.Time, 0, len(picked))
+	for _, idx := range slices.Compact(picked) {
+		out = append(out, set[idx])
+	}
+	return out
+}
+
+// matches reports whether day d belongs to the rule's set. Without BYDAY
+// or BYMONTHDAY, the day (and for YEARLY without BYMONTH, the month) comes
+// from dtstart.
+func (r RRule) matches(d, dtstart time.Time) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, int(d.Month())) {
+		return false
+	}
+	lastDay := clampDay(d.Year(), d.Month(), 31)
+	if len(r.ByMonthDay) > 0 && !slices.ContainsFunc(r.ByMonthDay, func(md int) bool {
+		return md == d.Day() || md < 0 && lastDay+1+md == d.Day()
+	}) {
+		return false
+	}
+	if len(r.ByDay) > 0 {
+		return slices.ContainsFunc(r.ByDay, func(wd WeekdayNum) bool { return r.matchesWeekday(d, wd) })
+	}
+	if len(r.ByMonthDay) > 0 {
+		return true
+	}
+	switch r.Freq {
+	case "WEEKLY":
+		return d.Weekday() == dtstart.Weekday()
+	case "MONTHLY":
+		return d.Day() == dtstart.Day()
+	case "YEARLY":
+		return d.Day() == dtstart.Day() && (len(r.ByMonth) > 0 || d.Month() == dtstart.Month())
+	default:
+		return true
+	}
+}
+
+// matchesWeekday reports whether d is the BYDAY entry's weekday and, for an
+// ordinal entry, its n-th occurrence in the month (or the year, for YEARLY
+// rules without BYMONTH).
+func (r RRule) matchesWeekday(d time.Time, wd WeekdayNum) bool {
+	if d.Weekday() != wd.Weekday {
+		return false
+	}
+	if wd.Ordinal == 0 {
+		return true
+	}
+	pos, length := d.Day(), clampDay(d.Year(), d.Month(), 31)
+	if r.Freq == "YEARLY" && len(r.ByMonth) == 0 {
+		pos, length = d.YearDay(), time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+	if wd.Ordinal > 0 {
+		return (pos-1)/7+1 == wd.Ordinal
+	}
+	return -((length-pos)/7 + 1) == wd.Ordinal
+}
+
+// parseRRuleInt parses an integer within [lo, hi].
+func parseRRuleInt(s string, lo, hi int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if n < lo || n > hi {
+		return 0, fmt.Errorf("%d is outside %d..%d", n, lo, hi)
+	}
+	return n, nil
+}
+
+// parseRRuleInts parses a comma-separated integer list within [lo, hi];
+// nonZero rejects 0.
+func parseRRuleInts(s string, lo, hi int, nonZero bool) ([]int, error) {
+	var out []int
+	for _, item := range strings.Split(s, ",") {
+		n, err := parseRRuleInt(strings.TrimSpace(item), lo, hi)
+		if err != nil {
+			return nil, err
+		}
+		if nonZero && n == 0 {
+			return nil, fmt.Errorf("0 is not allowed")
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+// joinRRuleInts formats an integer list as a comma-separated value.
+func joinRRuleInts(ns []int) string {
+	parts := make([]string, len(ns))
+	for i, n := range ns {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseRRuleWeekday parses a two-letter weekday ("MO").
+func parseRRuleWeekday(s string) (time.Weekday, error) {
+	idx := slices.Index(rruleWeekdays, s)
+	if idx < 0 {
+		return 0, fmt.Errorf("unknown weekday %q", s)
+	}
+	return time.Weekday(idx), nil
+}
+
+// parseRRuleWeekdayNum parses a BYDAY entry ("TU", "2TU", "-1FR", "+3MO").
+func parseRRuleWeekdayNum(s string) (WeekdayNum, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("unknown weekday %q", s)
+	}
+	wd, err := parseRRuleWeekday(s[len(s)-2:])
+	if err != nil {
+		return WeekdayNum{}, err
+	}
+	num := WeekdayNum{Weekday: wd}
+	if prefix := s[:len(s)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 {
+			return WeekdayNum{}, fmt.Errorf("invalid ordinal in %q", s)
+		}
+		num.Ordinal = n
+	}
+	return num, nil
+}
+
+// parseRRuleDate parses a DATE ("20250131") or DATE-TIME
+// ("20250131T120000Z") value, keeping the date.
+func parseRRuleDate(s string) (utilities.CalendarDate, error) {
+	if len(s) < 8 {
+		return "", fmt.Errorf("invalid date %q", s)
+	}
+	t, err := time.Parse("20060102", s[:8])
+	if err != nil || (len(s) > 8 && s[8] != 'T') {
+		return "", fmt.Errorf("invalid date %q", s)
+	}
+	return utilities.NewCalendarDate(t), nil
+}
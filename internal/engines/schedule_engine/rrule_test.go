@@ -0,0 +1,295 @@
+package schedule_engine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_ParseRRule_Canonicalises(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=weekly;interval=1;byday=mo,we", "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{"FREQ=MONTHLY;BYDAY=+2TU;COUNT=5", "FREQ=MONTHLY;BYDAY=2TU;COUNT=5"},
+		{"FREQ=MONTHLY;BYSETPOS=-1;BYDAY=MO,TU,WE,TH,FR", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+		{"FREQ=YEARLY;BYMONTH=3;BYDAY=1MO;UNTIL=20301231T235959Z", "FREQ=YEARLY;BYMONTH=3;BYDAY=1MO;UNTIL=20301231"},
+		{"FREQ=WEEKLY;INTERVAL=2;WKST=SU;BYDAY=TU,SU", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU;WKST=SU"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1;", "FREQ=MONTHLY;BYMONTHDAY=-1"},
+	}
+	for _, tt := range tests {
+		rule, err := ParseRRule(tt.in)
+		if err != nil {
+			t.Errorf("ParseRRule(%q): %v", tt.in, err)
+			continue
+		}
+		if got := rule.String(); got != tt.want {
+			t.Errorf("ParseRRule(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUnit_ParseRRule_Rejects(t *testing.T) {
+	tests := []struct {
+		in, wantErr string
+	}{
+		{"", "FREQ is required"},
+		{"INTERVAL=2", "FREQ is required"},
+		{"FREQ=HOURLY", "unsupported FREQ"},
+		{"FREQ=DAILY;BYHOUR=9", "unsupported rule part BYHOUR"},
+		{"FREQ=DAILY;FREQ=WEEKLY", "more than once"},
+		{"FREQ=DAILY;INTERVAL=0", "outside"},
+		{"FREQ=DAILY;COUNT=x", "not a number"},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20250101", "COUNT and UNTIL"},
+		{"FREQ=DAILY;UNTIL=2025-01-01", "invalid date"},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", "BYMONTHDAY cannot be used"},
+		{"FREQ=WEEKLY;BYDAY=2MO", "BYDAY ordinals need"},
+		{"FREQ=MONTHLY;BYDAY=6MO", "outside -5..5"},
+		{"FREQ=YEARLY;BYDAY=54MO", "outside -53..53"},
+		{"FREQ=MONTHLY;BYDAY=XX", "unknown weekday"},
+		{"FREQ=MONTHLY;BYDAY=0MO", "invalid ordinal"},
+		{"FREQ=MONTHLY;BYMONTHDAY=0", "0 is not allowed"},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", "outside"},
+		{"FREQ=YEARLY;BYMONTH=13", "outside"},
+		{"FREQ=DAILY;BYSETPOS=1", "BYSETPOS needs"},
+		{"FREQ", "malformed"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseRRule(tt.in); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ParseRRule(%q) = %v, want error containing %q", tt.in, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUnit_ParseRecurrence_RoundTrip(t *testing.T) {
+	in := "DTSTART;VALUE=DATE:20250106\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,FR\r\n"
+	rule, dtstart, err := ParseRecurrence(in)
+	if err != nil {
+		t.Fatalf("ParseRecurrence: %v", err)
+	}
+	if dtstart != "2025-01-06" || rule.String() != "FREQ=WEEKLY;BYDAY=MO,FR" {
+		t.Errorf("got %q from %s", rule.String(), dtstart)
+	}
+	if got := FormatRecurrence(rule, dtstart); got != "DTSTART;VALUE=DATE:20250106\nRRULE:FREQ=WEEKLY;BYDAY=MO,FR" {
+		t.Errorf("FormatRecurrence = %q", got)
+	}
+
+	if rule, dtstart, err := ParseRecurrence("FREQ=DAILY"); err != nil || dtstart != "" || rule.Freq != "DAILY" {
+		t.Errorf("bare rule = %+v, %q, %v", rule, dtstart, err)
+	}
+	if _, _, err := ParseRecurrence("DTSTART:20250106"); err == nil {
+		t.Error("expected an error without a rule")
+	}
+	if _, _, err := ParseRecurrence("RRULE:FREQ=DAILY\nRRULE:FREQ=WEEKLY"); err == nil {
+		t.Error("expected an error for two rules")
+	}
+}
+
+func TestUnit_ComputeOccurrences_RRule(t *testing.T) {
+	se := NewScheduleEngine()
+	tests := []struct {
+		name, rule, dtstart, start, end string
+		want                            []string
+	}{
+		{
+			name: "last weekday of the month", rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2025-06-30",
+			want: []string{"2025-01-31", "2025-02-28", "2025-03-31", "2025-04-30", "2025-05-30", "2025-06-30"},
+		},
+		{
+			name: "count stops the series", rule: "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2025-12-31",
+			want: []string{"2025-01-14", "2025-02-11", "2025-03-11"},
+		},
+		{
+			name: "count is used up before the range", rule: "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			dtstart: "2025-01-01", start: "2025-03-01", end: "2025-12-31",
+			want: []string{"2025-03-11"},
+		},
+		{
+			name: "until is inclusive", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20250124",
+			dtstart: "2025-01-06", start: "2025-01-01", end: "2025-02-28",
+			want: []string{"2025-01-06", "2025-01-10", "2025-01-20", "2025-01-24"},
+		},
+		{
+			name: "weekly defaults to the start weekday", rule: "FREQ=WEEKLY",
+			dtstart: "2025-01-08", start: "2025-01-01", end: "2025-01-31",
+			want: []string{"2025-01-08", "2025-01-15", "2025-01-22", "2025-01-29"},
+		},
+		{
+			name: "RFC 5545 WKST=MO example", rule: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			dtstart: "1997-08-05", start: "1997-08-01", end: "1997-09-30",
+			want: []string{"1997-08-05", "1997-08-10", "1997-08-19", "1997-08-24"},
+		},
+		{
+			name: "RFC 5545 WKST=SU example", rule: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			dtstart: "1997-08-05", start: "1997-08-01", end: "1997-09-30",
+			want: []string{"1997-08-05", "1997-08-17", "1997-08-19", "1997-08-31"},
+		},
+		{
+			name: "every Friday of the month", rule: "FREQ=MONTHLY;BYDAY=FR",
+			dtstart: "2025-02-01", start: "2025-02-01", end: "2025-02-28",
+			want: []string{"2025-02-07", "2025-02-14", "2025-02-21", "2025-02-28"},
+		},
+		{
+			name: "monthly defaults to the start day and skips short months", rule: "FREQ=MONTHLY",
+			dtstart: "2025-01-31", start: "2025-01-01", end: "2025-05-31",
+			want: []string{"2025-01-31", "2025-03-31", "2025-05-31"},
+		},
+		{
+			name: "the 31st only where it exists", rule: "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2025-06-30",
+			want: []string{"2025-01-31", "2025-03-31", "2025-05-31"},
+		},
+		{
+			name: "last day of the month in a leap year", rule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: "2024-01-01", start: "2024-01-01", end: "2024-03-31",
+			want: []string{"2024-01-31", "2024-02-29", "2024-03-31"},
+		},
+		{
+			name: "Friday the 13th", rule: "FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2026-12-31",
+			want: []string{"2025-06-13", "2026-02-13", "2026-03-13", "2026-11-13"},
+		},
+		{
+			name: "first Monday of March", rule: "FREQ=YEARLY;BYMONTH=3;BYDAY=1MO",
+			dtstart: "2024-01-01", start: "2024-01-01", end: "2026-12-31",
+			want: []string{"2024-03-04", "2025-03-03", "2026-03-02"},
+		},
+		{
+			name: "yearly on Feb 29 skips common years", rule: "FREQ=YEARLY",
+			dtstart: "2024-02-29", start: "2024-01-01", end: "2028-12-31",
+			want: []string{"2024-02-29", "2028-02-29"},
+		},
+		{
+			name: "20th Monday of the year", rule: "FREQ=YEARLY;BYDAY=20MO",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2026-12-31",
+			want: []string{"2025-05-19", "2026-05-18"},
+		},
+		{
+			name: "last Sunday of the year", rule: "FREQ=YEARLY;BYDAY=-1SU",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2026-12-31",
+			want: []string{"2025-12-28", "2026-12-27"},
+		},
+		{
+			name: "February weekends", rule: "FREQ=DAILY;BYMONTH=2;BYDAY=SA,SU",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2025-03-31",
+			want: []string{"2025-02-01", "2025-02-02", "2025-02-08", "2025-02-09", "2025-02-15", "2025-02-16", "2025-02-22", "2025-02-23"},
+		},
+		{
+			name: "invalid rule yields nothing", rule: "FREQ=SOMETIMES",
+			dtstart: "2025-01-01", start: "2025-01-01", end: "2025-12-31",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := RepeatPattern{RRule: tt.rule, StartDate: utilities.MustParseCalendarDate(tt.dtstart)}
+			got := se.ComputeOccurrences(pattern, nil, tt.start, tt.end)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_EvaluatePeriodCompletion_RRuleUsesItsFrequency(t *testing.T) {
+	se := NewScheduleEngine()
+	// 2nd and 4th Tuesday of February 2025: Feb 11 and 25.
+	result := se.EvaluatePeriodCompletion(RepeatPattern{
+		RRule:     "FREQ=MONTHLY;BYDAY=2TU,4TU",
+		StartDate: utilities.MustParseCalendarDate("2025-01-01"),
+	}, nil, []string{"2025-02-11"}, "2025-02-20", time.Monday)
+
+	want := PeriodCompletion{Completed: 1, Expected: 2, Period: "month", OnTrack: true, Start: "2025-02-01", End: "2025-02-28"}
+	if result != want {
+		t.Errorf("got %+v, want %+v", result, want)
+	}
+}
+
+// TestUnit_PatternToRRule_Lossless checks that every kind of repeat pattern
+// and its RRULE form yield the same occurrences over years that include
+// leap days, short months and five-weekday months.
+func TestUnit_PatternToRRule_Lossless(t *testing.T) {
+	se := NewScheduleEngine()
+	anchors := []string{"2024-02-29", "2024-01-31", "2025-03-15", "2023-12-31", "2024-04-30"}
+	var patterns []RepeatPattern
+	for _, interval := range []int{0, 1, 2, 3} {
+		patterns = append(patterns, RepeatPattern{Frequency: "daily", Interval: interval})
+		for _, wds := range [][]int{{1}, {0, 6}, {5, 1, 3}, {1, 2, 3, 4, 5}} {
+			patterns = append(patterns, RepeatPattern{Frequency: "weekly", Interval: interval, Weekdays: wds})
+		}
+		for _, dom := range []int{0, 1, 15, 28, 29, 30, 31, 40, -1, -2, -28, -29, -30, -31, -40} {
+			patterns = append(patterns, RepeatPattern{Frequency: "monthly", Interval: interval, DayOfMonth: dom})
+			for _, month := range []int{0, 2, 12} {
+				patterns = append(patterns, RepeatPattern{Frequency: "yearly", Interval: interval, DayOfMonth: dom, Month: month})
+			}
+		}
+		for _, wom := range []int{1, 2, 4, 5, -1, -2, -5} {
+			for _, wds := range [][]int{nil, {2}, {4, 1}} {
+				patterns = append(patterns, RepeatPattern{Frequency: "monthly", Interval: interval, WeekOfMonth: wom, Weekdays: wds})
+				patterns = append(patterns, RepeatPattern{Frequency: "yearly", Interval: interval, WeekOfMonth: wom, Weekdays: wds, Month: 3})
+			}
+		}
+	}
+
+	for _, anchor := range anchors {
+		for _, p := range patterns {
+			p.StartDate = utilities.MustParseCalendarDate(anchor)
+			name := fmt.Sprintf("%s %+v", anchor, p)
+			rule, err := PatternToRRule(p)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			reparsed, err := ParseRRule(rule.String())
+			if err != nil || reparsed.String() != rule.String() {
+				t.Errorf("%s: %q does not round-trip: %v", name, rule.String(), err)
+				continue
+			}
+			want := se.ComputeOccurrences(p, nil, "2023-01-01", "2030-12-31")
+			got := se.ComputeOccurrences(RepeatPattern{RRule: rule.String(), StartDate: p.StartDate}, nil, "2023-01-01", "2030-12-31")
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s as %s:\n got %v\nwant %v", name, rule.String(), got, want)
+			}
+		}
+	}
+}
+
+func TestUnit_PatternToRRule_Forms(t *testing.T) {
+	tests := []struct {
+		pattern RepeatPattern
+		want    string
+	}{
+		{RepeatPattern{Frequency: "daily", Interval: 3}, "FREQ=DAILY;INTERVAL=3"},
+		{RepeatPattern{Frequency: "weekly", Weekdays: []int{5, 1, 1}}, "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{RepeatPattern{Frequency: "monthly", DayOfMonth: 15}, "FREQ=MONTHLY;BYMONTHDAY=15"},
+		{RepeatPattern{Frequency: "monthly", DayOfMonth: 30}, "FREQ=MONTHLY;BYMONTHDAY=28,29,30;BYSETPOS=-1"},
+		{RepeatPattern{Frequency: "monthly", DayOfMonth: -1}, "FREQ=MONTHLY;BYMONTHDAY=-1"},
+		{RepeatPattern{Frequency: "monthly", WeekOfMonth: 2, Weekdays: []int{2}}, "FREQ=MONTHLY;BYDAY=2TU"},
+		{RepeatPattern{Frequency: "monthly", WeekOfMonth: -1, Weekdays: []int{5}}, "FREQ=MONTHLY;BYDAY=-1FR"},
+		{RepeatPattern{Frequency: "yearly", Month: 3, WeekOfMonth: 1, Weekdays: []int{1}}, "FREQ=YEARLY;BYMONTH=3;BYDAY=1MO"},
+		{RepeatPattern{Frequency: "yearly"}, "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=10"},
+		{RepeatPattern{RRule: "freq=daily;count=2"}, "FREQ=DAILY;COUNT=2"},
+		{RepeatPattern{Frequency: "weekly", Weekdays: []int{5}, Count: 6}, "FREQ=WEEKLY;BYDAY=FR;COUNT=6"},
+		{RepeatPattern{Frequency: "daily", EndDate: utilities.MustParseCalendarDate("2025-03-01")}, "FREQ=DAILY;UNTIL=20250301"},
+	}
+	for _, tt := range tests {
+		tt.pattern.StartDate = utilities.MustParseCalendarDate("2025-01-10")
+		rule, err := PatternToRRule(tt.pattern)
+		if err != nil || rule.String() != tt.want {
+			t.Errorf("PatternToRRule(%+v) = %q, %v; want %q", tt.pattern, rule.String(), err, tt.want)
+		}
+	}
+
+	for _, p := range []RepeatPattern{{Frequency: "weekly"}, {Frequency: "hourly"}, {Frequency: "daily", Count: 2, EndDate: "2025-03-01"}} {
+		if _, err := PatternToRRule(p); err == nil {
+			t.Errorf("PatternToRRule(%+v) succeeded, want an error", p)
+		}
+	}
+}
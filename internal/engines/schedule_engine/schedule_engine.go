@@ -1,7 +1,9 @@
 package schedule_engine
 
 import (
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/rkn/bearing/internal/utilities"
@@ -11,8 +13,12 @@ import (
 type IScheduleEngine interface {
 	ComputeOccurrences(pattern RepeatPattern, exceptions []Exception, start, end string) []string
 	ComputeOverdue(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) []string
-	EvaluatePeriodCompletion(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) PeriodCompletion
+	MissedOccurrences(pattern RepeatPattern, exceptions []Exception, completedDates []string, start, end, asOf string) []string
+	NextDue(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) string
+	EvaluatePeriodCompletion(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string, weekStart time.Weekday) PeriodCompletion
+	ComputeStats(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string, year int) RoutineStats
 	Plan(diff RoutineCheckDiff, routines []RoutineInput, today utilities.CalendarDate) MaterializationPlan
+	PlanDue(routines []RoutineInput, existing []ExistingTaskRef, today utilities.CalendarDate) MaterializationPlan
 }
 
 // ScheduleEngine is a stateless implementation of IScheduleEngine.
@@ -103,51 +109,97 @@ func generateWeekly(anchor, start, end time.Time, interval int, weekdays []int)
 	return dates
 }
 
-// generateMonthly produces dates on dayOfMonth every interval months from
-// anchor, clamping to month length, filtered to [start, end].
-func generateMonthly(anchor, start, end time.Time, interval, dayOfMonth int) []time.Time {
+// generateMonthly produces the pattern's days of every interval-th month
+// from the anchor's month, filtered to [start, end].
+func generateMonthly(anchor, start, end time.Time, interval int, pattern RepeatPattern) []time.Time {
+	return generateByMonth(anchor, start, end, anchor.Month(), interval, pattern)
+}
+
+// generateYearly produces the pattern's days of the pattern's month (the
+// anchor's by default) every interval years, filtered to [start, end]. A
+// Feb 29 anchor falls on Feb 28 in non-leap years.
+func generateYearly(anchor, start, end time.Time, interval int, pattern RepeatPattern) []time.Time {
+	month := anchor.Month()
+	if pattern.Month >= 1 && pattern.Month <= 12 {
+		month = time.Month(pattern.Month)
+	}
+	return generateByMonth(anchor, start, end, month, 12*interval, pattern)
+}
+
+// generateByMonth walks the months from month of the anchor's year in steps
+// of stepMonths, collecting the pattern's days that fall on or after the
+// anchor and within [start, end].
+func generateByMonth(anchor, start, end time.Time, month time.Month, stepMonths int, pattern RepeatPattern) []time.Time {
 	var dates []time.Time
-	y, m, _ := anchor.Date()
-	for {
-		clamped := clampDay(y, m, dayOfMonth)
-		d := time.Date(y, m, clamped, 0, 0, 0, 0, time.UTC)
-		if d.After(end) {
-			break
-		}
-		if !d.Before(anchor) && !d.Before(start) {
-			dates = append(dates, d)
-		}
-		// Advance by interval months.
-		m += time.Month(interval)
-		for m > 12 {
-			m -= 12
-			y++
+	for first := time.Date(anchor.Year(), month, 1, 0, 0, 0, 0, time.UTC); !first.After(end); first = first.AddDate(0, stepMonths, 0) {
+		for _, d := range daysInMonth(first.Year(), first.Month(), anchor, pattern) {
+			if d.After(end) {
+				break
+			}
+			if !d.Before(anchor) && !d.Before(start) {
+				dates = append(dates, d)
+			}
 		}
 	}
 	return dates
 }
 
-// generateYearly produces dates on the anchor's month/day every interval years,
-// clamping Feb 29 to Feb 28 in non-leap years, filtered to [start, end].
-func generateYearly(anchor, start, end time.Time, interval int) []time.Time {
-	var dates []time.Time
-	_, anchorMonth, anchorDay := anchor.Date()
-	for y := anchor.Year(); ; y += interval {
-		clamped := clampDay(y, anchorMonth, anchorDay)
-		d := time.Date(y, anchorMonth, clamped, 0, 0, 0, 0, time.UTC)
-		if d.After(end) {
-			break
+// daysInMonth returns, in order, the days of the given month a monthly or
+// yearly pattern falls on.
+func daysInMonth(year int, month time.Month, anchor time.Time, pattern RepeatPattern) []time.Time {
+	if pattern.WeekOfMonth != 0 {
+		weekdays := pattern.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []int{int(anchor.Weekday())}
 		}
-		if !d.Before(anchor) && !d.Before(start) {
-			dates = append(dates, d)
+		var days []time.Time
+		for _, wd := range weekdays {
+			if d, ok := nthWeekdayOfMonth(year, month, time.Weekday(wd), pattern.WeekOfMonth); ok {
+				days = append(days, d)
+			}
 		}
+		sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+		return days
 	}
-	return dates
+
+	day := pattern.DayOfMonth
+	switch {
+	case day == 0:
+		day = clampDay(year, month, anchor.Day())
+	case day < 0:
+		day = max(clampDay(year, month, 31)+1+day, 1)
+	default:
+		day = clampDay(year, month, day)
+	}
+	return []time.Time{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// nthWeekdayOfMonth returns the n-th (1-5) weekday of the month, or with a
+// negative n the n-th counted from the end (-1 = the last). ok is false when
+// the month has no such day, such as a fifth Monday in most months.
+func nthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int) (time.Time, bool) {
+	var d time.Time
+	if n > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		d = first.AddDate(0, 0, offset+7*(n-1))
+	} else {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+		d = last.AddDate(0, 0, -offset-7*(-n-1))
+	}
+	return d, d.Month() == month && d.Year() == year
 }
 
 // ComputeOccurrences generates all occurrence dates for the given pattern
-// within [start, end], applying exceptions (suppressions and replacements).
+// within [start, end], applying exceptions (suppressions and replacements),
+// the pattern's EndDate and Count, and its pauses. After-completion patterns
+// have no fixed occurrences and yield none; their due date comes from
+// NextDue.
 func (se *ScheduleEngine) ComputeOccurrences(pattern RepeatPattern, exceptions []Exception, start, end string) []string {
+	if pattern.AfterCompletion {
+		return nil
+	}
 	startDate, err := parseDate(start)
 	if err != nil {
 		return nil
@@ -166,23 +218,41 @@ func (se *ScheduleEngine) ComputeOccurrences(pattern RepeatPattern, exceptions [
 
 	interval := effectiveInterval(pattern.Interval)
 
+	// The grid is cut at EndDate, and with a Count it is generated from the
+	// anchor so that occurrences before start use up the count.
+	genStart, genEnd := startDate, endDate
+	if !pattern.EndDate.IsZero() && pattern.EndDate.Time().Before(genEnd) {
+		genEnd = pattern.EndDate.Time()
+	}
+	if pattern.Count > 0 {
+		genStart = anchor
+	}
+
 	var raw []time.Time
-	switch pattern.Frequency {
-	case "daily":
-		raw = generateDaily(anchor, startDate, endDate, interval)
-	case "weekly":
-		raw = generateWeekly(anchor, startDate, endDate, interval, pattern.Weekdays)
-	case "monthly":
-		day := pattern.DayOfMonth
-		if day <= 0 {
-			day = anchor.Day()
+	switch {
+	case pattern.RRule != "":
+		rule, err := ParseRRule(pattern.RRule)
+		if err != nil {
+			return nil
 		}
-		raw = generateMonthly(anchor, startDate, endDate, interval, day)
-	case "yearly":
-		raw = generateYearly(anchor, startDate, endDate, interval)
+		raw = expandRRule(rule, anchor, genStart, genEnd)
+	case pattern.Frequency == "daily":
+		raw = generateDaily(anchor, genStart, genEnd, interval)
+	case pattern.Frequency == "weekly":
+		raw = generateWeekly(anchor, genStart, genEnd, interval, pattern.Weekdays)
+	case pattern.Frequency == "monthly":
+		raw = generateMonthly(anchor, genStart, genEnd, interval, pattern)
+	case pattern.Frequency == "yearly":
+		raw = generateYearly(anchor, genStart, genEnd, interval, pattern)
 	default:
 		return nil
 	}
+	if pattern.Count > 0 {
+		raw = raw[:min(len(raw), pattern.Count)]
+		for len(raw) > 0 && raw[0].Before(startDate) {
+			raw = raw[1:]
+		}
+	}
 
 	// Build exception maps.
 	suppressed := make(map[string]bool)
@@ -197,7 +267,7 @@ func (se *ScheduleEngine) ComputeOccurrences(pattern RepeatPattern, exceptions [
 		}
 	}
 
-	// Filter suppressed, collect remaining.
+	// Filter suppressed, collect remaining, and drop paused dates.
 	var result []time.Time
 	for _, d := range raw {
 		if !suppressed[formatDate(d)] {
@@ -205,6 +275,7 @@ func (se *ScheduleEngine) ComputeOccurrences(pattern RepeatPattern, exceptions [
 		}
 	}
 	result = append(result, replacements...)
+	result = slices.DeleteFunc(result, func(d time.Time) bool { return paused(pattern.Pauses, formatDate(d)) })
 
 	// Sort and format.
 	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
@@ -220,8 +291,12 @@ func (se *ScheduleEngine) ComputeOccurrences(pattern RepeatPattern, exceptions [
 // open. The absorption rule applies: when completedDates is non-empty, any
 // occurrence on or before max(completedDates) is treated as absorbed by that
 // most-recent check and is excluded from the result. When completedDates is
-// empty, every uncompleted occurrence before asOf is returned.
+// empty, every uncompleted occurrence before asOf is returned. For
+// after-completion patterns the result is the due date when it has passed.
 func (se *ScheduleEngine) ComputeOverdue(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) []string {
+	if pattern.AfterCompletion {
+		return se.floatingOverdue(pattern, exceptions, completedDates, asOf)
+	}
 	asOfDate, err := parseDate(asOf)
 	if err != nil {
 		return nil
@@ -254,15 +329,67 @@ func (se *ScheduleEngine) ComputeOverdue(pattern RepeatPattern, exceptions []Exc
 	return overdue
 }
 
+// MissedOccurrences returns the occurrences within [start, end] that were
+// missed as of asOf. It applies the absorption rule of ComputeOverdue as
+// each next occurrence came due, so a late check still meets the occurrence
+// before it. An occurrence on or after asOf is still open and never missed.
+// After-completion patterns yield none; ComputeOverdue gives their missed
+// due date.
+func (se *ScheduleEngine) MissedOccurrences(pattern RepeatPattern, exceptions []Exception, completedDates []string, start, end, asOf string) []string {
+	if pattern.AfterCompletion {
+		return nil
+	}
+	occurrences := se.ComputeOccurrences(pattern, exceptions, start, asOf)
+	met := occurrencesMet(occurrences, completedDates, asOf)
+	var missed []string
+	for i, d := range occurrences {
+		// Lexicographic compare on YYYY-MM-DD is equivalent to chronological order.
+		if d > end || d >= asOf {
+			break
+		}
+		if !met[i] {
+			missed = append(missed, d)
+		}
+	}
+	return missed
+}
+
+// occurrencesMet reports for each of the sorted occurrences whether it was
+// met: checked on its date, or later but before the next occurrence came
+// due (by asOf for the last one). Until then ComputeOverdue absorbs the
+// occurrence into the later check; once the next one is due it was missed.
+func occurrencesMet(occurrences, completedDates []string, asOf string) []bool {
+	completions := slices.Sorted(slices.Values(completedDates))
+	met := make([]bool, len(occurrences))
+	for i, d := range occurrences {
+		j, _ := slices.BinarySearch(completions, d)
+		if j == len(completions) {
+			continue
+		}
+		// Lexicographic compare on YYYY-MM-DD is equivalent to chronological order.
+		if i+1 < len(occurrences) {
+			met[i] = completions[j] < occurrences[i+1]
+		} else {
+			met[i] = completions[j] <= asOf
+		}
+	}
+	return met
+}
+
 // EvaluatePeriodCompletion determines how many occurrences in the current
 // period have been completed versus how many were expected up to asOf.
-func (se *ScheduleEngine) EvaluatePeriodCompletion(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) PeriodCompletion {
+// After-completion patterns are evaluated over their current cycle, with
+// Period "cycle". Weekly periods begin on weekStart.
+func (se *ScheduleEngine) EvaluatePeriodCompletion(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string, weekStart time.Weekday) PeriodCompletion {
+	if pattern.AfterCompletion {
+		return se.floatingPeriodCompletion(pattern, exceptions, completedDates, asOf)
+	}
 	asOfDate, err := parseDate(asOf)
 	if err != nil {
 		return PeriodCompletion{}
 	}
 
-	periodStart, periodEnd, period := periodBounds(asOfDate, pattern.Frequency)
+	periodStart, periodEnd, period := periodBounds(asOfDate, patternFrequency(pattern), weekStart)
 
 	// All occurrences in the full period.
 	allInPeriod := se.ComputeOccurrences(pattern, exceptions, formatDate(periodStart), formatDate(periodEnd))
@@ -298,24 +425,43 @@ func (se *ScheduleEngine) EvaluatePeriodCompletion(pattern RepeatPattern, except
 		Expected:  len(allInPeriod),
 		Period:    period,
 		OnTrack:   completedSoFar >= expectedSoFar,
+		Start:     formatDate(periodStart),
+		End:       formatDate(periodEnd),
 	}
 }
 
+// paused reports whether date (YYYY-MM-DD) falls within one of the pauses.
+func paused(pauses []Pause, date string) bool {
+	for _, p := range pauses {
+		if p.contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// patternFrequency returns the pattern's frequency, taken from the FREQ of
+// its RRule when it has one.
+func patternFrequency(pattern RepeatPattern) string {
+	if pattern.RRule == "" {
+		return pattern.Frequency
+	}
+	rule, err := ParseRRule(pattern.RRule)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(rule.Freq)
+}
+
 // periodBounds returns the start and end dates of the period containing
-// asOf, based on the frequency.
-func periodBounds(asOf time.Time, frequency string) (time.Time, time.Time, string) {
+// asOf, based on the frequency. Weeks begin on weekStart.
+func periodBounds(asOf time.Time, frequency string, weekStart time.Weekday) (time.Time, time.Time, string) {
 	switch frequency {
 	case "daily":
 		return asOf, asOf, "day"
 	case "weekly":
-		// Monday to Sunday.
-		wd := int(asOf.Weekday())
-		if wd == 0 {
-			wd = 7
-		}
-		monday := asOf.AddDate(0, 0, -(wd - 1))
-		sunday := monday.AddDate(0, 0, 6)
-		return monday, sunday, "week"
+		first := asOf.AddDate(0, 0, -((int(asOf.Weekday()) - int(weekStart) + 7) % 7))
+		return first, first.AddDate(0, 0, 6), "week"
 	case "monthly":
 		first := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
 		last := first.AddDate(0, 1, -1)
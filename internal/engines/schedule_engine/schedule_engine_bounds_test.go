@@ -0,0 +1,151 @@
+package schedule_engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_ComputeOccurrencesBounds(t *testing.T) {
+	se := NewScheduleEngine()
+	start := utilities.MustParseCalendarDate("2026-07-27")
+	vacation := []Pause{{From: utilities.MustParseCalendarDate("2026-08-01"), To: utilities.MustParseCalendarDate("2026-08-14")}}
+	tests := []struct {
+		name       string
+		pattern    RepeatPattern
+		exceptions []Exception
+		from, to   string
+		want       []string
+	}{
+		{
+			name:    "end date is the last possible occurrence",
+			pattern: RepeatPattern{Frequency: "daily", Interval: 2, StartDate: start, EndDate: utilities.MustParseCalendarDate("2026-08-02")},
+			from:    "2026-07-27", to: "2026-08-10",
+			want: []string{"2026-07-27", "2026-07-29", "2026-07-31", "2026-08-02"},
+		},
+		{
+			name:    "count is taken from the start date",
+			pattern: RepeatPattern{Frequency: "weekly", Weekdays: []int{1, 4}, StartDate: start, Count: 3},
+			from:    "2026-07-29", to: "2026-08-31",
+			want: []string{"2026-07-30", "2026-08-03"},
+		},
+		{
+			name:    "count of a rule-based pattern",
+			pattern: RepeatPattern{RRule: "FREQ=DAILY", StartDate: start, Count: 2},
+			from:    "2026-07-01", to: "2026-07-31",
+			want: []string{"2026-07-27", "2026-07-28"},
+		},
+		{
+			name:    "no occurrences during a pause",
+			pattern: RepeatPattern{Frequency: "weekly", Weekdays: []int{1}, StartDate: start, Pauses: vacation},
+			from:    "2026-07-27", to: "2026-08-31",
+			want: []string{"2026-07-27", "2026-08-17", "2026-08-24", "2026-08-31"},
+		},
+		{
+			name:       "reschedule into a pause is dropped",
+			pattern:    RepeatPattern{Frequency: "weekly", Weekdays: []int{1}, StartDate: start, Pauses: vacation},
+			exceptions: []Exception{{OriginalDate: start, NewDate: utilities.MustParseCalendarDate("2026-08-01")}},
+			from:       "2026-07-27", to: "2026-08-17",
+			want: []string{"2026-08-17"},
+		},
+		{
+			name:       "skip removes without moving",
+			pattern:    RepeatPattern{Frequency: "weekly", Weekdays: []int{1}, StartDate: start},
+			exceptions: []Exception{{OriginalDate: utilities.MustParseCalendarDate("2026-08-03")}},
+			from:       "2026-07-27", to: "2026-08-10",
+			want: []string{"2026-07-27", "2026-08-10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := se.ComputeOccurrences(tt.pattern, tt.exceptions, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_BoundsOverdueAndPeriodCompletion(t *testing.T) {
+	se := NewScheduleEngine()
+	daily := RepeatPattern{
+		Frequency: "daily",
+		StartDate: utilities.MustParseCalendarDate("2026-07-27"),
+		Pauses:    []Pause{{From: utilities.MustParseCalendarDate("2026-08-01"), To: utilities.MustParseCalendarDate("2026-08-14")}},
+	}
+	skip := []Exception{{OriginalDate: utilities.MustParseCalendarDate("2026-07-30")}}
+
+	// Checked through July 29; the 30th is skipped and the vacation is not owed.
+	completed := []string{"2026-07-29"}
+	if got, want := se.ComputeOverdue(daily, skip, completed, "2026-08-16"), []string{"2026-07-31", "2026-08-15"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("overdue = %v, want %v", got, want)
+	}
+	if got := se.ComputeOverdue(daily, skip, completed, "2026-08-10"); !reflect.DeepEqual(got, []string{"2026-07-31"}) {
+		t.Errorf("overdue during the pause = %v, want [2026-07-31]", got)
+	}
+	if got, want := se.EvaluatePeriodCompletion(daily, skip, completed, "2026-08-10", time.Monday), (PeriodCompletion{Period: "day", OnTrack: true, Start: "2026-08-10", End: "2026-08-10"}); got != want {
+		t.Errorf("paused day = %+v, want %+v", got, want)
+	}
+
+	ended := RepeatPattern{Frequency: "weekly", Weekdays: []int{1, 3}, StartDate: utilities.MustParseCalendarDate("2026-07-27"), EndDate: utilities.MustParseCalendarDate("2026-08-03")}
+	if got, want := se.ComputeOverdue(ended, nil, nil, "2026-08-31"), []string{"2026-07-27", "2026-07-29", "2026-08-03"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("overdue after the end = %v, want %v", got, want)
+	}
+	// The week of August 3 ends after its Monday.
+	if got, want := se.EvaluatePeriodCompletion(ended, nil, []string{"2026-08-03"}, "2026-08-06", time.Monday), (PeriodCompletion{Completed: 1, Expected: 1, Period: "week", OnTrack: true, Start: "2026-08-03", End: "2026-08-09"}); got != want {
+		t.Errorf("last week = %+v, want %+v", got, want)
+	}
+}
+
+func TestUnit_NextDueBounds(t *testing.T) {
+	se := NewScheduleEngine()
+	start := utilities.MustParseCalendarDate("2026-07-01")
+	weekly := RepeatPattern{Frequency: "weekly", Interval: 1, AfterCompletion: true, StartDate: start}
+	tests := []struct {
+		name       string
+		modify     func(p *RepeatPattern)
+		exceptions []Exception
+		want       string
+	}{
+		{name: "unbounded", want: "2026-07-31"},
+		{
+			name: "deferred past a pause",
+			modify: func(p *RepeatPattern) {
+				p.Pauses = []Pause{{From: utilities.MustParseCalendarDate("2026-07-30"), To: utilities.MustParseCalendarDate("2026-08-14")}}
+			},
+			want: "2026-08-15",
+		},
+		{
+			name:       "skip moves on one interval",
+			exceptions: []Exception{{OriginalDate: utilities.MustParseCalendarDate("2026-07-31")}},
+			want:       "2026-08-07",
+		},
+		{
+			name:   "past the end date",
+			modify: func(p *RepeatPattern) { p.EndDate = utilities.MustParseCalendarDate("2026-07-30") },
+			want:   "",
+		},
+		{
+			name:   "count of completions reached",
+			modify: func(p *RepeatPattern) { p.Count = 2 },
+			want:   "",
+		},
+		{
+			name:   "count not yet reached",
+			modify: func(p *RepeatPattern) { p.Count = 3 },
+			want:   "2026-07-31",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := weekly
+			if tt.modify != nil {
+				tt.modify(&p)
+			}
+			if got := se.NextDue(p, tt.exceptions, []string{"2026-07-10", "2026-07-24"}, "2026-07-28"); got != tt.want {
+				t.Errorf("NextDue = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package schedule_engine
+
+import (
+	"slices"
+	"time"
+)
+
+// NextDue returns the due date of an after-completion pattern as of asOf:
+// Interval days, weeks, months or years (by Frequency) after the latest
+// completion before asOf, or StartDate when there is none. A rescheduling
+// exception whose OriginalDate is the due date moves it to its NewDate, a
+// skip moves it on by one interval, and a pause defers it to the day after
+// the pause. Month and year steps clamp to the month's length (Jan 31 + 1
+// month is Feb 28). Returns "" once the pattern has ended (past EndDate, or
+// Count completions made), for patterns that are not after-completion, or
+// when asOf is not a valid date.
+func (se *ScheduleEngine) NextDue(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) string {
+	if !pattern.AfterCompletion {
+		return ""
+	}
+	if _, err := parseDate(asOf); err != nil {
+		return ""
+	}
+
+	var last string
+	var done int
+	for _, d := range completedDates {
+		// Lexicographic compare on YYYY-MM-DD is equivalent to chronological order.
+		if d < asOf && d > last {
+			last = d
+		}
+		if d < asOf && d >= pattern.StartDate.String() {
+			done++
+		}
+	}
+	if pattern.Count > 0 && done >= pattern.Count {
+		return ""
+	}
+
+	interval := effectiveInterval(pattern.Interval)
+	due := pattern.StartDate.String()
+	if last != "" {
+		lastDate, err := parseDate(last)
+		if err != nil {
+			return ""
+		}
+		due = formatDate(addPatternInterval(lastDate, pattern.Frequency, interval))
+	}
+
+	// Follow exceptions, each at most once so a cycle cannot loop, and
+	// pauses, which only ever move the date forward.
+	used := make([]bool, len(exceptions))
+	for moved := true; moved; {
+		moved = false
+		for i, ex := range exceptions {
+			if used[i] || ex.OriginalDate.String() != due {
+				continue
+			}
+			used[i], moved = true, true
+			if ex.NewDate.IsZero() {
+				due = formatDate(addPatternInterval(ex.OriginalDate.Time(), pattern.Frequency, interval))
+			} else {
+				due = ex.NewDate.String()
+			}
+		}
+		for _, p := range pattern.Pauses {
+			if p.contains(due) {
+				due = formatDate(p.To.Time().AddDate(0, 0, 1))
+				moved = true
+			}
+		}
+	}
+	if !pattern.EndDate.IsZero() && due > pattern.EndDate.String() {
+		return ""
+	}
+	return due
+}
+
+// addPatternInterval steps n frequency units from t.
+func addPatternInterval(t time.Time, frequency string, n int) time.Time {
+	switch frequency {
+	case "weekly":
+		return t.AddDate(0, 0, 7*n)
+	case "monthly", "yearly":
+		months := n
+		if frequency == "yearly" {
+			months = 12 * n
+		}
+		first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, months, 0)
+		return time.Date(first.Year(), first.Month(), clampDay(first.Year(), first.Month(), t.Day()), 0, 0, 0, 0, time.UTC)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// floatingOverdue returns the due date of an after-completion pattern when
+// it lies before asOf and no completion on or after it has absorbed it. A
+// floating routine owes one completion at a time, so there is at most one
+// overdue date.
+func (se *ScheduleEngine) floatingOverdue(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) []string {
+	due := se.NextDue(pattern, exceptions, completedDates, asOf)
+	if due == "" || due >= asOf {
+		return nil
+	}
+	for _, d := range completedDates {
+		if d >= due {
+			return nil
+		}
+	}
+	return []string{due}
+}
+
+// floatingPeriodCompletion evaluates an after-completion pattern over its
+// current cycle, which runs from the latest completion to the next due
+// date. The cycle is done (1 of 1) while it is not yet due or when the
+// routine was completed on asOf, and behind (0 of 1) once the due date has
+// come. Before the first completion and the start date, nothing is expected.
+// Start and End name the completion fulfilling the cycle.
+func (se *ScheduleEngine) floatingPeriodCompletion(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string) PeriodCompletion {
+	if _, err := parseDate(asOf); err != nil {
+		return PeriodCompletion{}
+	}
+	result := PeriodCompletion{Period: "cycle", OnTrack: true}
+
+	// A completion on asOf starts a new cycle that it has already fulfilled.
+	if slices.Contains(completedDates, asOf) {
+		result.Completed, result.Expected = 1, 1
+		result.Start, result.End = asOf, asOf
+		return result
+	}
+
+	due := se.NextDue(pattern, exceptions, completedDates, asOf)
+	if due == "" {
+		return PeriodCompletion{}
+	}
+	if due > asOf {
+		// Not yet due: the current cycle was fulfilled by the completion
+		// that started it, if there is one.
+		var latest string
+		for _, d := range completedDates {
+			if d < asOf && d > latest {
+				latest = d
+			}
+		}
+		if latest != "" {
+			result.Completed, result.Expected = 1, 1
+			result.Start, result.End = latest, latest
+		}
+		return result
+	}
+	result.Expected = 1
+	result.OnTrack = false
+	return result
+}
@@ -0,0 +1,139 @@
+package schedule_engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_NextDueAfterCompletion(t *testing.T) {
+	se := NewScheduleEngine()
+	start := utilities.MustParseCalendarDate("2026-01-10")
+	tests := []struct {
+		name       string
+		pattern    RepeatPattern
+		exceptions []Exception
+		completed  []string
+		asOf       string
+		want       string
+	}{
+		{
+			name:    "no completion falls due on the start date",
+			pattern: RepeatPattern{Frequency: "daily", Interval: 10, AfterCompletion: true, StartDate: start},
+			asOf:    "2026-01-05",
+			want:    "2026-01-10",
+		},
+		{
+			name:      "days after the latest completion",
+			pattern:   RepeatPattern{Frequency: "daily", Interval: 10, AfterCompletion: true, StartDate: start},
+			completed: []string{"2026-01-10", "2026-01-14"},
+			asOf:      "2026-01-20",
+			want:      "2026-01-24",
+		},
+		{
+			name:      "completion on asOf is not yet counted",
+			pattern:   RepeatPattern{Frequency: "weekly", Interval: 2, AfterCompletion: true, StartDate: start},
+			completed: []string{"2026-01-10", "2026-01-24"},
+			asOf:      "2026-01-24",
+			want:      "2026-01-24",
+		},
+		{
+			name:      "month steps clamp to the month's length",
+			pattern:   RepeatPattern{Frequency: "monthly", Interval: 1, AfterCompletion: true, StartDate: start},
+			completed: []string{"2026-01-31"},
+			asOf:      "2026-02-01",
+			want:      "2026-02-28",
+		},
+		{
+			name:      "year steps from a leap day",
+			pattern:   RepeatPattern{Frequency: "yearly", Interval: 1, AfterCompletion: true, StartDate: start},
+			completed: []string{"2028-02-29"},
+			asOf:      "2028-03-01",
+			want:      "2029-02-28",
+		},
+		{
+			name:    "rescheduled due date",
+			pattern: RepeatPattern{Frequency: "daily", Interval: 3, AfterCompletion: true, StartDate: start},
+			exceptions: []Exception{
+				{OriginalDate: utilities.MustParseCalendarDate("2026-01-13"), NewDate: utilities.MustParseCalendarDate("2026-01-15")},
+				{OriginalDate: utilities.MustParseCalendarDate("2026-01-15"), NewDate: utilities.MustParseCalendarDate("2026-01-13")},
+			},
+			completed: []string{"2026-01-10"},
+			asOf:      "2026-01-11",
+			want:      "2026-01-13",
+		},
+		{
+			name:    "fixed-grid pattern",
+			pattern: RepeatPattern{Frequency: "daily", Interval: 1, StartDate: start},
+			asOf:    "2026-01-11",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := se.NextDue(tt.pattern, tt.exceptions, tt.completed, tt.asOf); got != tt.want {
+				t.Errorf("NextDue = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_AfterCompletionOverdueAndProgress(t *testing.T) {
+	se := NewScheduleEngine()
+	pattern := RepeatPattern{Frequency: "daily", Interval: 7, AfterCompletion: true, StartDate: utilities.MustParseCalendarDate("2026-01-01")}
+
+	if got := se.ComputeOccurrences(pattern, nil, "2026-01-01", "2026-12-31"); got != nil {
+		t.Errorf("ComputeOccurrences = %v, want none", got)
+	}
+
+	tests := []struct {
+		name         string
+		completed    []string
+		asOf         string
+		wantOverdue  []string
+		wantProgress PeriodCompletion
+	}{
+		{
+			name:         "not yet started",
+			asOf:         "2025-12-30",
+			wantProgress: PeriodCompletion{Period: "cycle", OnTrack: true},
+		},
+		{
+			name:         "within the cycle",
+			completed:    []string{"2026-01-01"},
+			asOf:         "2026-01-05",
+			wantProgress: PeriodCompletion{Completed: 1, Expected: 1, Period: "cycle", OnTrack: true, Start: "2026-01-01", End: "2026-01-01"},
+		},
+		{
+			name:         "due today",
+			completed:    []string{"2026-01-01"},
+			asOf:         "2026-01-08",
+			wantProgress: PeriodCompletion{Expected: 1, Period: "cycle"},
+		},
+		{
+			name:         "late by a week collapses to one overdue date",
+			completed:    []string{"2026-01-01"},
+			asOf:         "2026-01-15",
+			wantOverdue:  []string{"2026-01-08"},
+			wantProgress: PeriodCompletion{Expected: 1, Period: "cycle"},
+		},
+		{
+			name:         "done today restarts the cycle",
+			completed:    []string{"2026-01-01", "2026-01-15"},
+			asOf:         "2026-01-15",
+			wantProgress: PeriodCompletion{Completed: 1, Expected: 1, Period: "cycle", OnTrack: true, Start: "2026-01-15", End: "2026-01-15"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := se.ComputeOverdue(pattern, nil, tt.completed, tt.asOf); !reflect.DeepEqual(got, tt.wantOverdue) {
+				t.Errorf("ComputeOverdue = %v, want %v", got, tt.wantOverdue)
+			}
+			if got := se.EvaluatePeriodCompletion(pattern, nil, tt.completed, tt.asOf, time.Monday); got != tt.wantProgress {
+				t.Errorf("EvaluatePeriodCompletion = %+v, want %+v", got, tt.wantProgress)
+			}
+		})
+	}
+}
@@ -1,6 +1,28 @@
 package schedule_engine
 
-import "github.com/rkn/bearing/internal/utilities"
+import (
+	"slices"
+	"strings"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// Materialisation modes of a routine.
+const (
+	MaterializeOnCheck = "on-check" // a task is created when the routine is checked (the default)
+	MaterializeNever   = "never"    // no task is ever created
+	MaterializeAhead   = "ahead"    // a task is created once an occurrence falls due (see PlanDue)
+)
+
+// Materialization configures the tasks a routine turns into. The zero value
+// creates a todo task tagged Routine and titled with the routine's
+// description when the routine is checked.
+type Materialization struct {
+	Mode          string   // one of the Materialize* modes; empty = MaterializeOnCheck
+	Column        string   // board column slug new tasks land in; empty = "todo"
+	Tags          []string // replace the Routine tag when non-empty
+	TitleTemplate string   // task title with {description} and {date} substituted; empty = the description
+}
 
 // RoutineInput is the engine-layer view of a routine for materialisation
 // planning. The engine stays decoupled from the access layer: callers
@@ -9,12 +31,14 @@ import "github.com/rkn/bearing/internal/utilities"
 type RoutineInput struct {
 	ID            string
 	Description   string
+	ThemeID       string         // theme the routine serves; empty for none
 	RepeatPattern *RepeatPattern // nil for sporadic routines
 	Exceptions    []Exception
 	// CompletedDates lists every prior check for this routine across all
 	// dates. Used by ComputeOverdue's absorption rule when classifying the
 	// priority of a newly-checked occurrence.
-	CompletedDates []string
+	CompletedDates  []string
+	Materialization Materialization
 }
 
 // ExistingTaskRef captures the minimum information the engine needs about a
@@ -35,7 +59,9 @@ type ExistingTaskRef struct {
 // NewlyChecked and NewlyUnchecked are routine IDs. ExistingTasks supplies
 // the candidate-delete pool for NewlyUnchecked routines; only those whose
 // status is todo or doing will be added to MaterializationPlan.Deletes
-// (done and archived tasks preserve completion history).
+// (done and archived tasks preserve completion history). For NewlyChecked
+// routines in MaterializeAhead mode it holds the tasks already created
+// ahead of time, which suppress a second one.
 type RoutineCheckDiff struct {
 	Date           utilities.CalendarDate
 	NewlyChecked   []string
@@ -52,8 +78,9 @@ type TaskSpec struct {
 	RoutineID   string
 	Date        utilities.CalendarDate
 	Description string
+	ThemeID     string // the routine's theme; empty for routines serving none
 	Priority    string // "important-urgent" or "important-not-urgent"
-	Status      string // always "todo" for newly materialised routine tasks
+	Status      string // board column slug; "todo" unless the routine names another
 	Tags        []string
 }
 
@@ -73,11 +100,14 @@ const routineTagName = "Routine"
 
 // Plan computes the materialisation plan for a routine-check diff:
 //
-//   - For each newly-checked routine, emit a TaskSpec. Priority is
-//     important-urgent when either ComputeOverdue indicates an outstanding
-//     occurrence on or before today, or the diff date is in the past
-//     (covers sporadic routines and back-dated checks); otherwise
-//     important-not-urgent.
+//   - For each newly-checked routine, emit a TaskSpec in the routine's
+//     theme, shaped by its Materialization. Priority is important-urgent
+//     when either ComputeOverdue indicates an outstanding occurrence on or
+//     before today, or the diff date is in the past (covers sporadic
+//     routines and back-dated checks); otherwise important-not-urgent.
+//     Routines in MaterializeNever mode get no task, and routines in
+//     MaterializeAhead mode none when ExistingTasks already holds one for
+//     the date.
 //   - For each newly-unchecked routine, emit a delete for any matching
 //     ExistingTaskRef whose status is todo or doing. done/archived tasks
 //     are intentionally preserved — they are completion history.
@@ -101,19 +131,15 @@ func (se *ScheduleEngine) Plan(diff RoutineCheckDiff, routines []RoutineInput, t
 				continue
 			}
 
-			priority := "important-not-urgent"
-			if se.isCheckedOccurrenceUrgent(routine, diff.Date, today) {
-				priority = "important-urgent"
+			switch routine.Materialization.Mode {
+			case MaterializeNever:
+				continue
+			case MaterializeAhead:
+				if hasTaskFor(diff.ExistingTasks, routine.ID, diff.Date) {
+					continue
+				}
 			}
-
-			plan.Creates = append(plan.Creates, TaskSpec{
-				RoutineID:   routine.ID,
-				Date:        diff.Date,
-				Description: routine.Description,
-				Priority:    priority,
-				Status:      "todo",
-				Tags:        []string{routineTagName},
-			})
+			plan.Creates = append(plan.Creates, se.taskSpec(routine, diff.Date, today))
 		}
 	}
 
@@ -140,6 +166,86 @@ func (se *ScheduleEngine) Plan(diff RoutineCheckDiff, routines []RoutineInput, t
 	return plan
 }
 
+// PlanDue computes the tasks to create ahead of time for routines in
+// MaterializeAhead mode: one for each occurrence due on today that is not
+// checked yet and has no task in existing. A floating routine's occurrence
+// is its NextDue date once that is today or has passed, so an overdue one
+// keeps its single task until it is checked. The plan has no deletes.
+func (se *ScheduleEngine) PlanDue(routines []RoutineInput, existing []ExistingTaskRef, today utilities.CalendarDate) MaterializationPlan {
+	var plan MaterializationPlan
+	for _, routine := range routines {
+		if routine.Materialization.Mode != MaterializeAhead || routine.RepeatPattern == nil {
+			continue
+		}
+		if slices.Contains(routine.CompletedDates, today.String()) {
+			continue
+		}
+		pattern := *routine.RepeatPattern
+		var date utilities.CalendarDate
+		if pattern.AfterCompletion {
+			due := se.NextDue(pattern, routine.Exceptions, routine.CompletedDates, today.String())
+			// Lexicographic compare on YYYY-MM-DD matches chronological order.
+			if due == "" || due > today.String() {
+				continue
+			}
+			parsed, err := utilities.ParseCalendarDate(due)
+			if err != nil {
+				continue
+			}
+			date = parsed
+		} else {
+			if len(se.ComputeOccurrences(pattern, routine.Exceptions, today.String(), today.String())) == 0 {
+				continue
+			}
+			date = today
+		}
+		if hasTaskFor(existing, routine.ID, date) {
+			continue
+		}
+		plan.Creates = append(plan.Creates, se.taskSpec(routine, date, today))
+	}
+	return plan
+}
+
+// taskSpec describes the task materialising routine's occurrence on date.
+func (se *ScheduleEngine) taskSpec(routine RoutineInput, date, today utilities.CalendarDate) TaskSpec {
+	priority := "important-not-urgent"
+	if se.isCheckedOccurrenceUrgent(routine, date, today) {
+		priority = "important-urgent"
+	}
+
+	mat := routine.Materialization
+	title := routine.Description
+	if mat.TitleTemplate != "" {
+		title = strings.NewReplacer("{description}", routine.Description, "{date}", date.String()).Replace(mat.TitleTemplate)
+	}
+	status := "todo"
+	if mat.Column != "" {
+		status = mat.Column
+	}
+	tags := []string{routineTagName}
+	if len(mat.Tags) > 0 {
+		tags = slices.Clone(mat.Tags)
+	}
+
+	return TaskSpec{
+		RoutineID:   routine.ID,
+		Date:        date,
+		Description: title,
+		ThemeID:     routine.ThemeID,
+		Priority:    priority,
+		Status:      status,
+		Tags:        tags,
+	}
+}
+
+// hasTaskFor reports whether existing holds a task for routineID on date.
+func hasTaskFor(existing []ExistingTaskRef, routineID string, date utilities.CalendarDate) bool {
+	return slices.ContainsFunc(existing, func(t ExistingTaskRef) bool {
+		return t.RoutineID == routineID && t.Date == date
+	})
+}
+
 // isCheckedOccurrenceUrgent reports whether a newly-checked routine
 // occurrence on diff.Date should be classified as urgent. An occurrence
 // is urgent when:
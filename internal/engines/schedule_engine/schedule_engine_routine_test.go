@@ -165,6 +165,27 @@ func TestUnit_PlanSporadicRoutineBackdatedIsUrgent(t *testing.T) {
 	}
 }
 
+func TestUnit_PlanTaskTakesRoutineTheme(t *testing.T) {
+	se := NewScheduleEngine()
+	today := utilities.MustParseCalendarDate("2026-05-01")
+	routines := []RoutineInput{
+		{ID: "R1", Description: "Run", ThemeID: "H"},
+		{ID: "R2", Description: "Read paper"},
+	}
+
+	plan := se.Plan(RoutineCheckDiff{
+		Date:         today,
+		NewlyChecked: []string{"R1", "R2"},
+	}, routines, today)
+
+	if len(plan.Creates) != 2 {
+		t.Fatalf("Creates len = %d, want 2", len(plan.Creates))
+	}
+	if plan.Creates[0].ThemeID != "H" || plan.Creates[1].ThemeID != "" {
+		t.Errorf("ThemeIDs = %q, %q, want %q, none", plan.Creates[0].ThemeID, plan.Creates[1].ThemeID, "H")
+	}
+}
+
 func TestUnit_PlanUnknownRoutineIsSkipped(t *testing.T) {
 	se := NewScheduleEngine()
 	today := utilities.MustParseCalendarDate("2026-05-01")
@@ -305,3 +326,71 @@ func TestUnit_PlanMixedCheckedAndUnchecked(t *testing.T) {
 		t.Errorf("Deletes = %v, want [T-2]", plan.Deletes)
 	}
 }
+
+func TestUnit_PlanFollowsMaterialization(t *testing.T) {
+	se := NewScheduleEngine()
+	today := utilities.MustParseCalendarDate("2026-05-01")
+	routine := func(id string, mat Materialization) RoutineInput {
+		return RoutineInput{ID: id, Description: "Stretch", Materialization: mat}
+	}
+	routines := []RoutineInput{
+		routine("R1", Materialization{Mode: MaterializeNever}),
+		routine("R2", Materialization{Mode: MaterializeAhead}),
+		routine("R3", Materialization{Mode: MaterializeAhead}),
+		routine("R4", Materialization{Column: "doing", Tags: []string{"Health"}, TitleTemplate: "{description} ({date})"}),
+	}
+
+	plan := se.Plan(RoutineCheckDiff{
+		Date:          today,
+		NewlyChecked:  []string{"R1", "R2", "R3", "R4"},
+		ExistingTasks: []ExistingTaskRef{{TaskID: "T-T1", RoutineID: "R2", Date: today, Status: "todo"}},
+	}, routines, today)
+
+	want := []TaskSpec{
+		{RoutineID: "R3", Date: today, Description: "Stretch", Priority: "important-not-urgent", Status: "todo", Tags: []string{"Routine"}},
+		{RoutineID: "R4", Date: today, Description: "Stretch (2026-05-01)", Priority: "important-not-urgent", Status: "doing", Tags: []string{"Health"}},
+	}
+	if !reflect.DeepEqual(plan.Creates, want) {
+		t.Errorf("Creates = %#v, want %#v", plan.Creates, want)
+	}
+}
+
+func TestUnit_PlanDue(t *testing.T) {
+	se := NewScheduleEngine()
+	today := utilities.MustParseCalendarDate("2026-05-06") // Wednesday
+	ahead := Materialization{Mode: MaterializeAhead}
+	routines := []RoutineInput{
+		// Due today.
+		{ID: "R1", Description: "Stretch", Materialization: ahead,
+			RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, Weekdays: []int{3}, StartDate: utilities.MustParseCalendarDate("2026-05-06")}},
+		// Not due today.
+		{ID: "R2", Description: "Swim", Materialization: ahead,
+			RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, Weekdays: []int{4}, StartDate: utilities.MustParseCalendarDate("2026-05-07")}},
+		// Due today but already checked.
+		{ID: "R3", Description: "Read", Materialization: ahead, CompletedDates: []string{"2026-05-06"},
+			RepeatPattern: &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-05-06")}},
+		// Due today but materialised on check.
+		{ID: "R4", Description: "Walk",
+			RepeatPattern: &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-05-06")}},
+		// Floating, fell due two days ago.
+		{ID: "R5", Description: "Water the plants", Materialization: ahead, CompletedDates: []string{"2026-04-27"},
+			RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, AfterCompletion: true, StartDate: utilities.MustParseCalendarDate("2026-04-20")}},
+		// Due today with a task already created.
+		{ID: "R6", Description: "Plan", Materialization: ahead,
+			RepeatPattern: &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-05-06")}},
+	}
+	existing := []ExistingTaskRef{{TaskID: "T-T1", RoutineID: "R6", Date: today, Status: "doing"}}
+
+	plan := se.PlanDue(routines, existing, today)
+
+	want := []TaskSpec{
+		{RoutineID: "R1", Date: today, Description: "Stretch", Priority: "important-not-urgent", Status: "todo", Tags: []string{"Routine"}},
+		{RoutineID: "R5", Date: utilities.MustParseCalendarDate("2026-05-04"), Description: "Water the plants", Priority: "important-urgent", Status: "todo", Tags: []string{"Routine"}},
+	}
+	if !reflect.DeepEqual(plan.Creates, want) {
+		t.Errorf("Creates = %#v, want %#v", plan.Creates, want)
+	}
+	if len(plan.Deletes) != 0 {
+		t.Errorf("Deletes = %v, want empty", plan.Deletes)
+	}
+}
@@ -0,0 +1,162 @@
+package schedule_engine
+
+import (
+	"slices"
+	"time"
+)
+
+// AdherenceWindows are the trailing windows, in weeks, that ComputeStats
+// reports adherence for.
+var AdherenceWindows = []int{4, 12, 52}
+
+// RoutineStats summarises a routine's completion history as of a date.
+type RoutineStats struct {
+	CurrentStreak int          // occurrences met in a row, ending with the latest one due
+	LongestStreak int          // longest run of occurrences met in a row
+	Adherence     []Adherence  // one entry per AdherenceWindows window
+	ByWeekday     [7]int       // completions per weekday, 0=Sun..6=Sat
+	Heatmap       []HeatmapDay // every day of the requested year
+}
+
+// Adherence is the share of occurrences met within a trailing window.
+type Adherence struct {
+	Weeks     int     // window length, ending on asOf
+	Completed int     // occurrences met in the window
+	Expected  int     // occurrences due in the window
+	Rate      float64 // Completed / Expected; 0 when nothing was expected
+}
+
+// HeatmapDay is one day of a year heatmap.
+type HeatmapDay struct {
+	Date      string // YYYY-MM-DD
+	Scheduled bool   // an occurrence falls on this day
+	Completed bool   // the routine was checked on this day
+}
+
+// statOccurrence is one occurrence in a routine's history and whether it
+// was met.
+type statOccurrence struct {
+	date string
+	met  bool
+}
+
+// ComputeStats computes streaks, adherence, a weekday histogram and a
+// heatmap for year from a routine's completions.
+//
+// An occurrence is met when the routine was checked on its date or later,
+// before the next occurrence came due, the same absorption rule as
+// ComputeOverdue; an occurrence on asOf that is not yet checked is still
+// open and neither breaks a streak nor counts as expected. For after-completion patterns each
+// completion closes a cycle, met when it came by the due date, and a due
+// date that passed without one is a missed cycle. A zero pattern (a sporadic
+// routine) has no occurrences, so only the histogram and the heatmap's
+// completions are filled.
+func (se *ScheduleEngine) ComputeStats(pattern RepeatPattern, exceptions []Exception, completedDates []string, asOf string, year int) RoutineStats {
+	asOfDate, err := parseDate(asOf)
+	if err != nil {
+		return RoutineStats{}
+	}
+
+	completed := make(map[string]bool, len(completedDates))
+	var history []string
+	for _, d := range completedDates {
+		// Lexicographic compare on YYYY-MM-DD is equivalent to chronological order.
+		if d <= asOf && !completed[d] {
+			history = append(history, d)
+		}
+		completed[d] = true
+	}
+	slices.Sort(history)
+
+	var occurrences []statOccurrence
+	scheduled := make(map[string]bool)
+	if pattern.AfterCompletion {
+		occurrences = se.floatingHistory(pattern, exceptions, history, asOf, scheduled)
+	} else {
+		dates := se.ComputeOccurrences(pattern, exceptions, pattern.StartDate.String(), asOf)
+		met := occurrencesMet(dates, history, asOf)
+		for i, d := range dates {
+			if d == asOf && !met[i] {
+				continue
+			}
+			occurrences = append(occurrences, statOccurrence{date: d, met: met[i]})
+		}
+		first := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		for _, d := range se.ComputeOccurrences(pattern, exceptions, formatDate(first), formatDate(first.AddDate(1, 0, -1))) {
+			scheduled[d] = true
+		}
+	}
+
+	var stats RoutineStats
+	run := 0
+	for _, o := range occurrences {
+		if !o.met {
+			run = 0
+			continue
+		}
+		run++
+		stats.LongestStreak = max(stats.LongestStreak, run)
+	}
+	stats.CurrentStreak = run
+
+	for _, weeks := range AdherenceWindows {
+		from := formatDate(asOfDate.AddDate(0, 0, 1-7*weeks))
+		a := Adherence{Weeks: weeks}
+		for _, o := range occurrences {
+			if o.date < from {
+				continue
+			}
+			a.Expected++
+			if o.met {
+				a.Completed++
+			}
+		}
+		if a.Expected > 0 {
+			a.Rate = float64(a.Completed) / float64(a.Expected)
+		}
+		stats.Adherence = append(stats.Adherence, a)
+	}
+
+	for _, d := range history {
+		if t, err := parseDate(d); err == nil {
+			stats.ByWeekday[t.Weekday()]++
+		}
+	}
+
+	for t := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC); t.Year() == year; t = t.AddDate(0, 0, 1) {
+		d := formatDate(t)
+		stats.Heatmap = append(stats.Heatmap, HeatmapDay{Date: d, Scheduled: scheduled[d], Completed: completed[d]})
+	}
+	return stats
+}
+
+// floatingHistory reconstructs the cycles of an after-completion pattern
+// from its sorted completions up to asOf, and records every due date in
+// scheduled. Each completion meets the cycle it closes when it came by that
+// cycle's due date; a late completion leaves the cycle missed on its due
+// date. A due date before asOf that no completion has closed yet is missed
+// too.
+func (se *ScheduleEngine) floatingHistory(pattern RepeatPattern, exceptions []Exception, history []string, asOf string, scheduled map[string]bool) []statOccurrence {
+	var occurrences []statOccurrence
+	for _, c := range history {
+		due := se.NextDue(pattern, exceptions, history, c)
+		if due == "" {
+			continue
+		}
+		scheduled[due] = true
+		if c <= due {
+			occurrences = append(occurrences, statOccurrence{date: c, met: true})
+		} else {
+			occurrences = append(occurrences, statOccurrence{date: due})
+		}
+	}
+	if len(history) == 0 || history[len(history)-1] != asOf {
+		if due := se.NextDue(pattern, exceptions, history, asOf); due != "" {
+			scheduled[due] = true
+			if due < asOf {
+				occurrences = append(occurrences, statOccurrence{date: due})
+			}
+		}
+	}
+	return occurrences
+}
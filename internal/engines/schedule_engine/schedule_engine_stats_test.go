@@ -0,0 +1,116 @@
+package schedule_engine
+
+import (
+	"testing"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_ComputeStatsDaily(t *testing.T) {
+	se := NewScheduleEngine()
+	daily := RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-01-01")}
+	completed := []string{"2026-01-09", "2026-01-01", "2026-01-02", "2026-01-03", "2026-01-05", "2026-01-06", "2026-01-07", "2026-01-08"}
+
+	stats := se.ComputeStats(daily, nil, completed, "2026-01-10", 2026)
+	if stats.CurrentStreak != 5 || stats.LongestStreak != 5 {
+		t.Errorf("streaks = %d current, %d longest; want 5, 5", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if got, want := stats.Adherence[0], (Adherence{Weeks: 4, Completed: 8, Expected: 9, Rate: 8.0 / 9}); got != want {
+		t.Errorf("4-week adherence = %+v, want %+v", got, want)
+	}
+	if len(stats.Adherence) != 3 || stats.Adherence[2].Weeks != 52 || stats.Adherence[2].Expected != 9 {
+		t.Errorf("adherence = %+v, want 4, 12 and 52 weeks", stats.Adherence)
+	}
+	if want := [7]int{0, 1, 1, 1, 2, 2, 1}; stats.ByWeekday != want {
+		t.Errorf("by weekday = %v, want %v", stats.ByWeekday, want)
+	}
+	if len(stats.Heatmap) != 365 {
+		t.Fatalf("heatmap has %d days, want 365", len(stats.Heatmap))
+	}
+	for _, tt := range []struct {
+		index int
+		want  HeatmapDay
+	}{
+		{0, HeatmapDay{Date: "2026-01-01", Scheduled: true, Completed: true}},
+		{3, HeatmapDay{Date: "2026-01-04", Scheduled: true}},
+		{364, HeatmapDay{Date: "2026-12-31", Scheduled: true}},
+	} {
+		if got := stats.Heatmap[tt.index]; got != tt.want {
+			t.Errorf("heatmap[%d] = %+v, want %+v", tt.index, got, tt.want)
+		}
+	}
+
+	// Checking today extends the current streak.
+	stats = se.ComputeStats(daily, nil, append(completed, "2026-01-10"), "2026-01-10", 2026)
+	if stats.CurrentStreak != 6 || stats.LongestStreak != 6 {
+		t.Errorf("streaks after checking today = %d, %d; want 6, 6", stats.CurrentStreak, stats.LongestStreak)
+	}
+
+	// An earlier year has no occurrences.
+	stats = se.ComputeStats(daily, nil, completed, "2026-01-10", 2025)
+	if len(stats.Heatmap) != 365 || stats.Heatmap[0].Date != "2025-01-01" || stats.Heatmap[364].Scheduled {
+		t.Errorf("2025 heatmap = %d days from %+v", len(stats.Heatmap), stats.Heatmap[0])
+	}
+}
+
+func TestUnit_ComputeStatsRespectsSchedule(t *testing.T) {
+	se := NewScheduleEngine()
+	mondays := RepeatPattern{Frequency: "weekly", Interval: 1, Weekdays: []int{1}, StartDate: utilities.MustParseCalendarDate("2026-01-05")}
+	skip := []Exception{{OriginalDate: utilities.MustParseCalendarDate("2026-01-19")}}
+	moved := Exception{OriginalDate: utilities.MustParseCalendarDate("2026-01-26"), NewDate: utilities.MustParseCalendarDate("2026-01-28")}
+
+	// Checks off schedule neither count nor break the streak; the skipped
+	// Monday is not owed.
+	completed := []string{"2026-01-05", "2026-01-07", "2026-01-12", "2026-01-28", "2026-02-02"}
+	stats := se.ComputeStats(mondays, append(skip, moved), completed, "2026-02-04", 2026)
+	if stats.CurrentStreak != 4 || stats.LongestStreak != 4 {
+		t.Errorf("streaks = %d, %d; want 4, 4", stats.CurrentStreak, stats.LongestStreak)
+	}
+
+	// A check two days late still meets the Monday before it.
+	stats = se.ComputeStats(mondays, skip, completed, "2026-02-04", 2026)
+	if stats.CurrentStreak != 4 || stats.LongestStreak != 4 {
+		t.Errorf("streaks with a late check = %d, %d; want 4, 4", stats.CurrentStreak, stats.LongestStreak)
+	}
+
+	completed = []string{"2026-01-05", "2026-01-07", "2026-01-12", "2026-02-02"}
+	stats = se.ComputeStats(mondays, skip, completed, "2026-02-04", 2026)
+	if stats.CurrentStreak != 1 || stats.LongestStreak != 2 {
+		t.Errorf("streaks with a missed Monday = %d, %d; want 1, 2", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if got, want := stats.Adherence[0], (Adherence{Weeks: 4, Completed: 2, Expected: 3, Rate: 2.0 / 3}); got != want {
+		t.Errorf("4-week adherence = %+v, want %+v", got, want)
+	}
+}
+
+func TestUnit_ComputeStatsAfterCompletion(t *testing.T) {
+	se := NewScheduleEngine()
+	weekly := RepeatPattern{Frequency: "weekly", Interval: 1, AfterCompletion: true, StartDate: utilities.MustParseCalendarDate("2026-01-01")}
+
+	// On time on Jan 1 and 7; Jan 20 is late for Jan 14, and Jan 27 has passed.
+	stats := se.ComputeStats(weekly, nil, []string{"2026-01-01", "2026-01-07", "2026-01-20"}, "2026-01-30", 2026)
+	if stats.CurrentStreak != 0 || stats.LongestStreak != 2 {
+		t.Errorf("streaks = %d, %d; want 0, 2", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if got, want := stats.Adherence[0], (Adherence{Weeks: 4, Completed: 1, Expected: 3, Rate: 1.0 / 3}); got != want {
+		t.Errorf("4-week adherence = %+v, want %+v", got, want)
+	}
+	for _, d := range []int{0, 7, 13, 26} {
+		if !stats.Heatmap[d].Scheduled {
+			t.Errorf("%s not scheduled", stats.Heatmap[d].Date)
+		}
+	}
+}
+
+func TestUnit_ComputeStatsSporadic(t *testing.T) {
+	stats := NewScheduleEngine().ComputeStats(RepeatPattern{}, nil, []string{"2026-03-01", "2026-03-08", "2026-03-09"}, "2026-03-10", 2026)
+	if stats.CurrentStreak != 0 || stats.Adherence[0] != (Adherence{Weeks: 4}) {
+		t.Errorf("stats = %+v, want no streak or adherence", stats)
+	}
+	if stats.ByWeekday[0] != 2 || stats.ByWeekday[1] != 1 {
+		t.Errorf("by weekday = %v, want 2 Sundays and 1 Monday", stats.ByWeekday)
+	}
+	if !stats.Heatmap[59].Completed || stats.Heatmap[59].Scheduled {
+		t.Errorf("heatmap %+v, want completed and unscheduled", stats.Heatmap[59])
+	}
+}
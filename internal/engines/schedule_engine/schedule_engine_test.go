@@ -3,6 +3,7 @@ package schedule_engine
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/rkn/bearing/internal/utilities"
 )
@@ -234,6 +235,41 @@ func TestUnit_ComputeOverdueNoneCompleted(t *testing.T) {
 	}
 }
 
+func TestUnit_MissedOccurrencesAbsorbsLateCheck(t *testing.T) {
+	se := NewScheduleEngine()
+	// Mondays, Wednesdays and Fridays from Monday 2026-03-16.
+	pattern := RepeatPattern{
+		Frequency: "weekly",
+		Interval:  1,
+		Weekdays:  []int{1, 3, 5},
+		StartDate: utilities.MustParseCalendarDate("2026-03-16"),
+	}
+
+	// Tuesday's check meets Monday; Wednesday is missed once Friday is due.
+	// Friday is asOf and still open.
+	result := se.MissedOccurrences(pattern, nil, []string{"2026-03-17"}, "2026-03-16", "2026-03-22", "2026-03-20")
+	want := []string{"2026-03-18"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("got %v, want %v", result, want)
+	}
+
+	// A late check on Thursday meets Wednesday.
+	result = se.MissedOccurrences(pattern, nil, []string{"2026-03-17", "2026-03-19"}, "2026-03-16", "2026-03-22", "2026-03-20")
+	if len(result) != 0 {
+		t.Errorf("expected no missed occurrences, got %v", result)
+	}
+
+	// end bounds the result; after-completion patterns yield none.
+	result = se.MissedOccurrences(pattern, nil, nil, "2026-03-16", "2026-03-16", "2026-03-20")
+	if want := []string{"2026-03-16"}; !reflect.DeepEqual(result, want) {
+		t.Errorf("got %v, want %v", result, want)
+	}
+	pattern.AfterCompletion = true
+	if result := se.MissedOccurrences(pattern, nil, nil, "2026-03-16", "2026-03-22", "2026-03-20"); result != nil {
+		t.Errorf("after-completion: got %v, want nil", result)
+	}
+}
+
 func TestUnit_EvaluatePeriodCompletionWeeklyPattern(t *testing.T) {
 	se := NewScheduleEngine()
 	// 2025-01-08 is a Wednesday. Week is Mon Jan 6 - Sun Jan 12.
@@ -246,7 +282,7 @@ func TestUnit_EvaluatePeriodCompletionWeeklyPattern(t *testing.T) {
 		Interval:  1,
 		Weekdays:  []int{1, 3, 5},
 		StartDate: utilities.MustParseCalendarDate("2025-01-06"),
-	}, nil, []string{"2025-01-06"}, "2025-01-08")
+	}, nil, []string{"2025-01-06"}, "2025-01-08", time.Monday)
 
 	if result.Period != "week" {
 		t.Errorf("Period = %q, want \"week\"", result.Period)
@@ -262,6 +298,25 @@ func TestUnit_EvaluatePeriodCompletionWeeklyPattern(t *testing.T) {
 	}
 }
 
+func TestUnit_EvaluatePeriodCompletionWeekStartsSunday(t *testing.T) {
+	se := NewScheduleEngine()
+	// 2025-01-11 is a Saturday. A Sunday week is Sun Jan 5 - Sat Jan 11,
+	// holding Mon Jan 6, Wed Jan 8 and Fri Jan 10.
+	result := se.EvaluatePeriodCompletion(RepeatPattern{
+		Frequency: "weekly",
+		Interval:  1,
+		Weekdays:  []int{1, 3, 5},
+		StartDate: utilities.MustParseCalendarDate("2025-01-06"),
+	}, nil, []string{"2025-01-06", "2025-01-08", "2025-01-10"}, "2025-01-11", time.Sunday)
+
+	if result.Start != "2025-01-05" || result.End != "2025-01-11" {
+		t.Errorf("period = %s..%s, want 2025-01-05..2025-01-11", result.Start, result.End)
+	}
+	if result.Expected != 3 || result.Completed != 3 || !result.OnTrack {
+		t.Errorf("result = %+v, want 3 of 3 on track", result)
+	}
+}
+
 func TestUnit_EvaluatePeriodCompletionMonthlyOnTrack(t *testing.T) {
 	se := NewScheduleEngine()
 	// Monthly on the 15th, asOf = 2025-01-20.
@@ -273,7 +328,7 @@ func TestUnit_EvaluatePeriodCompletionMonthlyOnTrack(t *testing.T) {
 		Interval:   1,
 		DayOfMonth: 15,
 		StartDate:  utilities.MustParseCalendarDate("2025-01-15"),
-	}, nil, []string{"2025-01-15"}, "2025-01-20")
+	}, nil, []string{"2025-01-15"}, "2025-01-20", time.Monday)
 
 	if result.Period != "month" {
 		t.Errorf("Period = %q, want \"month\"", result.Period)
@@ -297,7 +352,7 @@ func TestUnit_EvaluatePeriodCompletionBehindSchedule(t *testing.T) {
 		Frequency: "daily",
 		Interval:  1,
 		StartDate: utilities.MustParseCalendarDate("2025-01-01"),
-	}, nil, nil, "2025-01-05")
+	}, nil, nil, "2025-01-05", time.Monday)
 
 	if result.Period != "day" {
 		t.Errorf("Period = %q, want \"day\"", result.Period)
@@ -552,3 +607,201 @@ func TestUnit_ComputeOverdueExceptionReplacementIntoFutureWithAbsorption(t *test
 		t.Errorf("got %v, want %v", result, want)
 	}
 }
+
+func TestUnit_MonthlyAndYearlyCalendarPatterns(t *testing.T) {
+	se := NewScheduleEngine()
+	tests := []struct {
+		name       string
+		pattern    RepeatPattern
+		start, end string
+		want       []string
+	}{
+		{
+			name:    "2nd Tuesday of every month",
+			pattern: RepeatPattern{Frequency: "monthly", WeekOfMonth: 2, Weekdays: []int{2}, StartDate: utilities.MustParseCalendarDate("2025-01-01")},
+			start:   "2025-01-01", end: "2025-06-30",
+			want: []string{"2025-01-14", "2025-02-11", "2025-03-11", "2025-04-08", "2025-05-13", "2025-06-10"},
+		},
+		{
+			name:    "nth weekday defaults to the anchor's weekday",
+			pattern: RepeatPattern{Frequency: "monthly", WeekOfMonth: 2, StartDate: utilities.MustParseCalendarDate("2025-01-14")},
+			start:   "2025-01-01", end: "2025-03-31",
+			want: []string{"2025-01-14", "2025-02-11", "2025-03-11"},
+		},
+		{
+			name:    "nth weekday before the anchor is skipped",
+			pattern: RepeatPattern{Frequency: "monthly", WeekOfMonth: 2, Weekdays: []int{2}, StartDate: utilities.MustParseCalendarDate("2025-01-20")},
+			start:   "2025-01-01", end: "2025-02-28",
+			want: []string{"2025-02-11"},
+		},
+		{
+			name:    "last Friday of the month",
+			pattern: RepeatPattern{Frequency: "monthly", WeekOfMonth: -1, Weekdays: []int{5}, StartDate: utilities.MustParseCalendarDate("2025-01-01")},
+			start:   "2025-01-01", end: "2025-06-30",
+			want: []string{"2025-01-31", "2025-02-28", "2025-03-28", "2025-04-25", "2025-05-30", "2025-06-27"},
+		},
+		{
+			name:    "last Sunday every 2 months",
+			pattern: RepeatPattern{Frequency: "monthly", Interval: 2, WeekOfMonth: -1, Weekdays: []int{0}, StartDate: utilities.MustParseCalendarDate("2025-01-01")},
+			start:   "2025-01-01", end: "2025-06-30",
+			want: []string{"2025-01-26", "2025-03-30", "2025-05-25"},
+		},
+		{
+			name:    "5th Thursday only in months that have one, leap February included",
+			pattern: RepeatPattern{Frequency: "monthly", WeekOfMonth: 5, Weekdays: []int{4}, StartDate: utilities.MustParseCalendarDate("2024-01-01")},
+			start:   "2024-01-01", end: "2024-06-30",
+			want: []string{"2024-02-29", "2024-05-30"},
+		},
+		{
+			name:    "1st Monday and 1st Wednesday in date order",
+			pattern: RepeatPattern{Frequency: "monthly", WeekOfMonth: 1, Weekdays: []int{1, 3}, StartDate: utilities.MustParseCalendarDate("2025-01-01")},
+			start:   "2025-01-01", end: "2025-01-31",
+			want: []string{"2025-01-01", "2025-01-06"},
+		},
+		{
+			name:    "last day of the month across a leap year",
+			pattern: RepeatPattern{Frequency: "monthly", DayOfMonth: -1, StartDate: utilities.MustParseCalendarDate("2024-01-01")},
+			start:   "2024-01-01", end: "2024-05-31",
+			want: []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30", "2024-05-31"},
+		},
+		{
+			name:    "last day of February in a common year",
+			pattern: RepeatPattern{Frequency: "monthly", DayOfMonth: -1, StartDate: utilities.MustParseCalendarDate("2025-01-01")},
+			start:   "2025-02-01", end: "2025-03-31",
+			want: []string{"2025-02-28", "2025-03-31"},
+		},
+		{
+			name:    "second-to-last day of the month",
+			pattern: RepeatPattern{Frequency: "monthly", DayOfMonth: -2, StartDate: utilities.MustParseCalendarDate("2024-02-01")},
+			start:   "2024-02-01", end: "2024-04-30",
+			want: []string{"2024-02-28", "2024-03-30", "2024-04-29"},
+		},
+		{
+			name:    "first Monday of March",
+			pattern: RepeatPattern{Frequency: "yearly", Month: 3, WeekOfMonth: 1, Weekdays: []int{1}, StartDate: utilities.MustParseCalendarDate("2024-01-01")},
+			start:   "2024-01-01", end: "2027-12-31",
+			want: []string{"2024-03-04", "2025-03-03", "2026-03-02", "2027-03-01"},
+		},
+		{
+			name:    "yearly month already past in the anchor's year",
+			pattern: RepeatPattern{Frequency: "yearly", Month: 3, WeekOfMonth: 1, Weekdays: []int{1}, StartDate: utilities.MustParseCalendarDate("2025-06-01")},
+			start:   "2025-01-01", end: "2026-12-31",
+			want: []string{"2026-03-02"},
+		},
+		{
+			name:    "last day of February every year",
+			pattern: RepeatPattern{Frequency: "yearly", Month: 2, DayOfMonth: -1, StartDate: utilities.MustParseCalendarDate("2023-01-01")},
+			start:   "2023-01-01", end: "2025-12-31",
+			want: []string{"2023-02-28", "2024-02-29", "2025-02-28"},
+		},
+		{
+			name:    "every weekday",
+			pattern: RepeatPattern{Frequency: "weekly", Weekdays: []int{1, 2, 3, 4, 5}, StartDate: utilities.MustParseCalendarDate("2025-02-27")},
+			start:   "2025-02-27", end: "2025-03-04",
+			want: []string{"2025-02-27", "2025-02-28", "2025-03-03", "2025-03-04"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := se.ComputeOccurrences(tt.pattern, nil, tt.start, tt.end)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_NthWeekdayOfMonth(t *testing.T) {
+	tests := []struct {
+		year    int
+		month   time.Month
+		weekday time.Weekday
+		n       int
+		want    string // empty when the month has no such day
+	}{
+		{2024, time.February, time.Thursday, 1, "2024-02-01"},
+		{2024, time.February, time.Thursday, 5, "2024-02-29"},
+		{2025, time.February, time.Thursday, 5, ""},
+		{2025, time.February, time.Friday, -1, "2025-02-28"},
+		{2025, time.February, time.Saturday, -1, "2025-02-22"},
+		{2024, time.December, time.Tuesday, -1, "2024-12-31"},
+		{2024, time.December, time.Tuesday, -2, "2024-12-24"},
+		{2025, time.March, time.Saturday, 1, "2025-03-01"},
+		{2025, time.March, time.Monday, 5, "2025-03-31"},
+		{2025, time.April, time.Monday, 5, ""},
+	}
+	for _, tt := range tests {
+		d, ok := nthWeekdayOfMonth(tt.year, tt.month, tt.weekday, tt.n)
+		got := ""
+		if ok {
+			got = formatDate(d)
+		}
+		if got != tt.want {
+			t.Errorf("nthWeekdayOfMonth(%d, %s, %s, %d) = %q, want %q", tt.year, tt.month, tt.weekday, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestUnit_ComputeOverdueLastDayOfMonth(t *testing.T) {
+	se := NewScheduleEngine()
+	// Last day of the month from Jan 2024; asOf May 1. Occurrences before
+	// asOf: Jan 31, Feb 29 (leap), Mar 31, Apr 30. The Feb 29 check absorbs
+	// Jan 31 and itself.
+	result := se.ComputeOverdue(RepeatPattern{
+		Frequency:  "monthly",
+		DayOfMonth: -1,
+		StartDate:  utilities.MustParseCalendarDate("2024-01-31"),
+	}, nil, []string{"2024-02-29"}, "2024-05-01")
+
+	want := []string{"2024-03-31", "2024-04-30"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("got %v, want %v", result, want)
+	}
+}
+
+func TestUnit_EvaluatePeriodCompletionCalendarPatterns(t *testing.T) {
+	se := NewScheduleEngine()
+	tests := []struct {
+		name      string
+		pattern   RepeatPattern
+		completed []string
+		asOf      string
+		want      PeriodCompletion
+	}{
+		{
+			// 2nd Tuesday and 2nd Thursday of February 2025: Feb 11 and 13.
+			name:      "nth weekdays in the month so far",
+			pattern:   RepeatPattern{Frequency: "monthly", WeekOfMonth: 2, Weekdays: []int{2, 4}, StartDate: utilities.MustParseCalendarDate("2025-01-01")},
+			completed: []string{"2025-02-11"},
+			asOf:      "2025-02-12",
+			want:      PeriodCompletion{Completed: 1, Expected: 2, Period: "month", OnTrack: true, Start: "2025-02-01", End: "2025-02-28"},
+		},
+		{
+			name:    "last day of the month not yet reached",
+			pattern: RepeatPattern{Frequency: "monthly", DayOfMonth: -1, StartDate: utilities.MustParseCalendarDate("2024-01-01")},
+			asOf:    "2024-02-28",
+			want:    PeriodCompletion{Completed: 0, Expected: 1, Period: "month", OnTrack: true, Start: "2024-02-01", End: "2024-02-29"},
+		},
+		{
+			name:    "last day of the month missed",
+			pattern: RepeatPattern{Frequency: "monthly", DayOfMonth: -1, StartDate: utilities.MustParseCalendarDate("2024-01-01")},
+			asOf:    "2024-02-29",
+			want:    PeriodCompletion{Completed: 0, Expected: 1, Period: "month", OnTrack: false, Start: "2024-02-01", End: "2024-02-29"},
+		},
+		{
+			name:      "first Monday of March within the year",
+			pattern:   RepeatPattern{Frequency: "yearly", Month: 3, WeekOfMonth: 1, Weekdays: []int{1}, StartDate: utilities.MustParseCalendarDate("2024-01-01")},
+			completed: []string{"2025-03-03"},
+			asOf:      "2025-06-01",
+			want:      PeriodCompletion{Completed: 1, Expected: 1, Period: "year", OnTrack: true, Start: "2025-01-01", End: "2025-12-31"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := se.EvaluatePeriodCompletion(tt.pattern, nil, tt.completed, tt.asOf, time.Monday)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -76,8 +76,29 @@ func setupBenchmarkEnvironment(b *testing.B) (*managers.PlanningManager, *access
 		b.Fatalf("Failed to create RoutineAccess: %v", err)
 	}
 
+	cycleAccess, err := access.NewCycleAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		b.Fatalf("Failed to create CycleAccess: %v", err)
+	}
+
+	weekAccess, err := access.NewWeekAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		b.Fatalf("Failed to create WeekAccess: %v", err)
+	}
+
+	settingsAccess, err := access.NewSettingsAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		b.Fatalf("Failed to create SettingsAccess: %v", err)
+	}
+
 	uiStateAccess := access.NewUIStateAccess(dataDir)
-	manager, err := managers.NewPlanningManager(themeAccess, taskAccess, calendarAccess, routineAccess, visionAccess, uiStateAccess, repo)
+	manager, err := managers.NewPlanningManager(themeAccess, taskAccess, calendarAccess, routineAccess, cycleAccess, weekAccess, settingsAccess, visionAccess, uiStateAccess, repo, utilities.DefaultClock())
 	if err != nil {
 		repo.Close()
 		os.RemoveAll(tmpDir)
@@ -97,9 +97,30 @@ func setupIntegrationTest(t *testing.T) (*managers.PlanningManager, *access.Task
 		t.Fatalf("Failed to create RoutineAccess: %v", err)
 	}
 
+	cycleAccess, err := access.NewCycleAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		t.Fatalf("Failed to create CycleAccess: %v", err)
+	}
+
+	weekAccess, err := access.NewWeekAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		t.Fatalf("Failed to create WeekAccess: %v", err)
+	}
+
+	settingsAccess, err := access.NewSettingsAccess(dataDir, repo)
+	if err != nil {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+		t.Fatalf("Failed to create SettingsAccess: %v", err)
+	}
+
 	uiStateAccess := access.NewUIStateAccess(dataDir)
 
-	manager, err := managers.NewPlanningManager(themeAccess, taskAccess, calendarAccess, routineAccess, visionAccess, uiStateAccess, repo)
+	manager, err := managers.NewPlanningManager(themeAccess, taskAccess, calendarAccess, routineAccess, cycleAccess, weekAccess, settingsAccess, visionAccess, uiStateAccess, repo, utilities.DefaultClock())
 	if err != nil {
 		repo.Close()
 		os.RemoveAll(tmpDir)
@@ -132,7 +153,7 @@ func intCreateObjective(m *managers.PlanningManager, parentId, title string) (*m
 	return res.Objective, nil
 }
 
-func intCreateKeyResult(m *managers.PlanningManager, parentObjId, description string, startValue, targetValue int) (*managers.KeyResult, error) {
+func intCreateKeyResult(m *managers.PlanningManager, parentObjId, description string, startValue, targetValue float64) (*managers.KeyResult, error) {
 	res, err := m.Establish(managers.EstablishRequest{
 		GoalType:    "key-result",
 		ParentID:    parentObjId,
@@ -550,8 +571,20 @@ func TestIntegration_DataPersistence(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to reopen RoutineAccess: %v", err)
 	}
+	cycleAccess2, err := access.NewCycleAccess(dataDir, repo2)
+	if err != nil {
+		t.Fatalf("Failed to reopen CycleAccess: %v", err)
+	}
+	weekAccess2, err := access.NewWeekAccess(dataDir, repo2)
+	if err != nil {
+		t.Fatalf("Failed to reopen WeekAccess: %v", err)
+	}
+	settingsAccess2, err := access.NewSettingsAccess(dataDir, repo2)
+	if err != nil {
+		t.Fatalf("Failed to reopen SettingsAccess: %v", err)
+	}
 	uiStateAccess2 := access.NewUIStateAccess(dataDir)
-	manager2, err := managers.NewPlanningManager(themeAccess2, taskAccess2, calendarAccess2, routineAccess2, visionAccess2, uiStateAccess2, repo2)
+	manager2, err := managers.NewPlanningManager(themeAccess2, taskAccess2, calendarAccess2, routineAccess2, cycleAccess2, weekAccess2, settingsAccess2, visionAccess2, uiStateAccess2, repo2, utilities.DefaultClock())
 	if err != nil {
 		t.Fatalf("Failed to reopen PlanningManager: %v", err)
 	}
@@ -649,9 +682,12 @@ func TestIntegration_NavigationContextPersistence(t *testing.T) {
 	taskAccess2, _ := access.NewTaskAccess(dataDir2, repo2)
 	calendarAccess2, _ := access.NewCalendarAccess(dataDir2, repo2)
 	routineAccess2, _ := access.NewRoutineAccess(dataDir2, repo2)
+	cycleAccess2, _ := access.NewCycleAccess(dataDir2, repo2)
+	weekAccess2, _ := access.NewWeekAccess(dataDir2, repo2)
+	settingsAccess2, _ := access.NewSettingsAccess(dataDir2, repo2)
 	visionAccess2, _ := access.NewVisionAccess(dataDir2, repo2)
 	uiStateAccess2 := access.NewUIStateAccess(dataDir2)
-	manager2, _ := managers.NewPlanningManager(themeAccess2, taskAccess2, calendarAccess2, routineAccess2, visionAccess2, uiStateAccess2, repo2)
+	manager2, _ := managers.NewPlanningManager(themeAccess2, taskAccess2, calendarAccess2, routineAccess2, cycleAccess2, weekAccess2, settingsAccess2, visionAccess2, uiStateAccess2, repo2, utilities.DefaultClock())
 
 	// Load and verify
 	loadedCtx, err := manager2.LoadNavigationContext()
@@ -720,6 +756,55 @@ func TestIntegration_DeleteTheme(t *testing.T) {
 	t.Logf("Orphaned tasks after theme deletion: %d", len(orphanedTasks))
 }
 
+// TestIntegration_ChangeThemeID verifies that changing a theme abbreviation
+// renames its task files and leaves a clean working tree behind one commit.
+func TestIntegration_ChangeThemeID(t *testing.T) {
+	manager, taskAccess, repo, tmpDir, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	theme, err := intCreateTheme(manager, "Health", "#22c55e")
+	if err != nil {
+		t.Fatalf("Failed to create theme: %v", err)
+	}
+	objective, err := intCreateObjective(manager, theme.ID, "Get fit")
+	if err != nil {
+		t.Fatalf("Failed to create objective: %v", err)
+	}
+	task, err := manager.CreateTask("Morning run", theme.ID, "important-urgent", "", "", "")
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	historyBefore, _ := repo.GetHistory(0)
+
+	result, err := manager.ChangeThemeID(theme.ID, "HB")
+	if err != nil {
+		t.Fatalf("ChangeThemeID failed: %v", err)
+	}
+	if result.IDMapping[objective.ID] != "HB-O1" || result.IDMapping[task.ID] != "HB-T1" {
+		t.Errorf("IDMapping = %v", result.IDMapping)
+	}
+
+	tasks, _ := taskAccess.GetTasksByTheme("HB")
+	if len(tasks) != 1 || tasks[0].ID != "HB-T1" {
+		t.Errorf("Expected task HB-T1, got %+v", tasks)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "data", "tasks", "todo", task.ID+".json")); !os.IsNotExist(err) {
+		t.Error("Old task file should be gone")
+	}
+
+	historyAfter, _ := repo.GetHistory(0)
+	if len(historyAfter) != len(historyBefore)+1 {
+		t.Errorf("Expected exactly one commit, got %d", len(historyAfter)-len(historyBefore))
+	}
+	status, err := repo.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(status.ModifiedFiles)+len(status.StagedFiles)+len(status.UntrackedFiles) != 0 {
+		t.Errorf("Expected a clean working tree, got %+v", status)
+	}
+}
+
 // =============================================================================
 // Additional Integration Tests
 // =============================================================================
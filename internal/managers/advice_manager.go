@@ -18,13 +18,13 @@ type IAdviceManager interface {
 }
 
 // AdviceManager orchestrates the AI advisor flow by coordinating ChatEngine,
-// ModelAccess, ThemeAccess, RoutineAccess, and UIStateAccess.
+// ModelAccess, ThemeAccess, RoutineAccess, and SettingsAccess.
 type AdviceManager struct {
 	themeAccess     access.IThemeAccess
 	routineAccess   access.IRoutineAccess
 	chatEngine      chat_engine.IChatEngine
 	modelAccess     access.IModelAccess
-	uiStateAccess   access.IUIStateAccess
+	settingsAccess  access.ISettingsAccess
 	planningManager *PlanningManager
 }
 
@@ -35,7 +35,7 @@ func NewAdviceManager(
 	routineAccess access.IRoutineAccess,
 	chatEngine chat_engine.IChatEngine,
 	modelAccess access.IModelAccess,
-	uiStateAccess access.IUIStateAccess,
+	settingsAccess access.ISettingsAccess,
 	planningManager *PlanningManager,
 ) (*AdviceManager, error) {
 	if themeAccess == nil {
@@ -50,8 +50,8 @@ func NewAdviceManager(
 	if modelAccess == nil {
 		return nil, fmt.Errorf("modelAccess cannot be nil")
 	}
-	if uiStateAccess == nil {
-		return nil, fmt.Errorf("uiStateAccess cannot be nil")
+	if settingsAccess == nil {
+		return nil, fmt.Errorf("settingsAccess cannot be nil")
 	}
 	if planningManager == nil {
 		return nil, fmt.Errorf("planningManager cannot be nil")
@@ -62,7 +62,7 @@ func NewAdviceManager(
 		routineAccess:   routineAccess,
 		chatEngine:      chatEngine,
 		modelAccess:     modelAccess,
-		uiStateAccess:   uiStateAccess,
+		settingsAccess:  settingsAccess,
 		planningManager: planningManager,
 	}, nil
 }
@@ -86,8 +86,9 @@ func (am *AdviceManager) RequestAdvice(message string, history []chat_engine.Cha
 		return nil, fmt.Errorf("Unable to load your routines. Please try again.")
 	}
 
-	// 2. Convert themes to OKR context, filtering by selectedOKRIds
-	okrContexts := convertThemesToOKRContext(themes, routines, selectedOKRIds)
+	// 2. Convert themes to OKR context, filtering by selectedOKRIds and
+	// leaving out archived themes
+	okrContexts := convertThemesToOKRContext(visibleThemes(themes), routines, selectedOKRIds)
 
 	// 3. Assemble conversation
 	conversationMessages := am.chatEngine.AssembleConversation(okrContexts, history, message)
@@ -119,12 +120,24 @@ func (am *AdviceManager) GetAvailableModels() []access.ModelInfo {
 
 // GetEnabled returns whether the advisor feature is enabled.
 func (am *AdviceManager) GetEnabled() (bool, error) {
-	return am.uiStateAccess.LoadAdvisorEnabled()
+	settings, err := am.settingsAccess.LoadSettings()
+	if err != nil {
+		return false, fmt.Errorf("%w", err)
+	}
+	return settings.Advisor.Enabled, nil
 }
 
 // SetEnabled enables or disables the advisor feature.
 func (am *AdviceManager) SetEnabled(enabled bool) error {
-	return am.uiStateAccess.SaveAdvisorEnabled(enabled)
+	settings, err := am.settingsAccess.LoadSettings()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	if settings.Advisor.Enabled == enabled {
+		return nil
+	}
+	settings.Advisor.Enabled = enabled
+	return am.settingsAccess.SaveSettings(*settings)
 }
 
 // AcceptSuggestion applies a suggestion from the advisor to the OKR hierarchy.
@@ -202,6 +215,8 @@ func (am *AdviceManager) acceptCreate(suggestion chat_engine.Suggestion, parentC
 			Description: suggestion.KeyResultData.Description,
 			StartValue:  &startVal,
 			TargetValue: &targetVal,
+			Direction:   suggestion.KeyResultData.Direction,
+			Unit:        suggestion.KeyResultData.Unit,
 		})
 		if err != nil {
 			slog.Error("AcceptSuggestion: failed to create key result",
@@ -282,6 +297,14 @@ func (am *AdviceManager) acceptEdit(suggestion chat_engine.Suggestion) error {
 		req.StartValue = &startVal
 		targetVal := suggestion.KeyResultData.TargetValue
 		req.TargetValue = &targetVal
+		if suggestion.KeyResultData.Direction != "" {
+			direction := suggestion.KeyResultData.Direction
+			req.Direction = &direction
+		}
+		if suggestion.KeyResultData.Unit != "" {
+			unit := suggestion.KeyResultData.Unit
+			req.Unit = &unit
+		}
 		if err := am.planningManager.Revise(req); err != nil {
 			slog.Error("AcceptSuggestion: failed to edit key result",
 				"id", suggestion.KeyResultData.ID, "error", err)
@@ -311,11 +334,23 @@ func (am *AdviceManager) acceptEdit(suggestion chat_engine.Suggestion) error {
 }
 
 // convertThemesToOKRContext converts access layer themes and routines to engine
-// layer OKR contexts. Routines are now independent of themes and are included as
-// a separate context entry. If selectedOKRIds is non-empty, only selected items
-// are included.
+// layer OKR contexts. A routine linked to one of the given themes is listed
+// under that theme; unlinked routines, and routines whose theme is not in the
+// list, go into a separate "Routines" entry. If selectedOKRIds is non-empty,
+// only selected items are included.
 func convertThemesToOKRContext(themes []access.LifeTheme, routines []access.Routine, selectedOKRIds []string) []chat_engine.OKRContext {
 	filter := buildIDSet(selectedOKRIds)
+
+	byTheme := make(map[string][]access.Routine)
+	var unthemed []access.Routine
+	for _, r := range routines {
+		if r.ThemeID != "" && themeExists(themes, r.ThemeID) {
+			byTheme[r.ThemeID] = append(byTheme[r.ThemeID], r)
+			continue
+		}
+		unthemed = append(unthemed, r)
+	}
+
 	if len(filter) == 0 {
 		// No filter — include everything.
 		contexts := make([]chat_engine.OKRContext, 0, len(themes)+1)
@@ -324,13 +359,14 @@ func convertThemesToOKRContext(themes []access.LifeTheme, routines []access.Rout
 				ThemeID:    theme.ID,
 				ThemeName:  theme.Name,
 				Objectives: convertObjectivesToOKR(theme.Objectives),
+				Routines:   convertRoutinesToOKR(byTheme[theme.ID]),
 			})
 		}
-		// Add routines as a separate context entry
-		if len(routines) > 0 {
+		// Add unlinked routines as a separate context entry
+		if len(unthemed) > 0 {
 			contexts = append(contexts, chat_engine.OKRContext{
 				ThemeName: "Routines",
-				Routines:  convertRoutinesToOKR(routines),
+				Routines:  convertRoutinesToOKR(unthemed),
 			})
 		}
 		return contexts
@@ -342,25 +378,28 @@ func convertThemesToOKRContext(themes []access.LifeTheme, routines []access.Rout
 		_, themeSelected := filter[theme.ID]
 
 		objs := filterObjectivesToOKR(theme.Objectives, filter)
-
-		if !themeSelected && len(objs) == 0 {
-			continue
-		}
+		themeRoutines := filterRoutinesToOKR(byTheme[theme.ID], filter)
 
 		// If the theme itself was selected, include all its descendants.
 		if themeSelected {
 			objs = convertObjectivesToOKR(theme.Objectives)
+			themeRoutines = convertRoutinesToOKR(byTheme[theme.ID])
+		}
+
+		if !themeSelected && len(objs) == 0 && len(themeRoutines) == 0 {
+			continue
 		}
 
 		contexts = append(contexts, chat_engine.OKRContext{
 			ThemeID:    theme.ID,
 			ThemeName:  theme.Name,
 			Objectives: objs,
+			Routines:   themeRoutines,
 		})
 	}
 
-	// Add filtered routines as a separate context entry
-	filteredRoutines := filterRoutinesToOKR(routines, filter)
+	// Add filtered unlinked routines as a separate context entry
+	filteredRoutines := filterRoutinesToOKR(unthemed, filter)
 	if len(filteredRoutines) > 0 {
 		contexts = append(contexts, chat_engine.OKRContext{
 			ThemeName: "Routines",
@@ -396,6 +435,7 @@ func filterObjectivesToOKR(objectives []access.Objective, filter map[string]stru
 			ID:         obj.ID,
 			Title:      obj.Title,
 			Status:     obj.Status,
+			Weight:     obj.Weight,
 			KeyResults: krs,
 			Children:   children,
 		})
@@ -411,6 +451,10 @@ func filterKeyResultsToOKR(keyResults []access.KeyResult, filter map[string]stru
 			result = append(result, chat_engine.OKRKeyResult{
 				ID:           kr.ID,
 				Description:  kr.Description,
+				Type:         kr.Type,
+				Direction:    kr.Direction,
+				Unit:         kr.Unit,
+				Weight:       kr.Weight,
 				StartValue:   kr.StartValue,
 				CurrentValue: kr.CurrentValue,
 				TargetValue:  kr.TargetValue,
@@ -420,14 +464,18 @@ func filterKeyResultsToOKR(keyResults []access.KeyResult, filter map[string]stru
 	return result
 }
 
-// filterRoutinesToOKR keeps only routines whose ID is in the filter set.
+// filterRoutinesToOKR keeps only routines whose ID, or linked key result ID,
+// is in the filter set.
 func filterRoutinesToOKR(routines []access.Routine, filter map[string]struct{}) []chat_engine.OKRRoutine {
 	var result []chat_engine.OKRRoutine
 	for _, r := range routines {
-		if _, ok := filter[r.ID]; ok {
+		_, idSelected := filter[r.ID]
+		_, krSelected := filter[r.KeyResultID]
+		if idSelected || (r.KeyResultID != "" && krSelected) {
 			result = append(result, chat_engine.OKRRoutine{
 				ID:          r.ID,
 				Description: r.Description,
+				KeyResultID: r.KeyResultID,
 			})
 		}
 	}
@@ -447,6 +495,7 @@ func convertObjectivesToOKR(objectives []access.Objective) []chat_engine.OKRObje
 			ID:         obj.ID,
 			Title:      obj.Title,
 			Status:     obj.Status,
+			Weight:     obj.Weight,
 			KeyResults: convertKeyResultsToOKR(obj.KeyResults),
 			Children:   convertObjectivesToOKR(obj.Objectives),
 		}
@@ -465,6 +514,10 @@ func convertKeyResultsToOKR(keyResults []access.KeyResult) []chat_engine.OKRKeyR
 		result[i] = chat_engine.OKRKeyResult{
 			ID:           kr.ID,
 			Description:  kr.Description,
+			Type:         kr.Type,
+			Direction:    kr.Direction,
+			Unit:         kr.Unit,
+			Weight:       kr.Weight,
 			StartValue:   kr.StartValue,
 			CurrentValue: kr.CurrentValue,
 			TargetValue:  kr.TargetValue,
@@ -484,6 +537,7 @@ func convertRoutinesToOKR(routines []access.Routine) []chat_engine.OKRRoutine {
 		result[i] = chat_engine.OKRRoutine{
 			ID:          r.ID,
 			Description: r.Description,
+			KeyResultID: r.KeyResultID,
 		}
 	}
 	return result
@@ -3,11 +3,13 @@ package managers
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/rkn/bearing/internal/access"
 	"github.com/rkn/bearing/internal/engines/chat_engine"
+	"github.com/rkn/bearing/internal/utilities"
 )
 
 // --- Mock implementations for AdviceManager tests ---
@@ -38,6 +40,10 @@ func (m *mockAdviceThemeAccess) WriteDeleteTheme(_ string) error {
 	return nil
 }
 
+func (m *mockAdviceThemeAccess) WriteRenameTheme(_ string, _ access.LifeTheme) error {
+	return nil
+}
+
 // mockAdviceChatEngine implements chat_engine.IChatEngine for AdviceManager tests.
 type mockAdviceChatEngine struct {
 	assembledMessages []chat_engine.ChatMessage
@@ -82,47 +88,57 @@ func (m *mockAdviceModelAccess) GetAvailableModels() []access.ModelInfo {
 	return []access.ModelInfo{}
 }
 
-// mockAdviceUIStateAccess implements access.IUIStateAccess for AdviceManager tests.
-type mockAdviceUIStateAccess struct {
+// mockAdviceStateAccess implements access.IUIStateAccess and
+// access.ISettingsAccess for AdviceManager tests.
+type mockAdviceStateAccess struct {
 	advisorEnabled bool
 	advisorErr     error
 }
 
-func (m *mockAdviceUIStateAccess) LoadNavigationContext() (*access.NavigationContext, error) {
+func (m *mockAdviceStateAccess) LoadNavigationContext() (*access.NavigationContext, error) {
 	return nil, nil
 }
 
-func (m *mockAdviceUIStateAccess) SaveNavigationContext(_ access.NavigationContext) error {
+func (m *mockAdviceStateAccess) SaveNavigationContext(_ access.NavigationContext) error {
 	return nil
 }
 
-func (m *mockAdviceUIStateAccess) LoadTaskDrafts() (json.RawMessage, error) {
+func (m *mockAdviceStateAccess) LoadTaskDrafts() (json.RawMessage, error) {
 	return nil, nil
 }
 
-func (m *mockAdviceUIStateAccess) SaveTaskDrafts(_ json.RawMessage) error {
+func (m *mockAdviceStateAccess) SaveTaskDrafts(_ json.RawMessage) error {
 	return nil
 }
 
-func (m *mockAdviceUIStateAccess) LoadAdvisorEnabled() (bool, error) {
-	return m.advisorEnabled, m.advisorErr
+func (m *mockAdviceStateAccess) LoadSettings() (*access.Settings, error) {
+	if m.advisorErr != nil {
+		return nil, m.advisorErr
+	}
+	settings := access.DefaultSettings()
+	settings.Advisor.Enabled = m.advisorEnabled
+	return &settings, nil
 }
 
-func (m *mockAdviceUIStateAccess) SaveAdvisorEnabled(enabled bool) error {
+func (m *mockAdviceStateAccess) SaveSettings(settings access.Settings) error {
 	if m.advisorErr != nil {
 		return m.advisorErr
 	}
-	m.advisorEnabled = enabled
+	m.advisorEnabled = settings.Advisor.Enabled
 	return nil
 }
 
+func (m *mockAdviceStateAccess) WriteSettings(settings access.Settings) error {
+	return m.SaveSettings(settings)
+}
+
 // newTestAdviceManager creates an AdviceManager with the given mocks for
 // testing. It also creates a minimal PlanningManager using stub dependencies.
 func newTestAdviceManager(
 	themeAccess access.IThemeAccess,
 	chatEngine chat_engine.IChatEngine,
 	modelAccess access.IModelAccess,
-	uiStateAccess access.IUIStateAccess,
+	stateAccess *mockAdviceStateAccess,
 ) (*AdviceManager, error) {
 	ra := newMockRoutineAccess()
 	// Create a minimal PlanningManager for the dependency
@@ -131,15 +147,19 @@ func newTestAdviceManager(
 		newMockTaskAccess(),
 		&mockCalendarAccess{},
 		ra,
+		newMockCycleAccess(),
+		newMockWeekAccess(),
+		newMockSettingsAccess(),
 		&mockVisionAccess{},
-		uiStateAccess,
+		stateAccess,
 		newStubRepo(),
+		utilities.DefaultClock(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create PlanningManager for test: %w", err)
 	}
 
-	return NewAdviceManager(themeAccess, ra, chatEngine, modelAccess, uiStateAccess, pm)
+	return NewAdviceManager(themeAccess, ra, chatEngine, modelAccess, stateAccess, pm)
 }
 
 // sampleThemes returns test themes with objectives, key results, and routines.
@@ -197,7 +217,7 @@ func TestUnit_AdviceManager_RequestAdvice_Success(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{cleanText: "Here is my advice."}
 	ma := &mockAdviceModelAccess{response: "Here is my advice."}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -228,10 +248,10 @@ func TestUnit_AdviceManager_RequestAdvice_WithSelectedOKRIds(t *testing.T) {
 		captured: &capturedContexts,
 	}
 	ma := &mockAdviceModelAccess{response: "Advice for health."}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	ra := newMockRoutineAccess()
-	pm, _ := NewPlanningManager(ta, newMockTaskAccess(), &mockCalendarAccess{}, ra, &mockVisionAccess{}, ua, newStubRepo())
+	pm, _ := NewPlanningManager(ta, newMockTaskAccess(), &mockCalendarAccess{}, ra, newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, ua, newStubRepo(), utilities.DefaultClock())
 	am, err := NewAdviceManager(ta, ra, capturingEngine, ma, ua, pm)
 	if err != nil {
 		t.Fatalf("failed to create AdviceManager: %v", err)
@@ -255,12 +275,37 @@ func TestUnit_AdviceManager_RequestAdvice_WithSelectedOKRIds(t *testing.T) {
 	}
 }
 
+func TestUnit_AdviceManager_RequestAdvice_SkipsArchivedThemes(t *testing.T) {
+	themes := sampleThemes()
+	themes[1].Status = string(access.ThemeStatusArchived)
+
+	var capturedContexts []chat_engine.OKRContext
+	ta := &mockAdviceThemeAccess{themes: themes}
+	capturingEngine := &capturingChatEngine{inner: &mockAdviceChatEngine{}, captured: &capturedContexts}
+	ua := &mockAdviceStateAccess{}
+	ra := newMockRoutineAccess()
+	pm, _ := NewPlanningManager(ta, newMockTaskAccess(), &mockCalendarAccess{}, ra, newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, ua, newStubRepo(), utilities.DefaultClock())
+	am, err := NewAdviceManager(ta, ra, capturingEngine, &mockAdviceModelAccess{response: "ok"}, ua, pm)
+	if err != nil {
+		t.Fatalf("failed to create AdviceManager: %v", err)
+	}
+
+	if _, err := am.RequestAdvice("How am I doing?", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, c := range capturedContexts {
+		if c.ThemeID == "CF" {
+			t.Errorf("archived theme CF was passed to the advisor: %+v", c)
+		}
+	}
+}
+
 func TestUnit_AdviceManager_RequestAdvice_ModelError(t *testing.T) {
 	themes := sampleThemes()
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{err: fmt.Errorf("The advisor is currently unavailable. Please try again later.")}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -283,7 +328,7 @@ func TestUnit_AdviceManager_RequestAdvice_ThemeAccessError(t *testing.T) {
 	ta := &mockAdviceThemeAccess{err: fmt.Errorf("disk read error")}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{response: "unused"}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -309,7 +354,7 @@ func TestUnit_AdviceManager_GetAvailableModels(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: []access.LifeTheme{}}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{models: models}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -329,7 +374,7 @@ func TestUnit_AdviceManager_GetEnabled_Default(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: []access.LifeTheme{}}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{advisorEnabled: false}
+	ua := &mockAdviceStateAccess{advisorEnabled: false}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -349,7 +394,7 @@ func TestUnit_AdviceManager_SetEnabled(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: []access.LifeTheme{}}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -374,7 +419,7 @@ func TestUnit_AdviceManager_MultiTurn(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{response: "First response."}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -412,7 +457,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_CreateObjective(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -452,7 +497,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_CreateRoutine(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -490,7 +535,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_EditObjective(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -526,7 +571,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_UnknownType(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -551,7 +596,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_ParentContextFallback(t *testing.T)
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -591,7 +636,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_CreateKeyResult(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -621,10 +666,10 @@ func TestUnit_AdviceManager_AcceptSuggestion_CreateKeyResult(t *testing.T) {
 		if kr.Description == "Run 50km per week" {
 			found = true
 			if kr.StartValue != 0 {
-				t.Errorf("expected start value 0, got %d", kr.StartValue)
+				t.Errorf("expected start value 0, got %g", kr.StartValue)
 			}
 			if kr.TargetValue != 50 {
-				t.Errorf("expected target value 50, got %d", kr.TargetValue)
+				t.Errorf("expected target value 50, got %g", kr.TargetValue)
 			}
 			break
 		}
@@ -639,7 +684,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_UnknownAction(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -664,7 +709,7 @@ func TestUnit_AdviceManager_AcceptSuggestion_MissingParentErrors(t *testing.T) {
 	ta := &mockAdviceThemeAccess{themes: themes}
 	ce := &mockAdviceChatEngine{}
 	ma := &mockAdviceModelAccess{}
-	ua := &mockAdviceUIStateAccess{}
+	ua := &mockAdviceStateAccess{}
 
 	am, err := newTestAdviceManager(ta, ce, ma, ua)
 	if err != nil {
@@ -749,7 +794,7 @@ func TestUnit_ConvertThemesToOKRContext(t *testing.T) {
 		t.Errorf("expected KR ID 'H-KR1', got %q", kr.ID)
 	}
 	if kr.StartValue != 0 || kr.CurrentValue != 25 || kr.TargetValue != 40 {
-		t.Errorf("unexpected KR values: start=%d current=%d target=%d", kr.StartValue, kr.CurrentValue, kr.TargetValue)
+		t.Errorf("unexpected KR values: start=%g current=%g target=%g", kr.StartValue, kr.CurrentValue, kr.TargetValue)
 	}
 
 	// Verify nested objectives
@@ -850,6 +895,60 @@ func TestUnit_ConvertThemesToOKRContext(t *testing.T) {
 	}
 }
 
+func TestUnit_ConvertThemesToOKRContext_LinkedRoutines(t *testing.T) {
+	themes := sampleThemes()
+	routines := []access.Routine{
+		{ID: "R1", Description: "Sleep 8 hours"},
+		{ID: "R2", Description: "Run twice a week", ThemeID: "H", KeyResultID: "H-KR1"},
+		{ID: "R3", Description: "Stretch", ThemeID: "H"},
+		{ID: "R4", Description: "Old habit", ThemeID: "GONE"},
+	}
+
+	contexts := convertThemesToOKRContext(themes, routines, nil)
+	if len(contexts) != 3 {
+		t.Fatalf("expected 3 contexts, got %d", len(contexts))
+	}
+	if got := okrRoutineIDs(contexts[0].Routines); !slices.Equal(got, []string{"R2", "R3"}) {
+		t.Errorf("Health routines = %v, want [R2 R3]", got)
+	}
+	if contexts[0].Routines[0].KeyResultID != "H-KR1" {
+		t.Errorf("R2 key result = %q, want H-KR1", contexts[0].Routines[0].KeyResultID)
+	}
+	if got := okrRoutineIDs(contexts[2].Routines); !slices.Equal(got, []string{"R1", "R4"}) {
+		t.Errorf("unlinked routines = %v, want [R1 R4]", got)
+	}
+
+	// Selecting a theme brings its routines along.
+	themeContexts := convertThemesToOKRContext(themes, routines, []string{"H"})
+	if len(themeContexts) != 1 || !slices.Equal(okrRoutineIDs(themeContexts[0].Routines), []string{"R2", "R3"}) {
+		t.Errorf("theme filter = %+v, want Health with R2 and R3", themeContexts)
+	}
+
+	// Selecting a key result brings the routines serving it along.
+	krContexts := convertThemesToOKRContext(themes, routines, []string{"H-KR1"})
+	if len(krContexts) != 1 || !slices.Equal(okrRoutineIDs(krContexts[0].Routines), []string{"R2"}) {
+		t.Errorf("KR filter = %+v, want Health with R2", krContexts)
+	}
+
+	// A selected linked routine alone still shows under its theme.
+	routineContexts := convertThemesToOKRContext(themes, routines, []string{"R3"})
+	if len(routineContexts) != 1 || routineContexts[0].ThemeID != "H" || len(routineContexts[0].Objectives) != 0 {
+		t.Fatalf("routine filter = %+v, want Health with no objectives", routineContexts)
+	}
+	if got := okrRoutineIDs(routineContexts[0].Routines); !slices.Equal(got, []string{"R3"}) {
+		t.Errorf("routine filter routines = %v, want [R3]", got)
+	}
+}
+
+// okrRoutineIDs returns the IDs of the given OKR routines in order.
+func okrRoutineIDs(routines []chat_engine.OKRRoutine) []string {
+	ids := make([]string, len(routines))
+	for i, r := range routines {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
 // capturingChatEngine wraps a chat engine and captures the OKR contexts
 // passed to AssembleConversation.
 type capturingChatEngine struct {
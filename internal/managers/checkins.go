@@ -0,0 +1,203 @@
+package managers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// maxCheckInConfidence is the top of the optional 1-10 confidence scale.
+// Zero means the confidence was not recorded.
+const maxCheckInConfidence = 10
+
+// CheckIn is a dated progress update on a key result in the Manager layer's
+// public interface.
+type CheckIn struct {
+	ID         string              `json:"id"`
+	Value      float64             `json:"value"`
+	Timestamp  utilities.Timestamp `json:"timestamp"`
+	Note       string              `json:"note,omitempty"`
+	Confidence int                 `json:"confidence,omitempty"`
+}
+
+// ListCheckIns returns the check-ins of a key result, oldest first.
+func (m *PlanningManager) ListCheckIns(keyResultId string) ([]CheckIn, error) {
+	if keyResultId == "" {
+		return nil, fmt.Errorf("keyResultId cannot be empty")
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	for i := range themes {
+		if obj, krIdx := findKeyResultParent(themes[i].Objectives, keyResultId); obj != nil {
+			checkIns := toManagerCheckIns(obj.KeyResults[krIdx].CheckIns)
+			if checkIns == nil {
+				checkIns = []CheckIn{}
+			}
+			return checkIns, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key result with ID %s not found", keyResultId)
+}
+
+// RecordCheckIn appends a check-in stamped with the current time and makes
+// its value the key result's current value.
+func (m *PlanningManager) RecordCheckIn(keyResultId string, value float64, note string, confidence int) (*CheckIn, error) {
+	if err := validateCheckInConfidence(confidence); err != nil {
+		return nil, err
+	}
+
+	var recorded access.CheckIn
+	err := m.updateKeyResult(keyResultId, func(kr *access.KeyResult) error {
+		if err := validateKeyResultValue(*kr, value); err != nil {
+			return err
+		}
+		recorded = access.CheckIn{
+			ID:         nextCheckInID(kr.CheckIns),
+			Value:      value,
+			Timestamp:  m.clock.Now(),
+			Note:       strings.TrimSpace(note),
+			Confidence: confidence,
+		}
+		kr.CheckIns = append(kr.CheckIns, recorded)
+		deriveCurrentValue(kr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toManagerCheckIns([]access.CheckIn{recorded})[0]
+	return &result, nil
+}
+
+// UpdateCheckIn replaces the value, timestamp, note and confidence of an
+// existing check-in. The timestamp cannot lie in the future, as a recorded
+// one never does. Moving a check-in in time may change which one is the
+// latest, so the current value is re-derived.
+func (m *PlanningManager) UpdateCheckIn(keyResultId string, checkIn CheckIn) error {
+	if checkIn.ID == "" {
+		return fmt.Errorf("check-in ID cannot be empty")
+	}
+	if checkIn.Timestamp.IsZero() {
+		return fmt.Errorf("check-in timestamp cannot be empty")
+	}
+	if _, err := utilities.ParseTimestamp(checkIn.Timestamp.String()); err != nil {
+		return fmt.Errorf("check-in timestamp: %w", err)
+	}
+	if now := m.clock.Now(); checkIn.Timestamp.Time().After(now.Time()) {
+		return fmt.Errorf("check-in timestamp %s is later than now (%s)", checkIn.Timestamp, now)
+	}
+	if err := validateCheckInConfidence(checkIn.Confidence); err != nil {
+		return err
+	}
+
+	return m.updateKeyResult(keyResultId, func(kr *access.KeyResult) error {
+		for i := range kr.CheckIns {
+			if kr.CheckIns[i].ID == checkIn.ID {
+				if err := validateKeyResultValue(*kr, checkIn.Value); err != nil {
+					return err
+				}
+				kr.CheckIns[i].Value = checkIn.Value
+				kr.CheckIns[i].Timestamp = checkIn.Timestamp
+				kr.CheckIns[i].Note = strings.TrimSpace(checkIn.Note)
+				kr.CheckIns[i].Confidence = checkIn.Confidence
+				deriveCurrentValue(kr)
+				return nil
+			}
+		}
+		return fmt.Errorf("check-in %s not found on key result %s", checkIn.ID, keyResultId)
+	})
+}
+
+// DeleteCheckIn removes a check-in. Deleting the last remaining check-in
+// resets the current value to the start value.
+func (m *PlanningManager) DeleteCheckIn(keyResultId, checkInId string) error {
+	if checkInId == "" {
+		return fmt.Errorf("check-in ID cannot be empty")
+	}
+
+	return m.updateKeyResult(keyResultId, func(kr *access.KeyResult) error {
+		for i := range kr.CheckIns {
+			if kr.CheckIns[i].ID == checkInId {
+				kr.CheckIns = append(kr.CheckIns[:i], kr.CheckIns[i+1:]...)
+				deriveCurrentValue(kr)
+				return nil
+			}
+		}
+		return fmt.Errorf("check-in %s not found on key result %s", checkInId, keyResultId)
+	})
+}
+
+// updateKeyResult finds a key result anywhere in the tree, applies mutate to
+// it and saves the owning theme. Nothing is saved when mutate fails.
+func (m *PlanningManager) updateKeyResult(keyResultId string, mutate func(kr *access.KeyResult) error) error {
+	if keyResultId == "" {
+		return fmt.Errorf("keyResultId cannot be empty")
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	for i := range themes {
+		if obj, krIdx := findKeyResultParent(themes[i].Objectives, keyResultId); obj != nil {
+			if err := mutate(&obj.KeyResults[krIdx]); err != nil {
+				return err
+			}
+			if err := m.themeAccess.SaveTheme(themes[i]); err != nil {
+				return fmt.Errorf("%w", err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("key result with ID %s not found", keyResultId)
+}
+
+// deriveCurrentValue orders the check-ins oldest first and sets CurrentValue
+// to the latest one, or to StartValue when there are none. Check-ins sharing
+// a timestamp keep their recorded order. Milestone key results take the
+// share of milestones completed instead.
+func deriveCurrentValue(kr *access.KeyResult) {
+	sort.SliceStable(kr.CheckIns, func(i, j int) bool {
+		return kr.CheckIns[i].Timestamp.Time().Before(kr.CheckIns[j].Timestamp.Time())
+	})
+	if kr.Type == access.KRTypeMilestone {
+		kr.CurrentValue = milestoneCompletion(kr.Milestones)
+		return
+	}
+	if len(kr.CheckIns) == 0 {
+		kr.CurrentValue = kr.StartValue
+		return
+	}
+	kr.CurrentValue = kr.CheckIns[len(kr.CheckIns)-1].Value
+}
+
+// nextCheckInID returns the next unused "C<n>" ID within a key result.
+func nextCheckInID(checkIns []access.CheckIn) string {
+	maxNum := 0
+	for _, c := range checkIns {
+		if n, err := strconv.Atoi(strings.TrimPrefix(c.ID, "C")); err == nil && n > maxNum {
+			maxNum = n
+		}
+	}
+	return fmt.Sprintf("C%d", maxNum+1)
+}
+
+// validateCheckInConfidence accepts 0 (unset) or a score on the 1-10 scale.
+func validateCheckInConfidence(confidence int) error {
+	if confidence < 0 || confidence > maxCheckInConfidence {
+		return fmt.Errorf("confidence must be between 1 and %d, or 0 for none", maxCheckInConfidence)
+	}
+	return nil
+}
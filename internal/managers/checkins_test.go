@@ -0,0 +1,180 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// newCheckInTestManager returns a manager on a frozen clock with one key
+// result (start 10, target 20) under objective T-O1.
+func newCheckInTestManager(t *testing.T) (*PlanningManager, *utilities.FrozenClock, string) {
+	t.Helper()
+	clock := utilities.NewFrozenClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	pm := newTestManager(t, testManagerDeps{clock: clock})
+	obj, err := testCreateObjective(pm, "T", "Objective")
+	if err != nil {
+		t.Fatalf("create objective: %v", err)
+	}
+	kr, err := testCreateKeyResult(pm, obj.ID, "Read books", 10, 20)
+	if err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	return pm, clock, kr.ID
+}
+
+// currentValueOf returns the CurrentValue of the key result as seen through GetHierarchy.
+func currentValueOf(t *testing.T, pm *PlanningManager, krID string) float64 {
+	t.Helper()
+	themes, err := pm.GetHierarchy()
+	if err != nil {
+		t.Fatalf("GetHierarchy: %v", err)
+	}
+	for _, obj := range themes[0].Objectives {
+		for _, kr := range obj.KeyResults {
+			if kr.ID == krID {
+				return kr.CurrentValue
+			}
+		}
+	}
+	t.Fatalf("key result %s not found", krID)
+	return 0
+}
+
+func TestUnit_RecordCheckIn_AppendsDatedHistory(t *testing.T) {
+	pm, clock, krID := newCheckInTestManager(t)
+
+	first, err := pm.RecordCheckIn(krID, 12, "  slow start ", 6)
+	if err != nil {
+		t.Fatalf("RecordCheckIn: %v", err)
+	}
+	clock.Advance(24 * time.Hour)
+	if err := pm.RecordProgress(krID, 15); err != nil {
+		t.Fatalf("RecordProgress: %v", err)
+	}
+
+	if first.ID != "C1" || first.Note != "slow start" || first.Confidence != 6 {
+		t.Errorf("first check-in = %+v", first)
+	}
+	checkIns, err := pm.ListCheckIns(krID)
+	if err != nil {
+		t.Fatalf("ListCheckIns: %v", err)
+	}
+	if len(checkIns) != 2 {
+		t.Fatalf("got %d check-ins, want 2", len(checkIns))
+	}
+	if checkIns[1].ID != "C2" || checkIns[1].Value != 15 || checkIns[1].Timestamp != "2026-03-02T09:00:00Z" {
+		t.Errorf("second check-in = %+v", checkIns[1])
+	}
+	if got := currentValueOf(t, pm, krID); got != 15 {
+		t.Errorf("CurrentValue = %g, want 15", got)
+	}
+}
+
+func TestUnit_UpdateCheckIn_RederivesCurrentValue(t *testing.T) {
+	pm, clock, krID := newCheckInTestManager(t)
+	_, _ = pm.RecordCheckIn(krID, 12, "", 0)
+	clock.Advance(time.Hour)
+	latest, _ := pm.RecordCheckIn(krID, 14, "", 0)
+
+	// Backdating the latest check-in makes C1 the most recent one.
+	latest.Timestamp = "2026-02-01T00:00:00Z"
+	latest.Note = "entered late"
+	if err := pm.UpdateCheckIn(krID, *latest); err != nil {
+		t.Fatalf("UpdateCheckIn: %v", err)
+	}
+
+	checkIns, _ := pm.ListCheckIns(krID)
+	if checkIns[0].ID != "C2" || checkIns[1].ID != "C1" {
+		t.Errorf("check-ins not reordered by timestamp: %+v", checkIns)
+	}
+	if got := currentValueOf(t, pm, krID); got != 12 {
+		t.Errorf("CurrentValue = %g, want 12", got)
+	}
+}
+
+func TestUnit_DeleteCheckIn_FallsBackToStartValue(t *testing.T) {
+	pm, _, krID := newCheckInTestManager(t)
+	c1, _ := pm.RecordCheckIn(krID, 12, "", 0)
+	c2, _ := pm.RecordCheckIn(krID, 16, "", 0)
+
+	if err := pm.DeleteCheckIn(krID, c2.ID); err != nil {
+		t.Fatalf("DeleteCheckIn: %v", err)
+	}
+	if got := currentValueOf(t, pm, krID); got != 12 {
+		t.Errorf("CurrentValue = %g, want 12", got)
+	}
+	if err := pm.DeleteCheckIn(krID, c1.ID); err != nil {
+		t.Fatalf("DeleteCheckIn: %v", err)
+	}
+	if got := currentValueOf(t, pm, krID); got != 10 {
+		t.Errorf("CurrentValue = %g, want start value 10", got)
+	}
+
+	// Numbering continues from the highest remaining check-in ID.
+	c3, _ := pm.RecordCheckIn(krID, 11, "", 0)
+	if c3.ID != "C1" {
+		t.Errorf("ID after deleting all = %s, want C1", c3.ID)
+	}
+	c4, _ := pm.RecordCheckIn(krID, 11, "", 0)
+	if c4.ID != "C2" {
+		t.Errorf("next ID = %s, want C2", c4.ID)
+	}
+}
+
+func TestUnit_Revise_StartValueRederivesWithoutCheckIns(t *testing.T) {
+	pm, _, krID := newCheckInTestManager(t)
+
+	start := 5.0
+	if err := pm.Revise(ReviseRequest{GoalID: krID, StartValue: &start}); err != nil {
+		t.Fatalf("Revise: %v", err)
+	}
+	if got := currentValueOf(t, pm, krID); got != 5 {
+		t.Errorf("CurrentValue = %g, want 5", got)
+	}
+}
+
+func TestUnit_CheckIns_RejectInvalidInput(t *testing.T) {
+	pm, _, krID := newCheckInTestManager(t)
+	recorded, _ := pm.RecordCheckIn(krID, 12, "", 0)
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{"confidence too high", func() error { _, err := pm.RecordCheckIn(krID, 1, "", 11); return err }, "confidence"},
+		{"negative confidence", func() error { _, err := pm.RecordCheckIn(krID, 1, "", -1); return err }, "confidence"},
+		{"unknown key result", func() error { _, err := pm.RecordCheckIn("T-KR99", 1, "", 0); return err }, "not found"},
+		{"list unknown key result", func() error { _, err := pm.ListCheckIns("T-KR99"); return err }, "not found"},
+		{"update missing timestamp", func() error {
+			return pm.UpdateCheckIn(krID, CheckIn{ID: recorded.ID, Value: 1})
+		}, "timestamp"},
+		{"update future timestamp", func() error {
+			return pm.UpdateCheckIn(krID, CheckIn{ID: recorded.ID, Value: 1, Timestamp: "2026-03-01T09:00:01Z"})
+		}, "later than now"},
+		{"update malformed timestamp", func() error {
+			return pm.UpdateCheckIn(krID, CheckIn{ID: recorded.ID, Value: 1, Timestamp: "yesterday"})
+		}, "invalid Timestamp"},
+		{"update unknown check-in", func() error {
+			return pm.UpdateCheckIn(krID, CheckIn{ID: "C9", Value: 1, Timestamp: recorded.Timestamp})
+		}, "C9 not found"},
+		{"delete unknown check-in", func() error { return pm.DeleteCheckIn(krID, "C9") }, "C9 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	checkIns, _ := pm.ListCheckIns(krID)
+	if len(checkIns) != 1 || checkIns[0].Value != 12 {
+		t.Errorf("rejected calls changed history: %+v", checkIns)
+	}
+}
@@ -1,9 +1,12 @@
 package managers
 
 import (
+	"slices"
+
 	"github.com/rkn/bearing/internal/access"
 	"github.com/rkn/bearing/internal/engines/progress_engine"
 	"github.com/rkn/bearing/internal/engines/schedule_engine"
+	"github.com/rkn/bearing/internal/utilities"
 )
 
 // toManagerLifeTheme converts an access.LifeTheme to the Manager's LifeTheme.
@@ -16,6 +19,7 @@ func toManagerLifeTheme(a access.LifeTheme) LifeTheme {
 		ID:         a.ID,
 		Name:       a.Name,
 		Color:      a.Color,
+		Status:     a.Status,
 		Objectives: objectives,
 	}
 }
@@ -30,6 +34,7 @@ func toAccessLifeTheme(m LifeTheme) access.LifeTheme {
 		ID:         m.ID,
 		Name:       m.Name,
 		Color:      m.Color,
+		Status:     m.Status,
 		Objectives: objectives,
 	}
 }
@@ -56,6 +61,11 @@ func toManagerObjective(a access.Objective) Objective {
 		ClosingStatus: a.ClosingStatus,
 		ClosingNotes:  a.ClosingNotes,
 		ClosedAt:      a.ClosedAt,
+		CreatedAt:     a.CreatedAt,
+		StartDate:     a.StartDate,
+		EndDate:       a.EndDate,
+		Weight:        a.Weight,
+		CycleID:       a.CycleID,
 		KeyResults:    keyResults,
 		Objectives:    children,
 	}
@@ -83,6 +93,11 @@ func toAccessObjective(m Objective) access.Objective {
 		ClosingStatus: m.ClosingStatus,
 		ClosingNotes:  m.ClosingNotes,
 		ClosedAt:      m.ClosedAt,
+		CreatedAt:     m.CreatedAt,
+		StartDate:     m.StartDate,
+		EndDate:       m.EndDate,
+		Weight:        m.Weight,
+		CycleID:       m.CycleID,
 		KeyResults:    keyResults,
 		Objectives:    children,
 	}
@@ -96,9 +111,14 @@ func toManagerKeyResult(a access.KeyResult) KeyResult {
 		Description:  a.Description,
 		Type:         a.Type,
 		Status:       a.Status,
+		Direction:    a.Direction,
+		Unit:         a.Unit,
+		Weight:       a.Weight,
 		StartValue:   a.StartValue,
 		CurrentValue: a.CurrentValue,
 		TargetValue:  a.TargetValue,
+		CheckIns:     toManagerCheckIns(a.CheckIns),
+		Milestones:   toManagerMilestones(a.Milestones),
 	}
 }
 
@@ -110,10 +130,149 @@ func toAccessKeyResult(m KeyResult) access.KeyResult {
 		Description:  m.Description,
 		Type:         m.Type,
 		Status:       m.Status,
+		Direction:    m.Direction,
+		Unit:         m.Unit,
+		Weight:       m.Weight,
 		StartValue:   m.StartValue,
 		CurrentValue: m.CurrentValue,
 		TargetValue:  m.TargetValue,
+		CheckIns:     toAccessCheckIns(m.CheckIns),
+		Milestones:   toAccessMilestones(m.Milestones),
+	}
+}
+
+// toManagerCheckIns converts access.CheckIns to the Manager's CheckIns.
+func toManagerCheckIns(a []access.CheckIn) []CheckIn {
+	if len(a) == 0 {
+		return nil
+	}
+	result := make([]CheckIn, len(a))
+	for i, c := range a {
+		result[i] = CheckIn{
+			ID:         c.ID,
+			Value:      c.Value,
+			Timestamp:  c.Timestamp,
+			Note:       c.Note,
+			Confidence: c.Confidence,
+		}
+	}
+	return result
+}
+
+// toManagerRetrospective converts the retrospective stored on a closed
+// access.Objective to the Manager's Retrospective.
+func toManagerRetrospective(obj access.Objective) *Retrospective {
+	a := obj.Retrospective
+	result := &Retrospective{
+		ObjectiveID:   obj.ID,
+		Title:         obj.Title,
+		ClosingStatus: obj.ClosingStatus,
+		ClosingNotes:  obj.ClosingNotes,
+		Since:         a.Since,
+		ClosedAt:      a.ClosedAt,
+		KeyResults:    make([]RetrospectiveKeyResult, len(a.KeyResults)),
+		Tasks:         make([]RetrospectiveTask, len(a.Tasks)),
+		FocusDays:     append([]utilities.CalendarDate{}, a.FocusDays...),
 	}
+	for i, kr := range a.KeyResults {
+		result.KeyResults[i] = RetrospectiveKeyResult{
+			ID:          kr.ID,
+			Description: kr.Description,
+			Type:        kr.Type,
+			Direction:   kr.Direction,
+			Unit:        kr.Unit,
+			StartValue:  kr.StartValue,
+			FinalValue:  kr.FinalValue,
+			TargetValue: kr.TargetValue,
+			Reached:     kr.Reached,
+			CheckIns:    toManagerCheckIns(kr.CheckIns),
+		}
+	}
+	for i, t := range a.Tasks {
+		result.Tasks[i] = RetrospectiveTask{
+			ID:          t.ID,
+			Title:       t.Title,
+			ThemeID:     t.ThemeID,
+			Tags:        t.Tags,
+			CompletedAt: t.CompletedAt,
+		}
+	}
+	return result
+}
+
+// toAccessCheckIns converts Manager CheckIns to access.CheckIns.
+func toAccessCheckIns(m []CheckIn) []access.CheckIn {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make([]access.CheckIn, len(m))
+	for i, c := range m {
+		result[i] = access.CheckIn{
+			ID:         c.ID,
+			Value:      c.Value,
+			Timestamp:  c.Timestamp,
+			Note:       c.Note,
+			Confidence: c.Confidence,
+		}
+	}
+	return result
+}
+
+// toManagerContributions converts progress_engine.Contributions to the Manager's ProgressContributions.
+func toManagerContributions(e []progress_engine.Contribution) []ProgressContribution {
+	if len(e) == 0 {
+		return nil
+	}
+	result := make([]ProgressContribution, len(e))
+	for i, c := range e {
+		result[i] = ProgressContribution{
+			ID:       c.ID,
+			Kind:     c.Kind,
+			Progress: c.Progress,
+			Weight:   c.Weight,
+			Share:    c.Share,
+			Points:   c.Points,
+		}
+	}
+	return result
+}
+
+// toManagerMilestones converts access.Milestones to the Manager's Milestones.
+func toManagerMilestones(a []access.Milestone) []Milestone {
+	if len(a) == 0 {
+		return nil
+	}
+	result := make([]Milestone, len(a))
+	for i, ms := range a {
+		result[i] = Milestone{
+			ID:          ms.ID,
+			Name:        ms.Name,
+			Done:        ms.Done,
+			DueDate:     ms.DueDate,
+			CompletedAt: ms.CompletedAt,
+			Weight:      ms.Weight,
+		}
+	}
+	return result
+}
+
+// toAccessMilestones converts the Manager's Milestones to access.Milestones.
+func toAccessMilestones(m []Milestone) []access.Milestone {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make([]access.Milestone, len(m))
+	for i, ms := range m {
+		result[i] = access.Milestone{
+			ID:          ms.ID,
+			Name:        ms.Name,
+			Done:        ms.Done,
+			DueDate:     ms.DueDate,
+			CompletedAt: ms.CompletedAt,
+			Weight:      ms.Weight,
+		}
+	}
+	return result
 }
 
 // toManagerRepeatPattern converts an access.RepeatPattern to the Manager's RepeatPattern.
@@ -122,11 +281,18 @@ func toManagerRepeatPattern(a *access.RepeatPattern) *RepeatPattern {
 		return nil
 	}
 	return &RepeatPattern{
-		Frequency:  a.Frequency,
-		Interval:   a.Interval,
-		Weekdays:   a.Weekdays,
-		DayOfMonth: a.DayOfMonth,
-		StartDate:  a.StartDate,
+		Frequency:       a.Frequency,
+		Interval:        a.Interval,
+		Weekdays:        a.Weekdays,
+		DayOfMonth:      a.DayOfMonth,
+		WeekOfMonth:     a.WeekOfMonth,
+		Month:           a.Month,
+		RRule:           a.RRule,
+		AfterCompletion: a.AfterCompletion,
+		StartDate:       a.StartDate,
+		EndDate:         a.EndDate,
+		Count:           a.Count,
+		Pauses:          toManagerPauses(a.Pauses),
 	}
 }
 
@@ -136,12 +302,43 @@ func toAccessRepeatPattern(m *RepeatPattern) *access.RepeatPattern {
 		return nil
 	}
 	return &access.RepeatPattern{
-		Frequency:  m.Frequency,
-		Interval:   m.Interval,
-		Weekdays:   m.Weekdays,
-		DayOfMonth: m.DayOfMonth,
-		StartDate:  m.StartDate,
+		Frequency:       m.Frequency,
+		Interval:        m.Interval,
+		Weekdays:        m.Weekdays,
+		DayOfMonth:      m.DayOfMonth,
+		WeekOfMonth:     m.WeekOfMonth,
+		Month:           m.Month,
+		RRule:           m.RRule,
+		AfterCompletion: m.AfterCompletion,
+		StartDate:       m.StartDate,
+		EndDate:         m.EndDate,
+		Count:           m.Count,
+		Pauses:          toAccessPauses(m.Pauses),
+	}
+}
+
+// toManagerPauses converts access.SchedulePause slice to the Manager's SchedulePause slice.
+func toManagerPauses(a []access.SchedulePause) []SchedulePause {
+	if len(a) == 0 {
+		return nil
+	}
+	result := make([]SchedulePause, len(a))
+	for i, p := range a {
+		result[i] = SchedulePause{From: p.From, To: p.To}
+	}
+	return result
+}
+
+// toAccessPauses converts Manager SchedulePause slice to access.SchedulePause slice.
+func toAccessPauses(m []SchedulePause) []access.SchedulePause {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make([]access.SchedulePause, len(m))
+	for i, p := range m {
+		result[i] = access.SchedulePause{From: p.From, To: p.To}
 	}
+	return result
 }
 
 // toManagerExceptions converts access.ScheduleException slice to the Manager's ScheduleException slice.
@@ -177,20 +374,56 @@ func toAccessExceptions(m []ScheduleException) []access.ScheduleException {
 // toManagerRoutine converts an access.Routine to the Manager's Routine.
 func toManagerRoutine(a access.Routine) Routine {
 	return Routine{
-		ID:            a.ID,
-		Description:   a.Description,
-		RepeatPattern: toManagerRepeatPattern(a.RepeatPattern),
-		Exceptions:    toManagerExceptions(a.Exceptions),
+		ID:              a.ID,
+		Description:     a.Description,
+		RepeatPattern:   toManagerRepeatPattern(a.RepeatPattern),
+		Exceptions:      toManagerExceptions(a.Exceptions),
+		ThemeID:         a.ThemeID,
+		KeyResultID:     a.KeyResultID,
+		FeedKeyResult:   a.FeedKeyResult,
+		Materialization: toManagerMaterialization(a.Materialization),
 	}
 }
 
 // toAccessRoutine converts a Manager Routine to an access.Routine.
 func toAccessRoutine(m Routine) access.Routine {
 	return access.Routine{
-		ID:            m.ID,
-		Description:   m.Description,
-		RepeatPattern: toAccessRepeatPattern(m.RepeatPattern),
-		Exceptions:    toAccessExceptions(m.Exceptions),
+		ID:              m.ID,
+		Description:     m.Description,
+		RepeatPattern:   toAccessRepeatPattern(m.RepeatPattern),
+		Exceptions:      toAccessExceptions(m.Exceptions),
+		ThemeID:         m.ThemeID,
+		KeyResultID:     m.KeyResultID,
+		FeedKeyResult:   m.FeedKeyResult,
+		Materialization: toAccessMaterialization(m.Materialization),
+	}
+}
+
+// toManagerMaterialization converts an access.RoutineMaterialization to the
+// Manager's RoutineMaterialization. Nil stays nil.
+func toManagerMaterialization(a *access.RoutineMaterialization) *RoutineMaterialization {
+	if a == nil {
+		return nil
+	}
+	return &RoutineMaterialization{
+		Mode:          a.Mode,
+		Column:        a.Column,
+		Tags:          slices.Clone(a.Tags),
+		TitleTemplate: a.TitleTemplate,
+	}
+}
+
+// toAccessMaterialization converts a Manager RoutineMaterialization to an
+// access.RoutineMaterialization. Nil stays nil.
+func toAccessMaterialization(m *RoutineMaterialization) *access.RoutineMaterialization {
+	if m == nil {
+		return nil
+	}
+	return &access.RoutineMaterialization{
+		Mode:          m.Mode,
+		Column:        m.Column,
+		Tags:          slices.Clone(m.Tags),
+		TitleTemplate: m.TitleTemplate,
 	}
 }
 
@@ -227,29 +460,55 @@ func toAccessTask(m Task) access.Task {
 // toManagerDayFocus converts an access.DayFocus to the Manager's DayFocus.
 func toManagerDayFocus(a access.DayFocus) DayFocus {
 	return DayFocus{
-		Date:          a.Date,
-		ThemeIDs:      a.ThemeIDs,
-		Notes:         a.Notes,
-		Text:          a.Text,
-		OkrIDs:        a.OkrIDs,
-		Tags:          a.Tags,
-		RoutineChecks: a.RoutineChecks,
+		Date:                a.Date,
+		ThemeIDs:            a.ThemeIDs,
+		Notes:               a.Notes,
+		Text:                a.Text,
+		OkrIDs:              a.OkrIDs,
+		Tags:                a.Tags,
+		RoutineChecks:       a.RoutineChecks,
+		RoutineCheckDetails: toManagerRoutineCheckDetails(a.RoutineCheckDetails),
 	}
 }
 
 // toAccessDayFocus converts a Manager DayFocus to an access.DayFocus.
 func toAccessDayFocus(m DayFocus) access.DayFocus {
 	return access.DayFocus{
-		Date:          m.Date,
-		ThemeIDs:      m.ThemeIDs,
-		Notes:         m.Notes,
-		Text:          m.Text,
-		OkrIDs:        m.OkrIDs,
-		Tags:          m.Tags,
-		RoutineChecks: m.RoutineChecks,
+		Date:                m.Date,
+		ThemeIDs:            m.ThemeIDs,
+		Notes:               m.Notes,
+		Text:                m.Text,
+		OkrIDs:              m.OkrIDs,
+		Tags:                m.Tags,
+		RoutineChecks:       m.RoutineChecks,
+		RoutineCheckDetails: toAccessRoutineCheckDetails(m.RoutineCheckDetails),
 	}
 }
 
+// toManagerRoutineCheckDetails converts access check-in details to manager ones.
+func toManagerRoutineCheckDetails(details []access.RoutineCheckDetail) []RoutineCheckDetail {
+	if len(details) == 0 {
+		return nil
+	}
+	result := make([]RoutineCheckDetail, len(details))
+	for i, d := range details {
+		result[i] = RoutineCheckDetail(d)
+	}
+	return result
+}
+
+// toAccessRoutineCheckDetails converts manager check-in details to access ones.
+func toAccessRoutineCheckDetails(details []RoutineCheckDetail) []access.RoutineCheckDetail {
+	if len(details) == 0 {
+		return nil
+	}
+	result := make([]access.RoutineCheckDetail, len(details))
+	for i, d := range details {
+		result[i] = access.RoutineCheckDetail(d)
+	}
+	return result
+}
+
 // toManagerBoardConfig converts an access.BoardConfiguration to the Manager's BoardConfiguration.
 func toManagerBoardConfig(a *access.BoardConfiguration) *BoardConfiguration {
 	columns := make([]ColumnDefinition, len(a.ColumnDefinitions))
@@ -278,6 +537,57 @@ func toManagerBoardConfig(a *access.BoardConfiguration) *BoardConfiguration {
 	}
 }
 
+// toManagerProfile converts an access-layer profile; active marks the
+// profile currently opened.
+func toManagerProfile(p access.Profile, active bool) Profile {
+	return Profile{
+		ID:        p.ID,
+		Name:      p.Name,
+		DataDir:   p.DataDir,
+		CreatedAt: p.CreatedAt.String(),
+		Active:    active,
+	}
+}
+
+func toManagerSettings(a access.Settings) Settings {
+	return Settings{
+		GitAuthorName:       a.GitAuthor.Name,
+		GitAuthorEmail:      a.GitAuthor.Email,
+		WeekStart:           a.WeekStart,
+		Timezone:            a.Timezone,
+		DayStartHour:        a.DayStartHour,
+		Locale:              a.Locale,
+		DefaultThemeID:      a.DefaultThemeID,
+		AdvisorEnabled:      a.Advisor.Enabled,
+		ModelProvider:       a.Advisor.Provider,
+		ModelTimeoutSeconds: a.Advisor.TimeoutSeconds,
+		AutoArchiveMode:     string(a.AutoArchive.Mode),
+		AutoArchiveDays:     a.AutoArchive.Days,
+	}
+}
+
+func toAccessSettings(m Settings) access.Settings {
+	settings := access.Settings{
+		Version:        access.SettingsVersion,
+		GitAuthor:      access.GitAuthorSettings{Name: m.GitAuthorName, Email: m.GitAuthorEmail},
+		WeekStart:      m.WeekStart,
+		Timezone:       m.Timezone,
+		DayStartHour:   m.DayStartHour,
+		Locale:         m.Locale,
+		DefaultThemeID: m.DefaultThemeID,
+		Advisor: access.AdvisorSettings{
+			Enabled:        m.AdvisorEnabled,
+			Provider:       m.ModelProvider,
+			TimeoutSeconds: m.ModelTimeoutSeconds,
+		},
+		AutoArchive: access.AutoArchiveSettings{Mode: access.AutoArchiveMode(m.AutoArchiveMode)},
+	}
+	if settings.AutoArchive.Mode == access.AutoArchiveAfterDays {
+		settings.AutoArchive.Days = m.AutoArchiveDays
+	}
+	return settings
+}
+
 // toManagerPersonalVision converts an access.PersonalVision to the Manager's PersonalVision.
 func toManagerPersonalVision(a *access.PersonalVision) *PersonalVision {
 	return &PersonalVision{
@@ -301,17 +611,31 @@ func toEngineObjectiveDataSlice(objectives []access.Objective) []progress_engine
 	for i, obj := range objectives {
 		krs := make([]progress_engine.KeyResultData, len(obj.KeyResults))
 		for j, kr := range obj.KeyResults {
+			history := make([]progress_engine.ValuePoint, len(kr.CheckIns))
+			for k, c := range kr.CheckIns {
+				history[k] = progress_engine.ValuePoint{At: c.Timestamp.Time(), Value: c.Value}
+			}
+			if kr.Type == access.KRTypeMilestone {
+				history = milestoneHistory(kr.Milestones)
+			}
 			krs[j] = progress_engine.KeyResultData{
 				ID:           kr.ID,
+				Type:         kr.Type,
 				Status:       kr.Status,
+				Direction:    kr.Direction,
+				Weight:       kr.Weight,
 				StartValue:   kr.StartValue,
 				CurrentValue: kr.CurrentValue,
 				TargetValue:  kr.TargetValue,
+				History:      history,
 			}
 		}
 		result[i] = progress_engine.ObjectiveData{
 			ID:         obj.ID,
 			Status:     obj.Status,
+			Weight:     obj.Weight,
+			StartDate:  obj.StartDate.Time(),
+			EndDate:    obj.EndDate.Time(),
 			KeyResults: krs,
 			Objectives: toEngineObjectiveDataSlice(obj.Objectives),
 		}
@@ -325,12 +649,31 @@ func toEngineRepeatPattern(p *access.RepeatPattern) *schedule_engine.RepeatPatte
 		return nil
 	}
 	return &schedule_engine.RepeatPattern{
-		Frequency:  p.Frequency,
-		Interval:   p.Interval,
-		Weekdays:   p.Weekdays,
-		DayOfMonth: p.DayOfMonth,
-		StartDate:  p.StartDate,
+		Frequency:       p.Frequency,
+		Interval:        p.Interval,
+		Weekdays:        p.Weekdays,
+		DayOfMonth:      p.DayOfMonth,
+		WeekOfMonth:     p.WeekOfMonth,
+		Month:           p.Month,
+		RRule:           p.RRule,
+		AfterCompletion: p.AfterCompletion,
+		StartDate:       p.StartDate,
+		EndDate:         p.EndDate,
+		Count:           p.Count,
+		Pauses:          toEnginePauses(p.Pauses),
+	}
+}
+
+// toEnginePauses converts a slice of access.SchedulePause to schedule_engine.Pause.
+func toEnginePauses(pauses []access.SchedulePause) []schedule_engine.Pause {
+	if len(pauses) == 0 {
+		return nil
+	}
+	result := make([]schedule_engine.Pause, len(pauses))
+	for i, p := range pauses {
+		result[i] = schedule_engine.Pause{From: p.From, To: p.To}
 	}
+	return result
 }
 
 // toEngineExceptions converts a slice of access.ScheduleException to schedule_engine.Exception.
@@ -347,3 +690,31 @@ func toEngineExceptions(exceptions []access.ScheduleException) []schedule_engine
 	}
 	return result
 }
+
+// toManagerCycle converts an access.Cycle to a Manager Cycle.
+func toManagerCycle(a access.Cycle) Cycle {
+	return Cycle{
+		ID:        a.ID,
+		Name:      a.Name,
+		StartDate: a.StartDate,
+		EndDate:   a.EndDate,
+		ClosedAt:  a.ClosedAt,
+	}
+}
+
+// toManagerWeekPlan converts an access.WeekPlan to a Manager WeekPlan,
+// deriving the week's first and last day.
+func toManagerWeekPlan(a access.WeekPlan) WeekPlan {
+	monday, sunday, _ := utilities.ParseISOWeek(a.Week)
+	return WeekPlan{
+		Week:             a.Week,
+		StartDate:        monday,
+		EndDate:          sunday,
+		Intentions:       a.Intentions,
+		ObjectiveIDs:     a.ObjectiveIDs,
+		KeyResultIDs:     a.KeyResultIDs,
+		ThemeIDs:         a.ThemeIDs,
+		CommittedTaskIDs: a.CommittedTaskIDs,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
@@ -79,6 +79,24 @@ func TestUnit_ToManagerRoutine_RoundTripWithRepeatPattern(t *testing.T) {
 	assertRoutineEqual(t, original, result)
 }
 
+func TestUnit_ToManagerRoutine_RoundTripWithCalendarPattern(t *testing.T) {
+	original := access.Routine{
+		ID:          "r-3",
+		Description: "Quarterly review",
+		RepeatPattern: &access.RepeatPattern{
+			Frequency:   "yearly",
+			Interval:    1,
+			Weekdays:    []int{1},
+			WeekOfMonth: -1,
+			Month:       3,
+			StartDate:   utilities.MustParseCalendarDate("2026-01-01"),
+		},
+	}
+	mRoutine := toManagerRoutine(original)
+	result := toAccessRoutine(mRoutine)
+	assertRoutineEqual(t, original, result)
+}
+
 func TestUnit_ToManagerObjective_RoundTrip(t *testing.T) {
 	original := access.Objective{
 		ID:            "obj-1",
@@ -273,7 +291,7 @@ func TestUnit_ToEngineThemeData(t *testing.T) {
 	assertEngineObjective(t, obj, "obj-1", "active", 2, 1)
 	// Check key results mapped correctly
 	if obj.KeyResults[0].CurrentValue != 50 || obj.KeyResults[0].TargetValue != 100 {
-		t.Errorf("kr-1 values: current=%d target=%d", obj.KeyResults[0].CurrentValue, obj.KeyResults[0].TargetValue)
+		t.Errorf("kr-1 values: current=%g target=%g", obj.KeyResults[0].CurrentValue, obj.KeyResults[0].TargetValue)
 	}
 	// Check nested objective
 	child := obj.Objectives[0]
@@ -340,13 +358,13 @@ func assertKeyResultEqual(t *testing.T, want, got access.KeyResult) {
 		t.Errorf("Status: got %q, want %q", got.Status, want.Status)
 	}
 	if got.StartValue != want.StartValue {
-		t.Errorf("StartValue: got %d, want %d", got.StartValue, want.StartValue)
+		t.Errorf("StartValue: got %g, want %g", got.StartValue, want.StartValue)
 	}
 	if got.CurrentValue != want.CurrentValue {
-		t.Errorf("CurrentValue: got %d, want %d", got.CurrentValue, want.CurrentValue)
+		t.Errorf("CurrentValue: got %g, want %g", got.CurrentValue, want.CurrentValue)
 	}
 	if got.TargetValue != want.TargetValue {
-		t.Errorf("TargetValue: got %d, want %d", got.TargetValue, want.TargetValue)
+		t.Errorf("TargetValue: got %g, want %g", got.TargetValue, want.TargetValue)
 	}
 }
 
@@ -367,6 +385,9 @@ func assertRoutineEqual(t *testing.T, want, got access.Routine) {
 		if got.RepeatPattern.Interval != want.RepeatPattern.Interval {
 			t.Errorf("RepeatPattern.Interval: got %d, want %d", got.RepeatPattern.Interval, want.RepeatPattern.Interval)
 		}
+		if got.RepeatPattern.DayOfMonth != want.RepeatPattern.DayOfMonth || got.RepeatPattern.WeekOfMonth != want.RepeatPattern.WeekOfMonth || got.RepeatPattern.Month != want.RepeatPattern.Month {
+			t.Errorf("RepeatPattern day/week/month: got %+v, want %+v", *got.RepeatPattern, *want.RepeatPattern)
+		}
 		if got.RepeatPattern.StartDate.String() != want.RepeatPattern.StartDate.String() {
 			t.Errorf("RepeatPattern.StartDate: got %q, want %q", got.RepeatPattern.StartDate, want.RepeatPattern.StartDate)
 		}
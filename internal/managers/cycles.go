@@ -0,0 +1,514 @@
+package managers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// maxCycleNameLength bounds the display name of a cycle.
+const maxCycleNameLength = 100
+
+// Cycle is a time-boxed OKR period, such as a quarter, in the Manager
+// layer's public interface. ClosedAt is set once the cycle is rolled over.
+type Cycle struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	StartDate utilities.CalendarDate `json:"startDate"`
+	EndDate   utilities.CalendarDate `json:"endDate"`
+	ClosedAt  utilities.Timestamp    `json:"closedAt,omitempty"`
+}
+
+// ObjectiveClosing is the closing decision for one objective at rollover.
+type ObjectiveClosing struct {
+	ObjectiveID   string `json:"objectiveId"`
+	ClosingStatus string `json:"closingStatus"`
+	ClosingNotes  string `json:"closingNotes,omitempty"`
+}
+
+// RolloverRequest closes a cycle. Every active objective of the cycle needs
+// a closing; postponed objectives are copied into NextCycleID.
+type RolloverRequest struct {
+	CycleID     string             `json:"cycleId"`
+	NextCycleID string             `json:"nextCycleId,omitempty"` // required when any objective is postponed
+	Closings    []ObjectiveClosing `json:"closings"`
+}
+
+// CarriedObjective maps a postponed objective to its copy in the next cycle.
+type CarriedObjective struct {
+	FromID string `json:"fromId"`
+	ToID   string `json:"toId"`
+}
+
+// RolloverResult reports the objectives closed and carried over by a rollover.
+type RolloverResult struct {
+	Closed      []string           `json:"closed"`
+	CarriedOver []CarriedObjective `json:"carriedOver,omitempty"`
+}
+
+// GetCycles returns all cycles ordered by start date.
+func (m *PlanningManager) GetCycles() ([]Cycle, error) {
+	cycles, err := m.cycleAccess.GetCycles()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	sort.SliceStable(cycles, func(i, j int) bool {
+		return cycles[i].StartDate < cycles[j].StartDate
+	})
+	result := make([]Cycle, len(cycles))
+	for i, c := range cycles {
+		result[i] = toManagerCycle(c)
+	}
+	return result, nil
+}
+
+// CreateCycle creates a new open cycle spanning startDate to endDate inclusive.
+func (m *PlanningManager) CreateCycle(name, startDate, endDate string) (*Cycle, error) {
+	cycle, err := newAccessCycle(name, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles, err := m.cycleAccess.GetCycles()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	cycle.ID = access.NextCycleID(cycles)
+
+	if err := m.cycleAccess.SaveCycle(cycle); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	result := toManagerCycle(cycle)
+	return &result, nil
+}
+
+// UpdateCycle renames a cycle or moves its dates. The closing time is
+// managed by RolloverCycle and cannot be changed here.
+func (m *PlanningManager) UpdateCycle(cycle Cycle) error {
+	existing, err := m.findCycle(cycle.ID)
+	if err != nil {
+		return err
+	}
+	updated, err := newAccessCycle(cycle.Name, cycle.StartDate.String(), cycle.EndDate.String())
+	if err != nil {
+		return err
+	}
+	updated.ID = existing.ID
+	updated.ClosedAt = existing.ClosedAt
+
+	if err := m.cycleAccess.SaveCycle(updated); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// DeleteCycle deletes a cycle that no objective belongs to.
+func (m *PlanningManager) DeleteCycle(cycleId string) error {
+	if _, err := m.findCycle(cycleId); err != nil {
+		return err
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	var assigned []string
+	for _, theme := range themes {
+		for _, obj := range theme.Objectives {
+			if obj.CycleID == cycleId {
+				assigned = append(assigned, obj.ID)
+			}
+		}
+	}
+	if len(assigned) > 0 {
+		return fmt.Errorf("cannot delete cycle %s: objectives still belong to it (%s)", cycleId, strings.Join(assigned, ", "))
+	}
+
+	if err := m.cycleAccess.DeleteCycle(cycleId); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// GetHierarchyForCycle returns the OKR hierarchy restricted to the top-level
+// objectives of a cycle, with their subtrees. Themes without objectives in
+// the cycle are omitted.
+func (m *PlanningManager) GetHierarchyForCycle(cycleId string) ([]LifeTheme, error) {
+	themes, err := m.cycleThemes(cycleId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]LifeTheme, len(themes))
+	for i, t := range themes {
+		result[i] = toManagerLifeTheme(t)
+	}
+	return result, nil
+}
+
+// GetThemeProgressForCycle computes theme and objective progress counting
+// only the objectives of a cycle.
+func (m *PlanningManager) GetThemeProgressForCycle(cycleId string) ([]ThemeProgress, error) {
+	themes, err := m.cycleThemes(cycleId)
+	if err != nil {
+		return nil, err
+	}
+	return m.computeThemeProgress(themes), nil
+}
+
+// RolloverCycle closes every active objective of a cycle with its closing
+// status, copies postponed objectives into the next cycle and marks the
+// cycle closed, all in a single commit.
+//
+// A copy keeps the objective's title, tags, weight and its active child
+// objectives. Its key results start afresh: check-ins are dropped, the
+// current value becomes the new start value and completed milestones are
+// left behind. Key results that already reached their target and archived
+// ones are not carried over.
+func (m *PlanningManager) RolloverCycle(req RolloverRequest) (*RolloverResult, error) {
+	cycles, err := m.cycleAccess.GetCycles()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	cycle := findAccessCycle(cycles, req.CycleID)
+	if cycle == nil {
+		return nil, fmt.Errorf("cycle with ID %s not found", req.CycleID)
+	}
+	if !cycle.ClosedAt.IsZero() {
+		return nil, fmt.Errorf("cycle %s is already closed", cycle.ID)
+	}
+
+	closings := make(map[string]ObjectiveClosing, len(req.Closings))
+	postponed := false
+	for _, c := range req.Closings {
+		if !IsValidClosingStatus(c.ClosingStatus) {
+			return nil, fmt.Errorf("invalid closing status %q for objective %s", c.ClosingStatus, c.ObjectiveID)
+		}
+		if _, dup := closings[c.ObjectiveID]; dup {
+			return nil, fmt.Errorf("duplicate closing for objective %s", c.ObjectiveID)
+		}
+		closings[c.ObjectiveID] = c
+		postponed = postponed || c.ClosingStatus == access.ClosingStatusPostponed
+	}
+
+	var next *access.Cycle
+	if postponed {
+		if req.NextCycleID == "" {
+			return nil, fmt.Errorf("nextCycleId is required to carry over postponed objectives")
+		}
+		if next = findAccessCycle(cycles, req.NextCycleID); next == nil {
+			return nil, fmt.Errorf("cycle with ID %s not found", req.NextCycleID)
+		}
+		if next.ID == cycle.ID || !next.ClosedAt.IsZero() {
+			return nil, fmt.Errorf("cannot carry objectives over into cycle %s: it must be another open cycle", next.ID)
+		}
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	// Validate the closings against the cycle's active objectives before
+	// changing anything.
+	var missing []string
+	for _, theme := range themes {
+		for _, obj := range theme.Objectives {
+			if obj.CycleID != cycle.ID || EffectiveOKRStatus(obj.Status) != string(access.OKRStatusActive) {
+				continue
+			}
+			if _, ok := closings[obj.ID]; ok {
+				delete(closings, obj.ID)
+			} else {
+				missing = append(missing, obj.ID)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("active objectives of cycle %s need a closing status: %s", cycle.ID, strings.Join(missing, ", "))
+	}
+	if len(closings) > 0 {
+		unknown := make([]string, 0, len(closings))
+		for id := range closings {
+			unknown = append(unknown, id)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("not active objectives of cycle %s: %s", cycle.ID, strings.Join(unknown, ", "))
+	}
+
+	type carried struct {
+		theme, index int
+		fromID       string
+	}
+	var copies []carried
+	changed := make([]bool, len(themes))
+	src, err := m.loadRetrospectiveSources()
+	if err != nil {
+		return nil, err
+	}
+	now := m.clock.Now()
+	result := &RolloverResult{Closed: []string{}}
+
+	for _, c := range req.Closings {
+		for i := range themes {
+			obj := findObjectiveByID(themes[i].Objectives, c.ObjectiveID)
+			if obj == nil {
+				continue
+			}
+			if err := closeAccessObjective(obj, c.ClosingStatus, c.ClosingNotes, now); err != nil {
+				return nil, err
+			}
+			obj.Retrospective = buildRetrospective(src, themes[i].ID, *obj)
+			changed[i] = true
+			result.Closed = append(result.Closed, obj.ID)
+
+			if c.ClosingStatus == access.ClosingStatusPostponed {
+				fromID, copied := obj.ID, carryOverObjective(*obj, next.ID, now)
+				themes[i].Objectives = append(themes[i].Objectives, copied)
+				copies = append(copies, carried{theme: i, index: len(themes[i].Objectives) - 1, fromID: fromID})
+			}
+			break
+		}
+	}
+
+	cycle.ClosedAt = now
+	commitMsg := fmt.Sprintf("Roll over cycle: %s", cycle.Name)
+	if err := utilities.RunTransaction(m.repo, commitMsg, func() error {
+		for i := range themes {
+			if !changed[i] {
+				continue
+			}
+			if err := m.themeAccess.WriteTheme(themes[i]); err != nil {
+				return fmt.Errorf("write theme failed: %w", err)
+			}
+		}
+		if err := m.cycleAccess.WriteCycle(*cycle); err != nil {
+			return fmt.Errorf("write cycle failed: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("RolloverCycle: %w", err)
+	}
+
+	if len(copies) > 0 {
+		// Copies are appended to their theme, so their IDs (assigned on
+		// write) can be looked up by position.
+		saved, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve updated themes: %w", err)
+		}
+		for _, c := range copies {
+			toID := ""
+			for _, theme := range saved {
+				if theme.ID == themes[c.theme].ID && c.index < len(theme.Objectives) {
+					toID = theme.Objectives[c.index].ID
+				}
+			}
+			result.CarriedOver = append(result.CarriedOver, CarriedObjective{FromID: c.fromID, ToID: toID})
+		}
+	}
+
+	return result, nil
+}
+
+// carryOverObjective returns a fresh copy of obj for cycleId, created at now.
+// IDs are left empty for ThemeAccess to assign on write.
+func carryOverObjective(obj access.Objective, cycleId string, now utilities.Timestamp) access.Objective {
+	fresh := access.Objective{
+		Title:      obj.Title,
+		Tags:       append([]string(nil), obj.Tags...),
+		Weight:     obj.Weight,
+		CycleID:    cycleId,
+		CreatedAt:  now,
+		KeyResults: []access.KeyResult{},
+	}
+	if len(fresh.Tags) == 0 {
+		fresh.Tags = nil
+	}
+	for _, kr := range obj.KeyResults {
+		if copied, ok := carryOverKeyResult(kr); ok {
+			fresh.KeyResults = append(fresh.KeyResults, copied)
+		}
+	}
+	for _, child := range obj.Objectives {
+		if EffectiveOKRStatus(child.Status) == string(access.OKRStatusActive) {
+			fresh.Objectives = append(fresh.Objectives, carryOverObjective(child, "", now))
+		}
+	}
+	return fresh
+}
+
+// carryOverKeyResult returns kr rebased to start where it left off. ok is
+// false for archived key results and those that already reached their target.
+func carryOverKeyResult(kr access.KeyResult) (access.KeyResult, bool) {
+	if kr.Status == string(access.OKRStatusArchived) {
+		return access.KeyResult{}, false
+	}
+	fresh := access.KeyResult{
+		Description: kr.Description,
+		Type:        kr.Type,
+		Direction:   kr.Direction,
+		Unit:        kr.Unit,
+		Weight:      kr.Weight,
+		StartValue:  kr.StartValue,
+		TargetValue: kr.TargetValue,
+	}
+
+	switch kr.Type {
+	case access.KRTypeMilestone:
+		for _, ms := range kr.Milestones {
+			if !ms.Done {
+				fresh.Milestones = append(fresh.Milestones, ms)
+			}
+		}
+		if len(fresh.Milestones) == 0 {
+			return access.KeyResult{}, false
+		}
+	case access.KRTypeBinary:
+		if kr.CurrentValue >= 1 {
+			return access.KeyResult{}, false
+		}
+	default:
+		if keyResultReached(kr) {
+			return access.KeyResult{}, false
+		}
+		fresh.StartValue = kr.CurrentValue
+	}
+
+	deriveCurrentValue(&fresh)
+	return fresh, true
+}
+
+// keyResultReached reports whether a valued key result has reached its
+// target. Untracked key results never do.
+func keyResultReached(kr access.KeyResult) bool {
+	if kr.Direction == access.KRDirectionDecrease {
+		return kr.CurrentValue <= kr.TargetValue
+	}
+	return kr.TargetValue != 0 && kr.CurrentValue >= kr.TargetValue
+}
+
+// cycleThemes returns the themes restricted to the top-level objectives of
+// cycleId, dropping themes left without objectives.
+func (m *PlanningManager) cycleThemes(cycleId string) ([]access.LifeTheme, error) {
+	if _, err := m.findCycle(cycleId); err != nil {
+		return nil, err
+	}
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	result := make([]access.LifeTheme, 0, len(themes))
+	for _, theme := range visibleThemes(themes) {
+		var objectives []access.Objective
+		for _, obj := range theme.Objectives {
+			if obj.CycleID == cycleId {
+				objectives = append(objectives, obj)
+			}
+		}
+		if len(objectives) > 0 {
+			theme.Objectives = objectives
+			result = append(result, theme)
+		}
+	}
+	return result, nil
+}
+
+// applyCycleWindows fills in the missing start and end dates of top-level
+// objectives from their cycle, so forecasts measure them over the cycle.
+func (m *PlanningManager) applyCycleWindows(themes []access.LifeTheme) error {
+	cycles, err := m.cycleAccess.GetCycles()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	if len(cycles) == 0 {
+		return nil
+	}
+	for i := range themes {
+		for j := range themes[i].Objectives {
+			obj := &themes[i].Objectives[j]
+			cycle := findAccessCycle(cycles, obj.CycleID)
+			if cycle == nil {
+				continue
+			}
+			if obj.StartDate.IsZero() {
+				obj.StartDate = cycle.StartDate
+			}
+			if obj.EndDate.IsZero() {
+				obj.EndDate = cycle.EndDate
+			}
+		}
+	}
+	return nil
+}
+
+// checkCycleAssignment verifies that an objective under parentId may belong
+// to cycleId: cycles group top-level objectives only, and the cycle must
+// exist and still be open.
+func (m *PlanningManager) checkCycleAssignment(parentId, cycleId string) error {
+	if detectGoalType(parentId) != GoalTypeTheme {
+		return fmt.Errorf("only top-level objectives can belong to a cycle")
+	}
+	cycle, err := m.findCycle(cycleId)
+	if err != nil {
+		return err
+	}
+	if !cycle.ClosedAt.IsZero() {
+		return fmt.Errorf("cycle %s is closed", cycleId)
+	}
+	return nil
+}
+
+// findCycle returns the stored cycle with the given ID.
+func (m *PlanningManager) findCycle(cycleId string) (*access.Cycle, error) {
+	if cycleId == "" {
+		return nil, fmt.Errorf("cycleId cannot be empty")
+	}
+	cycles, err := m.cycleAccess.GetCycles()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if cycle := findAccessCycle(cycles, cycleId); cycle != nil {
+		return cycle, nil
+	}
+	return nil, fmt.Errorf("cycle with ID %s not found", cycleId)
+}
+
+// findAccessCycle returns a pointer into cycles for the given ID, or nil.
+func findAccessCycle(cycles []access.Cycle, id string) *access.Cycle {
+	if id == "" {
+		return nil
+	}
+	for i := range cycles {
+		if cycles[i].ID == id {
+			return &cycles[i]
+		}
+	}
+	return nil
+}
+
+// newAccessCycle validates a cycle's name and dates and returns it without ID.
+func newAccessCycle(name, startDate, endDate string) (access.Cycle, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return access.Cycle{}, fmt.Errorf("cycle name cannot be empty")
+	}
+	if len(name) > maxCycleNameLength {
+		return access.Cycle{}, fmt.Errorf("cycle name cannot exceed %d characters", maxCycleNameLength)
+	}
+	start, err := utilities.ParseCalendarDate(startDate)
+	if err != nil {
+		return access.Cycle{}, fmt.Errorf("invalid start date: %w", err)
+	}
+	end, err := utilities.ParseCalendarDate(endDate)
+	if err != nil {
+		return access.Cycle{}, fmt.Errorf("invalid end date: %w", err)
+	}
+	if start > end {
+		return access.Cycle{}, fmt.Errorf("start date %s is after end date %s", start, end)
+	}
+	return access.Cycle{Name: name, StartDate: start, EndDate: end}, nil
+}
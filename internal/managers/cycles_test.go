@@ -0,0 +1,324 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// newCycleTestManager returns a manager on a frozen clock (2026-03-01) with
+// cycles CY1 (2026 Q1) and CY2 (2026 Q2), and the stub repo to inspect
+// commits.
+func newCycleTestManager(t *testing.T) (*PlanningManager, *stubRepo) {
+	t.Helper()
+	clock := utilities.NewFrozenClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	repo := newStubRepo()
+	pm := newTestManager(t, testManagerDeps{repo: repo, clock: clock})
+	if _, err := pm.CreateCycle("2026 Q2", "2026-04-01", "2026-06-30"); err != nil {
+		t.Fatalf("CreateCycle Q2: %v", err)
+	}
+	if _, err := pm.CreateCycle(" 2026 Q1 ", "2026-01-01", "2026-03-31"); err != nil {
+		t.Fatalf("CreateCycle Q1: %v", err)
+	}
+	return pm, repo
+}
+
+// establishCycleObjective creates a top-level objective in cycleId under the
+// mock theme T.
+func establishCycleObjective(t *testing.T, pm *PlanningManager, title, cycleId string) string {
+	t.Helper()
+	res, err := pm.Establish(EstablishRequest{GoalType: GoalTypeObjective, ParentID: "T", Title: title, CycleID: cycleId})
+	if err != nil {
+		t.Fatalf("Establish objective: %v", err)
+	}
+	return res.Objective.ID
+}
+
+func TestUnit_Cycles_CRUD(t *testing.T) {
+	pm, _ := newCycleTestManager(t)
+
+	cycles, err := pm.GetCycles()
+	if err != nil {
+		t.Fatalf("GetCycles: %v", err)
+	}
+	if len(cycles) != 2 || cycles[0].ID != "CY2" || cycles[0].Name != "2026 Q1" || cycles[1].ID != "CY1" {
+		t.Fatalf("cycles = %+v, want Q1 (CY2) before Q2 (CY1)", cycles)
+	}
+
+	renamed := cycles[1]
+	renamed.Name = "Spring"
+	renamed.EndDate = "2026-05-31"
+	renamed.ClosedAt = "2026-01-01T00:00:00Z"
+	if err := pm.UpdateCycle(renamed); err != nil {
+		t.Fatalf("UpdateCycle: %v", err)
+	}
+	cycles, _ = pm.GetCycles()
+	if got := cycles[1]; got.Name != "Spring" || got.EndDate != "2026-05-31" || !got.ClosedAt.IsZero() {
+		t.Errorf("updated cycle = %+v, want renamed, shortened and still open", got)
+	}
+
+	if err := pm.DeleteCycle("CY1"); err != nil {
+		t.Fatalf("DeleteCycle: %v", err)
+	}
+	if cycles, _ = pm.GetCycles(); len(cycles) != 1 {
+		t.Errorf("expected 1 cycle after delete, got %+v", cycles)
+	}
+}
+
+func TestUnit_CreateCycle_Validation(t *testing.T) {
+	pm, _ := newCycleTestManager(t)
+
+	tests := []struct {
+		name, cycleName, start, end, wantErr string
+	}{
+		{"empty name", " ", "2026-01-01", "2026-03-31", "name cannot be empty"},
+		{"long name", strings.Repeat("x", 101), "2026-01-01", "2026-03-31", "cannot exceed"},
+		{"bad start", "Q", "January", "2026-03-31", "invalid start date"},
+		{"missing end", "Q", "2026-01-01", "", "invalid end date"},
+		{"reversed", "Q", "2026-03-31", "2026-01-01", "after end date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pm.CreateCycle(tt.cycleName, tt.start, tt.end); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUnit_Cycles_ObjectiveAssignment(t *testing.T) {
+	pm, _ := newCycleTestManager(t)
+	objID := establishCycleObjective(t, pm, "Ship v2", "CY2")
+
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeObjective, ParentID: objID, Title: "Child", CycleID: "CY2"}); err == nil || !strings.Contains(err.Error(), "top-level") {
+		t.Errorf("expected top-level error for a child objective, got %v", err)
+	}
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeObjective, ParentID: "T", Title: "X", CycleID: "CY9"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected unknown cycle error, got %v", err)
+	}
+
+	if err := pm.DeleteCycle("CY2"); err == nil || !strings.Contains(err.Error(), objID) {
+		t.Errorf("expected delete to be refused while %s belongs to CY2, got %v", objID, err)
+	}
+
+	moved, cleared := "CY1", ""
+	if err := pm.Revise(ReviseRequest{GoalID: objID, CycleID: &moved}); err != nil {
+		t.Fatalf("Revise cycle: %v", err)
+	}
+	themes, _ := pm.GetHierarchy()
+	if got := themes[0].Objectives[0].CycleID; got != "CY1" {
+		t.Errorf("CycleID = %q, want CY1", got)
+	}
+	if err := pm.Revise(ReviseRequest{GoalID: objID, CycleID: &cleared}); err != nil {
+		t.Fatalf("Revise clear cycle: %v", err)
+	}
+	themes, _ = pm.GetHierarchy()
+	if got := themes[0].Objectives[0].CycleID; got != "" {
+		t.Errorf("CycleID = %q, want cleared", got)
+	}
+}
+
+func TestUnit_Cycles_FilteredViews(t *testing.T) {
+	pm, _ := newCycleTestManager(t)
+	q1 := establishCycleObjective(t, pm, "Q1 goal", "CY2")
+	establishCycleObjective(t, pm, "Q2 goal", "CY1")
+	establishCycleObjective(t, pm, "Someday", "")
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeObjective, ParentID: q1, Title: "Sub-goal"}); err != nil {
+		t.Fatalf("Establish child: %v", err)
+	}
+	kr, err := testCreateKeyResult(pm, q1, "Pages", 0, 10)
+	if err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	if _, err := pm.RecordCheckIn(kr.ID, 5, "", 0); err != nil {
+		t.Fatalf("RecordCheckIn: %v", err)
+	}
+
+	themes, err := pm.GetHierarchyForCycle("CY2")
+	if err != nil {
+		t.Fatalf("GetHierarchyForCycle: %v", err)
+	}
+	if len(themes) != 1 || len(themes[0].Objectives) != 1 || themes[0].Objectives[0].ID != q1 || len(themes[0].Objectives[0].Objectives) != 1 {
+		t.Fatalf("hierarchy for CY2 = %+v, want only %s with its child", themes, q1)
+	}
+
+	progress, err := pm.GetThemeProgressForCycle("CY2")
+	if err != nil {
+		t.Fatalf("GetThemeProgressForCycle: %v", err)
+	}
+	if len(progress) != 1 || progress[0].Progress != 50 || len(progress[0].Contributions) != 1 || progress[0].Contributions[0].ID != q1 {
+		t.Errorf("progress for CY2 = %+v, want 50%% from %s alone", progress, q1)
+	}
+
+	if _, err := pm.GetHierarchyForCycle("CY9"); err == nil {
+		t.Error("expected error for unknown cycle")
+	}
+}
+
+func TestUnit_Cycles_ForecastUsesCycleWindow(t *testing.T) {
+	pm, _ := newCycleTestManager(t)
+	objID := establishCycleObjective(t, pm, "Q1 goal", "CY2")
+	kr, err := testCreateKeyResult(pm, objID, "Pages", 0, 90)
+	if err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	if _, err := pm.RecordCheckIn(kr.ID, 59, "", 0); err != nil {
+		t.Fatalf("RecordCheckIn: %v", err)
+	}
+
+	forecasts, err := pm.GetProgressForecast()
+	if err != nil {
+		t.Fatalf("GetProgressForecast: %v", err)
+	}
+	// 59 of the 90 days from 2026-01-01 through 2026-03-31 have passed.
+	f := forecasts[0].KeyResults[0]
+	if f.Status == "unknown" || f.ExpectedProgress < 65.5 || f.ExpectedProgress > 65.6 {
+		t.Errorf("forecast = %+v, want it measured over the Q1 window", f)
+	}
+}
+
+func TestUnit_RolloverCycle(t *testing.T) {
+	pm, repo := newCycleTestManager(t)
+	done := establishCycleObjective(t, pm, "Launch", "CY2")
+	late := establishCycleObjective(t, pm, "Write book", "CY2")
+	other := establishCycleObjective(t, pm, "Q2 goal", "CY1")
+
+	pages, err := testCreateKeyResult(pm, late, "Pages", 0, 300)
+	if err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	if _, err := pm.RecordCheckIn(pages.ID, 120, "", 0); err != nil {
+		t.Fatalf("RecordCheckIn: %v", err)
+	}
+	chapters, err := testCreateKeyResult(pm, late, "Outline", 0, 1)
+	if err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	if _, err := pm.RecordCheckIn(chapters.ID, 1, "", 0); err != nil {
+		t.Fatalf("RecordCheckIn: %v", err)
+	}
+	repo.tx.commits = nil
+
+	result, err := pm.RolloverCycle(RolloverRequest{
+		CycleID:     "CY2",
+		NextCycleID: "CY1",
+		Closings: []ObjectiveClosing{
+			{ObjectiveID: done, ClosingStatus: "achieved"},
+			{ObjectiveID: late, ClosingStatus: "postponed", ClosingNotes: "Ran out of time"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RolloverCycle: %v", err)
+	}
+	if len(repo.tx.commits) != 1 || repo.tx.commits[0] != "Roll over cycle: 2026 Q1" {
+		t.Errorf("commits = %q, want a single rollover commit", repo.tx.commits)
+	}
+	if len(result.Closed) != 2 || len(result.CarriedOver) != 1 || result.CarriedOver[0].FromID != late {
+		t.Fatalf("result = %+v", result)
+	}
+
+	themes, _ := pm.GetHierarchy()
+	byID := map[string]Objective{}
+	for _, obj := range themes[0].Objectives {
+		byID[obj.ID] = obj
+	}
+	if obj := byID[late]; obj.Status != "completed" || obj.ClosingStatus != "postponed" || obj.ClosingNotes != "Ran out of time" || obj.ClosedAt.IsZero() {
+		t.Errorf("postponed objective = %+v, want closed as postponed", obj)
+	}
+	if obj := byID[done]; obj.ClosingStatus != "achieved" {
+		t.Errorf("achieved objective = %+v", obj)
+	}
+	if obj := byID[other]; obj.Status != "" {
+		t.Errorf("objective of another cycle was touched: %+v", obj)
+	}
+
+	copied, ok := byID[result.CarriedOver[0].ToID]
+	if !ok {
+		t.Fatalf("carried-over objective %q not found", result.CarriedOver[0].ToID)
+	}
+	if copied.Title != "Write book" || copied.CycleID != "CY1" || copied.Status != "" || copied.ClosingStatus != "" {
+		t.Errorf("copy = %+v, want an active Write book in CY1", copied)
+	}
+	// The finished outline stays behind; pages restart from 120.
+	if len(copied.KeyResults) != 1 {
+		t.Fatalf("copied key results = %+v, want only Pages", copied.KeyResults)
+	}
+	if kr := copied.KeyResults[0]; kr.ID == pages.ID || kr.StartValue != 120 || kr.CurrentValue != 120 || kr.TargetValue != 300 || len(kr.CheckIns) != 0 {
+		t.Errorf("copied key result = %+v, want a fresh 120 -> 300 baseline", kr)
+	}
+
+	cycles, _ := pm.GetCycles()
+	if cycles[0].ID != "CY2" || cycles[0].ClosedAt.IsZero() {
+		t.Errorf("cycle CY2 = %+v, want closed", cycles[0])
+	}
+	if _, err := pm.RolloverCycle(RolloverRequest{CycleID: "CY2"}); err == nil || !strings.Contains(err.Error(), "already closed") {
+		t.Errorf("expected second rollover to fail, got %v", err)
+	}
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeObjective, ParentID: "T", Title: "Late", CycleID: "CY2"}); err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Errorf("expected assignment to a closed cycle to fail, got %v", err)
+	}
+}
+
+func TestUnit_RolloverCycle_Validation(t *testing.T) {
+	pm, repo := newCycleTestManager(t)
+	a := establishCycleObjective(t, pm, "A", "CY2")
+	b := establishCycleObjective(t, pm, "B", "CY2")
+	other := establishCycleObjective(t, pm, "Q2", "CY1")
+	repo.tx.commits = nil
+
+	tests := []struct {
+		name    string
+		req     RolloverRequest
+		wantErr string
+	}{
+		{"unknown cycle", RolloverRequest{CycleID: "CY9"}, "not found"},
+		{"missing closing", RolloverRequest{CycleID: "CY2", Closings: []ObjectiveClosing{{ObjectiveID: a, ClosingStatus: "missed"}}}, "need a closing status: " + b},
+		{"invalid status", RolloverRequest{CycleID: "CY2", Closings: []ObjectiveClosing{{ObjectiveID: a, ClosingStatus: "done"}}}, "invalid closing status"},
+		{"duplicate", RolloverRequest{CycleID: "CY2", Closings: []ObjectiveClosing{{ObjectiveID: a, ClosingStatus: "missed"}, {ObjectiveID: a, ClosingStatus: "achieved"}}}, "duplicate"},
+		{"no next cycle", RolloverRequest{CycleID: "CY2", Closings: []ObjectiveClosing{{ObjectiveID: a, ClosingStatus: "postponed"}, {ObjectiveID: b, ClosingStatus: "missed"}}}, "nextCycleId is required"},
+		{"same cycle", RolloverRequest{CycleID: "CY2", NextCycleID: "CY2", Closings: []ObjectiveClosing{{ObjectiveID: a, ClosingStatus: "postponed"}, {ObjectiveID: b, ClosingStatus: "missed"}}}, "another open cycle"},
+		{"foreign objective", RolloverRequest{CycleID: "CY2", Closings: []ObjectiveClosing{{ObjectiveID: a, ClosingStatus: "missed"}, {ObjectiveID: b, ClosingStatus: "missed"}, {ObjectiveID: other, ClosingStatus: "missed"}}}, "not active objectives of cycle CY2: " + other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pm.RolloverCycle(tt.req); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if len(repo.tx.commits) != 0 {
+		t.Errorf("failed rollovers committed: %q", repo.tx.commits)
+	}
+	themes, _ := pm.GetHierarchy()
+	for _, obj := range themes[0].Objectives {
+		if obj.Status != "" {
+			t.Errorf("objective %s changed by a failed rollover: %+v", obj.ID, obj)
+		}
+	}
+}
+
+func TestUnit_CarryOverKeyResult(t *testing.T) {
+	pm, krID := newMilestoneTestManager(t)
+	if _, err := pm.SetMilestoneDone(krID, "M1", true); err != nil {
+		t.Fatalf("SetMilestoneDone: %v", err)
+	}
+	themes, _ := pm.themeAccess.GetThemes()
+
+	copied, ok := carryOverKeyResult(themes[0].Objectives[0].KeyResults[1])
+	if !ok || len(copied.Milestones) != 2 || copied.Milestones[0].ID != "M2" || copied.CurrentValue != 0 {
+		t.Errorf("milestone copy = %+v, %v; want the two open steps at 0%%", copied, ok)
+	}
+
+	decreasing := themes[0].Objectives[0].KeyResults[0]
+	decreasing.Direction, decreasing.StartValue, decreasing.CurrentValue, decreasing.TargetValue = "decrease", 80, 75, 70
+	if copied, ok := carryOverKeyResult(decreasing); !ok || copied.StartValue != 75 || copied.CurrentValue != 75 {
+		t.Errorf("decreasing copy = %+v, %v; want rebased to 75", copied, ok)
+	}
+	decreasing.CurrentValue = 69
+	if _, ok := carryOverKeyResult(decreasing); ok {
+		t.Error("expected a key result below its decreasing target to stay behind")
+	}
+}
@@ -0,0 +1,127 @@
+package managers
+
+import (
+	"fmt"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/engines/progress_engine"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// KeyResultForecast is the forecast for a key result in the Manager layer's
+// public interface. Progress values are 0-100, or -1 when Status is "unknown".
+type KeyResultForecast struct {
+	KeyResultID      string  `json:"keyResultId"`
+	Progress         float64 `json:"progress"`
+	ExpectedProgress float64 `json:"expectedProgress"`
+	ForecastValue    float64 `json:"forecastValue"`
+	ForecastProgress float64 `json:"forecastProgress"`
+	Method           string  `json:"method,omitempty"` // "linear" or "regression"
+	Status           string  `json:"status"`           // on-track, at-risk, off-track, unknown
+}
+
+// ObjectiveForecast is the aggregated forecast for an objective.
+type ObjectiveForecast struct {
+	ObjectiveID      string  `json:"objectiveId"`
+	Progress         float64 `json:"progress"`
+	ExpectedProgress float64 `json:"expectedProgress"`
+	ForecastProgress float64 `json:"forecastProgress"`
+	Status           string  `json:"status"`
+}
+
+// ThemeForecast holds the forecasts of all active goals in a theme.
+type ThemeForecast struct {
+	ThemeID    string              `json:"themeId"`
+	Objectives []ObjectiveForecast `json:"objectives"`
+	KeyResults []KeyResultForecast `json:"keyResults"`
+}
+
+// GetProgressForecast forecasts every active key result and objective as of
+// the clock's current logical date. The value history is the key results'
+// check-ins; the time window comes from the nearest objective with a start
+// and end date. Top-level objectives without their own dates take them from
+// their cycle.
+func (m *PlanningManager) GetProgressForecast() ([]ThemeForecast, error) {
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("GetProgressForecast: failed to get themes: %w", err)
+	}
+	themes = visibleThemes(themes)
+	if err := m.applyCycleWindows(themes); err != nil {
+		return nil, fmt.Errorf("GetProgressForecast: %w", err)
+	}
+
+	engineThemes := make([]progress_engine.ThemeData, len(themes))
+	for i, t := range themes {
+		engineThemes[i] = toEngineThemeData(t)
+	}
+
+	engineResult := m.progressEngine.ComputeForecasts(engineThemes, m.clock.Today().Time())
+
+	result := make([]ThemeForecast, len(engineResult))
+	for i, tf := range engineResult {
+		objectives := make([]ObjectiveForecast, len(tf.Objectives))
+		for j, of := range tf.Objectives {
+			objectives[j] = ObjectiveForecast{
+				ObjectiveID:      of.ObjectiveID,
+				Progress:         of.Progress,
+				ExpectedProgress: of.ExpectedProgress,
+				ForecastProgress: of.ForecastProgress,
+				Status:           string(of.Status),
+			}
+		}
+		keyResults := make([]KeyResultForecast, len(tf.KeyResults))
+		for j, kf := range tf.KeyResults {
+			keyResults[j] = KeyResultForecast{
+				KeyResultID:      kf.KeyResultID,
+				Progress:         kf.Progress,
+				ExpectedProgress: kf.ExpectedProgress,
+				ForecastValue:    kf.ForecastValue,
+				ForecastProgress: kf.ForecastProgress,
+				Method:           kf.Method,
+				Status:           string(kf.Status),
+			}
+		}
+		result[i] = ThemeForecast{
+			ThemeID:    tf.ThemeID,
+			Objectives: objectives,
+			KeyResults: keyResults,
+		}
+	}
+
+	return result, nil
+}
+
+// reviseObjectiveWindow applies optional start/end date updates to obj. An
+// empty string clears the date. When both dates are set the start must not
+// be after the end.
+func reviseObjectiveWindow(obj *access.Objective, startDate, endDate *string) error {
+	start, end := obj.StartDate, obj.EndDate
+	if startDate != nil {
+		d, err := parseOptionalDate(*startDate)
+		if err != nil {
+			return fmt.Errorf("invalid start date: %w", err)
+		}
+		start = d
+	}
+	if endDate != nil {
+		d, err := parseOptionalDate(*endDate)
+		if err != nil {
+			return fmt.Errorf("invalid end date: %w", err)
+		}
+		end = d
+	}
+	if !start.IsZero() && !end.IsZero() && start > end {
+		return fmt.Errorf("start date %s is after end date %s", start, end)
+	}
+	obj.StartDate, obj.EndDate = start, end
+	return nil
+}
+
+// parseOptionalDate parses a YYYY-MM-DD string; "" yields the zero date.
+func parseOptionalDate(s string) (utilities.CalendarDate, error) {
+	if s == "" {
+		return "", nil
+	}
+	return utilities.ParseCalendarDate(s)
+}
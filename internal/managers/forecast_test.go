@@ -0,0 +1,65 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnit_GetProgressForecast(t *testing.T) {
+	pm, _, krID := newCheckInTestManager(t)
+	// The frozen clock sits on 2026-03-01, half way through this window.
+	start, end := "2026-02-14", "2026-03-15"
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O1", StartDate: &start, EndDate: &end}); err != nil {
+		t.Fatalf("Revise: %v", err)
+	}
+	if _, err := pm.RecordCheckIn(krID, 14, "", 0); err != nil {
+		t.Fatalf("RecordCheckIn: %v", err)
+	}
+
+	forecasts, err := pm.GetProgressForecast()
+	if err != nil {
+		t.Fatalf("GetProgressForecast: %v", err)
+	}
+	if len(forecasts) != 1 || len(forecasts[0].KeyResults) != 1 || len(forecasts[0].Objectives) != 1 {
+		t.Fatalf("unexpected forecast shape: %+v", forecasts)
+	}
+	kr := forecasts[0].KeyResults[0]
+	// 10 -> 14 of 10 -> 20 is 40% against 50% expected; the pace reaches 18 (80%).
+	if kr.KeyResultID != krID || kr.Progress != 40 || kr.ExpectedProgress != 50 || kr.ForecastValue != 18 {
+		t.Errorf("key result forecast = %+v", kr)
+	}
+	if kr.Status != "at-risk" || forecasts[0].Objectives[0].Status != "at-risk" {
+		t.Errorf("statuses = %s/%s, want at-risk", kr.Status, forecasts[0].Objectives[0].Status)
+	}
+}
+
+func TestUnit_Revise_ObjectiveWindow(t *testing.T) {
+	pm, _, _ := newCheckInTestManager(t)
+	start, end, bad, empty := "2026-04-01", "2026-03-01", "April", ""
+
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O1", StartDate: &start, EndDate: &end}); err == nil || !strings.Contains(err.Error(), "after end date") {
+		t.Errorf("expected start-after-end error, got %v", err)
+	}
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O1", StartDate: &bad}); err == nil || !strings.Contains(err.Error(), "invalid start date") {
+		t.Errorf("expected invalid date error, got %v", err)
+	}
+
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O1", EndDate: &start}); err != nil {
+		t.Fatalf("Revise end: %v", err)
+	}
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O1", StartDate: &end}); err != nil {
+		t.Fatalf("Revise start: %v", err)
+	}
+	themes, _ := pm.GetHierarchy()
+	if obj := themes[0].Objectives[0]; obj.StartDate != "2026-03-01" || obj.EndDate != "2026-04-01" {
+		t.Errorf("window = %s..%s, want 2026-03-01..2026-04-01", obj.StartDate, obj.EndDate)
+	}
+
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O1", StartDate: &empty}); err != nil {
+		t.Fatalf("Revise clear: %v", err)
+	}
+	themes, _ = pm.GetHierarchy()
+	if obj := themes[0].Objectives[0]; obj.StartDate != "" || obj.EndDate != "2026-04-01" {
+		t.Errorf("after clearing start, window = %q..%q", obj.StartDate, obj.EndDate)
+	}
+}
@@ -0,0 +1,439 @@
+package managers
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// IntegrityIssueKind classifies an IntegrityIssue.
+type IntegrityIssueKind string
+
+const (
+	// IssueDayFocusTheme: DayFocus.ThemeIDs names a theme that no longer exists.
+	IssueDayFocusTheme IntegrityIssueKind = "day-focus-theme"
+	// IssueDayFocusOKR: DayFocus.OkrIDs names an objective or key result that no longer exists.
+	IssueDayFocusOKR IntegrityIssueKind = "day-focus-okr"
+	// IssueRoutineCheck: DayFocus.RoutineChecks names a routine that no longer exists.
+	IssueRoutineCheck IntegrityIssueKind = "routine-check"
+	// IssueTaskOrder: task_order.json lists a missing task, lists a task in
+	// the wrong zone, or omits an existing task.
+	IssueTaskOrder IntegrityIssueKind = "task-order"
+	// IssueArchivedOrder: archived_order.json lists a task that is not
+	// archived, lists a task twice, or omits an archived task.
+	IssueArchivedOrder IntegrityIssueKind = "archived-order"
+	// IssueGoalParent: an objective or key result carries a stale ParentID.
+	IssueGoalParent IntegrityIssueKind = "goal-parent"
+	// IssueTaskPriority: a task file has a priority outside the Eisenhower set.
+	IssueTaskPriority IntegrityIssueKind = "task-priority"
+	// IssueTaskTheme: a task links to a theme that no longer exists.
+	IssueTaskTheme IntegrityIssueKind = "task-theme"
+	// IssueDuplicateGoalID: the same objective or key result ID appears more than once.
+	IssueDuplicateGoalID IntegrityIssueKind = "duplicate-goal-id"
+)
+
+// IntegrityIssue is a single consistency problem found by CheckIntegrity.
+// Subject identifies the entity carrying the bad data (a date, task ID or
+// goal ID); Reference is the offending value.
+type IntegrityIssue struct {
+	Kind       IntegrityIssueKind `json:"kind"`
+	Subject    string             `json:"subject"`
+	Reference  string             `json:"reference,omitempty"`
+	Message    string             `json:"message"`
+	Repairable bool               `json:"repairable"`
+	Repaired   bool               `json:"repaired"`
+}
+
+// IntegrityReport is the structured result of CheckIntegrity. Repaired counts
+// issues fixed by this run; Manual counts issues that need user action.
+type IntegrityReport struct {
+	Issues   []IntegrityIssue `json:"issues"`
+	Repaired int              `json:"repaired"`
+	Manual   int              `json:"manual"`
+}
+
+// CheckIntegrity scans themes, routines, calendar entries, tasks and the task
+// order files for references that have drifted out of sync.
+//
+// Dangling calendar references, stale goal ParentIDs and task_order.json or
+// archived_order.json drift are safe to repair: the fix only drops references to entities that
+// no longer exist or re-derives data from the authoritative source. With
+// repair set, all such fixes are written in a single git commit. Invalid
+// priorities, tasks pointing at deleted themes and duplicate goal IDs need a
+// human decision and are only reported.
+//
+// The routine completion index is rebuilt from the calendar files first, so
+// edits made to them outside the app are picked up.
+func (m *PlanningManager) CheckIntegrity(repair bool) (*IntegrityReport, error) {
+	if err := m.calendarAccess.RebuildRoutineCompletionIndex(); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	routines, err := m.routineAccess.GetRoutines()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	report := &IntegrityReport{Issues: []IntegrityIssue{}}
+
+	themeIDs := make(map[string]bool, len(themes))
+	goalIDs := make(map[string]int)
+	var themesToRewrite []access.LifeTheme
+	for _, theme := range themes {
+		themeIDs[theme.ID] = true
+		before := len(report.Issues)
+		checkGoalTree(report, theme.ID, theme.Objectives, goalIDs)
+		if len(report.Issues) > before {
+			themesToRewrite = append(themesToRewrite, theme)
+		}
+	}
+	dupIDs := make([]string, 0)
+	for id, n := range goalIDs {
+		if id != "" && n > 1 {
+			dupIDs = append(dupIDs, id)
+		}
+	}
+	sort.Strings(dupIDs)
+	for _, id := range dupIDs {
+		report.Issues = append(report.Issues, IntegrityIssue{
+			Kind:    IssueDuplicateGoalID,
+			Subject: id,
+			Message: fmt.Sprintf("goal ID %s is used %d times", id, goalIDs[id]),
+		})
+	}
+
+	routineIDs := make(map[string]bool, len(routines))
+	for _, r := range routines {
+		routineIDs[r.ID] = true
+	}
+
+	daysToRewrite, err := m.checkCalendarRefs(report, themeIDs, goalIDs, routineIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.checkTasks(report, themeIDs); err != nil {
+		return nil, err
+	}
+
+	repairedOrder, err := m.checkTaskOrder(report)
+	if err != nil {
+		return nil, err
+	}
+	repairedArchived, err := m.checkArchivedOrder(report)
+	if err != nil {
+		return nil, err
+	}
+
+	repairable := 0
+	for _, issue := range report.Issues {
+		if issue.Repairable {
+			repairable++
+		}
+	}
+
+	if repair && repairable > 0 {
+		if err := utilities.RunTransaction(m.repo, "Repair data integrity", func() error {
+			for _, theme := range themesToRewrite {
+				if err := m.themeAccess.WriteTheme(theme); err != nil {
+					return fmt.Errorf("rewrite theme %s: %w", theme.ID, err)
+				}
+			}
+			for _, day := range daysToRewrite {
+				if err := m.calendarAccess.WriteDayFocus(day); err != nil {
+					return fmt.Errorf("rewrite day %s: %w", day.Date, err)
+				}
+			}
+			if repairedOrder != nil {
+				if err := m.taskAccess.WriteTaskOrder(repairedOrder); err != nil {
+					return fmt.Errorf("rewrite task order: %w", err)
+				}
+			}
+			if repairedArchived != nil {
+				if err := m.taskAccess.WriteArchivedOrder(repairedArchived); err != nil {
+					return fmt.Errorf("rewrite archived order: %w", err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to repair data integrity: %w", err)
+		}
+		for i := range report.Issues {
+			if report.Issues[i].Repairable {
+				report.Issues[i].Repaired = true
+			}
+		}
+		report.Repaired = repairable
+		slog.Info("CheckIntegrity: repaired issues", "count", repairable)
+	}
+	report.Manual = len(report.Issues) - repairable
+
+	return report, nil
+}
+
+// checkGoalTree records stale ParentIDs under parentID and counts every goal
+// ID into seen. ParentID drift is repairable because ThemeAccess re-derives
+// ParentIDs from the tree structure on every write.
+func checkGoalTree(report *IntegrityReport, parentID string, objectives []access.Objective, seen map[string]int) {
+	for _, obj := range objectives {
+		seen[obj.ID]++
+		if obj.ParentID != parentID {
+			report.Issues = append(report.Issues, IntegrityIssue{
+				Kind:       IssueGoalParent,
+				Subject:    obj.ID,
+				Reference:  obj.ParentID,
+				Message:    fmt.Sprintf("objective %s has parentId %q but is nested under %s", obj.ID, obj.ParentID, parentID),
+				Repairable: true,
+			})
+		}
+		for _, kr := range obj.KeyResults {
+			seen[kr.ID]++
+			if kr.ParentID != obj.ID {
+				report.Issues = append(report.Issues, IntegrityIssue{
+					Kind:       IssueGoalParent,
+					Subject:    kr.ID,
+					Reference:  kr.ParentID,
+					Message:    fmt.Sprintf("key result %s has parentId %q but belongs to %s", kr.ID, kr.ParentID, obj.ID),
+					Repairable: true,
+				})
+			}
+		}
+		checkGoalTree(report, obj.ID, obj.Objectives, seen)
+	}
+}
+
+// checkCalendarRefs records dangling theme, OKR and routine references in
+// every calendar year and returns the cleaned copies of the affected days.
+func (m *PlanningManager) checkCalendarRefs(report *IntegrityReport, themeIDs map[string]bool, goalIDs map[string]int, routineIDs map[string]bool) ([]access.DayFocus, error) {
+	years, err := m.calendarAccess.GetFocusYears()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	var cleaned []access.DayFocus
+	for _, year := range years {
+		days, err := m.calendarAccess.GetYearFocus(year)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		for _, day := range days {
+			date := day.Date.String()
+			changed := false
+			keep := func(ids []string, exists func(string) bool, kind IntegrityIssueKind, what string) []string {
+				var out []string
+				for _, id := range ids {
+					if exists(id) {
+						out = append(out, id)
+						continue
+					}
+					changed = true
+					report.Issues = append(report.Issues, IntegrityIssue{
+						Kind:       kind,
+						Subject:    date,
+						Reference:  id,
+						Message:    fmt.Sprintf("%s references missing %s %s", date, what, id),
+						Repairable: true,
+					})
+				}
+				return out
+			}
+			day.ThemeIDs = keep(day.ThemeIDs, func(id string) bool { return themeIDs[id] }, IssueDayFocusTheme, "theme")
+			day.OkrIDs = keep(day.OkrIDs, func(id string) bool { return goalIDs[id] > 0 }, IssueDayFocusOKR, "objective or key result")
+			day.RoutineChecks = keep(day.RoutineChecks, func(id string) bool { return routineIDs[id] }, IssueRoutineCheck, "routine")
+			day.RoutineCheckDetails = keepCheckedDetails(day.RoutineCheckDetails, day.RoutineChecks)
+			if changed {
+				cleaned = append(cleaned, day)
+			}
+		}
+	}
+	return cleaned, nil
+}
+
+// checkTasks records tasks with invalid priorities or missing themes. Both
+// need a human decision, so neither is repairable. An empty priority is
+// not flagged; only values outside the Eisenhower set are.
+func (m *PlanningManager) checkTasks(report *IntegrityReport, themeIDs map[string]bool) error {
+	config, err := m.getAccessBoardConfig()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	statuses := make([]string, 0, len(config.ColumnDefinitions)+1)
+	for _, col := range config.ColumnDefinitions {
+		statuses = append(statuses, col.Name)
+	}
+	statuses = append(statuses, string(access.TaskStatusArchived))
+
+	for _, status := range statuses {
+		tasks, err := m.taskAccess.GetTasksByStatus(status)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		for _, t := range tasks {
+			if t.Priority != "" && !IsValidPriority(t.Priority) {
+				report.Issues = append(report.Issues, IntegrityIssue{
+					Kind:      IssueTaskPriority,
+					Subject:   t.ID,
+					Reference: t.Priority,
+					Message:   fmt.Sprintf("task %s has invalid priority %q", t.ID, t.Priority),
+				})
+			}
+			if t.ThemeID != "" && !themeIDs[t.ThemeID] {
+				report.Issues = append(report.Issues, IntegrityIssue{
+					Kind:      IssueTaskTheme,
+					Subject:   t.ID,
+					Reference: t.ThemeID,
+					Message:   fmt.Sprintf("task %s references missing theme %s", t.ID, t.ThemeID),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// checkTaskOrder compares task_order.json against the zones the tasks
+// actually occupy. It returns the reconciled order map when drift was found,
+// or nil when the stored map is already consistent (or absent).
+func (m *PlanningManager) checkTaskOrder(report *IntegrityReport) (map[string][]string, error) {
+	actualZone, err := m.taskZones()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	orderMap, err := m.taskAccess.LoadTaskOrder()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if len(orderMap) == 0 {
+		return nil, nil
+	}
+
+	zones := make([]string, 0, len(orderMap))
+	for zone := range orderMap {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	listed := make(map[string]bool)
+	deduped := make(map[string][]string, len(orderMap))
+	hasDuplicates := false
+	for _, zone := range zones {
+		deduped[zone] = make([]string, 0, len(orderMap[zone]))
+		for _, id := range orderMap[zone] {
+			var msg string
+			switch actual, ok := actualZone[id]; {
+			case !ok:
+				msg = fmt.Sprintf("task_order.json lists missing task %s in %s", id, zone)
+			case listed[id]:
+				msg = fmt.Sprintf("task_order.json lists task %s more than once", id)
+			case actual != zone:
+				msg = fmt.Sprintf("task_order.json lists task %s in %s but it belongs in %s", id, zone, actual)
+			}
+			if listed[id] {
+				hasDuplicates = true
+			} else {
+				deduped[zone] = append(deduped[zone], id)
+			}
+			listed[id] = true
+			if msg != "" {
+				report.Issues = append(report.Issues, IntegrityIssue{
+					Kind: IssueTaskOrder, Subject: zone, Reference: id, Message: msg, Repairable: true,
+				})
+			}
+		}
+	}
+	unlisted := make([]string, 0)
+	for id := range actualZone {
+		if !listed[id] {
+			unlisted = append(unlisted, id)
+		}
+	}
+	sort.Strings(unlisted)
+	for _, id := range unlisted {
+		report.Issues = append(report.Issues, IntegrityIssue{
+			Kind:       IssueTaskOrder,
+			Subject:    actualZone[id],
+			Reference:  id,
+			Message:    fmt.Sprintf("task %s is missing from task_order.json", id),
+			Repairable: true,
+		})
+	}
+
+	reconciled, changed := m.ruleEngine.ReconcileTaskOrder(deduped, actualZone)
+	if !changed && !hasDuplicates {
+		return nil, nil
+	}
+	return reconciled, nil
+}
+
+// checkArchivedOrder compares archived_order.json against the archived
+// tasks. It returns the reconciled order when drift was found, or nil when
+// the stored order is already consistent (or absent). Entries that are not
+// archived tasks and repeats are dropped; archived tasks the file omits are
+// appended in ID order.
+func (m *PlanningManager) checkArchivedOrder(report *IntegrityReport) ([]string, error) {
+	order, err := m.taskAccess.LoadArchivedOrder()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if len(order) == 0 {
+		return nil, nil
+	}
+	tasks, err := m.taskAccess.GetTasksByStatus(string(access.TaskStatusArchived))
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	archived := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		archived[t.ID] = true
+	}
+
+	listed := make(map[string]bool, len(order))
+	reconciled := make([]string, 0, len(tasks))
+	changed := false
+	for _, id := range order {
+		var msg string
+		switch {
+		case !archived[id]:
+			msg = fmt.Sprintf("archived_order.json lists %s, which is not an archived task", id)
+		case listed[id]:
+			msg = fmt.Sprintf("archived_order.json lists task %s more than once", id)
+		}
+		if msg != "" {
+			report.Issues = append(report.Issues, IntegrityIssue{
+				Kind: IssueArchivedOrder, Subject: string(access.TaskStatusArchived), Reference: id, Message: msg, Repairable: true,
+			})
+			changed = true
+			continue
+		}
+		listed[id] = true
+		reconciled = append(reconciled, id)
+	}
+	unlisted := make([]string, 0)
+	for id := range archived {
+		if !listed[id] {
+			unlisted = append(unlisted, id)
+		}
+	}
+	sort.Strings(unlisted)
+	for _, id := range unlisted {
+		report.Issues = append(report.Issues, IntegrityIssue{
+			Kind:       IssueArchivedOrder,
+			Subject:    string(access.TaskStatusArchived),
+			Reference:  id,
+			Message:    fmt.Sprintf("task %s is missing from archived_order.json", id),
+			Repairable: true,
+		})
+		reconciled = append(reconciled, id)
+		changed = true
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return reconciled, nil
+}
@@ -0,0 +1,178 @@
+package managers
+
+import (
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// newIntegrityFixture builds a PlanningManager whose stores contain one of
+// every kind of drift CheckIntegrity knows about.
+func newIntegrityFixture(t *testing.T) (*PlanningManager, *mockCalendarAccess, *mockTaskAccess, *stubRepo) {
+	t.Helper()
+
+	ta := newMockThemeAccess()
+	ta.themes[0].Objectives = []access.Objective{
+		{
+			ID: "T-O1", ParentID: "STALE", Title: "Objective",
+			KeyResults: []access.KeyResult{{ID: "T-KR1", ParentID: "T-O1", Description: "KR"}},
+		},
+	}
+
+	ra := newMockRoutineAccess()
+	ra.routines = []access.Routine{{ID: "R1", Description: "Walk"}}
+
+	ca := newMockCalendarAccess()
+	ca.days["2025-03-01"] = access.DayFocus{
+		Date:          utilities.MustParseCalendarDate("2025-03-01"),
+		ThemeIDs:      []string{"T", "GONE"},
+		OkrIDs:        []string{"T-O1", "T-O9"},
+		RoutineChecks: []string{"R1", "R9"},
+	}
+	ca.days["2026-01-01"] = access.DayFocus{
+		Date:     utilities.MustParseCalendarDate("2026-01-01"),
+		ThemeIDs: []string{"T"},
+	}
+
+	ka := newMockTaskAccess()
+	ka.tasks["todo"] = []access.Task{
+		{ID: "T-T1", ThemeID: "T", Priority: string(access.PriorityImportantUrgent)},
+		{ID: "T-T2", ThemeID: "GONE", Priority: "someday"},
+	}
+	ka.tasks["archived"] = []access.Task{
+		{ID: "T-T3", ThemeID: "T", Priority: string(access.PriorityImportantUrgent)},
+		{ID: "T-T4", ThemeID: "T", Priority: string(access.PriorityImportantUrgent)},
+	}
+
+	repo := newStubRepo()
+	pm := newTestManager(t, testManagerDeps{themes: ta, tasks: ka, calendar: ca, routines: ra, repo: repo})
+	// Inject order drift after construction; NewPlanningManager would
+	// otherwise reconcile it on startup.
+	ka.taskOrder = map[string][]string{
+		string(access.PriorityImportantUrgent): {"T-T1", "T-T1", "GHOST"},
+		string(access.TaskStatusArchived):      {"T-T3", "T-T4"},
+	}
+	ka.archivedOrder = []string{"T-T3", "T-T1", "T-T3"}
+	return pm, ca, ka, repo
+}
+
+func countIssues(report *IntegrityReport, kind IntegrityIssueKind) int {
+	n := 0
+	for _, issue := range report.Issues {
+		if issue.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func TestUnit_CheckIntegrity_ReportsAllKinds(t *testing.T) {
+	pm, _, _, repo := newIntegrityFixture(t)
+
+	report, err := pm.CheckIntegrity(false)
+	if err != nil {
+		t.Fatalf("CheckIntegrity: %v", err)
+	}
+
+	want := map[IntegrityIssueKind]int{
+		IssueDayFocusTheme: 1,
+		IssueDayFocusOKR:   1,
+		IssueRoutineCheck:  1,
+		IssueGoalParent:    1,
+		IssueTaskPriority:  1,
+		IssueTaskTheme:     1,
+	}
+	for kind, n := range want {
+		if got := countIssues(report, kind); got != n {
+			t.Errorf("%s issues = %d, want %d", kind, got, n)
+		}
+	}
+	// Duplicate T-T1, missing GHOST, unlisted T-T2.
+	if got := countIssues(report, IssueTaskOrder); got != 3 {
+		t.Errorf("task-order issues = %d, want 3", got)
+	}
+	// T-T1 not archived, duplicate T-T3, unlisted T-T4.
+	if got := countIssues(report, IssueArchivedOrder); got != 3 {
+		t.Errorf("archived-order issues = %d, want 3", got)
+	}
+	if report.Manual != 2 {
+		t.Errorf("Manual = %d, want 2", report.Manual)
+	}
+	if report.Repaired != 0 {
+		t.Errorf("Repaired = %d, want 0 in check-only mode", report.Repaired)
+	}
+	if repo.commitCount() != 0 {
+		t.Errorf("check-only mode committed %d times", repo.commitCount())
+	}
+}
+
+func TestUnit_CheckIntegrity_RepairInSingleCommit(t *testing.T) {
+	pm, ca, ka, repo := newIntegrityFixture(t)
+
+	report, err := pm.CheckIntegrity(true)
+	if err != nil {
+		t.Fatalf("CheckIntegrity(repair): %v", err)
+	}
+	if repo.commitCount() != 1 {
+		t.Fatalf("expected exactly 1 commit, got %d", repo.commitCount())
+	}
+	if report.Repaired != len(report.Issues)-report.Manual {
+		t.Errorf("Repaired = %d, want %d", report.Repaired, len(report.Issues)-report.Manual)
+	}
+	for _, issue := range report.Issues {
+		if issue.Repaired != issue.Repairable {
+			t.Errorf("issue %+v: Repaired should equal Repairable", issue)
+		}
+	}
+
+	day := ca.days["2025-03-01"]
+	if len(day.ThemeIDs) != 1 || day.ThemeIDs[0] != "T" {
+		t.Errorf("ThemeIDs = %v, want [T]", day.ThemeIDs)
+	}
+	if len(day.OkrIDs) != 1 || day.OkrIDs[0] != "T-O1" {
+		t.Errorf("OkrIDs = %v, want [T-O1]", day.OkrIDs)
+	}
+	if len(day.RoutineChecks) != 1 || day.RoutineChecks[0] != "R1" {
+		t.Errorf("RoutineChecks = %v, want [R1]", day.RoutineChecks)
+	}
+
+	order, _ := ka.LoadTaskOrder()
+	seen := map[string]int{}
+	for _, ids := range order {
+		for _, id := range ids {
+			seen[id]++
+		}
+	}
+	if seen["GHOST"] != 0 || seen["T-T1"] != 1 || seen["T-T2"] != 1 {
+		t.Errorf("repaired order = %v", order)
+	}
+	if archived, _ := ka.LoadArchivedOrder(); len(archived) != 2 || archived[0] != "T-T3" || archived[1] != "T-T4" {
+		t.Errorf("repaired archived order = %v, want [T-T3 T-T4]", archived)
+	}
+
+	// A second check finds only the manual issues.
+	again, err := pm.CheckIntegrity(false)
+	if err != nil {
+		t.Fatalf("second CheckIntegrity: %v", err)
+	}
+	if len(again.Issues) != again.Manual || again.Manual != 2 {
+		t.Errorf("after repair: %d issues, %d manual; want only the 2 manual issues", len(again.Issues), again.Manual)
+	}
+}
+
+func TestUnit_CheckIntegrity_CleanDataNoCommit(t *testing.T) {
+	pm, _, _ := newMockManager()
+	repo := pm.repo.(*stubRepo)
+
+	report, err := pm.CheckIntegrity(true)
+	if err != nil {
+		t.Fatalf("CheckIntegrity: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("expected no issues, got %+v", report.Issues)
+	}
+	if repo.commitCount() != 0 {
+		t.Errorf("expected no commit on clean data, got %d", repo.commitCount())
+	}
+}
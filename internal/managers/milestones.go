@@ -0,0 +1,208 @@
+package managers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/engines/progress_engine"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// maxMilestoneNameLength bounds the name of a single milestone.
+const maxMilestoneNameLength = 200
+
+// Milestone is one named step of a milestone key result in the Manager
+// layer's public interface. An empty ID marks a new milestone.
+type Milestone struct {
+	ID          string                 `json:"id,omitempty"`
+	Name        string                 `json:"name"`
+	Done        bool                   `json:"done,omitempty"`
+	DueDate     utilities.CalendarDate `json:"dueDate,omitempty"`
+	CompletedAt utilities.Timestamp    `json:"completedAt,omitempty"`
+	Weight      float64                `json:"weight,omitempty"`
+}
+
+// SetMilestoneDone marks a milestone of a milestone key result as done or not
+// done. Completing a milestone stamps it with the current time; reopening it
+// clears the stamp. The key result's current value is re-derived.
+func (m *PlanningManager) SetMilestoneDone(keyResultId, milestoneId string, done bool) (*Milestone, error) {
+	if milestoneId == "" {
+		return nil, fmt.Errorf("milestoneId cannot be empty")
+	}
+
+	var updated access.Milestone
+	err := m.updateKeyResult(keyResultId, func(kr *access.KeyResult) error {
+		for i := range kr.Milestones {
+			ms := &kr.Milestones[i]
+			if ms.ID != milestoneId {
+				continue
+			}
+			if ms.Done != done {
+				ms.Done = done
+				ms.CompletedAt = ""
+				if done {
+					ms.CompletedAt = m.clock.Now()
+				}
+			}
+			updated = *ms
+			deriveCurrentValue(kr)
+			return nil
+		}
+		return fmt.Errorf("milestone %s not found on key result %s", milestoneId, keyResultId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toManagerMilestones([]access.Milestone{updated})[0]
+	return &result, nil
+}
+
+// applyMilestones replaces the milestones of kr with the given ordered list.
+// Entries with an ID update the existing milestone of that ID; entries
+// without one are added under a fresh ID. A milestone newly marked done
+// without a completion time is stamped with now, and reopened milestones
+// lose their stamp. Validation of names and weights is left to
+// normalizeKeyResult.
+func applyMilestones(kr *access.KeyResult, milestones []Milestone, now utilities.Timestamp) error {
+	existing := make(map[string]access.Milestone, len(kr.Milestones))
+	for _, ms := range kr.Milestones {
+		existing[ms.ID] = ms
+	}
+
+	nextID := nextMilestoneNumber(kr.Milestones)
+	seen := make(map[string]bool, len(milestones))
+	result := make([]access.Milestone, 0, len(milestones))
+	for _, in := range milestones {
+		ms := access.Milestone{
+			ID:          in.ID,
+			Name:        strings.TrimSpace(in.Name),
+			Done:        in.Done,
+			DueDate:     in.DueDate,
+			CompletedAt: in.CompletedAt,
+			Weight:      in.Weight,
+		}
+		if ms.ID == "" {
+			ms.ID = fmt.Sprintf("M%d", nextID)
+			nextID++
+		} else if _, ok := existing[ms.ID]; !ok {
+			return fmt.Errorf("milestone %s not found on key result %s", ms.ID, kr.ID)
+		}
+		if seen[ms.ID] {
+			return fmt.Errorf("duplicate milestone ID: %s", ms.ID)
+		}
+		seen[ms.ID] = true
+
+		switch {
+		case !ms.Done:
+			ms.CompletedAt = ""
+		case ms.CompletedAt.IsZero() && existing[ms.ID].Done:
+			ms.CompletedAt = existing[ms.ID].CompletedAt
+		case ms.CompletedAt.IsZero():
+			ms.CompletedAt = now
+		}
+		result = append(result, ms)
+	}
+
+	if len(result) == 0 {
+		result = nil
+	}
+	kr.Milestones = result
+	return nil
+}
+
+// validateMilestones checks the milestones of a milestone key result: there
+// must be at least one, each needs a name, and weights are either absent on
+// all of them or positive on all of them.
+func validateMilestones(milestones []access.Milestone) error {
+	if len(milestones) == 0 {
+		return fmt.Errorf("milestone key results need at least one milestone")
+	}
+	weighted := 0
+	for _, ms := range milestones {
+		if ms.Name == "" {
+			return fmt.Errorf("milestone name cannot be empty")
+		}
+		if len(ms.Name) > maxMilestoneNameLength {
+			return fmt.Errorf("milestone name cannot exceed %d characters", maxMilestoneNameLength)
+		}
+		if ms.Weight < 0 || math.IsNaN(ms.Weight) || math.IsInf(ms.Weight, 0) {
+			return fmt.Errorf("milestone %q has an invalid weight", ms.Name)
+		}
+		if ms.Weight > 0 {
+			weighted++
+		}
+	}
+	if weighted > 0 && weighted < len(milestones) {
+		return fmt.Errorf("either all milestones or none must have a weight")
+	}
+	return nil
+}
+
+// milestoneCompletion returns the share of milestones done as a percentage.
+// Unweighted milestones count equally.
+func milestoneCompletion(milestones []access.Milestone) float64 {
+	var total, done float64
+	for _, ms := range milestones {
+		w := milestoneWeight(ms)
+		total += w
+		if ms.Done {
+			done += w
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return done / total * 100
+}
+
+// milestoneHistory replays the completion times of a milestone key result as
+// a value history for forecasting: each completion raises the value by the
+// milestone's share.
+func milestoneHistory(milestones []access.Milestone) []progress_engine.ValuePoint {
+	var total float64
+	var completed []access.Milestone
+	for _, ms := range milestones {
+		total += milestoneWeight(ms)
+		if ms.Done && !ms.CompletedAt.IsZero() {
+			completed = append(completed, ms)
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.SliceStable(completed, func(i, j int) bool {
+		return completed[i].CompletedAt.Time().Before(completed[j].CompletedAt.Time())
+	})
+
+	history := make([]progress_engine.ValuePoint, len(completed))
+	var done float64
+	for i, ms := range completed {
+		done += milestoneWeight(ms)
+		history[i] = progress_engine.ValuePoint{At: ms.CompletedAt.Time(), Value: done / total * 100}
+	}
+	return history
+}
+
+// milestoneWeight returns the weight of ms, counting unweighted milestones as 1.
+func milestoneWeight(ms access.Milestone) float64 {
+	if ms.Weight > 0 {
+		return ms.Weight
+	}
+	return 1
+}
+
+// nextMilestoneNumber returns the number of the next unused "M<n>" ID.
+func nextMilestoneNumber(milestones []access.Milestone) int {
+	maxNum := 0
+	for _, ms := range milestones {
+		if n, err := strconv.Atoi(strings.TrimPrefix(ms.ID, "M")); err == nil && n > maxNum {
+			maxNum = n
+		}
+	}
+	return maxNum + 1
+}
@@ -0,0 +1,207 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+)
+
+// newMilestoneTestManager extends newCheckInTestManager with a milestone key
+// result "draft → review → publish" under T-O1 and returns its ID.
+func newMilestoneTestManager(t *testing.T, weights ...float64) (*PlanningManager, string) {
+	t.Helper()
+	pm, _, _ := newCheckInTestManager(t)
+	milestones := []Milestone{{Name: "Draft"}, {Name: " Review "}, {Name: "Publish", DueDate: "2026-04-01"}}
+	for i, w := range weights {
+		milestones[i].Weight = w
+	}
+	res, err := pm.Establish(EstablishRequest{
+		GoalType:      GoalTypeKeyResult,
+		ParentID:      "T-O1",
+		Description:   "Publish the paper",
+		KeyResultType: "milestone",
+		Milestones:    milestones,
+	})
+	if err != nil {
+		t.Fatalf("Establish milestone key result: %v", err)
+	}
+	return pm, res.KeyResult.ID
+}
+
+func TestUnit_Establish_MilestoneKeyResult(t *testing.T) {
+	pm, krID := newMilestoneTestManager(t)
+
+	themes, _ := pm.GetHierarchy()
+	kr := themes[0].Objectives[0].KeyResults[1]
+	if kr.ID != krID || kr.Type != "milestone" || kr.TargetValue != 100 || kr.CurrentValue != 0 || kr.Unit != "%" {
+		t.Errorf("key result = %+v, want milestone 0 of 100%%", kr)
+	}
+	if len(kr.Milestones) != 3 {
+		t.Fatalf("expected 3 milestones, got %+v", kr.Milestones)
+	}
+	for i, want := range []Milestone{{ID: "M1", Name: "Draft"}, {ID: "M2", Name: "Review"}, {ID: "M3", Name: "Publish"}} {
+		if ms := kr.Milestones[i]; ms.ID != want.ID || ms.Name != want.Name || ms.Done {
+			t.Errorf("milestone %d = %+v, want open %s %s", i, ms, want.ID, want.Name)
+		}
+	}
+	if kr.Milestones[2].DueDate != "2026-04-01" {
+		t.Errorf("due date = %q, want 2026-04-01", kr.Milestones[2].DueDate)
+	}
+}
+
+func TestUnit_Establish_MilestoneValidation(t *testing.T) {
+	pm, _, _ := newCheckInTestManager(t)
+	establish := func(milestones []Milestone) error {
+		_, err := pm.Establish(EstablishRequest{GoalType: GoalTypeKeyResult, ParentID: "T-O1", Description: "KR", KeyResultType: "milestone", Milestones: milestones})
+		return err
+	}
+
+	tests := []struct {
+		name       string
+		milestones []Milestone
+		wantErr    string
+	}{
+		{"no milestones", nil, "at least one milestone"},
+		{"blank name", []Milestone{{Name: "  "}}, "name cannot be empty"},
+		{"partial weights", []Milestone{{Name: "A", Weight: 2}, {Name: "B"}}, "all milestones or none"},
+		{"negative weight", []Milestone{{Name: "A", Weight: -1}}, "invalid weight"},
+		{"unknown ID", []Milestone{{ID: "M9", Name: "A"}}, "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := establish(tt.milestones); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	_, err := pm.Establish(EstablishRequest{GoalType: GoalTypeKeyResult, ParentID: "T-O1", Description: "KR", Milestones: []Milestone{{Name: "A"}}})
+	if err == nil || !strings.Contains(err.Error(), "only milestone key results") {
+		t.Errorf("expected error for milestones on a metric key result, got %v", err)
+	}
+}
+
+func TestUnit_SetMilestoneDone(t *testing.T) {
+	pm, krID := newMilestoneTestManager(t)
+
+	ms, err := pm.SetMilestoneDone(krID, "M1", true)
+	if err != nil {
+		t.Fatalf("SetMilestoneDone: %v", err)
+	}
+	if !ms.Done || ms.CompletedAt != "2026-03-01T09:00:00Z" {
+		t.Errorf("milestone = %+v, want done at the clock time", ms)
+	}
+	if got := currentValueOf(t, pm, krID); got < 33.3 || got > 33.4 {
+		t.Errorf("CurrentValue = %g, want one third", got)
+	}
+
+	if ms, err := pm.SetMilestoneDone(krID, "M1", false); err != nil || ms.Done || !ms.CompletedAt.IsZero() {
+		t.Errorf("reopen = %+v, %v; want open without completion time", ms, err)
+	}
+	if got := currentValueOf(t, pm, krID); got != 0 {
+		t.Errorf("CurrentValue after reopening = %g, want 0", got)
+	}
+
+	if _, err := pm.SetMilestoneDone(krID, "M7", true); err == nil {
+		t.Error("expected error for unknown milestone")
+	}
+	if _, err := pm.RecordCheckIn(krID, 50, "", 0); err == nil || !strings.Contains(err.Error(), "completing milestones") {
+		t.Errorf("expected check-ins to be rejected, got %v", err)
+	}
+}
+
+func TestUnit_MilestoneProgress_Weighted(t *testing.T) {
+	pm, krID := newMilestoneTestManager(t, 1, 1, 2)
+
+	if _, err := pm.SetMilestoneDone(krID, "M3", true); err != nil {
+		t.Fatalf("SetMilestoneDone: %v", err)
+	}
+	if got := currentValueOf(t, pm, krID); got != 50 {
+		t.Errorf("CurrentValue = %g, want 50", got)
+	}
+
+	progress, err := pm.GetAllThemeProgress()
+	if err != nil {
+		t.Fatalf("GetAllThemeProgress: %v", err)
+	}
+	// T-O1 averages the untouched "Read books" key result (0%) and this one.
+	if got := progress[0].Objectives[0].Progress; got != 25 {
+		t.Errorf("objective progress = %g, want 25", got)
+	}
+}
+
+func TestUnit_Revise_Milestones(t *testing.T) {
+	pm, krID := newMilestoneTestManager(t)
+	if _, err := pm.SetMilestoneDone(krID, "M1", true); err != nil {
+		t.Fatalf("SetMilestoneDone: %v", err)
+	}
+
+	// Reorder, rename, drop M2, add a new step and complete Publish.
+	milestones := []Milestone{
+		{ID: "M3", Name: "Publish", Done: true},
+		{ID: "M1", Name: "Write draft", Done: true},
+		{Name: "Announce"},
+	}
+	if err := pm.Revise(ReviseRequest{GoalID: krID, Milestones: &milestones}); err != nil {
+		t.Fatalf("Revise: %v", err)
+	}
+
+	themes, _ := pm.GetHierarchy()
+	kr := themes[0].Objectives[0].KeyResults[1]
+	if len(kr.Milestones) != 3 {
+		t.Fatalf("milestones = %+v, want 3", kr.Milestones)
+	}
+	got := kr.Milestones
+	if got[0].ID != "M3" || got[1].ID != "M1" || got[1].Name != "Write draft" || got[2].ID != "M4" {
+		t.Errorf("milestones = %+v, want M3, M1 (renamed), M4", got)
+	}
+	if got[1].CompletedAt != "2026-03-01T09:00:00Z" || got[0].CompletedAt.IsZero() {
+		t.Errorf("completion times = %q/%q, want M1 kept and M3 stamped", got[1].CompletedAt, got[0].CompletedAt)
+	}
+	if kr.CurrentValue < 66.6 || kr.CurrentValue > 66.7 {
+		t.Errorf("CurrentValue = %g, want two thirds", kr.CurrentValue)
+	}
+
+	empty := []Milestone{}
+	if err := pm.Revise(ReviseRequest{GoalID: krID, Milestones: &empty}); err == nil {
+		t.Error("expected error when removing every milestone")
+	}
+}
+
+func TestUnit_MilestoneHistory(t *testing.T) {
+	pm, krID := newMilestoneTestManager(t)
+	start, end := "2026-02-01", "2026-04-02"
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O1", StartDate: &start, EndDate: &end}); err != nil {
+		t.Fatalf("Revise window: %v", err)
+	}
+	if _, err := pm.SetMilestoneDone(krID, "M1", true); err != nil {
+		t.Fatalf("SetMilestoneDone: %v", err)
+	}
+
+	forecasts, err := pm.GetProgressForecast()
+	if err != nil {
+		t.Fatalf("GetProgressForecast: %v", err)
+	}
+	for _, f := range forecasts[0].KeyResults {
+		if f.KeyResultID != krID {
+			continue
+		}
+		// One of three steps after 28 of 60 days: the pace reaches about 71%.
+		if f.Progress < 33.3 || f.Progress > 33.4 || f.Status != "at-risk" {
+			t.Errorf("forecast = %+v, want one third done and at risk", f)
+		}
+		return
+	}
+	t.Fatalf("no forecast for %s", krID)
+}
+
+func TestUnit_MilestoneCompletion(t *testing.T) {
+	pm, krID := newMilestoneTestManager(t)
+	for _, id := range []string{"M1", "M2", "M3"} {
+		if _, err := pm.SetMilestoneDone(krID, id, true); err != nil {
+			t.Fatalf("SetMilestoneDone %s: %v", id, err)
+		}
+	}
+	if got := currentValueOf(t, pm, krID); got != 100 {
+		t.Errorf("CurrentValue = %g, want 100", got)
+	}
+}
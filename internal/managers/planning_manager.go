@@ -50,19 +50,37 @@ type NavigationContext struct {
 // IGoalStructure defines behavioral operations for managing the OKR hierarchy.
 type IGoalStructure interface {
 	GetHierarchy() ([]LifeTheme, error)
+	GetArchivedThemes() ([]LifeTheme, error)
 	Establish(req EstablishRequest) (*EstablishResult, error)
 	Revise(req ReviseRequest) error
-	RecordProgress(goalId string, value int) error
+	RecordProgress(goalId string, value float64) error
 	Dismiss(goalId string) error
+	Reparent(goalId, newParentId string) (*ReparentResult, error)
+	ChangeThemeID(themeId, newThemeId string) (*ThemeIDChangeResult, error)
 	SuggestAbbreviation(name string) (string, error)
 }
 
 // IGoalLifecycle defines operations for OKR status transitions.
 type IGoalLifecycle interface {
+	SetThemeStatus(themeId, status string) error
 	SetObjectiveStatus(objectiveId, status string) error
 	SetKeyResultStatus(keyResultId, status string) error
 	CloseObjective(objectiveId, closingStatus, closingNotes string) error
 	ReopenObjective(objectiveId string) error
+	GetRetrospective(objectiveId string) (*Retrospective, error)
+	ExportRetrospectiveMarkdown(objectiveId string) (string, error)
+}
+
+// ICycles defines operations on OKR cycles: time-boxed periods that
+// top-level objectives belong to, with cycle-filtered views and rollover.
+type ICycles interface {
+	GetCycles() ([]Cycle, error)
+	CreateCycle(name, startDate, endDate string) (*Cycle, error)
+	UpdateCycle(cycle Cycle) error
+	DeleteCycle(cycleId string) error
+	GetHierarchyForCycle(cycleId string) ([]LifeTheme, error)
+	GetThemeProgressForCycle(cycleId string) ([]ThemeProgress, error)
+	RolloverCycle(req RolloverRequest) (*RolloverResult, error)
 }
 
 // ITaskExecution defines operations for task management on the board.
@@ -74,6 +92,7 @@ type ITaskExecution interface {
 	DeleteTask(taskId string) error
 	ArchiveTask(taskId string) error
 	ArchiveAllDoneTasks() error
+	ArchiveDoneTasksOlderThan(days int) (int, error)
 	RestoreTask(taskId string) error
 	ReorderTasks(positions map[string][]string) (*ReorderResult, error)
 	ProcessPriorityPromotions() ([]PromotedTask, error)
@@ -81,6 +100,7 @@ type ITaskExecution interface {
 
 // IFocusPlanning defines operations for calendar day focus.
 type IFocusPlanning interface {
+	GetToday() string
 	GetYearFocus(year int) ([]DayFocus, error)
 	SaveDayFocus(day DayFocus) error
 	ClearDayFocus(date string) error
@@ -95,6 +115,22 @@ type IVision interface {
 // IProgress defines operations for progress computation.
 type IProgress interface {
 	GetAllThemeProgress() ([]ThemeProgress, error)
+	GetProgressForecast() ([]ThemeForecast, error)
+}
+
+// IIntegrity defines cross-component data consistency checks.
+type IIntegrity interface {
+	CheckIntegrity(repair bool) (*IntegrityReport, error)
+}
+
+// ICheckIns defines operations on the dated progress history of key results:
+// check-ins for valued key results and completions for milestone key results.
+type ICheckIns interface {
+	ListCheckIns(keyResultId string) ([]CheckIn, error)
+	RecordCheckIn(keyResultId string, value float64, note string, confidence int) (*CheckIn, error)
+	UpdateCheckIn(keyResultId string, checkIn CheckIn) error
+	DeleteCheckIn(keyResultId, checkInId string) error
+	SetMilestoneDone(keyResultId, milestoneId string, done bool) (*Milestone, error)
 }
 
 // IUIState defines operations for UI state persistence.
@@ -106,15 +142,18 @@ type IUIState interface {
 }
 
 // IPlanningManager defines the full interface for planning business logic,
-// composed of 7 facet interfaces.
+// composed of 10 facet interfaces.
 type IPlanningManager interface {
 	IGoalStructure
+	ICheckIns
 	IGoalLifecycle
+	ICycles
 	ITaskExecution
 	IFocusPlanning
 	IVision
 	IProgress
 	IUIState
+	IIntegrity
 }
 
 // RuleViolation represents a single rule violation in the Manager layer's public interface.
@@ -149,15 +188,28 @@ type PromotedTask struct {
 
 // ObjectiveProgress represents the computed progress of an objective.
 type ObjectiveProgress struct {
-	ObjectiveID string  `json:"objectiveId"`
-	Progress    float64 `json:"progress"` // 0-100, or -1 if no data
+	ObjectiveID   string                 `json:"objectiveId"`
+	Progress      float64                `json:"progress"` // 0-100, or -1 if no data
+	Contributions []ProgressContribution `json:"contributions,omitempty"`
+}
+
+// ProgressContribution is the part a child key result or objective plays in
+// its parent's progress. Points sum to the parent's progress.
+type ProgressContribution struct {
+	ID       string  `json:"id"`
+	Kind     string  `json:"kind"` // "key-result" or "objective"
+	Progress float64 `json:"progress"`
+	Weight   float64 `json:"weight"`
+	Share    float64 `json:"share"` // 0-1
+	Points   float64 `json:"points"`
 }
 
 // ThemeProgress represents computed progress for a theme and its objectives.
 type ThemeProgress struct {
-	ThemeID    string              `json:"themeId"`
-	Progress   float64             `json:"progress"` // 0-100, average of objective progresses
-	Objectives []ObjectiveProgress `json:"objectives"`
+	ThemeID       string                 `json:"themeId"`
+	Progress      float64                `json:"progress"` // 0-100, weighted mean of top-level objective progresses
+	Objectives    []ObjectiveProgress    `json:"objectives"`
+	Contributions []ProgressContribution `json:"contributions,omitempty"`
 }
 
 // Task represents a task in the Manager layer's public interface.
@@ -175,37 +227,60 @@ type Task struct {
 
 // KeyResult represents a measurable outcome in the Manager layer's public interface.
 type KeyResult struct {
-	ID           string `json:"id"`
-	ParentID     string `json:"parentId"`
-	Description  string `json:"description"`
-	Type         string `json:"type,omitempty"`
-	Status       string `json:"status,omitempty"`
-	StartValue   int    `json:"startValue,omitempty"`
-	CurrentValue int    `json:"currentValue,omitempty"`
-	TargetValue  int    `json:"targetValue,omitempty"`
+	ID           string      `json:"id"`
+	ParentID     string      `json:"parentId"`
+	Description  string      `json:"description"`
+	Type         string      `json:"type,omitempty"`
+	Status       string      `json:"status,omitempty"`
+	Direction    string      `json:"direction,omitempty"`
+	Unit         string      `json:"unit,omitempty"`
+	Weight       float64     `json:"weight,omitempty"`
+	StartValue   float64     `json:"startValue,omitempty"`
+	CurrentValue float64     `json:"currentValue,omitempty"`
+	TargetValue  float64     `json:"targetValue,omitempty"`
+	CheckIns     []CheckIn   `json:"checkIns,omitempty"`
+	Milestones   []Milestone `json:"milestones,omitempty"`
 }
 
 // Objective represents a medium-term goal in the Manager layer's public interface.
 type Objective struct {
-	ID            string              `json:"id"`
-	ParentID      string              `json:"parentId"`
-	Title         string              `json:"title"`
-	Status        string              `json:"status,omitempty"`
-	Tags          []string            `json:"tags,omitempty"`
-	ClosingStatus string              `json:"closingStatus,omitempty"`
-	ClosingNotes  string              `json:"closingNotes,omitempty"`
-	ClosedAt      utilities.Timestamp `json:"closedAt,omitempty"`
-	KeyResults    []KeyResult         `json:"keyResults"`
-	Objectives    []Objective         `json:"objectives,omitempty"`
+	ID            string                 `json:"id"`
+	ParentID      string                 `json:"parentId"`
+	Title         string                 `json:"title"`
+	Status        string                 `json:"status,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	ClosingStatus string                 `json:"closingStatus,omitempty"`
+	ClosingNotes  string                 `json:"closingNotes,omitempty"`
+	ClosedAt      utilities.Timestamp    `json:"closedAt,omitempty"`
+	CreatedAt     utilities.Timestamp    `json:"createdAt,omitempty"`
+	StartDate     utilities.CalendarDate `json:"startDate,omitempty"`
+	EndDate       utilities.CalendarDate `json:"endDate,omitempty"`
+	Weight        float64                `json:"weight,omitempty"`
+	CycleID       string                 `json:"cycleId,omitempty"`
+	KeyResults    []KeyResult            `json:"keyResults"`
+	Objectives    []Objective            `json:"objectives,omitempty"`
 }
 
 // RepeatPattern defines a recurrence schedule for a routine in the Manager layer.
 type RepeatPattern struct {
-	Frequency  string                 `json:"frequency"`
-	Interval   int                    `json:"interval"`
-	Weekdays   []int                  `json:"weekdays,omitempty"`
-	DayOfMonth int                    `json:"dayOfMonth,omitempty"`
-	StartDate  utilities.CalendarDate `json:"startDate"`
+	Frequency       string                 `json:"frequency"`
+	Interval        int                    `json:"interval"`
+	Weekdays        []int                  `json:"weekdays,omitempty"`
+	DayOfMonth      int                    `json:"dayOfMonth,omitempty"`
+	WeekOfMonth     int                    `json:"weekOfMonth,omitempty"`
+	Month           int                    `json:"month,omitempty"`
+	RRule           string                 `json:"rrule,omitempty"`
+	AfterCompletion bool                   `json:"afterCompletion,omitempty"`
+	StartDate       utilities.CalendarDate `json:"startDate"`
+	EndDate         utilities.CalendarDate `json:"endDate,omitempty"`
+	Count           int                    `json:"count,omitempty"`
+	Pauses          []SchedulePause        `json:"pauses,omitempty"`
+}
+
+// SchedulePause is an inclusive date range during which a routine does not recur in the Manager layer.
+type SchedulePause struct {
+	From utilities.CalendarDate `json:"from"`
+	To   utilities.CalendarDate `json:"to"`
 }
 
 // ScheduleException represents a single date override in a routine's schedule in the Manager layer.
@@ -217,10 +292,24 @@ type ScheduleException struct {
 // Routine represents an ongoing activity tracked per occurrence in the Manager layer's public interface.
 // Periodic routines have a RepeatPattern; sporadic routines have none.
 type Routine struct {
-	ID            string              `json:"id"`
-	Description   string              `json:"description"`
-	RepeatPattern *RepeatPattern      `json:"repeatPattern,omitempty"`
-	Exceptions    []ScheduleException `json:"exceptions,omitempty"`
+	ID              string                  `json:"id"`
+	Description     string                  `json:"description"`
+	RepeatPattern   *RepeatPattern          `json:"repeatPattern,omitempty"`
+	Exceptions      []ScheduleException     `json:"exceptions,omitempty"`
+	ThemeID         string                  `json:"themeId,omitempty"`
+	KeyResultID     string                  `json:"keyResultId,omitempty"`
+	FeedKeyResult   bool                    `json:"feedKeyResult,omitempty"`
+	Materialization *RoutineMaterialization `json:"materialization,omitempty"`
+}
+
+// RoutineMaterialization configures the tasks a routine turns into in the
+// Manager layer: when (Mode), in which board column, with which tags and
+// under which title. Nil on a Routine means the defaults.
+type RoutineMaterialization struct {
+	Mode          string   `json:"mode,omitempty"`          // on-check (default), never or ahead
+	Column        string   `json:"column,omitempty"`        // board column slug; empty = todo
+	Tags          []string `json:"tags,omitempty"`          // replace the Routine tag when set
+	TitleTemplate string   `json:"titleTemplate,omitempty"` // {description} and {date} are substituted
 }
 
 // LifeTheme represents a life focus area in the Manager layer's public interface.
@@ -228,18 +317,30 @@ type LifeTheme struct {
 	ID         string      `json:"id"`
 	Name       string      `json:"name"`
 	Color      string      `json:"color"`
+	Status     string      `json:"status,omitempty"` // paused, archived (empty = active)
 	Objectives []Objective `json:"objectives"`
 }
 
 // DayFocus represents a daily focus entry in the Manager layer's public interface.
 type DayFocus struct {
-	Date          utilities.CalendarDate `json:"date"`
-	ThemeIDs      []string               `json:"themeIds,omitempty"`
-	Notes         string                 `json:"notes"`
-	Text          string                 `json:"text"`
-	OkrIDs        []string               `json:"okrIds,omitempty"`
-	Tags          []string               `json:"tags,omitempty"`
-	RoutineChecks []string               `json:"routineChecks,omitempty"`
+	Date                utilities.CalendarDate `json:"date"`
+	ThemeIDs            []string               `json:"themeIds,omitempty"`
+	Notes               string                 `json:"notes"`
+	Text                string                 `json:"text"`
+	OkrIDs              []string               `json:"okrIds,omitempty"`
+	Tags                []string               `json:"tags,omitempty"`
+	RoutineChecks       []string               `json:"routineChecks,omitempty"`
+	RoutineCheckDetails []RoutineCheckDetail   `json:"routineCheckDetails,omitempty"`
+}
+
+// RoutineCheckDetail optionally quantifies the check-in of a routine listed
+// in DayFocus.RoutineChecks.
+type RoutineCheckDetail struct {
+	RoutineID       string  `json:"routineId"`
+	Amount          float64 `json:"amount,omitempty"`
+	Unit            string  `json:"unit,omitempty"`
+	DurationMinutes int     `json:"durationMinutes,omitempty"`
+	Note            string  `json:"note,omitempty"`
 }
 
 // SectionDefinition defines a priority section within a column.
@@ -282,15 +383,23 @@ type RoutineOccurrence struct {
 	Status      string `json:"status"` // "scheduled", "overdue", "sporadic"
 	Checked     bool   `json:"checked"`
 	MissedCount int    `json:"missedCount,omitempty"`
+	ThemeID     string `json:"themeId,omitempty"`
 }
 
 // RoutinePeriodProgress represents period-based completion for a routine.
+// The quantity fields summarise the check-in details recorded in the period;
+// amounts count only in Unit, the unit of the latest amount.
 type RoutinePeriodProgress struct {
-	RoutineID string `json:"routineId"`
-	Completed int    `json:"completed"`
-	Expected  int    `json:"expected"`
-	Period    string `json:"period"`
-	OnTrack   bool   `json:"onTrack"`
+	RoutineID      string  `json:"routineId"`
+	Completed      int     `json:"completed"`
+	Expected       int     `json:"expected"`
+	Period         string  `json:"period"`
+	OnTrack        bool    `json:"onTrack"`
+	TotalAmount    float64 `json:"totalAmount,omitempty"`
+	AverageAmount  float64 `json:"averageAmount,omitempty"`
+	Unit           string  `json:"unit,omitempty"`
+	TotalMinutes   int     `json:"totalMinutes,omitempty"`
+	AverageMinutes float64 `json:"averageMinutes,omitempty"`
 }
 
 // GoalType identifies the kind of goal node in the OKR hierarchy.
@@ -305,15 +414,22 @@ const (
 
 // EstablishRequest carries the fields needed to create any goal node.
 type EstablishRequest struct {
-	ParentID      string         `json:"parentId"`
-	GoalType      GoalType       `json:"goalType"`
-	Name          string         `json:"name,omitempty"`
-	Color         string         `json:"color,omitempty"`
-	Title         string         `json:"title,omitempty"`
-	Description   string         `json:"description,omitempty"`
-	StartValue    *int           `json:"startValue,omitempty"`
-	TargetValue   *int           `json:"targetValue,omitempty"`
-	RepeatPattern *RepeatPattern `json:"repeatPattern,omitempty"`
+	ParentID        string                  `json:"parentId"`
+	GoalType        GoalType                `json:"goalType"`
+	Name            string                  `json:"name,omitempty"`
+	Color           string                  `json:"color,omitempty"`
+	Title           string                  `json:"title,omitempty"`
+	Description     string                  `json:"description,omitempty"`
+	StartValue      *float64                `json:"startValue,omitempty"`
+	TargetValue     *float64                `json:"targetValue,omitempty"`
+	KeyResultType   string                  `json:"keyResultType,omitempty"` // metric (default), binary, percentage, milestone
+	Direction       string                  `json:"direction,omitempty"`     // increase (default) or decrease
+	Unit            string                  `json:"unit,omitempty"`
+	Milestones      []Milestone             `json:"milestones,omitempty"` // ordered steps of a milestone key result
+	CycleID         string                  `json:"cycleId,omitempty"`    // top-level objectives only
+	RepeatPattern   *RepeatPattern          `json:"repeatPattern,omitempty"`
+	FeedKeyResult   bool                    `json:"feedKeyResult,omitempty"`   // routines whose parent is a percentage key result
+	Materialization *RoutineMaterialization `json:"materialization,omitempty"` // routines only
 }
 
 // EstablishResult contains the created goal node.
@@ -327,16 +443,28 @@ type EstablishResult struct {
 // ReviseRequest carries partial updates for an existing goal node.
 // Pointer fields: nil = leave unchanged, non-nil = update to this value.
 type ReviseRequest struct {
-	GoalID        string         `json:"goalId"`
-	Name          *string        `json:"name,omitempty"`
-	Color         *string        `json:"color,omitempty"`
-	Title         *string        `json:"title,omitempty"`
-	Tags          *[]string      `json:"tags,omitempty"`
-	Description   *string        `json:"description,omitempty"`
-	StartValue    *int           `json:"startValue,omitempty"`
-	TargetValue   *int           `json:"targetValue,omitempty"`
-	RepeatPattern *RepeatPattern `json:"repeatPattern,omitempty"`
-	ClearRepeat   bool           `json:"clearRepeat,omitempty"`
+	GoalID          string                  `json:"goalId"`
+	Name            *string                 `json:"name,omitempty"`
+	Color           *string                 `json:"color,omitempty"`
+	Title           *string                 `json:"title,omitempty"`
+	Tags            *[]string               `json:"tags,omitempty"`
+	StartDate       *string                 `json:"startDate,omitempty"` // objectives only; "" clears
+	EndDate         *string                 `json:"endDate,omitempty"`   // objectives only; "" clears
+	Description     *string                 `json:"description,omitempty"`
+	StartValue      *float64                `json:"startValue,omitempty"`
+	TargetValue     *float64                `json:"targetValue,omitempty"`
+	KeyResultType   *string                 `json:"keyResultType,omitempty"`
+	Direction       *string                 `json:"direction,omitempty"`
+	Unit            *string                 `json:"unit,omitempty"`
+	Milestones      *[]Milestone            `json:"milestones,omitempty"` // replaces the list; entries without ID are added
+	Weight          *float64                `json:"weight,omitempty"`     // objectives and key results; 0 resets to the default of 1
+	CycleID         *string                 `json:"cycleId,omitempty"`    // top-level objectives only; "" clears
+	RepeatPattern   *RepeatPattern          `json:"repeatPattern,omitempty"`
+	ClearRepeat     bool                    `json:"clearRepeat,omitempty"`
+	ThemeID         *string                 `json:"themeId,omitempty"`         // routines only; "" clears
+	KeyResultID     *string                 `json:"keyResultId,omitempty"`     // routines only; "" clears
+	FeedKeyResult   *bool                   `json:"feedKeyResult,omitempty"`   // routines only
+	Materialization *RoutineMaterialization `json:"materialization,omitempty"` // routines only; an empty value restores the defaults
 }
 
 // detectGoalType determines the goal type from its ID naming convention.
@@ -388,12 +516,16 @@ type PlanningManager struct {
 	taskAccess     taskAccessFacets
 	calendarAccess access.ICalendarAccess
 	routineAccess  access.IRoutineAccess
+	cycleAccess    access.ICycleAccess
+	weekAccess     access.IWeekAccess
+	settingsAccess access.ISettingsAccess
 	visionAccess   access.IVisionAccess
 	uiStateAccess  access.IUIStateAccess
 	repo           utilities.IRepository
 	ruleEngine     rule_engine.IRuleEngine
 	progressEngine progress_engine.IProgressEngine
 	scheduleEngine schedule_engine.IScheduleEngine
+	clock          utilities.Clock
 }
 
 // getAccessBoardConfig returns the access-layer board configuration,
@@ -409,6 +541,18 @@ func (m *PlanningManager) getAccessBoardConfig() (*access.BoardConfiguration, er
 	return config, nil
 }
 
+// doneStatuses returns the statuses of finished tasks: every done-type
+// column of config and the archive.
+func doneStatuses(config *access.BoardConfiguration) []string {
+	statuses := []string{string(access.TaskStatusArchived)}
+	for _, col := range config.ColumnDefinitions {
+		if col.Type == access.ColumnTypeDone {
+			statuses = append(statuses, col.Name)
+		}
+	}
+	return statuses
+}
+
 // NewPlanningManager creates a new PlanningManager instance.
 //
 // The repo handle is required by orchestrations that span multiple
@@ -417,14 +561,22 @@ func (m *PlanningManager) getAccessBoardConfig() (*access.BoardConfiguration, er
 // the supplied Access components so utilities.RunTransaction's per-repo
 // lock serialises the cross-Access write against any concurrent
 // committing verb.
+//
+// The clock decides what "today" is for overdue routines, priority
+// promotions and routine progress, honouring the configured timezone and
+// day boundary.
 func NewPlanningManager(
 	themeAccess access.IThemeAccess,
 	taskAccess taskAccessFacets,
 	calendarAccess access.ICalendarAccess,
 	routineAccess access.IRoutineAccess,
+	cycleAccess access.ICycleAccess,
+	weekAccess access.IWeekAccess,
+	settingsAccess access.ISettingsAccess,
 	visionAccess access.IVisionAccess,
 	uiStateAccess access.IUIStateAccess,
 	repo utilities.IRepository,
+	clock utilities.Clock,
 ) (*PlanningManager, error) {
 	if themeAccess == nil {
 		return nil, fmt.Errorf("themeAccess cannot be nil")
@@ -438,6 +590,15 @@ func NewPlanningManager(
 	if routineAccess == nil {
 		return nil, fmt.Errorf("routineAccess cannot be nil")
 	}
+	if cycleAccess == nil {
+		return nil, fmt.Errorf("cycleAccess cannot be nil")
+	}
+	if weekAccess == nil {
+		return nil, fmt.Errorf("weekAccess cannot be nil")
+	}
+	if settingsAccess == nil {
+		return nil, fmt.Errorf("settingsAccess cannot be nil")
+	}
 	if visionAccess == nil {
 		return nil, fmt.Errorf("visionAccess cannot be nil")
 	}
@@ -447,6 +608,9 @@ func NewPlanningManager(
 	if repo == nil {
 		return nil, fmt.Errorf("repo cannot be nil")
 	}
+	if clock == nil {
+		return nil, fmt.Errorf("clock cannot be nil")
+	}
 
 	engine := rule_engine.NewRuleEngine(rule_engine.DefaultRules())
 	progressEng := progress_engine.NewProgressEngine()
@@ -457,12 +621,16 @@ func NewPlanningManager(
 		taskAccess:     taskAccess,
 		calendarAccess: calendarAccess,
 		routineAccess:  routineAccess,
+		cycleAccess:    cycleAccess,
+		weekAccess:     weekAccess,
+		settingsAccess: settingsAccess,
 		visionAccess:   visionAccess,
 		uiStateAccess:  uiStateAccess,
 		repo:           repo,
 		ruleEngine:     engine,
 		progressEngine: progressEng,
 		scheduleEngine: scheduleEng,
+		clock:          clock,
 	}
 
 	pm.validateTaskOrder()
@@ -476,6 +644,7 @@ func (m *PlanningManager) getThemes() ([]LifeTheme, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
+	themes = visibleThemes(themes)
 	result := make([]LifeTheme, len(themes))
 	for i, t := range themes {
 		result[i] = toManagerLifeTheme(t)
@@ -483,12 +652,44 @@ func (m *PlanningManager) getThemes() ([]LifeTheme, error) {
 	return result, nil
 }
 
-// deleteTheme deletes a life theme by ID.
+// deleteTheme deletes a life theme by ID. When it is the default theme for
+// new tasks, the setting is cleared in the same commit.
 func (m *PlanningManager) deleteTheme(id string) error {
 	if id == "" {
 		return fmt.Errorf("id cannot be empty")
 	}
-	if err := m.themeAccess.DeleteTheme(id); err != nil {
+	settings, err := m.settingsAccess.LoadSettings()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	if settings.DefaultThemeID != id {
+		if err := m.themeAccess.DeleteTheme(id); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		return nil
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	name := id
+	for _, t := range themes {
+		if t.ID == id {
+			name = t.Name
+		}
+	}
+	commitMsg := fmt.Sprintf("Delete theme: %s", name)
+	if err := utilities.RunTransaction(m.repo, commitMsg, func() error {
+		if err := m.themeAccess.WriteDeleteTheme(id); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		settings.DefaultThemeID = ""
+		if err := m.settingsAccess.WriteSettings(*settings); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		return nil
+	}); err != nil {
 		return fmt.Errorf("%w", err)
 	}
 	return nil
@@ -586,13 +787,18 @@ func findKeyResultParent(objectives []access.Objective, krID string) (*access.Ob
 // createObjective creates a new objective under a parent (theme or objective).
 // parentId can be a theme ID or any objective ID at any depth.
 // Returns the created objective with its generated ID.
-func (m *PlanningManager) createObjective(parentId, title string) (*Objective, error) {
+func (m *PlanningManager) createObjective(parentId, title, cycleId string) (*Objective, error) {
 	if parentId == "" {
 		return nil, fmt.Errorf("parentId cannot be empty")
 	}
 	if title == "" {
 		return nil, fmt.Errorf("title cannot be empty")
 	}
+	if cycleId != "" {
+		if err := m.checkCycleAssignment(parentId, cycleId); err != nil {
+			return nil, err
+		}
+	}
 
 	themes, err := m.themeAccess.GetThemes()
 	if err != nil {
@@ -601,6 +807,8 @@ func (m *PlanningManager) createObjective(parentId, title string) (*Objective, e
 
 	newObjective := access.Objective{
 		Title:      title,
+		CycleID:    cycleId,
+		CreatedAt:  m.clock.Now(),
 		KeyResults: []access.KeyResult{},
 	}
 
@@ -709,16 +917,17 @@ func (m *PlanningManager) deleteObjective(objectiveId string) error {
 
 // createKeyResult creates a new key result under an objective found anywhere in the tree.
 // parentObjectiveId is the objective ID at any depth.
-func (m *PlanningManager) createKeyResult(parentObjectiveId, description string, startValue, targetValue int) (*KeyResult, error) {
+func (m *PlanningManager) createKeyResult(parentObjectiveId string, spec access.KeyResult) (*KeyResult, error) {
 	if parentObjectiveId == "" {
 		return nil, fmt.Errorf("parentObjectiveId cannot be empty")
 	}
-	if description == "" {
+	if spec.Description == "" {
 		return nil, fmt.Errorf("description cannot be empty")
 	}
-	if startValue > targetValue {
-		return nil, fmt.Errorf("startValue (%d) cannot exceed targetValue (%d)", startValue, targetValue)
+	if err := normalizeKeyResult(&spec); err != nil {
+		return nil, err
 	}
+	deriveCurrentValue(&spec)
 
 	themes, err := m.themeAccess.GetThemes()
 	if err != nil {
@@ -729,7 +938,7 @@ func (m *PlanningManager) createKeyResult(parentObjectiveId, description string,
 	for i := range themes {
 		if obj := findObjectiveByID(themes[i].Objectives, parentObjectiveId); obj != nil {
 			targetTheme = &themes[i]
-			obj.KeyResults = append(obj.KeyResults, access.KeyResult{Description: description, StartValue: startValue, TargetValue: targetValue})
+			obj.KeyResults = append(obj.KeyResults, spec)
 			break
 		}
 	}
@@ -762,30 +971,6 @@ func (m *PlanningManager) createKeyResult(parentObjectiveId, description string,
 	return nil, fmt.Errorf("key result was created but could not be retrieved")
 }
 
-// updateKeyResultProgress finds a key result by ID anywhere in the tree and updates its currentValue.
-func (m *PlanningManager) updateKeyResultProgress(keyResultId string, currentValue int) error {
-	if keyResultId == "" {
-		return fmt.Errorf("keyResultId cannot be empty")
-	}
-
-	themes, err := m.themeAccess.GetThemes()
-	if err != nil {
-		return fmt.Errorf("%w", err)
-	}
-
-	for i := range themes {
-		if obj, krIdx := findKeyResultParent(themes[i].Objectives, keyResultId); obj != nil {
-			obj.KeyResults[krIdx].CurrentValue = currentValue
-			if err := m.themeAccess.SaveTheme(themes[i]); err != nil {
-				return fmt.Errorf("%w", err)
-			}
-			return nil
-		}
-	}
-
-	return fmt.Errorf("key result with ID %s not found", keyResultId)
-}
-
 // deleteKeyResult finds a key result by ID anywhere in the tree and removes it.
 func (m *PlanningManager) deleteKeyResult(keyResultId string) error {
 	if keyResultId == "" {
@@ -810,8 +995,10 @@ func (m *PlanningManager) deleteKeyResult(keyResultId string) error {
 	return fmt.Errorf("key result with ID %s not found", keyResultId)
 }
 
-// addRoutine creates a new routine via RoutineAccess.
-func (m *PlanningManager) addRoutine(description string, repeatPattern *RepeatPattern) (*Routine, error) {
+// addRoutine creates a new routine via RoutineAccess. parentID optionally
+// names the theme or key result the routine serves; feed makes the
+// routine's adherence feed that key result's progress.
+func (m *PlanningManager) addRoutine(description string, repeatPattern *RepeatPattern, parentID string, feed bool, mat *RoutineMaterialization) (*Routine, error) {
 	if strings.TrimSpace(description) == "" {
 		return nil, fmt.Errorf("description cannot be empty")
 	}
@@ -821,10 +1008,38 @@ func (m *PlanningManager) addRoutine(description string, repeatPattern *RepeatPa
 		return nil, fmt.Errorf("%w", err)
 	}
 
+	repeatPattern, err = normalizeRepeatPattern(repeatPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	mat, err = m.normalizeMaterialization(mat)
+	if err != nil {
+		return nil, err
+	}
+
+	themeID, keyResultID, err := routineParentLink(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if themeID != "" || keyResultID != "" || feed {
+		themes, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		if themeID, err = resolveRoutineLink(themes, themeID, keyResultID, feed); err != nil {
+			return nil, err
+		}
+	}
+
 	routine := access.Routine{
-		ID:            access.NextRoutineID(routines),
-		Description:   strings.TrimSpace(description),
-		RepeatPattern: toAccessRepeatPattern(repeatPattern),
+		ID:              access.NextRoutineID(routines),
+		Description:     strings.TrimSpace(description),
+		RepeatPattern:   toAccessRepeatPattern(repeatPattern),
+		ThemeID:         themeID,
+		KeyResultID:     keyResultID,
+		FeedKeyResult:   feed,
+		Materialization: toAccessMaterialization(mat),
 	}
 
 	if err := m.routineAccess.SaveRoutine(routine); err != nil {
@@ -957,7 +1172,8 @@ func (m *PlanningManager) SetKeyResultStatus(keyResultId, status string) error {
 }
 
 // CloseObjective performs a structured close of an objective with a closing status and optional notes.
-// Unlike SetObjectiveStatus, this method actively closes all active child KRs as part of the operation.
+// Unlike SetObjectiveStatus, this method actively closes all active child KRs as part of the operation,
+// and records the objective's retrospective (see GetRetrospective).
 func (m *PlanningManager) CloseObjective(objectiveId, closingStatus, closingNotes string) error {
 	if objectiveId == "" {
 		return fmt.Errorf("objectiveId cannot be empty")
@@ -973,22 +1189,14 @@ func (m *PlanningManager) CloseObjective(objectiveId, closingStatus, closingNote
 
 	for i := range themes {
 		if obj := findObjectiveByID(themes[i].Objectives, objectiveId); obj != nil {
-			// Must be active to close
-			if EffectiveOKRStatus(obj.Status) != string(access.OKRStatusActive) {
-				return fmt.Errorf("cannot close: objective is not active (current status: %s)", EffectiveOKRStatus(obj.Status))
+			if err := closeAccessObjective(obj, closingStatus, closingNotes, m.clock.Now()); err != nil {
+				return err
 			}
-
-			obj.Status = string(access.OKRStatusCompleted)
-			obj.ClosingStatus = closingStatus
-			obj.ClosingNotes = closingNotes
-			obj.ClosedAt = utilities.Now()
-
-			// Close all active direct child KRs
-			for j := range obj.KeyResults {
-				if EffectiveOKRStatus(obj.KeyResults[j].Status) == string(access.OKRStatusActive) {
-					obj.KeyResults[j].Status = string(access.OKRStatusCompleted)
-				}
+			src, err := m.loadRetrospectiveSources()
+			if err != nil {
+				return err
 			}
+			obj.Retrospective = buildRetrospective(src, themes[i].ID, *obj)
 
 			if err := m.themeAccess.SaveTheme(themes[i]); err != nil {
 				return fmt.Errorf("%w", err)
@@ -1000,6 +1208,28 @@ func (m *PlanningManager) CloseObjective(objectiveId, closingStatus, closingNote
 	return fmt.Errorf("objective with ID %s not found", objectiveId)
 }
 
+// closeAccessObjective marks an active objective as completed with the given
+// closing status and notes, and completes its active direct child KRs.
+func closeAccessObjective(obj *access.Objective, closingStatus, closingNotes string, now utilities.Timestamp) error {
+	// Must be active to close
+	if EffectiveOKRStatus(obj.Status) != string(access.OKRStatusActive) {
+		return fmt.Errorf("cannot close: objective is not active (current status: %s)", EffectiveOKRStatus(obj.Status))
+	}
+
+	obj.Status = string(access.OKRStatusCompleted)
+	obj.ClosingStatus = closingStatus
+	obj.ClosingNotes = closingNotes
+	obj.ClosedAt = now
+
+	// Close all active direct child KRs
+	for j := range obj.KeyResults {
+		if EffectiveOKRStatus(obj.KeyResults[j].Status) == string(access.OKRStatusActive) {
+			obj.KeyResults[j].Status = string(access.OKRStatusCompleted)
+		}
+	}
+	return nil
+}
+
 // ReopenObjective reopens a closed/completed objective, clearing all closing metadata.
 // Also reopens all direct child KRs that were completed.
 func (m *PlanningManager) ReopenObjective(objectiveId string) error {
@@ -1023,6 +1253,7 @@ func (m *PlanningManager) ReopenObjective(objectiveId string) error {
 			obj.ClosingStatus = ""
 			obj.ClosingNotes = ""
 			obj.ClosedAt = ""
+			obj.Retrospective = nil
 
 			// Reopen all completed direct child KRs
 			for j := range obj.KeyResults {
@@ -1041,6 +1272,13 @@ func (m *PlanningManager) ReopenObjective(objectiveId string) error {
 	return fmt.Errorf("objective with ID %s not found", objectiveId)
 }
 
+// GetToday returns the current logical date (YYYY-MM-DD) according to the
+// configured timezone and day boundary, so the frontend agrees with the
+// backend about which day is "today".
+func (m *PlanningManager) GetToday() string {
+	return m.clock.Today().String()
+}
+
 // GetYearFocus returns all day focus entries for a specific year.
 func (m *PlanningManager) GetYearFocus(year int) ([]DayFocus, error) {
 	if year < 1900 || year > 9999 {
@@ -1062,6 +1300,9 @@ func (m *PlanningManager) SaveDayFocus(day DayFocus) error {
 	if day.Date.IsZero() {
 		return fmt.Errorf("date cannot be empty")
 	}
+	if err := normalizeRoutineCheckDetails(&day); err != nil {
+		return fmt.Errorf("%w", err)
+	}
 	if err := m.calendarAccess.SaveDayFocus(toAccessDayFocus(day)); err != nil {
 		return fmt.Errorf("%w", err)
 	}
@@ -1078,9 +1319,13 @@ func (m *PlanningManager) SaveDayFocus(day DayFocus) error {
 //   - Executes the create/delete batch via IBatch.CommitNoTx and saves
 //     the day focus via CalendarAccess.WriteDayFocus inside a single
 //     utilities.RunTransaction so a single git commit covers both.
+//   - Within the same commit, records an adherence check-in on the key
+//     result of each changed routine that feeds one.
 //
 // The "Routine" day-level tag is auto-managed based on whether any
 // routines are checked on the day, mirroring the legacy behaviour.
+// Check-in details only change the saved day; they take no part in the
+// diff.
 //
 // Closes audit finding #5 (N+1 commits regression in
 // SaveDayFocusWithRoutines).
@@ -1088,6 +1333,9 @@ func (m *PlanningManager) RecordRoutineCompletions(day DayFocus, previousChecks
 	if day.Date.IsZero() {
 		return fmt.Errorf("date cannot be empty")
 	}
+	if err := normalizeRoutineCheckDetails(&day); err != nil {
+		return fmt.Errorf("RecordRoutineCompletions: %w", err)
+	}
 
 	newlyChecked, newlyUnchecked := computeRoutineDiff(day.RoutineChecks, previousChecks)
 
@@ -1113,7 +1361,9 @@ func (m *PlanningManager) RecordRoutineCompletions(day DayFocus, previousChecks
 		return fmt.Errorf("RecordRoutineCompletions: failed to load routines: %w", err)
 	}
 
-	existingRefs, err := m.findExistingRoutineTasks(newlyUnchecked, day.Date)
+	// Tasks of newly-checked routines materialised ahead of time keep
+	// the plan from creating a second one.
+	existingRefs, err := m.findExistingRoutineTasks(append(materializedAhead(routines, newlyChecked), newlyUnchecked...), day.Date)
 	if err != nil {
 		return fmt.Errorf("RecordRoutineCompletions: %w", err)
 	}
@@ -1124,11 +1374,23 @@ func (m *PlanningManager) RecordRoutineCompletions(day DayFocus, previousChecks
 		NewlyUnchecked: newlyUnchecked,
 		ExistingTasks:  existingRefs,
 	}
+	if hasRoutineLinks(routines) {
+		themes, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return fmt.Errorf("RecordRoutineCompletions: failed to load themes: %w", err)
+		}
+		routines = dropDanglingRoutineLinks(routines, themes)
+	}
 	engineRoutines, err := m.toEngineRoutineInputs(routines)
 	if err != nil {
 		return fmt.Errorf("RecordRoutineCompletions: %w", err)
 	}
-	plan := m.scheduleEngine.Plan(engineDiff, engineRoutines, utilities.Today())
+	plan := m.scheduleEngine.Plan(engineDiff, engineRoutines, m.clock.Today())
+
+	fedThemes, err := m.feedRoutineAdherence(routines, append(newlyChecked, newlyUnchecked...), day.Date.String(), day.RoutineChecks)
+	if err != nil {
+		return fmt.Errorf("RecordRoutineCompletions: %w", err)
+	}
 
 	// Translate engine TaskSpecs to access.TaskCreate, computing the
 	// drop zone via RuleEngine (same source-of-truth used by other
@@ -1148,6 +1410,11 @@ func (m *PlanningManager) RecordRoutineCompletions(day DayFocus, previousChecks
 		if err := m.calendarAccess.WriteDayFocus(toAccessDayFocus(day)); err != nil {
 			return fmt.Errorf("write day focus failed: %w", err)
 		}
+		for _, theme := range fedThemes {
+			if err := m.themeAccess.WriteTheme(theme); err != nil {
+				return fmt.Errorf("write theme %s failed: %w", theme.ID, err)
+			}
+		}
 		return nil
 	}); err != nil {
 		return fmt.Errorf("RecordRoutineCompletions: %w", err)
@@ -1204,18 +1471,16 @@ func adjustRoutineTag(tags []string, hasChecks bool) []string {
 }
 
 // findExistingRoutineTasks looks up every task linked to the given
-// (routineID, date) pair across all status directories. The returned
-// engine refs carry the status so ScheduleEngine.Plan can apply its
-// "delete only if todo/doing" rule.
+// (routineID, date) pair across the board's columns and the archive. The
+// returned engine refs carry the status so ScheduleEngine.Plan can apply
+// its "delete only if todo/doing" rule.
 func (m *PlanningManager) findExistingRoutineTasks(routineIDs []string, date utilities.CalendarDate) ([]schedule_engine.ExistingTaskRef, error) {
 	if len(routineIDs) == 0 {
 		return nil, nil
 	}
-	statuses := []string{
-		string(access.TaskStatusTodo),
-		string(access.TaskStatusDoing),
-		string(access.TaskStatusDone),
-		string(access.TaskStatusArchived),
+	statuses, err := m.routineTaskStatuses()
+	if err != nil {
+		return nil, err
 	}
 	var refs []schedule_engine.ExistingTaskRef
 	for _, routineID := range routineIDs {
@@ -1264,16 +1529,26 @@ func (m *PlanningManager) toEngineRoutineInputs(routines []access.Routine) ([]sc
 		out[i] = schedule_engine.RoutineInput{
 			ID:             r.ID,
 			Description:    r.Description,
+			ThemeID:        r.ThemeID,
 			RepeatPattern:  toEngineRepeatPattern(r.RepeatPattern),
 			Exceptions:     toEngineExceptions(r.Exceptions),
 			CompletedDates: completed,
 		}
+		if mat := r.Materialization; mat != nil {
+			out[i].Materialization = schedule_engine.Materialization{
+				Mode:          mat.Mode,
+				Column:        mat.Column,
+				Tags:          mat.Tags,
+				TitleTemplate: mat.TitleTemplate,
+			}
+		}
 	}
 	return out, nil
 }
 
 // buildRoutineTaskCreates translates engine TaskSpecs to access.TaskCreate
-// values, computing each task's DropZone via RuleEngine.
+// values, computing each task's DropZone via RuleEngine. A task lands in
+// the column named by its spec's Status.
 func (m *PlanningManager) buildRoutineTaskCreates(specs []schedule_engine.TaskSpec) ([]access.TaskCreate, error) {
 	if len(specs) == 0 {
 		return nil, nil
@@ -1286,10 +1561,11 @@ func (m *PlanningManager) buildRoutineTaskCreates(specs []schedule_engine.TaskSp
 
 	out := make([]access.TaskCreate, 0, len(specs))
 	for _, spec := range specs {
-		now := utilities.Now()
+		now := m.clock.Now()
 		task := access.Task{
 			Title:       spec.Description,
 			Description: fmt.Sprintf("routine:%s:%s", spec.RoutineID, spec.Date),
+			ThemeID:     spec.ThemeID,
 			Priority:    spec.Priority,
 			Tags:        spec.Tags,
 			RoutineRef:  &access.RoutineRef{RoutineID: spec.RoutineID, Date: spec.Date},
@@ -1297,7 +1573,7 @@ func (m *PlanningManager) buildRoutineTaskCreates(specs []schedule_engine.TaskSp
 			UpdatedAt:   now,
 		}
 		zone := m.ruleEngine.DropZoneForTask(spec.Status, spec.Priority, todoSlug)
-		out = append(out, access.TaskCreate{Task: task, DropZone: zone})
+		out = append(out, access.TaskCreate{Task: task, DropZone: zone, Status: spec.Status})
 	}
 	return out, nil
 }
@@ -1346,11 +1622,31 @@ func toColumnInfos(defs []access.ColumnDefinition) []rule_engine.ColumnInfo {
 // validateTaskOrder repairs task_order.json so that each task appears in exactly
 // the zone that its current (status, priority) dictates.
 func (m *PlanningManager) validateTaskOrder() {
-	config, err := m.getAccessBoardConfig()
+	actualZone, err := m.taskZones()
 	if err != nil {
 		return
 	}
 
+	orderMap, err := m.taskAccess.LoadTaskOrder()
+	if err != nil || len(orderMap) == 0 {
+		return
+	}
+
+	orderMap, changed := m.ruleEngine.ReconcileTaskOrder(orderMap, actualZone)
+	if changed {
+		slog.Info("validateTaskOrder: repaired task_order.json")
+		_ = m.taskAccess.SaveTaskOrder(orderMap)
+	}
+}
+
+// taskZones maps every task ID, including archived ones, to the drop zone
+// its current (status, priority) dictates.
+func (m *PlanningManager) taskZones() (map[string]string, error) {
+	config, err := m.getAccessBoardConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	tSlug := m.ruleEngine.TodoSlugFromColumns(toColumnInfos(config.ColumnDefinitions))
 
 	statuses := make([]string, 0, len(config.ColumnDefinitions)+1)
@@ -1369,17 +1665,7 @@ func (m *PlanningManager) validateTaskOrder() {
 			actualZone[t.ID] = m.ruleEngine.DropZoneForTask(status, t.Priority, tSlug)
 		}
 	}
-
-	orderMap, err := m.taskAccess.LoadTaskOrder()
-	if err != nil || len(orderMap) == 0 {
-		return
-	}
-
-	orderMap, changed := m.ruleEngine.ReconcileTaskOrder(orderMap, actualZone)
-	if changed {
-		slog.Info("validateTaskOrder: repaired task_order.json")
-		_ = m.taskAccess.SaveTaskOrder(orderMap)
-	}
+	return actualZone, nil
 }
 
 // GetTasks returns all tasks with their status across all themes.
@@ -1521,10 +1807,18 @@ func (m *PlanningManager) evaluateRules(event rule_engine.TaskEvent) (*rule_engi
 // CreateTask creates a new task with the given properties.
 // Priority must be one of the valid Eisenhower priorities.
 // Optional fields: description, tags (comma-separated), promotionDate (YYYY-MM-DD).
+// An empty themeId falls back to the default theme from the settings.
 func (m *PlanningManager) CreateTask(title, themeId, priority, description, tags, promotionDate string) (*Task, error) {
 	if !IsValidPriority(priority) {
 		return nil, fmt.Errorf("invalid priority: %s", priority)
 	}
+	if themeId == "" {
+		settings, err := m.settingsAccess.LoadSettings()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load settings: %w", err)
+		}
+		themeId = settings.DefaultThemeID
+	}
 
 	var promDate utilities.CalendarDate
 	if promotionDate != "" {
@@ -1546,6 +1840,9 @@ func (m *PlanningManager) CreateTask(title, themeId, priority, description, tags
 	if err := validateTagNames(tagSlice); err != nil {
 		return nil, err
 	}
+	if err := m.checkThemeAcceptsTasks(themeId); err != nil {
+		return nil, err
+	}
 
 	task := Task{
 		Title:         title,
@@ -1792,7 +2089,7 @@ func (m *PlanningManager) ProcessPriorityPromotions() ([]PromotedTask, error) {
 		return nil, fmt.Errorf("failed to get tasks: %w", err)
 	}
 
-	today := utilities.Today()
+	today := m.clock.Today()
 	type candidate struct {
 		id          string
 		title       string
@@ -1944,6 +2241,43 @@ func (m *PlanningManager) ArchiveAllDoneTasks() error {
 	return nil
 }
 
+// ArchiveDoneTasksOlderThan archives done tasks that were last updated more
+// than days days ago and returns how many were archived. It implements the
+// "after-days" auto-archive policy; tasks without any timestamp are kept.
+func (m *PlanningManager) ArchiveDoneTasksOlderThan(days int) (int, error) {
+	if days < 1 {
+		return 0, fmt.Errorf("days must be at least 1")
+	}
+	allTasks, err := m.GetTasks()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	cutoff := m.clock.Now().Time().AddDate(0, 0, -days)
+	var staleIDs []string
+	for _, t := range allTasks {
+		if t.Status != string(access.TaskStatusDone) {
+			continue
+		}
+		last := t.UpdatedAt
+		if last.IsZero() {
+			last = t.CreatedAt
+		}
+		if last.IsZero() || !last.Time().Before(cutoff) {
+			continue
+		}
+		staleIDs = append(staleIDs, t.ID)
+	}
+
+	// Same ordering rule as ArchiveAllDoneTasks.
+	for i := len(staleIDs) - 1; i >= 0; i-- {
+		if err := m.taskAccess.Archive(staleIDs[i]); err != nil {
+			return len(staleIDs) - 1 - i, fmt.Errorf("failed to archive task %s: %w", staleIDs[i], err)
+		}
+	}
+	return len(staleIDs), nil
+}
+
 // RestoreTask restores an archived task to done.
 func (m *PlanningManager) RestoreTask(taskId string) error {
 	if taskId == "" {
@@ -2105,29 +2439,36 @@ func (m *PlanningManager) Establish(req EstablishRequest) (*EstablishResult, err
 		return &EstablishResult{Theme: theme}, nil
 
 	case GoalTypeObjective:
-		obj, err := m.createObjective(req.ParentID, req.Title)
+		obj, err := m.createObjective(req.ParentID, req.Title, req.CycleID)
 		if err != nil {
 			return nil, err
 		}
 		return &EstablishResult{Objective: obj}, nil
 
 	case GoalTypeKeyResult:
-		startVal := 0
+		spec := access.KeyResult{
+			Description: req.Description,
+			Type:        req.KeyResultType,
+			Direction:   req.Direction,
+			Unit:        req.Unit,
+		}
 		if req.StartValue != nil {
-			startVal = *req.StartValue
+			spec.StartValue = *req.StartValue
 		}
-		targetVal := 0
 		if req.TargetValue != nil {
-			targetVal = *req.TargetValue
+			spec.TargetValue = *req.TargetValue
+		}
+		if err := applyMilestones(&spec, req.Milestones, m.clock.Now()); err != nil {
+			return nil, err
 		}
-		kr, err := m.createKeyResult(req.ParentID, req.Description, startVal, targetVal)
+		kr, err := m.createKeyResult(req.ParentID, spec)
 		if err != nil {
 			return nil, err
 		}
 		return &EstablishResult{KeyResult: kr}, nil
 
 	case GoalTypeRoutine:
-		routine, err := m.addRoutine(req.Description, req.RepeatPattern)
+		routine, err := m.addRoutine(req.Description, req.RepeatPattern, req.ParentID, req.FeedKeyResult, req.Materialization)
 		if err != nil {
 			return nil, err
 		}
@@ -2181,6 +2522,25 @@ func (m *PlanningManager) Revise(req ReviseRequest) error {
 				if req.Tags != nil {
 					obj.Tags = validateTags(*req.Tags)
 				}
+				if req.StartDate != nil || req.EndDate != nil {
+					if err := reviseObjectiveWindow(obj, req.StartDate, req.EndDate); err != nil {
+						return err
+					}
+				}
+				if req.Weight != nil {
+					if err := validateWeight(*req.Weight); err != nil {
+						return err
+					}
+					obj.Weight = *req.Weight
+				}
+				if req.CycleID != nil {
+					if *req.CycleID != "" {
+						if err := m.checkCycleAssignment(obj.ParentID, *req.CycleID); err != nil {
+							return err
+						}
+					}
+					obj.CycleID = *req.CycleID
+				}
 				if err := m.themeAccess.SaveTheme(themes[i]); err != nil {
 					return fmt.Errorf("%w", err)
 				}
@@ -2196,15 +2556,41 @@ func (m *PlanningManager) Revise(req ReviseRequest) error {
 		}
 		for i := range themes {
 			if obj, krIdx := findKeyResultParent(themes[i].Objectives, req.GoalID); obj != nil {
+				kr := obj.KeyResults[krIdx]
 				if req.Description != nil {
-					obj.KeyResults[krIdx].Description = *req.Description
+					kr.Description = *req.Description
+				}
+				if req.KeyResultType != nil {
+					kr.Type = *req.KeyResultType
+				}
+				if req.Direction != nil {
+					kr.Direction = *req.Direction
+				}
+				if req.Unit != nil {
+					kr.Unit = *req.Unit
 				}
 				if req.StartValue != nil {
-					obj.KeyResults[krIdx].StartValue = *req.StartValue
+					kr.StartValue = *req.StartValue
 				}
 				if req.TargetValue != nil {
-					obj.KeyResults[krIdx].TargetValue = *req.TargetValue
+					kr.TargetValue = *req.TargetValue
+				}
+				if req.Milestones != nil {
+					if err := applyMilestones(&kr, *req.Milestones, m.clock.Now()); err != nil {
+						return err
+					}
 				}
+				if req.Weight != nil {
+					if err := validateWeight(*req.Weight); err != nil {
+						return err
+					}
+					kr.Weight = *req.Weight
+				}
+				if err := normalizeKeyResult(&kr); err != nil {
+					return err
+				}
+				deriveCurrentValue(&kr)
+				obj.KeyResults[krIdx] = kr
 				if err := m.themeAccess.SaveTheme(themes[i]); err != nil {
 					return fmt.Errorf("%w", err)
 				}
@@ -2234,7 +2620,45 @@ func (m *PlanningManager) Revise(req ReviseRequest) error {
 		if req.ClearRepeat {
 			routine.RepeatPattern = nil
 		} else if req.RepeatPattern != nil {
-			routine.RepeatPattern = toAccessRepeatPattern(req.RepeatPattern)
+			pattern, err := normalizeRepeatPattern(req.RepeatPattern)
+			if err != nil {
+				return err
+			}
+			routine.RepeatPattern = toAccessRepeatPattern(pattern)
+		}
+		if req.ThemeID != nil || req.KeyResultID != nil || req.FeedKeyResult != nil {
+			if req.ThemeID != nil {
+				routine.ThemeID = *req.ThemeID
+				if req.KeyResultID == nil && *req.ThemeID == "" {
+					routine.KeyResultID = ""
+				}
+			}
+			if req.KeyResultID != nil {
+				routine.KeyResultID = *req.KeyResultID
+				if *req.KeyResultID == "" {
+					routine.FeedKeyResult = false
+				} else if req.ThemeID == nil {
+					// The key result implies its theme.
+					routine.ThemeID = ""
+				}
+			}
+			if req.FeedKeyResult != nil {
+				routine.FeedKeyResult = *req.FeedKeyResult
+			}
+			themes, err := m.themeAccess.GetThemes()
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+			if routine.ThemeID, err = resolveRoutineLink(themes, routine.ThemeID, routine.KeyResultID, routine.FeedKeyResult); err != nil {
+				return err
+			}
+		}
+		if req.Materialization != nil {
+			mat, err := m.normalizeMaterialization(req.Materialization)
+			if err != nil {
+				return err
+			}
+			routine.Materialization = toAccessMaterialization(mat)
 		}
 		if err := m.routineAccess.SaveRoutine(*routine); err != nil {
 			return fmt.Errorf("%w", err)
@@ -2246,10 +2670,11 @@ func (m *PlanningManager) Revise(req ReviseRequest) error {
 	}
 }
 
-// RecordProgress updates the current value of a measurable goal.
+// RecordProgress updates the current value of a measurable goal. For key
+// results this records a check-in without a note or confidence.
 // Routines no longer carry a numeric currentValue — their progress is computed
 // from RepeatPattern + routineChecks via ScheduleEngine.
-func (m *PlanningManager) RecordProgress(goalId string, value int) error {
+func (m *PlanningManager) RecordProgress(goalId string, value float64) error {
 	if goalId == "" {
 		return fmt.Errorf("goalId cannot be empty")
 	}
@@ -2258,7 +2683,8 @@ func (m *PlanningManager) RecordProgress(goalId string, value int) error {
 
 	switch goalType {
 	case GoalTypeKeyResult:
-		return m.updateKeyResultProgress(goalId, value)
+		_, err := m.RecordCheckIn(goalId, value, "", 0)
+		return err
 
 	default:
 		return fmt.Errorf("RecordProgress not supported for goal type %s", goalType)
@@ -2386,7 +2812,7 @@ func (m *PlanningManager) SavePersonalVision(mission, vision string) error {
 	v := &access.PersonalVision{
 		Mission:   mission,
 		Vision:    vision,
-		UpdatedAt: utilities.Now(),
+		UpdatedAt: m.clock.Now(),
 	}
 	return m.visionAccess.SaveVision(v)
 }
@@ -2397,7 +2823,12 @@ func (m *PlanningManager) GetAllThemeProgress() ([]ThemeProgress, error) {
 	if err != nil {
 		return nil, err
 	}
+	return m.computeThemeProgress(visibleThemes(themes)), nil
+}
 
+// computeThemeProgress computes progress for the given themes via the
+// progress engine.
+func (m *PlanningManager) computeThemeProgress(themes []access.LifeTheme) []ThemeProgress {
 	// Convert access themes to engine DTOs
 	engineThemes := make([]progress_engine.ThemeData, len(themes))
 	for i, t := range themes {
@@ -2413,21 +2844,25 @@ func (m *PlanningManager) GetAllThemeProgress() ([]ThemeProgress, error) {
 		objectives := make([]ObjectiveProgress, len(tp.Objectives))
 		for j, op := range tp.Objectives {
 			objectives[j] = ObjectiveProgress{
-				ObjectiveID: op.ObjectiveID,
-				Progress:    op.Progress,
+				ObjectiveID:   op.ObjectiveID,
+				Progress:      op.Progress,
+				Contributions: toManagerContributions(op.Contributions),
 			}
 		}
 		result[i] = ThemeProgress{
-			ThemeID:    tp.ThemeID,
-			Progress:   tp.Progress,
-			Objectives: objectives,
+			ThemeID:       tp.ThemeID,
+			Progress:      tp.Progress,
+			Objectives:    objectives,
+			Contributions: toManagerContributions(tp.Contributions),
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 // GetRoutinesForDate returns all routine occurrences (scheduled, overdue, sporadic) for the given date.
+// Occurrences of routines serving a theme carry its ID and are grouped by
+// theme in theme order, ahead of the routines serving none.
 func (m *PlanningManager) GetRoutinesForDate(date string) ([]RoutineOccurrence, error) {
 	if date == "" {
 		return nil, fmt.Errorf("date cannot be empty")
@@ -2438,31 +2873,23 @@ func (m *PlanningManager) GetRoutinesForDate(date string) ([]RoutineOccurrence,
 		return nil, fmt.Errorf("GetRoutinesForDate: failed to get routines: %w", err)
 	}
 
-	year, err := strconv.Atoi(date[:4])
-	if err != nil {
+	if _, err := utilities.ParseCalendarDate(date); err != nil {
 		return nil, fmt.Errorf("GetRoutinesForDate: invalid date format: %w", err)
 	}
 
-	yearEntries, err := m.calendarAccess.GetYearFocus(year)
+	day, err := m.calendarAccess.GetDayFocus(date)
 	if err != nil {
-		return nil, fmt.Errorf("GetRoutinesForDate: failed to get year focus: %w", err)
+		return nil, fmt.Errorf("GetRoutinesForDate: failed to get day focus: %w", err)
 	}
-
-	// Build a map of date -> set of checked routine IDs
-	checkedByDate := make(map[string]map[string]bool)
-	for _, entry := range yearEntries {
-		if len(entry.RoutineChecks) > 0 {
-			set := make(map[string]bool)
-			for _, rid := range entry.RoutineChecks {
-				set[rid] = true
-			}
-			checkedByDate[entry.Date.String()] = set
+	todayChecks := make(map[string]bool)
+	if day != nil {
+		for _, rid := range day.RoutineChecks {
+			todayChecks[rid] = true
 		}
 	}
 
 	result := []RoutineOccurrence{}
-	todayChecks := checkedByDate[date]
-	today := utilities.Today().String()
+	today := m.clock.Today().String()
 
 	for _, routine := range routines {
 		enginePattern := toEngineRepeatPattern(routine.RepeatPattern)
@@ -2470,7 +2897,7 @@ func (m *PlanningManager) GetRoutinesForDate(date string) ([]RoutineOccurrence,
 
 		if enginePattern == nil {
 			// Sporadic routine — always shown, check if checked
-			checked := todayChecks != nil && todayChecks[routine.ID]
+			checked := todayChecks[routine.ID]
 			result = append(result, RoutineOccurrence{
 				RoutineID:   routine.ID,
 				Description: routine.Description,
@@ -2481,10 +2908,19 @@ func (m *PlanningManager) GetRoutinesForDate(date string) ([]RoutineOccurrence,
 			continue
 		}
 
+		if enginePattern.AfterCompletion {
+			floating, err := m.floatingRoutineOccurrences(routine, *enginePattern, date, today, todayChecks[routine.ID])
+			if err != nil {
+				return nil, fmt.Errorf("GetRoutinesForDate: %w", err)
+			}
+			result = append(result, floating...)
+			continue
+		}
+
 		// Periodic routine — check if scheduled for this date
 		occurrences := m.scheduleEngine.ComputeOccurrences(*enginePattern, engineExceptions, date, date)
 		for _, occ := range occurrences {
-			checked := todayChecks != nil && todayChecks[routine.ID]
+			checked := todayChecks[routine.ID]
 			result = append(result, RoutineOccurrence{
 				RoutineID:   routine.ID,
 				Description: routine.Description,
@@ -2501,12 +2937,11 @@ func (m *PlanningManager) GetRoutinesForDate(date string) ([]RoutineOccurrence,
 			continue
 		}
 
-		// Collect all completed dates for this routine across the year.
-		var completedDates []string
-		for d, checks := range checkedByDate {
-			if checks[routine.ID] {
-				completedDates = append(completedDates, d)
-			}
+		// Completions of every year, so occurrences missed across a year
+		// boundary are judged against the full history.
+		completedDates, err := m.calendarAccess.GetRoutineCompletions(routine.ID)
+		if err != nil {
+			return nil, fmt.Errorf("GetRoutinesForDate: failed to get completions for routine %s: %w", routine.ID, err)
 		}
 
 		// Collapse the engine-returned overdue dates into a single entry per
@@ -2533,6 +2968,21 @@ func (m *PlanningManager) GetRoutinesForDate(date string) ([]RoutineOccurrence,
 		})
 	}
 
+	if hasRoutineLinks(routines) {
+		themes, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return nil, fmt.Errorf("GetRoutinesForDate: failed to get themes: %w", err)
+		}
+		themeByRoutine := make(map[string]string, len(routines))
+		for _, r := range dropDanglingRoutineLinks(routines, themes) {
+			themeByRoutine[r.ID] = r.ThemeID
+		}
+		for i := range result {
+			result[i].ThemeID = themeByRoutine[result[i].RoutineID]
+		}
+		sortOccurrencesByTheme(result, themes)
+	}
+
 	return result, nil
 }
 
@@ -2571,7 +3021,8 @@ func (m *PlanningManager) RescheduleRoutineOccurrence(routineID, originalDate, n
 	return fmt.Errorf("RescheduleRoutineOccurrence: routine %s not found", routineID)
 }
 
-// GetRoutineProgress computes period-based completion stats for a periodic routine.
+// GetRoutineProgress computes period-based completion stats for a periodic routine,
+// with totals and averages of the amounts and durations checked in over the period.
 func (m *PlanningManager) GetRoutineProgress(routineID string) (*RoutinePeriodProgress, error) {
 	if routineID == "" {
 		return nil, fmt.Errorf("routineID cannot be empty")
@@ -2602,34 +3053,32 @@ func (m *PlanningManager) GetRoutineProgress(routineID string) (*RoutinePeriodPr
 	enginePattern := toEngineRepeatPattern(routine.RepeatPattern)
 	engineExceptions := toEngineExceptions(routine.Exceptions)
 
-	todayDate := utilities.Today()
+	todayDate := m.clock.Today()
 	today := todayDate.String()
 
-	year := todayDate.Time().Year()
-	yearEntries, err := m.calendarAccess.GetYearFocus(year)
+	// A week or a floating cycle can start in an earlier year.
+	completedDates, err := m.calendarAccess.GetRoutineCompletions(routineID)
 	if err != nil {
-		return nil, fmt.Errorf("GetRoutineProgress: failed to get year focus: %w", err)
+		return nil, fmt.Errorf("GetRoutineProgress: failed to get completions: %w", err)
 	}
 
-	var completedDates []string
-	for _, entry := range yearEntries {
-		for _, rid := range entry.RoutineChecks {
-			if rid == routineID {
-				completedDates = append(completedDates, entry.Date.String())
-				break
-			}
-		}
+	settings, err := m.settingsAccess.LoadSettings()
+	if err != nil {
+		return nil, fmt.Errorf("GetRoutineProgress: failed to load settings: %w", err)
 	}
+	completion := m.scheduleEngine.EvaluatePeriodCompletion(*enginePattern, engineExceptions, completedDates, today, weekStartDay(settings.WeekStart))
 
-	completion := m.scheduleEngine.EvaluatePeriodCompletion(*enginePattern, engineExceptions, completedDates, today)
-
-	return &RoutinePeriodProgress{
+	progress := &RoutinePeriodProgress{
 		RoutineID: routineID,
 		Completed: completion.Completed,
 		Expected:  completion.Expected,
 		Period:    completion.Period,
 		OnTrack:   completion.OnTrack,
-	}, nil
+	}
+	if err := m.addCheckInTotals(progress, completion.Start, completion.End); err != nil {
+		return nil, fmt.Errorf("GetRoutineProgress: failed to sum check-ins: %w", err)
+	}
+	return progress, nil
 }
 
 // GetRoutines returns all routines via RoutineAccess, converted to manager types.
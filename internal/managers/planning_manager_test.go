@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/rkn/bearing/internal/access"
 	"github.com/rkn/bearing/internal/utilities"
@@ -126,6 +127,19 @@ func (m *mockThemeAccess) WriteDeleteTheme(id string) error {
 	return m.DeleteTheme(id)
 }
 
+func (m *mockThemeAccess) WriteRenameTheme(oldID string, theme access.LifeTheme) error {
+	for i, t := range m.themes {
+		if t.ID == oldID {
+			maxO := collectMockMaxObjNum(theme.ID, theme.Objectives)
+			maxKR := collectMockMaxKRNum(theme.ID, theme.Objectives)
+			theme.Objectives, _, _ = ensureMockObjectiveIDs(theme.ID, theme.ID, theme.Objectives, maxO, maxKR)
+			m.themes[i] = theme
+			return nil
+		}
+	}
+	return fmt.Errorf("theme with ID %s not found", oldID)
+}
+
 // mockTaskAccess implements access.ITaskAccess plus the new ITask, IBatch,
 // and IBoard facet interfaces for testing.
 type mockTaskAccess struct {
@@ -198,8 +212,8 @@ func (m *mockTaskAccess) saveTaskInternal(task access.Task) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Match real TaskAccess validation: empty themeID requires Routine tag
-	if task.ThemeID == "" && !slices.Contains(task.Tags, "Routine") {
+	// Match real TaskAccess validation: empty themeID requires a routine link or the Routine tag
+	if task.ThemeID == "" && task.RoutineRef == nil && !slices.Contains(task.Tags, "Routine") {
 		return fmt.Errorf("mockTaskAccess.saveTaskInternal: themeID cannot be empty")
 	}
 
@@ -319,6 +333,10 @@ func (m *mockTaskAccess) SaveTaskOrder(order map[string][]string) error {
 	return nil
 }
 
+func (m *mockTaskAccess) WriteTaskOrder(order map[string][]string) error {
+	return m.SaveTaskOrder(order)
+}
+
 func (m *mockTaskAccess) GetBoardConfiguration() (*access.BoardConfiguration, error) {
 	if m.boardConfig != nil {
 		return m.boardConfig, nil
@@ -505,6 +523,36 @@ func (m *mockTaskAccess) ReorderColumns(slugs []string) (access.BoardConfigurati
 	return *m.boardConfig, nil
 }
 
+func (m *mockTaskAccess) WriteRenameTheme(oldThemeID, newThemeID string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mapping := make(map[string]string)
+	for status, tasks := range m.tasks {
+		for i := range tasks {
+			if rest, ok := strings.CutPrefix(tasks[i].ID, oldThemeID+"-T"); ok {
+				mapping[tasks[i].ID] = newThemeID + "-T" + rest
+				tasks[i].ID = newThemeID + "-T" + rest
+			}
+			if tasks[i].ThemeID == oldThemeID {
+				tasks[i].ThemeID = newThemeID
+			}
+		}
+		m.tasks[status] = tasks
+	}
+	rename := func(ids []string) {
+		for i, id := range ids {
+			if newID, ok := mapping[id]; ok {
+				ids[i] = newID
+			}
+		}
+	}
+	for _, ids := range m.taskOrder {
+		rename(ids)
+	}
+	rename(m.archivedOrder)
+	return mapping, nil
+}
+
 func (m *mockTaskAccess) LoadArchivedOrder() ([]string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -514,6 +562,13 @@ func (m *mockTaskAccess) LoadArchivedOrder() ([]string, error) {
 	return append([]string{}, m.archivedOrder...), nil
 }
 
+func (m *mockTaskAccess) WriteArchivedOrder(order []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.archivedOrder = append([]string{}, order...)
+	return nil
+}
+
 // =============================================================================
 // ITask facet (task 94) — implemented in terms of the legacy mock storage.
 // =============================================================================
@@ -881,6 +936,11 @@ func (m *mockTaskAccess) commitInternal(req access.BatchRequest) (access.BatchOu
 		if err != nil {
 			return access.BatchOutcome{}, err
 		}
+		if create.Status != "" && create.Status != "todo" {
+			if err := m.moveTaskInternal(saved.ID, create.Status); err != nil {
+				return access.BatchOutcome{}, err
+			}
+		}
 		outcome.CreatedIDs = append(outcome.CreatedIDs, saved.ID)
 	}
 	for _, taskID := range req.Deletes {
@@ -957,10 +1017,114 @@ func (m *mockRoutineAccess) WriteDeleteRoutine(id string) error {
 	return m.DeleteRoutine(id)
 }
 
+// mockCycleAccess implements access.ICycleAccess for testing.
+type mockCycleAccess struct {
+	mu     sync.Mutex
+	cycles []access.Cycle
+}
+
+func newMockCycleAccess() *mockCycleAccess {
+	return &mockCycleAccess{
+		cycles: []access.Cycle{},
+	}
+}
+
+func (m *mockCycleAccess) GetCycles() ([]access.Cycle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]access.Cycle, len(m.cycles))
+	copy(result, m.cycles)
+	return result, nil
+}
+
+func (m *mockCycleAccess) SaveCycle(cycle access.Cycle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, c := range m.cycles {
+		if c.ID == cycle.ID {
+			m.cycles[i] = cycle
+			return nil
+		}
+	}
+	m.cycles = append(m.cycles, cycle)
+	return nil
+}
+
+func (m *mockCycleAccess) DeleteCycle(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, c := range m.cycles {
+		if c.ID == id {
+			m.cycles = append(m.cycles[:i], m.cycles[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("cycle with ID %s not found", id)
+}
+
+func (m *mockCycleAccess) WriteCycle(cycle access.Cycle) error {
+	return m.SaveCycle(cycle)
+}
+
+// mockWeekAccess implements access.IWeekAccess for testing.
+type mockWeekAccess struct {
+	mu    sync.Mutex
+	weeks map[string]access.WeekPlan
+}
+
+func newMockWeekAccess() *mockWeekAccess {
+	return &mockWeekAccess{
+		weeks: map[string]access.WeekPlan{},
+	}
+}
+
+func (m *mockWeekAccess) GetWeekPlan(week string) (*access.WeekPlan, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	plan, ok := m.weeks[week]
+	if !ok {
+		return nil, nil
+	}
+	return &plan, nil
+}
+
+func (m *mockWeekAccess) GetWeekPlans() ([]access.WeekPlan, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]access.WeekPlan, 0, len(m.weeks))
+	for _, plan := range m.weeks {
+		result = append(result, plan)
+	}
+	slices.SortFunc(result, func(a, b access.WeekPlan) int { return strings.Compare(a.Week, b.Week) })
+	return result, nil
+}
+
+func (m *mockWeekAccess) SaveWeekPlan(plan access.WeekPlan) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.weeks[plan.Week] = plan
+	return nil
+}
+
+func (m *mockWeekAccess) DeleteWeekPlan(week string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.weeks[week]; !ok {
+		return fmt.Errorf("no plan for week %s", week)
+	}
+	delete(m.weeks, week)
+	return nil
+}
+
+func (m *mockWeekAccess) WriteWeekPlan(plan access.WeekPlan) error {
+	return m.SaveWeekPlan(plan)
+}
+
 // mockCalendarAccess implements access.ICalendarAccess for testing.
 // It stores day focus entries in memory so tests can verify saved data.
 type mockCalendarAccess struct {
-	days map[string]access.DayFocus
+	days          map[string]access.DayFocus
+	indexRebuilds int
 }
 
 func newMockCalendarAccess() *mockCalendarAccess {
@@ -1011,6 +1175,27 @@ func (m *mockCalendarAccess) GetRoutineCompletions(routineID string) ([]string,
 	return dates, nil
 }
 
+// RebuildRoutineCompletionIndex counts rebuilds; the mock has no index.
+func (m *mockCalendarAccess) RebuildRoutineCompletionIndex() error {
+	m.indexRebuilds++
+	return nil
+}
+
+// GetFocusYears returns the distinct years of all stored entries, ascending.
+func (m *mockCalendarAccess) GetFocusYears() ([]int, error) {
+	seen := make(map[int]bool)
+	years := []int{}
+	for date := range m.days {
+		var year int
+		if _, err := fmt.Sscanf(date, "%04d-", &year); err == nil && !seen[year] {
+			seen[year] = true
+			years = append(years, year)
+		}
+	}
+	sort.Ints(years)
+	return years, nil
+}
+
 // mockVisionAccess implements access.IVisionAccess for testing.
 type mockVisionAccess struct {
 	vision *access.PersonalVision
@@ -1047,19 +1232,11 @@ func (m *mockUIStateAccess) SaveTaskDrafts(data json.RawMessage) error {
 	return nil
 }
 
-func (m *mockUIStateAccess) LoadAdvisorEnabled() (bool, error) {
-	return false, nil
-}
-
-func (m *mockUIStateAccess) SaveAdvisorEnabled(enabled bool) error {
-	return nil
-}
-
 // newMockManager creates a PlanningManager with all mock dependencies for testing convenience.
 func newMockManager() (*PlanningManager, *mockThemeAccess, *mockTaskAccess) {
 	ta := newMockThemeAccess()
 	ka := newMockTaskAccess()
-	pm, _ := NewPlanningManager(ta, ka, newMockCalendarAccess(), newMockRoutineAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo())
+	pm, _ := NewPlanningManager(ta, ka, newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
 	return pm, ta, ka
 }
 
@@ -1071,10 +1248,55 @@ func newMockManagerWithRoutines() (*PlanningManager, *mockTaskAccess, *mockCalen
 	ka := newMockTaskAccess()
 	ca := newMockCalendarAccess()
 	ra := newMockRoutineAccess()
-	pm, _ := NewPlanningManager(ta, ka, ca, ra, &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo())
+	pm, _ := NewPlanningManager(ta, ka, ca, ra, newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
 	return pm, ka, ca, ra
 }
 
+// testManagerDeps names the dependencies a test wants to seed or inspect.
+// newTestManager fills every nil field with a fresh mock, the stub repo or
+// the default clock.
+type testManagerDeps struct {
+	themes   *mockThemeAccess
+	tasks    *mockTaskAccess
+	calendar *mockCalendarAccess
+	routines *mockRoutineAccess
+	uiState  access.IUIStateAccess
+	repo     *stubRepo
+	clock    utilities.Clock
+}
+
+// newTestManager creates a PlanningManager from deps, failing the test if
+// construction fails.
+func newTestManager(t *testing.T, deps testManagerDeps) *PlanningManager {
+	t.Helper()
+	if deps.themes == nil {
+		deps.themes = newMockThemeAccess()
+	}
+	if deps.tasks == nil {
+		deps.tasks = newMockTaskAccess()
+	}
+	if deps.calendar == nil {
+		deps.calendar = newMockCalendarAccess()
+	}
+	if deps.routines == nil {
+		deps.routines = newMockRoutineAccess()
+	}
+	if deps.uiState == nil {
+		deps.uiState = &mockUIStateAccess{}
+	}
+	if deps.repo == nil {
+		deps.repo = newStubRepo()
+	}
+	if deps.clock == nil {
+		deps.clock = utilities.DefaultClock()
+	}
+	pm, err := NewPlanningManager(deps.themes, deps.tasks, deps.calendar, deps.routines, newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, deps.uiState, deps.repo, deps.clock)
+	if err != nil {
+		t.Fatalf("NewPlanningManager: %v", err)
+	}
+	return pm
+}
+
 // stubRepo is a minimal utilities.IRepository for manager tests that do
 // not exercise on-disk git semantics. utilities.RunTransaction calls
 // only Begin(); the returned stubTransaction records Stage/Commit/Cancel
@@ -1107,10 +1329,12 @@ func (s *stubRepo) GetFileHistory(_ string, _ int) ([]utilities.CommitInfo, erro
 }
 func (s *stubRepo) GetFileHistoryStream(_ string) <-chan utilities.CommitInfo { return nil }
 func (s *stubRepo) GetFileDifferences(_, _ string) ([]byte, error)            { return nil, nil }
+func (s *stubRepo) GetFileAtCommit(_, _ string) ([]byte, error)               { return nil, nil }
 func (s *stubRepo) ValidateRepositoryAndPaths(_ utilities.RepositoryValidationRequest) (*utilities.RepositoryValidationResult, error) {
 	return nil, nil
 }
-func (s *stubRepo) Close() error { return nil }
+func (s *stubRepo) RestoreWorkTree() error { return nil }
+func (s *stubRepo) Close() error           { return nil }
 
 func (t *stubTransaction) Stage(_ []string) error {
 	t.mu.Lock()
@@ -1162,7 +1386,7 @@ func testCreateObjective(m *PlanningManager, parentId, title string) (*Objective
 }
 
 // testCreateKeyResult calls Establish to create a key result.
-func testCreateKeyResult(m *PlanningManager, parentObjectiveId, description string, startValue, targetValue int) (*KeyResult, error) {
+func testCreateKeyResult(m *PlanningManager, parentObjectiveId, description string, startValue, targetValue float64) (*KeyResult, error) {
 	res, err := m.Establish(EstablishRequest{
 		GoalType:    GoalTypeKeyResult,
 		ParentID:    parentObjectiveId,
@@ -1324,7 +1548,7 @@ func findKeyResultByID(objectives []Objective, id string) *KeyResult {
 
 func TestNewPlanningManager(t *testing.T) {
 	t.Run("creates manager with valid access", func(t *testing.T) {
-		manager, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo())
+		manager, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
@@ -1334,32 +1558,60 @@ func TestNewPlanningManager(t *testing.T) {
 	})
 
 	t.Run("returns error with nil theme access", func(t *testing.T) {
-		_, err := NewPlanningManager(nil, newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo())
+		_, err := NewPlanningManager(nil, newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
 		if err == nil {
 			t.Fatal("expected error for nil theme access")
 		}
 	})
 
 	t.Run("returns error with nil routine access", func(t *testing.T) {
-		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), nil, &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo())
+		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), nil, newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
 		if err == nil {
 			t.Fatal("expected error for nil routine access")
 		}
 	})
 
+	t.Run("returns error with nil cycle access", func(t *testing.T) {
+		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), nil, newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
+		if err == nil {
+			t.Fatal("expected error for nil cycle access")
+		}
+	})
+
+	t.Run("returns error with nil week access", func(t *testing.T) {
+		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), nil, newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
+		if err == nil {
+			t.Fatal("expected error for nil week access")
+		}
+	})
+
+	t.Run("returns error with nil settings access", func(t *testing.T) {
+		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), nil, &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
+		if err == nil {
+			t.Fatal("expected error for nil settings access")
+		}
+	})
+
 	t.Run("returns error with nil ui state access", func(t *testing.T) {
-		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), &mockVisionAccess{}, nil, newStubRepo())
+		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, nil, newStubRepo(), utilities.DefaultClock())
 		if err == nil {
 			t.Fatal("expected error for nil ui state access")
 		}
 	})
 
 	t.Run("returns error with nil repo", func(t *testing.T) {
-		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, nil)
+		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, nil, utilities.DefaultClock())
 		if err == nil {
 			t.Fatal("expected error for nil repo")
 		}
 	})
+
+	t.Run("returns error with nil clock", func(t *testing.T) {
+		_, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), nil)
+		if err == nil {
+			t.Fatal("expected error for nil clock")
+		}
+	})
 }
 
 // =============================================================================
@@ -1403,8 +1655,8 @@ func TestUpdateTheme(t *testing.T) {
 		}
 
 		// Now revise the KR (modify start=2, target=20)
-		startVal := 2
-		targetVal := 20
+		startVal := 2.0
+		targetVal := 20.0
 		err = manager.Revise(ReviseRequest{GoalID: kr.ID, StartValue: &startVal, TargetValue: &targetVal})
 		if err != nil {
 			t.Fatalf("expected no error revising KR, got %v", err)
@@ -1414,13 +1666,13 @@ func TestUpdateTheme(t *testing.T) {
 		themes, _ := manager.GetHierarchy()
 		found := findManagerObjectiveByID(themes[0].Objectives, obj.ID)
 		if found.KeyResults[0].StartValue != 2 {
-			t.Errorf("expected startValue 2, got %d", found.KeyResults[0].StartValue)
+			t.Errorf("expected startValue 2, got %g", found.KeyResults[0].StartValue)
 		}
 		if found.KeyResults[0].CurrentValue != 5 {
-			t.Errorf("expected currentValue 5, got %d", found.KeyResults[0].CurrentValue)
+			t.Errorf("expected currentValue 5, got %g", found.KeyResults[0].CurrentValue)
 		}
 		if found.KeyResults[0].TargetValue != 20 {
-			t.Errorf("expected targetValue 20, got %d", found.KeyResults[0].TargetValue)
+			t.Errorf("expected targetValue 20, got %g", found.KeyResults[0].TargetValue)
 		}
 	})
 
@@ -1468,10 +1720,10 @@ func TestUpdateTheme(t *testing.T) {
 			t.Errorf("expected KR %s to survive under Level 2", kr.ID)
 		}
 		if l2.KeyResults[0].StartValue != 1 {
-			t.Errorf("expected startValue 1, got %d", l2.KeyResults[0].StartValue)
+			t.Errorf("expected startValue 1, got %g", l2.KeyResults[0].StartValue)
 		}
 		if l2.KeyResults[0].TargetValue != 10 {
-			t.Errorf("expected targetValue 10, got %d", l2.KeyResults[0].TargetValue)
+			t.Errorf("expected targetValue 10, got %g", l2.KeyResults[0].TargetValue)
 		}
 	})
 }
@@ -1875,10 +2127,10 @@ func TestCreateKeyResult(t *testing.T) {
 			t.Fatalf("expected no error, got %v", err)
 		}
 		if kr.StartValue != 0 {
-			t.Errorf("expected startValue 0, got %d", kr.StartValue)
+			t.Errorf("expected startValue 0, got %g", kr.StartValue)
 		}
 		if kr.TargetValue != 12 {
-			t.Errorf("expected targetValue 12, got %d", kr.TargetValue)
+			t.Errorf("expected targetValue 12, got %g", kr.TargetValue)
 		}
 	})
 
@@ -1952,10 +2204,10 @@ func TestCreateKeyResult(t *testing.T) {
 			t.Fatalf("expected no error, got %v", err)
 		}
 		if kr.StartValue != 2 {
-			t.Errorf("expected startValue 2, got %d", kr.StartValue)
+			t.Errorf("expected startValue 2, got %g", kr.StartValue)
 		}
 		if kr.TargetValue != 14 {
-			t.Errorf("expected targetValue 14, got %d", kr.TargetValue)
+			t.Errorf("expected targetValue 14, got %g", kr.TargetValue)
 		}
 	})
 
@@ -1968,10 +2220,10 @@ func TestCreateKeyResult(t *testing.T) {
 			t.Fatalf("expected no error, got %v", err)
 		}
 		if kr.StartValue != 0 {
-			t.Errorf("expected startValue 0, got %d", kr.StartValue)
+			t.Errorf("expected startValue 0, got %g", kr.StartValue)
 		}
 		if kr.TargetValue != 12 {
-			t.Errorf("expected targetValue 12, got %d", kr.TargetValue)
+			t.Errorf("expected targetValue 12, got %g", kr.TargetValue)
 		}
 	})
 
@@ -1999,6 +2251,106 @@ func TestCreateKeyResult(t *testing.T) {
 	})
 }
 
+func TestCreateKeyResult_ValueTypes(t *testing.T) {
+	establish := func(m *PlanningManager, req EstablishRequest) (*KeyResult, error) {
+		req.GoalType = GoalTypeKeyResult
+		req.ParentID = "T-O1"
+		req.Description = "KR"
+		res, err := m.Establish(req)
+		if err != nil {
+			return nil, err
+		}
+		return res.KeyResult, nil
+	}
+	ptr := func(v float64) *float64 { return &v }
+
+	t.Run("decimal values with unit", func(t *testing.T) {
+		manager, _, _ := newMockManager()
+		testCreateObjective(manager, "T", "Objective")
+
+		kr, err := establish(manager, EstablishRequest{StartValue: ptr(1.5), TargetValue: ptr(4.25), Unit: "  km "})
+		if err != nil {
+			t.Fatalf("Establish: %v", err)
+		}
+		if kr.StartValue != 1.5 || kr.CurrentValue != 1.5 || kr.TargetValue != 4.25 {
+			t.Errorf("values = %g/%g/%g, want 1.5/1.5/4.25", kr.StartValue, kr.CurrentValue, kr.TargetValue)
+		}
+		if kr.Unit != "km" || kr.Direction != "increase" {
+			t.Errorf("unit/direction = %q/%q, want km/increase", kr.Unit, kr.Direction)
+		}
+	})
+
+	t.Run("decreasing requires explicit direction", func(t *testing.T) {
+		manager, _, _ := newMockManager()
+		testCreateObjective(manager, "T", "Objective")
+
+		if _, err := establish(manager, EstablishRequest{StartValue: ptr(90), TargetValue: ptr(80)}); err == nil {
+			t.Fatal("expected error for a falling target without direction")
+		}
+		kr, err := establish(manager, EstablishRequest{StartValue: ptr(90), TargetValue: ptr(0), Direction: "decrease"})
+		if err != nil {
+			t.Fatalf("Establish decreasing: %v", err)
+		}
+		if kr.Direction != "decrease" || kr.TargetValue != 0 {
+			t.Errorf("kr = %+v, want decreasing to 0", kr)
+		}
+		if _, err := establish(manager, EstablishRequest{StartValue: ptr(1), TargetValue: ptr(5), Direction: "decrease"}); err == nil {
+			t.Error("expected error for a decreasing KR whose target is above its start")
+		}
+	})
+
+	t.Run("percentage bounds and unit", func(t *testing.T) {
+		manager, _, _ := newMockManager()
+		testCreateObjective(manager, "T", "Objective")
+
+		kr, err := establish(manager, EstablishRequest{KeyResultType: "percentage", StartValue: ptr(12.5), TargetValue: ptr(95)})
+		if err != nil {
+			t.Fatalf("Establish percentage: %v", err)
+		}
+		if kr.Type != "percentage" || kr.Unit != "%" {
+			t.Errorf("type/unit = %q/%q, want percentage/%%", kr.Type, kr.Unit)
+		}
+		if _, err := establish(manager, EstablishRequest{KeyResultType: "percentage", TargetValue: ptr(120)}); err == nil {
+			t.Error("expected error for a percentage target above 100")
+		}
+		if err := manager.RecordProgress(kr.ID, 101); err == nil {
+			t.Error("expected error for a percentage check-in above 100")
+		}
+	})
+
+	t.Run("rejects invalid type and direction", func(t *testing.T) {
+		manager, _, _ := newMockManager()
+		testCreateObjective(manager, "T", "Objective")
+
+		if _, err := establish(manager, EstablishRequest{KeyResultType: "ratio"}); err == nil {
+			t.Error("expected error for unknown type")
+		}
+		if _, err := establish(manager, EstablishRequest{Direction: "sideways"}); err == nil {
+			t.Error("expected error for unknown direction")
+		}
+	})
+
+	t.Run("revise switches to decreasing", func(t *testing.T) {
+		manager, _, _ := newMockManager()
+		testCreateObjective(manager, "T", "Objective")
+		kr, _ := testCreateKeyResult(manager, "T-O1", "Weight", 0, 0)
+
+		start, target := 92.4, 85.0
+		if err := manager.Revise(ReviseRequest{GoalID: kr.ID, StartValue: &start, TargetValue: &target}); err == nil {
+			t.Fatal("expected error while the key result still increases")
+		}
+		direction, unit := "decrease", "kg"
+		if err := manager.Revise(ReviseRequest{GoalID: kr.ID, StartValue: &start, TargetValue: &target, Direction: &direction, Unit: &unit}); err != nil {
+			t.Fatalf("Revise: %v", err)
+		}
+		themes, _ := manager.GetHierarchy()
+		got := themes[0].Objectives[0].KeyResults[0]
+		if got.Direction != "decrease" || got.Unit != "kg" || got.StartValue != 92.4 || got.CurrentValue != 92.4 || got.TargetValue != 85 {
+			t.Errorf("revised kr = %+v", got)
+		}
+	})
+}
+
 func TestUpdateKeyResult(t *testing.T) {
 	t.Run("updates key result description", func(t *testing.T) {
 		manager, _, _ := newMockManager()
@@ -2071,7 +2423,7 @@ func TestUpdateKeyResultProgress(t *testing.T) {
 		themes, _ := manager.GetHierarchy()
 		found := findManagerObjectiveByID(themes[0].Objectives, obj.ID)
 		if found.KeyResults[0].CurrentValue != 5 {
-			t.Errorf("expected currentValue 5, got %d", found.KeyResults[0].CurrentValue)
+			t.Errorf("expected currentValue 5, got %g", found.KeyResults[0].CurrentValue)
 		}
 	})
 
@@ -2090,7 +2442,7 @@ func TestUpdateKeyResultProgress(t *testing.T) {
 		themes, _ := manager.GetHierarchy()
 		childObj := findManagerObjectiveByID(themes[0].Objectives, child.ID)
 		if childObj.KeyResults[0].CurrentValue != 10 {
-			t.Errorf("expected currentValue 10, got %d", childObj.KeyResults[0].CurrentValue)
+			t.Errorf("expected currentValue 10, got %g", childObj.KeyResults[0].CurrentValue)
 		}
 	})
 
@@ -2678,7 +3030,7 @@ func newRoutinesForDateManager(t *testing.T) (*PlanningManager, *mockRoutineAcce
 	t.Helper()
 	ra := newMockRoutineAccess()
 	ca := newMockCalendarAccess()
-	pm, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), ca, ra, &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo())
+	pm, err := NewPlanningManager(newMockThemeAccess(), newMockTaskAccess(), ca, ra, newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
 	if err != nil {
 		t.Fatalf("NewPlanningManager: %v", err)
 	}
@@ -4233,7 +4585,7 @@ func TestUnit_ValidateTaskOrder_RepairsCorruptData(t *testing.T) {
 	}
 
 	// NewPlanningManager calls validateTaskOrder
-	manager, err := NewPlanningManager(newMockThemeAccess(), mockTasks, newMockCalendarAccess(), newMockRoutineAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo())
+	manager, err := NewPlanningManager(newMockThemeAccess(), mockTasks, newMockCalendarAccess(), newMockRoutineAccess(), newMockCycleAccess(), newMockWeekAccess(), newMockSettingsAccess(), &mockVisionAccess{}, &mockUIStateAccess{}, newStubRepo(), utilities.DefaultClock())
 	if err != nil {
 		t.Fatalf("NewPlanningManager failed: %v", err)
 	}
@@ -4307,6 +4659,43 @@ func TestGetAllThemeProgress(t *testing.T) {
 		}
 	})
 
+	t.Run("weighted KRs and contributions", func(t *testing.T) {
+		manager, _, _ := newMockManager()
+
+		obj, _ := testCreateObjective(manager, "T", "Obj1")
+		kr1, _ := testCreateKeyResult(manager, obj.ID, "KR critical", 0, 100)
+		_ = manager.RecordProgress(kr1.ID, 100)
+		kr2, _ := testCreateKeyResult(manager, obj.ID, "KR minor", 0, 100)
+
+		weight := 3.0
+		if err := manager.Revise(ReviseRequest{GoalID: kr1.ID, Weight: &weight}); err != nil {
+			t.Fatalf("Revise weight: %v", err)
+		}
+		invalid := -1.0
+		if err := manager.Revise(ReviseRequest{GoalID: obj.ID, Weight: &invalid}); err == nil {
+			t.Error("expected error for negative objective weight")
+		}
+
+		progress, err := manager.GetAllThemeProgress()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		// Weighted: (3*100 + 1*0) / 4 = 75%
+		if progress[0].Progress != 75 {
+			t.Errorf("expected theme progress 75, got %f", progress[0].Progress)
+		}
+		contributions := progress[0].Objectives[0].Contributions
+		if len(contributions) != 2 {
+			t.Fatalf("expected 2 contributions, got %+v", contributions)
+		}
+		if c := contributions[0]; c.ID != kr1.ID || c.Kind != "key-result" || c.Weight != 3 || c.Share != 0.75 || c.Points != 75 {
+			t.Errorf("critical KR contribution = %+v", c)
+		}
+		if c := contributions[1]; c.ID != kr2.ID || c.Share != 0.25 || c.Points != 0 {
+			t.Errorf("minor KR contribution = %+v", c)
+		}
+	})
+
 	t.Run("untracked KR excluded", func(t *testing.T) {
 		manager, _, _ := newMockManager()
 
@@ -4924,6 +5313,37 @@ func TestUnit_ProcessPriorityPromotions_MovesZone(t *testing.T) {
 	assertTaskOrderConsistency(t, manager)
 }
 
+func TestUnit_ProcessPriorityPromotions_HonoursDayBoundary(t *testing.T) {
+	manager, _, _ := newMockManager()
+	// 2026-03-02 02:00 UTC with days starting at 04:00 is still March 1st.
+	clock := utilities.NewFrozenClock(time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC), time.UTC, 4)
+	manager.clock = clock
+
+	if _, err := manager.CreateTask("Due tomorrow", "T", "important-not-urgent", "", "", "2026-03-02"); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if got := manager.GetToday(); got != "2026-03-01" {
+		t.Errorf("GetToday = %s, want 2026-03-01", got)
+	}
+
+	promoted, err := manager.ProcessPriorityPromotions()
+	if err != nil {
+		t.Fatalf("ProcessPriorityPromotions failed: %v", err)
+	}
+	if len(promoted) != 0 {
+		t.Fatalf("expected no promotion before the day boundary, got %d", len(promoted))
+	}
+
+	clock.Advance(3 * time.Hour)
+	promoted, err = manager.ProcessPriorityPromotions()
+	if err != nil {
+		t.Fatalf("ProcessPriorityPromotions failed: %v", err)
+	}
+	if len(promoted) != 1 {
+		t.Fatalf("expected 1 promotion after the day boundary, got %d", len(promoted))
+	}
+}
+
 // =============================================================================
 // Archived Order Integration Tests
 // =============================================================================
@@ -5560,6 +5980,32 @@ func TestUnit_CreateTask_FailsWithEmptyThemeIDNoRoutineTag(t *testing.T) {
 	}
 }
 
+func TestUnit_CreateTask_FallsBackToDefaultTheme(t *testing.T) {
+	manager, _, _ := newMockManager()
+	manager.settingsAccess.(*mockSettingsAccess).settings.DefaultThemeID = "T"
+
+	task, err := manager.CreateTask("Some task", "", "important-urgent", "", "", "")
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if task.ThemeID != "T" {
+		t.Errorf("ThemeID = %q, want the default theme T", task.ThemeID)
+	}
+}
+
+func TestUnit_Dismiss_ClearsDefaultTheme(t *testing.T) {
+	manager, _, _ := newMockManager()
+	sa := manager.settingsAccess.(*mockSettingsAccess)
+	sa.settings.DefaultThemeID = "T"
+
+	if err := manager.Dismiss("T"); err != nil {
+		t.Fatalf("Dismiss: %v", err)
+	}
+	if sa.settings.DefaultThemeID != "" {
+		t.Errorf("DefaultThemeID = %q, want cleared", sa.settings.DefaultThemeID)
+	}
+}
+
 func TestUnit_CreateTask_RejectsReservedTagName(t *testing.T) {
 	manager, _, _ := newMockManager()
 
@@ -0,0 +1,277 @@
+package managers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// DefaultProfileID is the ID of the profile that wraps the original data
+// directory. It always exists and cannot be removed.
+const DefaultProfileID = "default"
+
+// Profile is the manager-layer view of a named data directory.
+type Profile struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	DataDir   string `json:"dataDir"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	Active    bool   `json:"active"`
+}
+
+// IProfileManager defines operations on the profile registry. Opening a
+// profile (re-initialising access components and managers against its data
+// directory) is orchestrated by bootstrap; this manager owns the registry
+// rules and remembers which profile was used last.
+type IProfileManager interface {
+	ListProfiles() ([]Profile, error)
+	GetProfile(id string) (*Profile, error)
+	CurrentProfile() (*Profile, error)
+	CreateProfile(name, dataDir string) (*Profile, error)
+	RenameProfile(id, name string) error
+	RemoveProfile(id string) error
+	MarkUsed(id string) error
+}
+
+// ProfileManager implements IProfileManager.
+//
+// mu serialises the load-modify-save cycle on the registry file.
+type ProfileManager struct {
+	profileAccess  access.IProfileAccess
+	defaultDataDir string
+	mu             sync.Mutex
+}
+
+// NewProfileManager creates a ProfileManager. defaultDataDir is the data
+// directory of the built-in default profile; new profiles without an
+// explicit directory are created next to it as "<defaultDataDir>-<id>".
+// The default profile is registered on first use.
+func NewProfileManager(profileAccess access.IProfileAccess, defaultDataDir string) (*ProfileManager, error) {
+	if profileAccess == nil {
+		return nil, fmt.Errorf("profileAccess cannot be nil")
+	}
+	if defaultDataDir == "" {
+		return nil, fmt.Errorf("defaultDataDir cannot be empty")
+	}
+	pm := &ProfileManager{profileAccess: profileAccess, defaultDataDir: defaultDataDir}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if _, err := pm.loadLocked(); err != nil {
+		return nil, err
+	}
+	return pm, nil
+}
+
+// loadLocked reads the registry and registers the default profile when it is
+// missing. The default profile always points at defaultDataDir, so a changed
+// BEARING_DATA_DIR is honoured without editing the registry. Caller must
+// hold m.mu.
+func (m *ProfileManager) loadLocked() (*access.ProfileRegistry, error) {
+	registry, err := m.profileAccess.LoadProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if idx := findProfile(registry.Profiles, DefaultProfileID); idx >= 0 {
+		registry.Profiles[idx].DataDir = m.defaultDataDir
+	} else {
+		registry.Profiles = append([]access.Profile{{
+			ID:        DefaultProfileID,
+			Name:      "Default",
+			DataDir:   m.defaultDataDir,
+			CreatedAt: utilities.Now(),
+		}}, registry.Profiles...)
+		if err := m.profileAccess.SaveProfiles(*registry); err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+	}
+	return registry, nil
+}
+
+// findProfile returns the index of id in profiles, or -1.
+func findProfile(profiles []access.Profile, id string) int {
+	for i, p := range profiles {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// activeID returns the profile that should be opened: the last-used one when
+// it is still registered, otherwise the default.
+func activeID(registry *access.ProfileRegistry) string {
+	if registry.LastUsed != "" && findProfile(registry.Profiles, registry.LastUsed) >= 0 {
+		return registry.LastUsed
+	}
+	return DefaultProfileID
+}
+
+// ListProfiles returns all registered profiles, default first.
+func (m *ProfileManager) ListProfiles() ([]Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	registry, err := m.loadLocked()
+	if err != nil {
+		return nil, err
+	}
+	current := activeID(registry)
+	result := make([]Profile, len(registry.Profiles))
+	for i, p := range registry.Profiles {
+		result[i] = toManagerProfile(p, p.ID == current)
+	}
+	return result, nil
+}
+
+// GetProfile returns the profile with the given ID.
+func (m *ProfileManager) GetProfile(id string) (*Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	registry, err := m.loadLocked()
+	if err != nil {
+		return nil, err
+	}
+	idx := findProfile(registry.Profiles, id)
+	if idx < 0 {
+		return nil, fmt.Errorf("profile %q not found", id)
+	}
+	p := toManagerProfile(registry.Profiles[idx], id == activeID(registry))
+	return &p, nil
+}
+
+// CurrentProfile returns the profile to open on startup: the last-used one,
+// falling back to the default profile.
+func (m *ProfileManager) CurrentProfile() (*Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	registry, err := m.loadLocked()
+	if err != nil {
+		return nil, err
+	}
+	p := toManagerProfile(registry.Profiles[findProfile(registry.Profiles, activeID(registry))], true)
+	return &p, nil
+}
+
+// CreateProfile registers a new profile. The ID is derived from name; an
+// empty dataDir places the data next to the default profile's directory.
+// The directory itself is created when the profile is first opened.
+func (m *ProfileManager) CreateProfile(name, dataDir string) (*Profile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("profile name cannot be empty")
+	}
+	id := utilities.Slugify(name)
+	if id == "" {
+		return nil, fmt.Errorf("profile name %q does not contain any usable characters", name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	registry, err := m.loadLocked()
+	if err != nil {
+		return nil, err
+	}
+	if findProfile(registry.Profiles, id) >= 0 {
+		return nil, fmt.Errorf("profile %q already exists", id)
+	}
+
+	if dataDir == "" {
+		dataDir = m.defaultDataDir + "-" + id
+	}
+	dataDir, err = filepath.Abs(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data directory: %w", err)
+	}
+	for _, p := range registry.Profiles {
+		if filepath.Clean(p.DataDir) == dataDir {
+			return nil, fmt.Errorf("data directory %s is already used by profile %q", dataDir, p.ID)
+		}
+	}
+
+	created := access.Profile{ID: id, Name: name, DataDir: dataDir, CreatedAt: utilities.Now()}
+	registry.Profiles = append(registry.Profiles, created)
+	if err := m.profileAccess.SaveProfiles(*registry); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	p := toManagerProfile(created, false)
+	return &p, nil
+}
+
+// RenameProfile changes a profile's display name. The ID and data directory
+// are stable so that renaming never moves data.
+func (m *ProfileManager) RenameProfile(id, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	registry, err := m.loadLocked()
+	if err != nil {
+		return err
+	}
+	idx := findProfile(registry.Profiles, id)
+	if idx < 0 {
+		return fmt.Errorf("profile %q not found", id)
+	}
+	registry.Profiles[idx].Name = name
+	if err := m.profileAccess.SaveProfiles(*registry); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// RemoveProfile unregisters a profile. The default profile and the profile
+// currently in use cannot be removed. The data directory is left on disk;
+// deleting a git-versioned history is a decision for the user, not the app.
+func (m *ProfileManager) RemoveProfile(id string) error {
+	if id == DefaultProfileID {
+		return fmt.Errorf("the default profile cannot be removed")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	registry, err := m.loadLocked()
+	if err != nil {
+		return err
+	}
+	idx := findProfile(registry.Profiles, id)
+	if idx < 0 {
+		return fmt.Errorf("profile %q not found", id)
+	}
+	if activeID(registry) == id {
+		return fmt.Errorf("profile %q is in use; switch to another profile first", id)
+	}
+	registry.Profiles = append(registry.Profiles[:idx], registry.Profiles[idx+1:]...)
+	if err := m.profileAccess.SaveProfiles(*registry); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// MarkUsed records id as the last-used profile so that the next startup
+// opens it. Called by bootstrap only after the profile opened successfully.
+func (m *ProfileManager) MarkUsed(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	registry, err := m.loadLocked()
+	if err != nil {
+		return err
+	}
+	if findProfile(registry.Profiles, id) < 0 {
+		return fmt.Errorf("profile %q not found", id)
+	}
+	if registry.LastUsed == id {
+		return nil
+	}
+	registry.LastUsed = id
+	if err := m.profileAccess.SaveProfiles(*registry); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
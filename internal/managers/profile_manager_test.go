@@ -0,0 +1,98 @@
+package managers
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+)
+
+func newTestProfileManager(t *testing.T) (*ProfileManager, string) {
+	t.Helper()
+	root := t.TempDir()
+	pa, err := access.NewProfileAccess(filepath.Join(root, "profiles.json"))
+	if err != nil {
+		t.Fatalf("NewProfileAccess: %v", err)
+	}
+	defaultDir := filepath.Join(root, "bearing")
+	pm, err := NewProfileManager(pa, defaultDir)
+	if err != nil {
+		t.Fatalf("NewProfileManager: %v", err)
+	}
+	return pm, defaultDir
+}
+
+func TestUnit_ProfileManager_DefaultProfileRegistered(t *testing.T) {
+	pm, defaultDir := newTestProfileManager(t)
+
+	current, err := pm.CurrentProfile()
+	if err != nil {
+		t.Fatalf("CurrentProfile: %v", err)
+	}
+	if current.ID != DefaultProfileID || current.DataDir != defaultDir || !current.Active {
+		t.Errorf("CurrentProfile = %+v, want active default at %s", current, defaultDir)
+	}
+	if err := pm.RemoveProfile(DefaultProfileID); err == nil {
+		t.Error("expected error removing the default profile")
+	}
+}
+
+func TestUnit_ProfileManager_CreateRenameRemove(t *testing.T) {
+	pm, defaultDir := newTestProfileManager(t)
+
+	created, err := pm.CreateProfile("Work Life", "")
+	if err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+	if created.ID != "work-life" || created.DataDir != defaultDir+"-work-life" {
+		t.Errorf("created = %+v", created)
+	}
+	if _, err := pm.CreateProfile("work life", ""); err == nil {
+		t.Error("expected duplicate-ID error")
+	}
+	if _, err := pm.CreateProfile("Other", defaultDir); err == nil {
+		t.Error("expected error reusing the default data directory")
+	}
+
+	if err := pm.RenameProfile("work-life", "  Job  "); err != nil {
+		t.Fatalf("RenameProfile: %v", err)
+	}
+	renamed, _ := pm.GetProfile("work-life")
+	if renamed.Name != "Job" || renamed.DataDir != created.DataDir {
+		t.Errorf("renamed = %+v, want name Job with unchanged data dir", renamed)
+	}
+
+	if err := pm.RemoveProfile("work-life"); err != nil {
+		t.Fatalf("RemoveProfile: %v", err)
+	}
+	list, _ := pm.ListProfiles()
+	if len(list) != 1 || list[0].ID != DefaultProfileID {
+		t.Errorf("ListProfiles after remove = %+v", list)
+	}
+}
+
+func TestUnit_ProfileManager_LastUsedRemembered(t *testing.T) {
+	pm, _ := newTestProfileManager(t)
+	if _, err := pm.CreateProfile("Side", ""); err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+	if err := pm.MarkUsed("side"); err != nil {
+		t.Fatalf("MarkUsed: %v", err)
+	}
+	if err := pm.RemoveProfile("side"); err == nil {
+		t.Error("expected error removing the profile in use")
+	}
+
+	// A fresh manager over the same registry resumes the last-used profile.
+	reopened, err := NewProfileManager(pm.profileAccess, pm.defaultDataDir)
+	if err != nil {
+		t.Fatalf("NewProfileManager: %v", err)
+	}
+	current, _ := reopened.CurrentProfile()
+	if current.ID != "side" {
+		t.Errorf("CurrentProfile = %q, want side", current.ID)
+	}
+	if err := reopened.MarkUsed("missing"); err == nil {
+		t.Error("expected error marking an unknown profile")
+	}
+}
@@ -0,0 +1,357 @@
+package managers
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// ReparentResult reports where a moved goal ended up. IDMapping maps the old
+// ID of the goal and of every objective and key result beneath it to its new
+// ID; IDs only change when the goal moves to another theme.
+type ReparentResult struct {
+	IDMapping map[string]string `json:"idMapping"`
+}
+
+// Reparent moves an objective (with its subtree) under another theme or
+// objective, or a key result under another objective. Moving into another
+// theme reassigns IDs in that theme's namespace; day focus OKR references
+// are rewritten in the same commit. The navigation context, which is not
+// versioned, is remapped afterwards.
+//
+// Tasks link to themes only and are unaffected. Objectives moved below
+// another objective leave their cycle, since cycles group top-level
+// objectives only.
+func (m *PlanningManager) Reparent(goalId, newParentId string) (*ReparentResult, error) {
+	if goalId == "" {
+		return nil, fmt.Errorf("goalId cannot be empty")
+	}
+	if newParentId == "" {
+		return nil, fmt.Errorf("newParentId cannot be empty")
+	}
+	goalType := detectGoalType(goalId)
+	if goalType != GoalTypeObjective && goalType != GoalTypeKeyResult {
+		return nil, fmt.Errorf("only objectives and key results can be moved")
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	srcIdx, dstIdx := -1, -1
+	dstIsTheme := false
+	for i := range themes {
+		if themes[i].ID == newParentId {
+			dstIdx, dstIsTheme = i, true
+		} else if findObjectiveByID(themes[i].Objectives, newParentId) != nil {
+			dstIdx = i
+		}
+		if goalType == GoalTypeObjective && findObjectiveByID(themes[i].Objectives, goalId) != nil {
+			srcIdx = i
+		}
+		if goalType == GoalTypeKeyResult {
+			if obj, _ := findKeyResultParent(themes[i].Objectives, goalId); obj != nil {
+				srcIdx = i
+			}
+		}
+	}
+	if srcIdx < 0 {
+		return nil, fmt.Errorf("%s with ID %s not found", goalType, goalId)
+	}
+	if dstIdx < 0 {
+		return nil, fmt.Errorf("parent with ID %s not found", newParentId)
+	}
+
+	var moved access.Objective // key results travel wrapped in an objective
+	switch goalType {
+	case GoalTypeObjective:
+		obj := findObjectiveByID(themes[srcIdx].Objectives, goalId)
+		if goalId == newParentId || findObjectiveByID(obj.Objectives, newParentId) != nil {
+			return nil, fmt.Errorf("cannot move objective %s below itself", goalId)
+		}
+		if obj.ParentID == newParentId {
+			return identityMapping(*obj), nil
+		}
+		moved = detachObjective(&themes[srcIdx].Objectives, goalId)
+		if !dstIsTheme {
+			moved.CycleID = ""
+		}
+	case GoalTypeKeyResult:
+		if dstIsTheme {
+			return nil, fmt.Errorf("key results can only be moved under an objective")
+		}
+		parent, idx := findKeyResultParent(themes[srcIdx].Objectives, goalId)
+		if parent.ID == newParentId {
+			return identityMapping(access.Objective{KeyResults: []access.KeyResult{parent.KeyResults[idx]}}), nil
+		}
+		moved.KeyResults = []access.KeyResult{parent.KeyResults[idx]}
+		parent.KeyResults = append(append([]access.KeyResult{}, parent.KeyResults[:idx]...), parent.KeyResults[idx+1:]...)
+	}
+
+	oldIDs := goalTreeIDs(moved)
+	crossTheme := srcIdx != dstIdx
+	if crossTheme {
+		clearGoalIDs(&moved)
+	}
+
+	// Attach as the last child of the new parent.
+	switch {
+	case goalType == GoalTypeKeyResult:
+		parent := findObjectiveByID(themes[dstIdx].Objectives, newParentId)
+		parent.KeyResults = append(parent.KeyResults, moved.KeyResults[0])
+	case dstIsTheme:
+		themes[dstIdx].Objectives = append(themes[dstIdx].Objectives, moved)
+	default:
+		parent := findObjectiveByID(themes[dstIdx].Objectives, newParentId)
+		parent.Objectives = append(parent.Objectives, moved)
+	}
+
+	mapping := make(map[string]string, len(oldIDs))
+	for _, id := range oldIDs {
+		mapping[id] = id
+	}
+
+	commitMsg := fmt.Sprintf("Move %s to %s", goalId, newParentId)
+	if err := utilities.RunTransaction(m.repo, commitMsg, func() error {
+		if err := m.themeAccess.WriteTheme(themes[srcIdx]); err != nil {
+			return fmt.Errorf("write theme %s: %w", themes[srcIdx].ID, err)
+		}
+		if !crossTheme {
+			return nil
+		}
+		if err := m.themeAccess.WriteTheme(themes[dstIdx]); err != nil {
+			return fmt.Errorf("write theme %s: %w", themes[dstIdx].ID, err)
+		}
+
+		// ThemeAccess assigned the new IDs on write; read them back from
+		// the attached copy, which keeps the structure of the original.
+		saved, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		placed, err := attachedGoal(saved, themes[dstIdx].ID, newParentId, goalType, dstIsTheme)
+		if err != nil {
+			return err
+		}
+		for i, id := range goalTreeIDs(placed) {
+			mapping[oldIDs[i]] = id
+		}
+
+		days, err := m.remapDayFocusIDs(mapping)
+		if err != nil {
+			return err
+		}
+		for _, day := range days {
+			if err := m.calendarAccess.WriteDayFocus(day); err != nil {
+				return fmt.Errorf("write day %s: %w", day.Date, err)
+			}
+		}
+		weeks, err := m.remapWeekPlanIDs(mapping)
+		if err != nil {
+			return err
+		}
+		for _, week := range weeks {
+			if err := m.weekAccess.WriteWeekPlan(week); err != nil {
+				return fmt.Errorf("write week %s: %w", week.Week, err)
+			}
+		}
+		routines, changed, err := m.remapRoutineLinks(mapping)
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := m.routineAccess.WriteSaveRoutines(routines); err != nil {
+				return fmt.Errorf("%w", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("Reparent: %w", err)
+	}
+
+	// The navigation context is not versioned, so a failure is logged
+	// rather than undoing the committed move.
+	if crossTheme {
+		if err := m.remapNavigationContext(mapping); err != nil {
+			slog.Warn("Reparent: failed to remap navigation context", "error", err)
+		}
+	}
+	return &ReparentResult{IDMapping: mapping}, nil
+}
+
+// detachObjective removes the objective with the given ID from the tree and
+// returns it. The caller must have checked that it exists.
+func detachObjective(objectives *[]access.Objective, id string) access.Objective {
+	siblings, idx := findObjectiveParent(objectives, id)
+	obj := (*siblings)[idx]
+	*siblings = append(append([]access.Objective{}, (*siblings)[:idx]...), (*siblings)[idx+1:]...)
+	return obj
+}
+
+// attachedGoal returns the goal most recently attached under parentId, as a
+// tree comparable with the one passed to goalTreeIDs before the move.
+func attachedGoal(themes []access.LifeTheme, themeID, parentID string, goalType GoalType, parentIsTheme bool) (access.Objective, error) {
+	for _, theme := range themes {
+		if theme.ID != themeID {
+			continue
+		}
+		children := theme.Objectives
+		if !parentIsTheme {
+			parent := findObjectiveByID(theme.Objectives, parentID)
+			if parent == nil {
+				break
+			}
+			if goalType == GoalTypeKeyResult && len(parent.KeyResults) > 0 {
+				return access.Objective{KeyResults: parent.KeyResults[len(parent.KeyResults)-1:]}, nil
+			}
+			children = parent.Objectives
+		}
+		if goalType == GoalTypeObjective && len(children) > 0 {
+			return children[len(children)-1], nil
+		}
+	}
+	return access.Objective{}, fmt.Errorf("moved goal could not be retrieved under %s", parentID)
+}
+
+// goalTreeIDs lists the IDs in obj's subtree in a fixed order: the objective
+// itself (when it has an ID), its key results, then its children.
+func goalTreeIDs(obj access.Objective) []string {
+	var ids []string
+	if obj.ID != "" {
+		ids = append(ids, obj.ID)
+	}
+	for _, kr := range obj.KeyResults {
+		ids = append(ids, kr.ID)
+	}
+	for _, child := range obj.Objectives {
+		ids = append(ids, goalTreeIDs(child)...)
+	}
+	return ids
+}
+
+// clearGoalIDs empties every ID in obj's subtree so ThemeAccess assigns new
+// ones on write.
+func clearGoalIDs(obj *access.Objective) {
+	obj.ID = ""
+	for i := range obj.KeyResults {
+		obj.KeyResults[i].ID = ""
+	}
+	for i := range obj.Objectives {
+		clearGoalIDs(&obj.Objectives[i])
+	}
+}
+
+// identityMapping returns the result of a move that leaves obj where it is.
+func identityMapping(obj access.Objective) *ReparentResult {
+	mapping := make(map[string]string)
+	for _, id := range goalTreeIDs(obj) {
+		mapping[id] = id
+	}
+	return &ReparentResult{IDMapping: mapping}
+}
+
+// remapDayFocusIDs returns the day focus entries whose theme or OKR
+// references change under mapping, with the references rewritten.
+func (m *PlanningManager) remapDayFocusIDs(mapping map[string]string) ([]access.DayFocus, error) {
+	years, err := m.calendarAccess.GetFocusYears()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	var changed []access.DayFocus
+	for _, year := range years {
+		days, err := m.calendarAccess.GetYearFocus(year)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		for _, day := range days {
+			themeIDs, themesChanged := remapIDs(day.ThemeIDs, mapping)
+			okrIDs, okrsChanged := remapIDs(day.OkrIDs, mapping)
+			if themesChanged {
+				day.ThemeIDs = themeIDs
+			}
+			if okrsChanged {
+				day.OkrIDs = okrIDs
+			}
+			if themesChanged || okrsChanged {
+				changed = append(changed, day)
+			}
+		}
+	}
+	return changed, nil
+}
+
+// remapWeekPlanIDs returns the week plans whose theme, OKR or task
+// references change under mapping, with the references rewritten.
+func (m *PlanningManager) remapWeekPlanIDs(mapping map[string]string) ([]access.WeekPlan, error) {
+	plans, err := m.weekAccess.GetWeekPlans()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	var changed []access.WeekPlan
+	for _, plan := range plans {
+		planChanged := false
+		for _, ids := range []*[]string{&plan.ThemeIDs, &plan.ObjectiveIDs, &plan.KeyResultIDs, &plan.CommittedTaskIDs} {
+			if out, ok := remapIDs(*ids, mapping); ok {
+				*ids, planChanged = out, true
+			}
+		}
+		if planChanged {
+			changed = append(changed, plan)
+		}
+	}
+	return changed, nil
+}
+
+// remapNavigationContext rewrites theme and OKR IDs in the saved navigation
+// context: the theme filters, expanded tree nodes and the current item.
+func (m *PlanningManager) remapNavigationContext(mapping map[string]string) error {
+	ctx, err := m.uiStateAccess.LoadNavigationContext()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	if ctx == nil {
+		return nil
+	}
+	changed := false
+	if ids, ok := remapIDs(ctx.FilterThemeIDs, mapping); ok {
+		ctx.FilterThemeIDs, changed = ids, true
+	}
+	if newID, ok := mapping[ctx.FilterThemeID]; ok && newID != ctx.FilterThemeID {
+		ctx.FilterThemeID, changed = newID, true
+	}
+	if ids, ok := remapIDs(ctx.ExpandedOkrIds, mapping); ok {
+		ctx.ExpandedOkrIds, changed = ids, true
+	}
+	if ids, ok := remapIDs(ctx.CalendarDayEditorExpandedIds, mapping); ok {
+		ctx.CalendarDayEditorExpandedIds, changed = ids, true
+	}
+	if newID, ok := mapping[ctx.CurrentItem]; ok && newID != ctx.CurrentItem {
+		ctx.CurrentItem, changed = newID, true
+	}
+	if !changed {
+		return nil
+	}
+	if err := m.uiStateAccess.SaveNavigationContext(*ctx); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// remapIDs applies mapping to ids. ok is false when nothing changes.
+func remapIDs(ids []string, mapping map[string]string) ([]string, bool) {
+	var out []string
+	for i, id := range ids {
+		newID, found := mapping[id]
+		if !found || newID == id {
+			continue
+		}
+		if out == nil {
+			out = append([]string{}, ids...)
+		}
+		out[i] = newID
+	}
+	return out, out != nil
+}
@@ -0,0 +1,195 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+)
+
+// recordingUIStateAccess keeps the navigation context in memory. A non-nil
+// saveErr fails every save.
+type recordingUIStateAccess struct {
+	mockUIStateAccess
+	ctx     *access.NavigationContext
+	saveErr error
+}
+
+func (r *recordingUIStateAccess) LoadNavigationContext() (*access.NavigationContext, error) {
+	return r.ctx, nil
+}
+
+func (r *recordingUIStateAccess) SaveNavigationContext(ctx access.NavigationContext) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.ctx = &ctx
+	return nil
+}
+
+// newReparentTestManager returns a manager with theme T holding T-O1 (with
+// T-KR1 and child T-O2 with T-KR2) and T-O3, and an empty theme "Career".
+func newReparentTestManager(t *testing.T) (*PlanningManager, *mockCalendarAccess, *recordingUIStateAccess, *stubRepo, string) {
+	t.Helper()
+	ca := newMockCalendarAccess()
+	ua := &recordingUIStateAccess{}
+	repo := newStubRepo()
+	pm := newTestManager(t, testManagerDeps{calendar: ca, uiState: ua, repo: repo})
+	res, err := pm.Establish(EstablishRequest{GoalType: GoalTypeTheme, Name: "Career", Color: "#ff0000"})
+	if err != nil {
+		t.Fatalf("Establish theme: %v", err)
+	}
+	for _, step := range []func() error{
+		func() error { _, err := testCreateObjective(pm, "T", "Get fit"); return err },
+		func() error { _, err := testCreateKeyResult(pm, "T-O1", "Run km", 0, 100); return err },
+		func() error { _, err := testCreateObjective(pm, "T-O1", "Strength"); return err },
+		func() error { _, err := testCreateKeyResult(pm, "T-O2", "Push-ups", 0, 50); return err },
+		func() error { _, err := testCreateObjective(pm, "T", "Read more"); return err },
+	} {
+		if err := step(); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+	repo.tx.commits = nil
+	return pm, ca, ua, repo, res.Theme.ID
+}
+
+func TestUnit_Reparent_ObjectiveAcrossThemes(t *testing.T) {
+	pm, ca, ua, repo, career := newReparentTestManager(t)
+	ca.days["2026-03-01"] = access.DayFocus{Date: "2026-03-01", OkrIDs: []string{"T-KR2", "T-O3", "T-O1"}}
+	ca.days["2026-03-02"] = access.DayFocus{Date: "2026-03-02", OkrIDs: []string{"T-O3"}}
+	ua.ctx = &access.NavigationContext{CurrentItem: "T-O2", ExpandedOkrIds: []string{"T", "T-O1"}}
+
+	result, err := pm.Reparent("T-O1", career)
+	if err != nil {
+		t.Fatalf("Reparent: %v", err)
+	}
+
+	want := map[string]string{
+		"T-O1":  career + "-O1",
+		"T-KR1": career + "-KR1",
+		"T-O2":  career + "-O2",
+		"T-KR2": career + "-KR2",
+	}
+	if len(result.IDMapping) != len(want) {
+		t.Fatalf("IDMapping = %v, want %v", result.IDMapping, want)
+	}
+	for old, id := range want {
+		if result.IDMapping[old] != id {
+			t.Errorf("IDMapping[%s] = %q, want %q", old, result.IDMapping[old], id)
+		}
+	}
+	if len(repo.tx.commits) != 1 || repo.tx.commits[0] != "Move T-O1 to "+career {
+		t.Errorf("commits = %q, want a single move commit", repo.tx.commits)
+	}
+
+	themes, _ := pm.GetHierarchy()
+	if len(themes[0].Objectives) != 1 || themes[0].Objectives[0].ID != "T-O3" {
+		t.Errorf("theme T objectives = %+v, want only T-O3", themes[0].Objectives)
+	}
+	moved := themes[1].Objectives[0]
+	if moved.ID != career+"-O1" || moved.ParentID != career || moved.KeyResults[0].ParentID != moved.ID || moved.Objectives[0].ParentID != moved.ID {
+		t.Errorf("moved objective = %+v, want renumbered with consistent parents", moved)
+	}
+
+	if got := ca.days["2026-03-01"].OkrIDs; strings.Join(got, ",") != career+"-KR2,T-O3,"+career+"-O1" {
+		t.Errorf("day focus OKR IDs = %v", got)
+	}
+	if ua.ctx.CurrentItem != career+"-O2" || ua.ctx.ExpandedOkrIds[1] != career+"-O1" {
+		t.Errorf("navigation context = %+v, want remapped", ua.ctx)
+	}
+}
+
+func TestUnit_Reparent_WithinTheme(t *testing.T) {
+	pm, _, _, repo, _ := newReparentTestManager(t)
+
+	result, err := pm.Reparent("T-O2", "T")
+	if err != nil {
+		t.Fatalf("Reparent objective: %v", err)
+	}
+	if result.IDMapping["T-O2"] != "T-O2" || result.IDMapping["T-KR2"] != "T-KR2" {
+		t.Errorf("IDMapping = %v, want IDs kept within the theme", result.IDMapping)
+	}
+	themes, _ := pm.GetHierarchy()
+	if objs := themes[0].Objectives; len(objs) != 3 || objs[2].ID != "T-O2" || objs[2].ParentID != "T" || len(objs[0].Objectives) != 0 {
+		t.Errorf("objectives = %+v, want T-O2 promoted to the theme", objs)
+	}
+
+	if _, err := pm.Reparent("T-KR1", "T-O3"); err != nil {
+		t.Fatalf("Reparent key result: %v", err)
+	}
+	themes, _ = pm.GetHierarchy()
+	if krs := themes[0].Objectives[1].KeyResults; len(krs) != 1 || krs[0].ID != "T-KR1" || krs[0].ParentID != "T-O3" {
+		t.Errorf("T-O3 key results = %+v, want T-KR1", krs)
+	}
+	if len(repo.tx.commits) != 2 {
+		t.Errorf("commits = %q, want one per move", repo.tx.commits)
+	}
+
+	// Moving to the current parent changes nothing.
+	if _, err := pm.Reparent("T-KR1", "T-O3"); err != nil || len(repo.tx.commits) != 2 {
+		t.Errorf("no-op move = %v with commits %q", err, repo.tx.commits)
+	}
+}
+
+func TestUnit_Reparent_KeyResultAcrossThemes(t *testing.T) {
+	pm, _, _, _, career := newReparentTestManager(t)
+	obj, err := testCreateObjective(pm, career, "Promotion")
+	if err != nil {
+		t.Fatalf("create objective: %v", err)
+	}
+
+	result, err := pm.Reparent("T-KR2", obj.ID)
+	if err != nil {
+		t.Fatalf("Reparent: %v", err)
+	}
+	if len(result.IDMapping) != 1 || result.IDMapping["T-KR2"] != career+"-KR1" {
+		t.Errorf("IDMapping = %v, want T-KR2 -> %s-KR1", result.IDMapping, career)
+	}
+}
+
+func TestUnit_Reparent_LeavesCycle(t *testing.T) {
+	pm, _, _, _, _ := newReparentTestManager(t)
+	cycle, err := pm.CreateCycle("Q1", "2026-01-01", "2026-03-31")
+	if err != nil {
+		t.Fatalf("CreateCycle: %v", err)
+	}
+	cycleID := cycle.ID
+	if err := pm.Revise(ReviseRequest{GoalID: "T-O3", CycleID: &cycleID}); err != nil {
+		t.Fatalf("Revise: %v", err)
+	}
+
+	if _, err := pm.Reparent("T-O3", "T-O1"); err != nil {
+		t.Fatalf("Reparent: %v", err)
+	}
+	themes, _ := pm.GetHierarchy()
+	if child := themes[0].Objectives[0].Objectives[1]; child.ID != "T-O3" || child.CycleID != "" {
+		t.Errorf("nested objective = %+v, want it out of the cycle", child)
+	}
+}
+
+func TestUnit_Reparent_Validation(t *testing.T) {
+	pm, _, _, repo, career := newReparentTestManager(t)
+
+	tests := []struct {
+		name, goal, parent, wantErr string
+	}{
+		{"empty goal", "", "T", "goalId cannot be empty"},
+		{"theme", "T", career, "only objectives and key results"},
+		{"unknown goal", "T-O9", "T", "not found"},
+		{"unknown parent", "T-O1", "X-O1", "parent with ID X-O1 not found"},
+		{"into itself", "T-O1", "T-O1", "below itself"},
+		{"into descendant", "T-O1", "T-O2", "below itself"},
+		{"key result under theme", "T-KR1", career, "under an objective"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pm.Reparent(tt.goal, tt.parent); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+	if len(repo.tx.commits) != 0 {
+		t.Errorf("failed moves committed: %q", repo.tx.commits)
+	}
+}
@@ -0,0 +1,309 @@
+package managers
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// Retrospective is the record produced when an objective is closed.
+type Retrospective struct {
+	ObjectiveID   string                   `json:"objectiveId"`
+	Title         string                   `json:"title"`
+	ClosingStatus string                   `json:"closingStatus"`
+	ClosingNotes  string                   `json:"closingNotes,omitempty"`
+	Since         utilities.Timestamp      `json:"since,omitempty"` // start of the objective's life; empty when unknown
+	ClosedAt      utilities.Timestamp      `json:"closedAt"`
+	KeyResults    []RetrospectiveKeyResult `json:"keyResults"`
+	Tasks         []RetrospectiveTask      `json:"tasks"`
+	FocusDays     []utilities.CalendarDate `json:"focusDays"`
+}
+
+// RetrospectiveKeyResult is the final state of a key result in a retrospective.
+type RetrospectiveKeyResult struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	Type        string    `json:"type,omitempty"`
+	Direction   string    `json:"direction,omitempty"`
+	Unit        string    `json:"unit,omitempty"`
+	StartValue  float64   `json:"startValue"`
+	FinalValue  float64   `json:"finalValue"`
+	TargetValue float64   `json:"targetValue"`
+	Reached     bool      `json:"reached"`
+	CheckIns    []CheckIn `json:"checkIns"`
+}
+
+// RetrospectiveTask is a completed task counted towards an objective.
+type RetrospectiveTask struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	ThemeID     string              `json:"themeId"`
+	Tags        []string            `json:"tags,omitempty"`
+	CompletedAt utilities.Timestamp `json:"completedAt"`
+}
+
+// GetRetrospective returns the retrospective recorded when the objective was
+// closed, or nil for objectives closed before retrospectives existed.
+func (m *PlanningManager) GetRetrospective(objectiveId string) (*Retrospective, error) {
+	obj, err := m.findAccessObjective(objectiveId)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Retrospective == nil {
+		return nil, nil
+	}
+	return toManagerRetrospective(*obj), nil
+}
+
+// ExportRetrospectiveMarkdown renders an objective's retrospective as a
+// Markdown document.
+func (m *PlanningManager) ExportRetrospectiveMarkdown(objectiveId string) (string, error) {
+	retro, err := m.GetRetrospective(objectiveId)
+	if err != nil {
+		return "", err
+	}
+	if retro == nil {
+		return "", fmt.Errorf("objective %s has no retrospective", objectiveId)
+	}
+	return renderRetrospectiveMarkdown(*retro), nil
+}
+
+// findAccessObjective returns a copy of the objective with the given ID.
+func (m *PlanningManager) findAccessObjective(objectiveId string) (*access.Objective, error) {
+	if objectiveId == "" {
+		return nil, fmt.Errorf("objectiveId cannot be empty")
+	}
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	for i := range themes {
+		if obj := findObjectiveByID(themes[i].Objectives, objectiveId); obj != nil {
+			found := *obj
+			return &found, nil
+		}
+	}
+	return nil, fmt.Errorf("objective with ID %s not found", objectiveId)
+}
+
+// retrospectiveSources holds what a retrospective draws on besides the
+// objective itself, loaded once per close or rollover.
+type retrospectiveSources struct {
+	doneTasks []Task
+	days      []access.DayFocus
+}
+
+// loadRetrospectiveSources collects the tasks in done-type columns or the
+// archive, and every day focus entry.
+func (m *PlanningManager) loadRetrospectiveSources() (*retrospectiveSources, error) {
+	config, err := m.getAccessBoardConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board config: %w", err)
+	}
+	done := doneStatuses(config)
+	tasks, err := m.GetTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	src := &retrospectiveSources{}
+	for _, t := range tasks {
+		if slices.Contains(done, t.Status) {
+			src.doneTasks = append(src.doneTasks, t.Task)
+		}
+	}
+
+	years, err := m.calendarAccess.GetFocusYears()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	for _, year := range years {
+		days, err := m.calendarAccess.GetYearFocus(year)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		src.days = append(src.days, days...)
+	}
+	return src, nil
+}
+
+// buildRetrospective records the retrospective of a just-closed objective of
+// theme themeID. Tasks count when they belong to the theme or share a tag
+// with the objective and were completed, by their last update, between the
+// start of the objective's life and its closing.
+func buildRetrospective(src *retrospectiveSources, themeID string, obj access.Objective) *access.Retrospective {
+	retro := &access.Retrospective{
+		Since:      objectiveSince(obj),
+		ClosedAt:   obj.ClosedAt,
+		KeyResults: make([]access.RetrospectiveKeyResult, 0, len(obj.KeyResults)),
+	}
+
+	refs := []string{obj.ID}
+	for _, kr := range obj.KeyResults {
+		refs = append(refs, kr.ID)
+		if kr.Status == string(access.OKRStatusArchived) {
+			continue
+		}
+		retro.KeyResults = append(retro.KeyResults, access.RetrospectiveKeyResult{
+			ID:          kr.ID,
+			Description: kr.Description,
+			Type:        kr.Type,
+			Direction:   kr.Direction,
+			Unit:        kr.Unit,
+			StartValue:  kr.StartValue,
+			FinalValue:  kr.CurrentValue,
+			TargetValue: kr.TargetValue,
+			Reached:     keyResultReached(kr),
+			CheckIns:    slices.Clone(kr.CheckIns),
+		})
+	}
+
+	for _, t := range src.doneTasks {
+		linked := t.ThemeID == themeID || slices.ContainsFunc(t.Tags, func(tag string) bool {
+			return slices.Contains(obj.Tags, tag)
+		})
+		if !linked {
+			continue
+		}
+		completed := t.UpdatedAt
+		if completed.IsZero() {
+			completed = t.CreatedAt
+		}
+		if completed.IsZero() || completed.Time().After(obj.ClosedAt.Time()) {
+			continue
+		}
+		if !retro.Since.IsZero() && completed.Time().Before(retro.Since.Time()) {
+			continue
+		}
+		retro.Tasks = append(retro.Tasks, access.RetrospectiveTask{
+			ID:          t.ID,
+			Title:       t.Title,
+			ThemeID:     t.ThemeID,
+			Tags:        slices.Clone(t.Tags),
+			CompletedAt: completed,
+		})
+	}
+	slices.SortStableFunc(retro.Tasks, func(a, b access.RetrospectiveTask) int {
+		return strings.Compare(a.CompletedAt.String(), b.CompletedAt.String())
+	})
+
+	for _, day := range src.days {
+		if slices.ContainsFunc(day.OkrIDs, func(id string) bool { return slices.Contains(refs, id) }) {
+			retro.FocusDays = append(retro.FocusDays, day.Date)
+		}
+	}
+	slices.Sort(retro.FocusDays)
+	return retro
+}
+
+// objectiveSince returns when an objective's life started: its start date,
+// else its creation, else its earliest key result check-in. Objectives
+// created before creation times were recorded and without dates or
+// check-ins return an empty timestamp.
+func objectiveSince(obj access.Objective) utilities.Timestamp {
+	if !obj.StartDate.IsZero() {
+		return utilities.NewTimestamp(obj.StartDate.Time())
+	}
+	if !obj.CreatedAt.IsZero() {
+		return obj.CreatedAt
+	}
+	var earliest utilities.Timestamp
+	for _, kr := range obj.KeyResults {
+		for _, c := range kr.CheckIns {
+			if earliest.IsZero() || c.Timestamp.Time().Before(earliest.Time()) {
+				earliest = c.Timestamp
+			}
+		}
+	}
+	return earliest
+}
+
+// renderRetrospectiveMarkdown formats a retrospective as Markdown.
+func renderRetrospectiveMarkdown(r Retrospective) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Retrospective: %s\n\n", r.Title)
+	fmt.Fprintf(&b, "- **Objective:** %s\n", r.ObjectiveID)
+	fmt.Fprintf(&b, "- **Outcome:** %s\n", r.ClosingStatus)
+	if !r.Since.IsZero() {
+		fmt.Fprintf(&b, "- **Period:** %s – %s\n", formatRetroDate(r.Since), formatRetroDate(r.ClosedAt))
+	} else {
+		fmt.Fprintf(&b, "- **Closed:** %s\n", formatRetroDate(r.ClosedAt))
+	}
+	if r.ClosingNotes != "" {
+		fmt.Fprintf(&b, "\n## Notes\n\n%s\n", r.ClosingNotes)
+	}
+
+	b.WriteString("\n## Key results\n\n")
+	if len(r.KeyResults) == 0 {
+		b.WriteString("No key results.\n")
+	} else {
+		b.WriteString("| Key result | Start | Final | Target | Reached |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, kr := range r.KeyResults {
+			reached := "no"
+			if kr.Reached {
+				reached = "yes"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", escapeTableCell(kr.Description),
+				formatRetroValue(kr.StartValue, kr.Unit), formatRetroValue(kr.FinalValue, kr.Unit),
+				formatRetroValue(kr.TargetValue, kr.Unit), reached)
+		}
+	}
+
+	for _, kr := range r.KeyResults {
+		if len(kr.CheckIns) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### Check-ins: %s\n\n", kr.Description)
+		for _, c := range kr.CheckIns {
+			fmt.Fprintf(&b, "- %s: %s", formatRetroDate(c.Timestamp), formatRetroValue(c.Value, kr.Unit))
+			if c.Note != "" {
+				fmt.Fprintf(&b, " — %s", c.Note)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n## Completed tasks\n\n")
+	if len(r.Tasks) == 0 {
+		b.WriteString("No linked tasks were completed.\n")
+	}
+	for _, t := range r.Tasks {
+		fmt.Fprintf(&b, "- %s %s (%s)\n", formatRetroDate(t.CompletedAt), t.Title, t.ID)
+	}
+
+	b.WriteString("\n## Focus days\n\n")
+	if len(r.FocusDays) == 0 {
+		b.WriteString("No days were focused on this objective.\n")
+	}
+	for _, d := range r.FocusDays {
+		fmt.Fprintf(&b, "- %s\n", d)
+	}
+	return b.String()
+}
+
+// formatRetroDate renders the date part of a timestamp.
+func formatRetroDate(ts utilities.Timestamp) string {
+	return ts.Time().Format("2006-01-02")
+}
+
+// formatRetroValue renders a key result value with its unit.
+func formatRetroValue(v float64, unit string) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if unit == "" {
+		return s
+	}
+	if unit == "%" {
+		return s + unit
+	}
+	return s + " " + unit
+}
+
+// escapeTableCell keeps pipes and line breaks from breaking a Markdown table.
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,148 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// newRetrospectiveTestManager returns a manager frozen at 2026-03-01 with
+// objective T-O1 (tag "fitness", started 2026-01-01) holding key result
+// T-KR1, plus done, archived and open tasks and day focus around it.
+func newRetrospectiveTestManager(t *testing.T) (*PlanningManager, *mockThemeAccess) {
+	t.Helper()
+	ta := newMockThemeAccess()
+	ka := newMockTaskAccess()
+	ca := newMockCalendarAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	pm := newTestManager(t, testManagerDeps{themes: ta, tasks: ka, calendar: ca, clock: clock})
+	if _, err := testCreateObjective(pm, "T", "Get fit"); err != nil {
+		t.Fatalf("create objective: %v", err)
+	}
+	if _, err := testCreateKeyResult(pm, "T-O1", "Run | walk", 0, 100); err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	if _, err := pm.RecordCheckIn("T-KR1", 40, "Good start", 0); err != nil {
+		t.Fatalf("RecordCheckIn: %v", err)
+	}
+	obj := &ta.themes[0].Objectives[0]
+	obj.StartDate = "2026-01-01"
+	obj.Tags = []string{"fitness"}
+
+	ka.tasks["done"] = []access.Task{
+		{ID: "T-T1", ThemeID: "T", Title: "Buy shoes", UpdatedAt: "2026-01-10T08:00:00Z"},
+		{ID: "C-T1", ThemeID: "C", Title: "Gym at work", Tags: []string{"fitness"}, CreatedAt: "2026-02-01T08:00:00Z"},
+		{ID: "T-T2", ThemeID: "T", Title: "Old plan", UpdatedAt: "2025-12-01T08:00:00Z"},
+		{ID: "C-T2", ThemeID: "C", Title: "Unrelated", UpdatedAt: "2026-02-01T08:00:00Z"},
+	}
+	ka.tasks["archived"] = []access.Task{{ID: "T-T3", ThemeID: "T", Title: "Stretch", UpdatedAt: "2026-02-20T08:00:00Z"}}
+	ka.tasks["todo"] = []access.Task{{ID: "T-T4", ThemeID: "T", Title: "Marathon", UpdatedAt: "2026-02-20T08:00:00Z"}}
+
+	ca.days["2026-02-02"] = access.DayFocus{Date: "2026-02-02", OkrIDs: []string{"T-KR1"}}
+	ca.days["2026-01-15"] = access.DayFocus{Date: "2026-01-15", OkrIDs: []string{"T-O1"}}
+	ca.days["2026-01-16"] = access.DayFocus{Date: "2026-01-16", ThemeIDs: []string{"T"}}
+	return pm, ta
+}
+
+func TestUnit_CloseObjective_RecordsRetrospective(t *testing.T) {
+	pm, _ := newRetrospectiveTestManager(t)
+
+	if err := pm.CloseObjective("T-O1", "partially-achieved", "Knee trouble"); err != nil {
+		t.Fatalf("CloseObjective: %v", err)
+	}
+	retro, err := pm.GetRetrospective("T-O1")
+	if err != nil || retro == nil {
+		t.Fatalf("GetRetrospective = %v, %v", retro, err)
+	}
+	if retro.Since != "2026-01-01T00:00:00Z" || retro.ClosedAt != "2026-03-01T09:00:00Z" || retro.ClosingNotes != "Knee trouble" {
+		t.Errorf("retrospective = %+v", retro)
+	}
+	if len(retro.KeyResults) != 1 {
+		t.Fatalf("key results = %+v", retro.KeyResults)
+	}
+	if kr := retro.KeyResults[0]; kr.FinalValue != 40 || kr.TargetValue != 100 || kr.Reached || len(kr.CheckIns) != 1 || kr.CheckIns[0].Note != "Good start" {
+		t.Errorf("key result = %+v", kr)
+	}
+
+	var ids []string
+	for _, task := range retro.Tasks {
+		ids = append(ids, task.ID)
+	}
+	if got := strings.Join(ids, ","); got != "T-T1,C-T1,T-T3" {
+		t.Errorf("tasks = %s, want theme and tag tasks completed in the window", got)
+	}
+	if len(retro.FocusDays) != 2 || retro.FocusDays[0] != "2026-01-15" || retro.FocusDays[1] != "2026-02-02" {
+		t.Errorf("focus days = %v", retro.FocusDays)
+	}
+
+	if err := pm.ReopenObjective("T-O1"); err != nil {
+		t.Fatalf("ReopenObjective: %v", err)
+	}
+	if retro, err := pm.GetRetrospective("T-O1"); err != nil || retro != nil {
+		t.Errorf("after reopen = %+v, %v; want no retrospective", retro, err)
+	}
+	if _, err := pm.ExportRetrospectiveMarkdown("T-O1"); err == nil || !strings.Contains(err.Error(), "no retrospective") {
+		t.Errorf("export after reopen = %v, want error", err)
+	}
+}
+
+func TestUnit_ExportRetrospectiveMarkdown(t *testing.T) {
+	pm, _ := newRetrospectiveTestManager(t)
+	if err := pm.CloseObjective("T-O1", "achieved", ""); err != nil {
+		t.Fatalf("CloseObjective: %v", err)
+	}
+
+	md, err := pm.ExportRetrospectiveMarkdown("T-O1")
+	if err != nil {
+		t.Fatalf("ExportRetrospectiveMarkdown: %v", err)
+	}
+	for _, want := range []string{
+		"# Retrospective: Get fit\n",
+		"- **Period:** 2026-01-01 – 2026-03-01\n",
+		"| Run \\| walk | 0 | 40 | 100 | no |\n",
+		"### Check-ins: Run | walk\n\n- 2026-03-01: 40 — Good start\n",
+		"- 2026-02-01 Gym at work (C-T1)\n",
+		"## Focus days\n\n- 2026-01-15\n- 2026-02-02\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "## Notes") {
+		t.Errorf("markdown has a notes section without notes:\n%s", md)
+	}
+
+	if _, err := pm.ExportRetrospectiveMarkdown("T-O9"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("unknown objective = %v, want not found", err)
+	}
+}
+
+func TestUnit_RolloverCycle_RecordsRetrospectives(t *testing.T) {
+	pm, repo := newCycleTestManager(t)
+	done := establishCycleObjective(t, pm, "Launch", "CY2")
+	late := establishCycleObjective(t, pm, "Write book", "CY2")
+	repo.tx.commits = nil
+
+	result, err := pm.RolloverCycle(RolloverRequest{
+		CycleID:     "CY2",
+		NextCycleID: "CY1",
+		Closings: []ObjectiveClosing{
+			{ObjectiveID: done, ClosingStatus: "achieved"},
+			{ObjectiveID: late, ClosingStatus: "postponed"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RolloverCycle: %v", err)
+	}
+	for _, id := range []string{done, late} {
+		if retro, err := pm.GetRetrospective(id); err != nil || retro == nil || retro.Since != "2026-03-01T09:00:00Z" {
+			t.Errorf("retrospective of %s = %+v, %v", id, retro, err)
+		}
+	}
+	if retro, err := pm.GetRetrospective(result.CarriedOver[0].ToID); err != nil || retro != nil {
+		t.Errorf("carried-over copy retrospective = %+v, %v; want none", retro, err)
+	}
+}
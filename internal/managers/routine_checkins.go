@@ -0,0 +1,123 @@
+package managers
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// maxCheckNoteLength bounds the note of a routine check-in.
+const maxCheckNoteLength = 200
+
+// normalizeRoutineCheckDetails validates a day's check-in details and trims
+// their text. Details of routines not checked on the day, and details left
+// empty, are dropped; a routine may have at most one entry.
+func normalizeRoutineCheckDetails(day *DayFocus) error {
+	var out []RoutineCheckDetail
+	for i, d := range day.RoutineCheckDetails {
+		d.Unit = strings.TrimSpace(d.Unit)
+		d.Note = strings.TrimSpace(d.Note)
+		if math.IsNaN(d.Amount) || math.IsInf(d.Amount, 0) || d.Amount < 0 {
+			return fmt.Errorf("routine %s: amount must be a non-negative number", d.RoutineID)
+		}
+		if d.DurationMinutes < 0 {
+			return fmt.Errorf("routine %s: duration cannot be negative", d.RoutineID)
+		}
+		if d.Unit != "" && d.Amount == 0 {
+			return fmt.Errorf("routine %s: a unit needs an amount", d.RoutineID)
+		}
+		if len(d.Unit) > maxKRUnitLength {
+			return fmt.Errorf("routine %s: unit cannot exceed %d characters", d.RoutineID, maxKRUnitLength)
+		}
+		if len(d.Note) > maxCheckNoteLength {
+			return fmt.Errorf("routine %s: note cannot exceed %d characters", d.RoutineID, maxCheckNoteLength)
+		}
+		if slices.ContainsFunc(day.RoutineCheckDetails[:i], func(o RoutineCheckDetail) bool { return o.RoutineID == d.RoutineID }) {
+			return fmt.Errorf("routine %s: check-in details given twice", d.RoutineID)
+		}
+		if !slices.Contains(day.RoutineChecks, d.RoutineID) || (d.Amount == 0 && d.DurationMinutes == 0 && d.Note == "") {
+			continue
+		}
+		out = append(out, d)
+	}
+	day.RoutineCheckDetails = out
+	return nil
+}
+
+// keepCheckedDetails drops check-in details of routines not in checks.
+func keepCheckedDetails(details []access.RoutineCheckDetail, checks []string) []access.RoutineCheckDetail {
+	var out []access.RoutineCheckDetail
+	for _, d := range details {
+		if slices.Contains(checks, d.RoutineID) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// addCheckInTotals fills the quantity fields of progress from the check-in
+// details of its routine dated start through end (YYYY-MM-DD, inclusive).
+// Amounts count only in the unit of the latest amount. An empty range
+// leaves progress unchanged.
+func (m *PlanningManager) addCheckInTotals(progress *RoutinePeriodProgress, start, end string) error {
+	if start == "" || end == "" {
+		return nil
+	}
+	from, err := utilities.ParseCalendarDate(start)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	to, err := utilities.ParseCalendarDate(end)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	var details []access.RoutineCheckDetail
+	for year := from.Time().Year(); year <= to.Time().Year(); year++ {
+		entries, err := m.calendarAccess.GetYearFocus(year)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		slices.SortFunc(entries, func(a, b access.DayFocus) int { return strings.Compare(a.Date.String(), b.Date.String()) })
+		for _, entry := range entries {
+			date := entry.Date.String()
+			if date < start || date > end || !slices.Contains(entry.RoutineChecks, progress.RoutineID) {
+				continue
+			}
+			for _, d := range entry.RoutineCheckDetails {
+				if d.RoutineID == progress.RoutineID {
+					details = append(details, d)
+				}
+			}
+		}
+	}
+
+	for i := len(details) - 1; i >= 0; i-- {
+		if details[i].Amount > 0 {
+			progress.Unit = details[i].Unit
+			break
+		}
+	}
+	var amounts, durations int
+	for _, d := range details {
+		if d.Amount > 0 && d.Unit == progress.Unit {
+			progress.TotalAmount += d.Amount
+			amounts++
+		}
+		if d.DurationMinutes > 0 {
+			progress.TotalMinutes += d.DurationMinutes
+			durations++
+		}
+	}
+	if amounts > 0 {
+		progress.AverageAmount = progress.TotalAmount / float64(amounts)
+	}
+	if durations > 0 {
+		progress.AverageMinutes = float64(progress.TotalMinutes) / float64(durations)
+	}
+	return nil
+}
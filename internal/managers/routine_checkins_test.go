@@ -0,0 +1,164 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// newRoutineCheckTestManager returns a manager frozen on Thursday 2026-03-19.
+func newRoutineCheckTestManager(t *testing.T) (*PlanningManager, *mockTaskAccess, *mockCalendarAccess) {
+	t.Helper()
+	ka := newMockTaskAccess()
+	ca := newMockCalendarAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 3, 19, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	pm := newTestManager(t, testManagerDeps{tasks: ka, calendar: ca, clock: clock})
+	return pm, ka, ca
+}
+
+func TestUnit_RoutineCheckDetails_SaveDayFocusNormalizes(t *testing.T) {
+	pm, _, ca := newRoutineCheckTestManager(t)
+
+	err := pm.SaveDayFocus(DayFocus{
+		Date:          utilities.MustParseCalendarDate("2026-03-18"),
+		RoutineChecks: []string{"R1", "R2"},
+		RoutineCheckDetails: []RoutineCheckDetail{
+			{RoutineID: "R1", Amount: 5, Unit: " km ", Note: " Windy "},
+			{RoutineID: "R2"},
+			{RoutineID: "R3", DurationMinutes: 20},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SaveDayFocus: %v", err)
+	}
+	saved := ca.days["2026-03-18"].RoutineCheckDetails
+	if len(saved) != 1 || saved[0] != (access.RoutineCheckDetail{RoutineID: "R1", Amount: 5, Unit: "km", Note: "Windy"}) {
+		t.Errorf("details = %+v, want only R1 trimmed", saved)
+	}
+
+	tests := []struct {
+		name    string
+		detail  RoutineCheckDetail
+		wantErr string
+	}{
+		{"negative amount", RoutineCheckDetail{Amount: -1}, "non-negative"},
+		{"negative duration", RoutineCheckDetail{DurationMinutes: -5}, "cannot be negative"},
+		{"unit without amount", RoutineCheckDetail{Unit: "km"}, "needs an amount"},
+		{"long note", RoutineCheckDetail{Note: strings.Repeat("x", maxCheckNoteLength+1)}, "cannot exceed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.detail.RoutineID = "R1"
+			day := DayFocus{Date: utilities.MustParseCalendarDate("2026-03-18"), RoutineChecks: []string{"R1"}, RoutineCheckDetails: []RoutineCheckDetail{tt.detail}}
+			if err := pm.SaveDayFocus(day); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SaveDayFocus = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A duplicate is rejected even when the first entry would be dropped.
+	day := DayFocus{
+		Date:                utilities.MustParseCalendarDate("2026-03-18"),
+		RoutineChecks:       []string{"R1"},
+		RoutineCheckDetails: []RoutineCheckDetail{{RoutineID: "R1"}, {RoutineID: "R1", Amount: 3}},
+	}
+	if err := pm.SaveDayFocus(day); err == nil || !strings.Contains(err.Error(), "given twice") {
+		t.Errorf("SaveDayFocus = %v, want error containing %q", err, "given twice")
+	}
+}
+
+func TestUnit_RoutineCheckDetails_RecordCompletions(t *testing.T) {
+	pm, ka, ca := newRoutineCheckTestManager(t)
+	routine, err := testAddRoutine(pm, "Run")
+	if err != nil {
+		t.Fatalf("testAddRoutine: %v", err)
+	}
+	date := utilities.MustParseCalendarDate("2026-03-19")
+
+	day := DayFocus{Date: date, RoutineChecks: []string{routine.ID}}
+	if err := pm.RecordRoutineCompletions(day, nil); err != nil {
+		t.Fatalf("RecordRoutineCompletions: %v", err)
+	}
+
+	// Adding details to an existing check changes no task.
+	day.RoutineCheckDetails = []RoutineCheckDetail{{RoutineID: routine.ID, Amount: 12, Unit: "km"}}
+	if err := pm.RecordRoutineCompletions(day, []string{routine.ID}); err != nil {
+		t.Fatalf("RecordRoutineCompletions with details: %v", err)
+	}
+	if got := len(ka.tasks["todo"]); got != 1 {
+		t.Errorf("todo tasks = %d, want 1", got)
+	}
+	if got := ca.days["2026-03-19"].RoutineCheckDetails; len(got) != 1 || got[0].Amount != 12 {
+		t.Errorf("details = %+v, want 12 km", got)
+	}
+
+	// Unchecking drops the details with the check.
+	day.RoutineChecks = nil
+	if err := pm.RecordRoutineCompletions(day, []string{routine.ID}); err != nil {
+		t.Fatalf("RecordRoutineCompletions unchecked: %v", err)
+	}
+	if got := ca.days["2026-03-19"].RoutineCheckDetails; len(got) != 0 {
+		t.Errorf("details = %+v, want none", got)
+	}
+}
+
+func TestUnit_RoutineCheckDetails_ProgressTotals(t *testing.T) {
+	pm, _, ca := newRoutineCheckTestManager(t)
+	res, err := pm.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Run",
+		RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, Weekdays: []int{1, 3, 5}, StartDate: utilities.MustParseCalendarDate("2026-01-05")},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+	check := func(date string, details ...access.RoutineCheckDetail) {
+		ca.days[date] = access.DayFocus{Date: utilities.MustParseCalendarDate(date), RoutineChecks: []string{id}, RoutineCheckDetails: details}
+	}
+	check("2026-03-13", access.RoutineCheckDetail{RoutineID: id, Amount: 20, Unit: "km"}) // last week
+	check("2026-03-16", access.RoutineCheckDetail{RoutineID: id, Amount: 3, Unit: "mi", DurationMinutes: 30})
+	check("2026-03-17", access.RoutineCheckDetail{RoutineID: id, Amount: 5, Unit: "km", DurationMinutes: 28})
+	check("2026-03-18", access.RoutineCheckDetail{RoutineID: id, Amount: 7, Unit: "km", Note: "Hills"})
+	check("2026-03-19")
+
+	progress, err := pm.GetRoutineProgress(id)
+	if err != nil {
+		t.Fatalf("GetRoutineProgress: %v", err)
+	}
+	want := RoutinePeriodProgress{
+		RoutineID: id, Completed: 2, Expected: 3, Period: "week", OnTrack: true,
+		TotalAmount: 12, AverageAmount: 6, Unit: "km", TotalMinutes: 58, AverageMinutes: 29,
+	}
+	if *progress != want {
+		t.Errorf("progress = %+v, want %+v", *progress, want)
+	}
+}
+
+func TestUnit_RoutineCheckDetails_FloatingProgressTotals(t *testing.T) {
+	pm, _, ca := newRoutineCheckTestManager(t)
+	res, err := pm.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Water the plants",
+		RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, AfterCompletion: true, StartDate: utilities.MustParseCalendarDate("2026-01-05")},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+	for date, minutes := range map[string]int{"2026-03-09": 15, "2026-03-16": 10} {
+		ca.days[date] = access.DayFocus{Date: utilities.MustParseCalendarDate(date), RoutineChecks: []string{id}, RoutineCheckDetails: []access.RoutineCheckDetail{{RoutineID: id, DurationMinutes: minutes}}}
+	}
+
+	progress, err := pm.GetRoutineProgress(id)
+	if err != nil {
+		t.Fatalf("GetRoutineProgress: %v", err)
+	}
+	want := RoutinePeriodProgress{RoutineID: id, Completed: 1, Expected: 1, Period: "cycle", OnTrack: true, TotalMinutes: 10, AverageMinutes: 10}
+	if *progress != want {
+		t.Errorf("progress = %+v, want %+v", *progress, want)
+	}
+}
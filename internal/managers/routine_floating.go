@@ -0,0 +1,50 @@
+package managers
+
+import (
+	"fmt"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/engines/schedule_engine"
+)
+
+// floatingRoutineOccurrences returns the entries an after-completion routine
+// contributes to date. The routine is scheduled on its next due date, and on
+// any later date it was checked. Today, a due date that has passed without a
+// completion shows as a single overdue entry, since a floating routine owes
+// one completion at a time.
+func (m *PlanningManager) floatingRoutineOccurrences(routine access.Routine, pattern schedule_engine.RepeatPattern, date, today string, checked bool) ([]RoutineOccurrence, error) {
+	completedDates, err := m.calendarAccess.GetRoutineCompletions(routine.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completions for routine %s: %w", routine.ID, err)
+	}
+	exceptions := toEngineExceptions(routine.Exceptions)
+
+	due := m.scheduleEngine.NextDue(pattern, exceptions, completedDates, date)
+	if due == "" {
+		return nil, nil
+	}
+	// Lexicographic compare on YYYY-MM-DD is equivalent to chronological order.
+	if due == date || (due < date && checked) {
+		return []RoutineOccurrence{{
+			RoutineID:   routine.ID,
+			Description: routine.Description,
+			Date:        date,
+			Status:      "scheduled",
+			Checked:     checked,
+		}}, nil
+	}
+	if date != today {
+		return nil, nil
+	}
+	overdue := m.scheduleEngine.ComputeOverdue(pattern, exceptions, completedDates, date)
+	if len(overdue) == 0 {
+		return nil, nil
+	}
+	return []RoutineOccurrence{{
+		RoutineID:   routine.ID,
+		Description: routine.Description,
+		Date:        overdue[0],
+		Status:      "overdue",
+		MissedCount: 1,
+	}}, nil
+}
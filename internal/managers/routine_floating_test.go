@@ -0,0 +1,99 @@
+package managers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_FloatingRoutine_DueAfterCompletion(t *testing.T) {
+	ca := newMockCalendarAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	manager := newTestManager(t, testManagerDeps{calendar: ca, clock: clock})
+	res, err := manager.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Water the plants",
+		RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, AfterCompletion: true, StartDate: utilities.MustParseCalendarDate("2025-12-01")},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+	check := func(date string) {
+		ca.days[date] = access.DayFocus{Date: utilities.MustParseCalendarDate(date), RoutineChecks: []string{id}}
+	}
+	forDate := func(date string) []RoutineOccurrence {
+		t.Helper()
+		occurrences, err := manager.GetRoutinesForDate(date)
+		if err != nil {
+			t.Fatalf("GetRoutinesForDate(%s): %v", date, err)
+		}
+		return occurrences
+	}
+	progress := func() RoutinePeriodProgress {
+		t.Helper()
+		p, err := manager.GetRoutineProgress(id)
+		if err != nil {
+			t.Fatalf("GetRoutineProgress: %v", err)
+		}
+		return *p
+	}
+
+	// Last done in the previous year, so due a week later and missed since.
+	check("2025-12-29")
+	if got, want := forDate("2026-01-12"), []RoutineOccurrence{{RoutineID: id, Description: "Water the plants", Date: "2026-01-05", Status: "overdue", MissedCount: 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("today = %+v, want %+v", got, want)
+	}
+	if got := forDate("2026-01-05"); len(got) != 1 || got[0].Status != "scheduled" {
+		t.Errorf("due date = %+v, want one scheduled entry", got)
+	}
+	if got, want := progress(), (RoutinePeriodProgress{RoutineID: id, Expected: 1, Period: "cycle"}); got != want {
+		t.Errorf("progress = %+v, want %+v", got, want)
+	}
+
+	// Doing it late restarts the interval from that day.
+	check("2026-01-09")
+	if got := forDate("2026-01-12"); len(got) != 0 {
+		t.Errorf("today = %+v, want nothing due", got)
+	}
+	if got := forDate("2026-01-09"); len(got) != 1 || got[0].Status != "scheduled" || !got[0].Checked {
+		t.Errorf("late completion day = %+v, want one checked entry", got)
+	}
+	if got := forDate("2026-01-16"); len(got) != 1 || got[0].Date != "2026-01-16" || got[0].Status != "scheduled" {
+		t.Errorf("next due date = %+v, want one scheduled entry", got)
+	}
+	if got, want := progress(), (RoutinePeriodProgress{RoutineID: id, Completed: 1, Expected: 1, Period: "cycle", OnTrack: true}); got != want {
+		t.Errorf("progress = %+v, want %+v", got, want)
+	}
+
+	if _, err := manager.ExportRoutineRRule(id); err == nil || !strings.Contains(err.Error(), "no RRULE equivalent") {
+		t.Errorf("ExportRoutineRRule = %v, want error", err)
+	}
+}
+
+func TestUnit_FloatingRoutine_Validation(t *testing.T) {
+	manager, _, _ := newMockManager()
+	start := utilities.MustParseCalendarDate("2026-01-01")
+
+	for _, tt := range []struct {
+		name    string
+		pattern RepeatPattern
+		wantErr string
+	}{
+		{"with rule", RepeatPattern{Frequency: "daily", AfterCompletion: true, RRule: "FREQ=DAILY", StartDate: start}, "cannot also have a recurrence rule"},
+		{"unknown unit", RepeatPattern{Frequency: "fortnightly", AfterCompletion: true, StartDate: start}, "invalid frequency"},
+		{"no start date", RepeatPattern{Frequency: "daily", AfterCompletion: true}, "needs a start date"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := tt.pattern
+			_, err := manager.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Walk", RepeatPattern: &pattern})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
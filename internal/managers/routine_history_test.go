@@ -0,0 +1,74 @@
+package managers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// newYearBoundaryManager returns a manager frozen on Friday 2026-01-02 with
+// one routine on the given pattern, checked on the given dates.
+func newYearBoundaryManager(t *testing.T, pattern *RepeatPattern, checked ...string) (*PlanningManager, *mockCalendarAccess, string) {
+	t.Helper()
+	ca := newMockCalendarAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	pm := newTestManager(t, testManagerDeps{calendar: ca, clock: clock})
+	res, err := pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Run", RepeatPattern: pattern})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	for _, date := range checked {
+		ca.days[date] = access.DayFocus{Date: utilities.MustParseCalendarDate(date), RoutineChecks: []string{res.Routine.ID}}
+	}
+	return pm, ca, res.Routine.ID
+}
+
+func TestUnit_GetRoutinesForDate_OverdueSpansYearBoundary(t *testing.T) {
+	pattern := &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2025-12-28")}
+	pm, _, id := newYearBoundaryManager(t, pattern, "2025-12-29", "2025-12-30", "2025-12-31")
+
+	occurrences, err := pm.GetRoutinesForDate("2026-01-02")
+	if err != nil {
+		t.Fatalf("GetRoutinesForDate: %v", err)
+	}
+	want := []RoutineOccurrence{
+		{RoutineID: id, Description: "Run", Date: "2026-01-02", Status: "scheduled"},
+		// Last year's checks absorb the occurrences before them.
+		{RoutineID: id, Description: "Run", Date: "2026-01-01", Status: "overdue", MissedCount: 1},
+	}
+	if len(occurrences) != len(want) {
+		t.Fatalf("occurrences = %+v, want %+v", occurrences, want)
+	}
+	for i := range want {
+		if occurrences[i] != want[i] {
+			t.Errorf("occurrence %d = %+v, want %+v", i, occurrences[i], want[i])
+		}
+	}
+}
+
+func TestUnit_GetRoutineProgress_WeekSpansYearBoundary(t *testing.T) {
+	pattern := &RepeatPattern{Frequency: "weekly", Interval: 1, Weekdays: []int{1, 3, 5}, StartDate: utilities.MustParseCalendarDate("2025-12-01")}
+	pm, _, id := newYearBoundaryManager(t, pattern, "2025-12-29", "2025-12-31", "2026-01-02")
+
+	progress, err := pm.GetRoutineProgress(id)
+	if err != nil {
+		t.Fatalf("GetRoutineProgress: %v", err)
+	}
+	want := RoutinePeriodProgress{RoutineID: id, Completed: 3, Expected: 3, Period: "week", OnTrack: true}
+	if *progress != want {
+		t.Errorf("progress = %+v, want %+v", *progress, want)
+	}
+}
+
+func TestUnit_CheckIntegrity_RebuildsCompletionIndex(t *testing.T) {
+	pm, ca, _ := newYearBoundaryManager(t, nil)
+
+	if _, err := pm.CheckIntegrity(false); err != nil {
+		t.Fatalf("CheckIntegrity: %v", err)
+	}
+	if ca.indexRebuilds != 1 {
+		t.Errorf("index rebuilds = %d, want 1", ca.indexRebuilds)
+	}
+}
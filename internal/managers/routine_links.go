@@ -0,0 +1,224 @@
+package managers
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+)
+
+// resolveRoutineLink validates the theme and key result a routine serves and
+// returns the theme to store. A key result implies its theme; a theme given
+// alongside must be the same one. Feeding needs a percentage key result,
+// since the routine's adherence rate is recorded as its check-ins.
+func resolveRoutineLink(themes []access.LifeTheme, themeID, keyResultID string, feed bool) (string, error) {
+	if keyResultID != "" {
+		krTheme, kr := findKeyResultTheme(themes, keyResultID)
+		if kr == nil {
+			return "", fmt.Errorf("key result with ID %s not found", keyResultID)
+		}
+		if themeID != "" && themeID != krTheme {
+			return "", fmt.Errorf("key result %s does not belong to theme %s", keyResultID, themeID)
+		}
+		if feed && kr.Type != access.KRTypePercentage {
+			return "", fmt.Errorf("only a percentage key result can be fed by a routine's adherence")
+		}
+		return krTheme, nil
+	}
+	if feed {
+		return "", fmt.Errorf("a routine needs a key result to feed")
+	}
+	if themeID != "" && !themeExists(themes, themeID) {
+		return "", fmt.Errorf("theme with ID %s not found", themeID)
+	}
+	return themeID, nil
+}
+
+// routineParentLink splits an Establish parent ID into the theme and key
+// result a new routine serves.
+func routineParentLink(parentID string) (themeID, keyResultID string, err error) {
+	switch {
+	case parentID == "":
+		return "", "", nil
+	case detectGoalType(parentID) == GoalTypeKeyResult:
+		return "", parentID, nil
+	case detectGoalType(parentID) == GoalTypeTheme:
+		return parentID, "", nil
+	default:
+		return "", "", fmt.Errorf("a routine's parent must be a theme or a key result, got %s", parentID)
+	}
+}
+
+// findKeyResultTheme returns the key result with the given ID and the ID of
+// the theme holding it, or a nil key result when there is none.
+func findKeyResultTheme(themes []access.LifeTheme, keyResultID string) (string, *access.KeyResult) {
+	for i := range themes {
+		if obj, idx := findKeyResultParent(themes[i].Objectives, keyResultID); obj != nil {
+			return themes[i].ID, &obj.KeyResults[idx]
+		}
+	}
+	return "", nil
+}
+
+// themeExists reports whether a theme with the given ID exists.
+func themeExists(themes []access.LifeTheme, themeID string) bool {
+	for _, t := range themes {
+		if t.ID == themeID {
+			return true
+		}
+	}
+	return false
+}
+
+// dropDanglingRoutineLinks returns routines with links to themes and key
+// results that no longer exist cleared. Deleting a theme or key result
+// leaves its routines in place, so readers treat such links as absent.
+func dropDanglingRoutineLinks(routines []access.Routine, themes []access.LifeTheme) []access.Routine {
+	out := make([]access.Routine, len(routines))
+	for i, r := range routines {
+		if r.KeyResultID != "" {
+			if _, kr := findKeyResultTheme(themes, r.KeyResultID); kr == nil {
+				r.KeyResultID, r.FeedKeyResult = "", false
+			}
+		}
+		if r.ThemeID != "" && !themeExists(themes, r.ThemeID) {
+			r.ThemeID, r.KeyResultID, r.FeedKeyResult = "", "", false
+		}
+		out[i] = r
+	}
+	return out
+}
+
+// hasRoutineLinks reports whether any routine serves a theme.
+func hasRoutineLinks(routines []access.Routine) bool {
+	for _, r := range routines {
+		if r.ThemeID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sortOccurrencesByTheme orders occurrences by their theme in theme order,
+// with occurrences of routines serving no theme last. Order within a theme
+// is kept.
+func sortOccurrencesByTheme(occurrences []RoutineOccurrence, themes []access.LifeTheme) {
+	rank := make(map[string]int, len(themes))
+	for i, t := range themes {
+		rank[t.ID] = i
+	}
+	themeRank := func(id string) int {
+		if r, ok := rank[id]; ok {
+			return r
+		}
+		return len(themes)
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return themeRank(occurrences[i].ThemeID) < themeRank(occurrences[j].ThemeID)
+	})
+}
+
+// remapRoutineLinks rewrites the theme and key result links of routines for
+// goals renamed in mapping (old ID to new ID). A key result moved to another
+// theme takes the routine's theme link along. Returns all routines and
+// whether any changed.
+func (m *PlanningManager) remapRoutineLinks(mapping map[string]string) ([]access.Routine, bool, error) {
+	routines, err := m.routineAccess.GetRoutines()
+	if err != nil {
+		return nil, false, fmt.Errorf("%w", err)
+	}
+	changed := false
+	for i := range routines {
+		r := &routines[i]
+		if newID, ok := mapping[r.ThemeID]; ok && newID != r.ThemeID {
+			r.ThemeID = newID
+			changed = true
+		}
+		if newID, ok := mapping[r.KeyResultID]; ok && newID != r.KeyResultID {
+			r.KeyResultID = newID
+			r.ThemeID, _, _ = strings.Cut(newID, "-")
+			changed = true
+		}
+	}
+	return routines, changed, nil
+}
+
+// feedRoutineAdherence records a check-in with the 4-week adherence rate, in
+// percent, on the key result of every routine in routineIDs that feeds one.
+// checkedOn is the date whose checks are being recorded and checked the
+// routines checked on it, which the stored completions do not reflect yet.
+// Returns the themes to write; their key results carry the new check-ins.
+func (m *PlanningManager) feedRoutineAdherence(routines []access.Routine, routineIDs []string, checkedOn string, checked []string) ([]access.LifeTheme, error) {
+	feeding := make(map[string]access.Routine)
+	for _, r := range routines {
+		if r.FeedKeyResult && r.KeyResultID != "" && slices.Contains(routineIDs, r.ID) {
+			feeding[r.ID] = r
+		}
+	}
+	if len(feeding) == 0 {
+		return nil, nil
+	}
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	today := m.clock.Today()
+	touched := make(map[string]bool)
+	for _, id := range routineIDs {
+		r, ok := feeding[id]
+		if !ok {
+			continue
+		}
+		themeID, kr := findKeyResultTheme(themes, r.KeyResultID)
+		if kr == nil || kr.Type != access.KRTypePercentage {
+			continue
+		}
+		completions, err := m.calendarAccess.GetRoutineCompletions(r.ID)
+		if err != nil {
+			return nil, fmt.Errorf("load completions for routine %s: %w", r.ID, err)
+		}
+		completions = withCompletion(completions, checkedOn, slices.Contains(checked, r.ID))
+
+		pattern := toEngineRepeatPattern(r.RepeatPattern)
+		if pattern == nil {
+			continue
+		}
+		stats := m.scheduleEngine.ComputeStats(*pattern, toEngineExceptions(r.Exceptions), completions, today.String(), today.Time().Year())
+		adherence := stats.Adherence[0]
+		kr.CheckIns = append(kr.CheckIns, access.CheckIn{
+			ID:        nextCheckInID(kr.CheckIns),
+			Value:     math.Round(adherence.Rate*1000) / 10,
+			Timestamp: m.clock.Now(),
+			Note:      fmt.Sprintf("Routine %s: %d of %d in the last %d weeks", r.ID, adherence.Completed, adherence.Expected, adherence.Weeks),
+		})
+		deriveCurrentValue(kr)
+		touched[themeID] = true
+	}
+
+	var out []access.LifeTheme
+	for _, t := range themes {
+		if touched[t.ID] {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// withCompletion returns dates with date added when checked and removed
+// otherwise.
+func withCompletion(dates []string, date string, checked bool) []string {
+	out := make([]string, 0, len(dates)+1)
+	for _, d := range dates {
+		if d != date {
+			out = append(out, d)
+		}
+	}
+	if checked {
+		out = append(out, date)
+	}
+	return out
+}
@@ -0,0 +1,217 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// newRoutineLinkTestManager returns a manager frozen on 2026-03-16 with theme
+// T holding T-O1 and its key results T-KR1 (metric) and T-KR2 (percentage),
+// plus a second theme whose ID is returned.
+func newRoutineLinkTestManager(t *testing.T) (*PlanningManager, *mockThemeAccess, *mockTaskAccess, *mockCalendarAccess, *mockRoutineAccess, string) {
+	t.Helper()
+	ta := newMockThemeAccess()
+	ka := newMockTaskAccess()
+	ca := newMockCalendarAccess()
+	ra := newMockRoutineAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	pm := newTestManager(t, testManagerDeps{themes: ta, tasks: ka, calendar: ca, routines: ra, clock: clock})
+	res, err := pm.Establish(EstablishRequest{GoalType: GoalTypeTheme, Name: "Career", Color: "#ff0000"})
+	if err != nil {
+		t.Fatalf("Establish theme: %v", err)
+	}
+	if _, err := testCreateObjective(pm, "T", "Get fit"); err != nil {
+		t.Fatalf("create objective: %v", err)
+	}
+	if _, err := testCreateKeyResult(pm, "T-O1", "Run km", 0, 100); err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeKeyResult, ParentID: "T-O1", Description: "Train consistently", KeyResultType: access.KRTypePercentage}); err != nil {
+		t.Fatalf("create percentage key result: %v", err)
+	}
+	return pm, ta, ka, ca, ra, res.Theme.ID
+}
+
+// dailyPattern returns a daily pattern started well before the test clock.
+func dailyPattern() *RepeatPattern {
+	return &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-01-01")}
+}
+
+func TestUnit_RoutineLinks_Establish(t *testing.T) {
+	pm, _, _, _, _, career := newRoutineLinkTestManager(t)
+
+	res, err := pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Run", ParentID: "T-KR1", RepeatPattern: dailyPattern()})
+	if err != nil {
+		t.Fatalf("Establish under key result: %v", err)
+	}
+	if r := res.Routine; r.ThemeID != "T" || r.KeyResultID != "T-KR1" || r.FeedKeyResult {
+		t.Errorf("routine links = %q/%q/%v, want T/T-KR1/false", r.ThemeID, r.KeyResultID, r.FeedKeyResult)
+	}
+
+	res, err = pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Network", ParentID: career})
+	if err != nil {
+		t.Fatalf("Establish under theme: %v", err)
+	}
+	if r := res.Routine; r.ThemeID != career || r.KeyResultID != "" {
+		t.Errorf("routine links = %q/%q, want %s/none", r.ThemeID, r.KeyResultID, career)
+	}
+
+	tests := []struct {
+		name    string
+		req     EstablishRequest
+		wantErr string
+	}{
+		{"objective parent", EstablishRequest{ParentID: "T-O1"}, "must be a theme or a key result"},
+		{"missing key result", EstablishRequest{ParentID: "T-KR9"}, "not found"},
+		{"missing theme", EstablishRequest{ParentID: "ZZ"}, "not found"},
+		{"feed without key result", EstablishRequest{ParentID: "T", FeedKeyResult: true}, "needs a key result"},
+		{"feed metric key result", EstablishRequest{ParentID: "T-KR1", FeedKeyResult: true}, "only a percentage key result"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.GoalType = GoalTypeRoutine
+			tt.req.Description = "Stretch"
+			if _, err := pm.Establish(tt.req); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Establish = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnit_RoutineLinks_Revise(t *testing.T) {
+	pm, _, _, _, ra, career := newRoutineLinkTestManager(t)
+	routine, err := testAddRoutine(pm, "Run")
+	if err != nil {
+		t.Fatalf("testAddRoutine: %v", err)
+	}
+	stored := func() access.Routine {
+		t.Helper()
+		routines, _ := ra.GetRoutines()
+		for _, r := range routines {
+			if r.ID == routine.ID {
+				return r
+			}
+		}
+		t.Fatalf("routine %s not stored", routine.ID)
+		return access.Routine{}
+	}
+	str := func(s string) *string { return &s }
+	yes := true
+
+	// Setting a key result derives its theme.
+	if err := pm.Revise(ReviseRequest{GoalID: routine.ID, KeyResultID: str("T-KR2"), FeedKeyResult: &yes}); err != nil {
+		t.Fatalf("Revise key result: %v", err)
+	}
+	if r := stored(); r.ThemeID != "T" || r.KeyResultID != "T-KR2" || !r.FeedKeyResult {
+		t.Errorf("links = %q/%q/%v, want T/T-KR2/true", r.ThemeID, r.KeyResultID, r.FeedKeyResult)
+	}
+
+	// A theme not holding the key result is rejected.
+	if err := pm.Revise(ReviseRequest{GoalID: routine.ID, ThemeID: str(career)}); err == nil || !strings.Contains(err.Error(), "does not belong") {
+		t.Errorf("Revise mismatched theme = %v, want error", err)
+	}
+
+	// Clearing the key result stops feeding but keeps the theme.
+	if err := pm.Revise(ReviseRequest{GoalID: routine.ID, KeyResultID: str("")}); err != nil {
+		t.Fatalf("Revise clear key result: %v", err)
+	}
+	if r := stored(); r.ThemeID != "T" || r.KeyResultID != "" || r.FeedKeyResult {
+		t.Errorf("links = %q/%q/%v, want T/none/false", r.ThemeID, r.KeyResultID, r.FeedKeyResult)
+	}
+
+	// Clearing the theme clears everything.
+	if err := pm.Revise(ReviseRequest{GoalID: routine.ID, KeyResultID: str("T-KR1")}); err != nil {
+		t.Fatalf("Revise key result: %v", err)
+	}
+	if err := pm.Revise(ReviseRequest{GoalID: routine.ID, ThemeID: str("")}); err != nil {
+		t.Fatalf("Revise clear theme: %v", err)
+	}
+	if r := stored(); r.ThemeID != "" || r.KeyResultID != "" {
+		t.Errorf("links = %q/%q, want none", r.ThemeID, r.KeyResultID)
+	}
+}
+
+func TestUnit_RoutineLinks_GetRoutinesForDateGroupsByTheme(t *testing.T) {
+	pm, _, _, _, _, career := newRoutineLinkTestManager(t)
+	for _, req := range []EstablishRequest{
+		{Description: "Read"},
+		{Description: "Network", ParentID: career},
+		{Description: "Run", ParentID: "T-KR1"},
+		{Description: "Stretch", ParentID: "T"},
+	} {
+		req.GoalType = GoalTypeRoutine
+		req.RepeatPattern = &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-03-16")}
+		if _, err := pm.Establish(req); err != nil {
+			t.Fatalf("Establish %s: %v", req.Description, err)
+		}
+	}
+
+	occurrences, err := pm.GetRoutinesForDate("2026-03-16")
+	if err != nil {
+		t.Fatalf("GetRoutinesForDate: %v", err)
+	}
+	var got []string
+	for _, o := range occurrences {
+		got = append(got, o.Description+"/"+o.ThemeID)
+	}
+	want := []string{"Run/T", "Stretch/T", "Network/" + career, "Read/"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("occurrences = %v, want %v", got, want)
+	}
+}
+
+func TestUnit_RoutineLinks_RecordCompletionsThemesTaskAndFeedsKeyResult(t *testing.T) {
+	pm, ta, ka, ca, _, _ := newRoutineLinkTestManager(t)
+	res, err := pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Train", ParentID: "T-KR2", FeedKeyResult: true, RepeatPattern: dailyPattern()})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+	// Done on 13 of the other 27 days of the 4-week window.
+	for d := 0; d < 13; d++ {
+		date := time.Date(2026, 2, 17+d*2, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+		ca.days[date] = access.DayFocus{Date: utilities.MustParseCalendarDate(date), RoutineChecks: []string{id}}
+	}
+
+	day := DayFocus{Date: utilities.MustParseCalendarDate("2026-03-16"), RoutineChecks: []string{id}}
+	if err := pm.RecordRoutineCompletions(day, nil); err != nil {
+		t.Fatalf("RecordRoutineCompletions: %v", err)
+	}
+
+	todo := ka.tasks["todo"]
+	if len(todo) != 1 || todo[0].ThemeID != "T" {
+		t.Fatalf("todo = %+v, want one task in theme T", todo)
+	}
+
+	_, kr := findKeyResultTheme(ta.themes, "T-KR2")
+	if kr == nil || len(kr.CheckIns) != 1 {
+		t.Fatalf("key result = %+v, want one check-in", kr)
+	}
+	checkIn := kr.CheckIns[0]
+	if checkIn.Value != 50 || kr.CurrentValue != 50 {
+		t.Errorf("check-in value = %g, current = %g, want 50", checkIn.Value, kr.CurrentValue)
+	}
+	if want := "Routine " + id + ": 14 of 28 in the last 4 weeks"; checkIn.Note != want {
+		t.Errorf("note = %q, want %q", checkIn.Note, want)
+	}
+}
+
+func TestUnit_RoutineLinks_FollowThemeIDChange(t *testing.T) {
+	pm, _, _, _, ra, _ := newRoutineLinkTestManager(t)
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Run", ParentID: "T-KR1"}); err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+
+	if _, err := pm.ChangeThemeID("T", "FIT"); err != nil {
+		t.Fatalf("ChangeThemeID: %v", err)
+	}
+
+	routines, _ := ra.GetRoutines()
+	if len(routines) != 1 || routines[0].ThemeID != "FIT" || routines[0].KeyResultID != "FIT-KR1" {
+		t.Errorf("routines = %+v, want links FIT/FIT-KR1", routines)
+	}
+}
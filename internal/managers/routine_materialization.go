@@ -0,0 +1,160 @@
+package managers
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/engines/schedule_engine"
+)
+
+// maxTitleTemplateLength bounds a routine's task title template.
+const maxTitleTemplateLength = 200
+
+// normalizeMaterialization validates a routine's materialisation settings
+// and trims their text. The mode must be known and the column must exist on
+// the board. Settings equal to the defaults normalise to nil.
+func (m *PlanningManager) normalizeMaterialization(mat *RoutineMaterialization) (*RoutineMaterialization, error) {
+	if mat == nil {
+		return nil, nil
+	}
+	out := RoutineMaterialization{
+		Mode:          strings.TrimSpace(mat.Mode),
+		Column:        strings.TrimSpace(mat.Column),
+		TitleTemplate: strings.TrimSpace(mat.TitleTemplate),
+	}
+	switch out.Mode {
+	case "", schedule_engine.MaterializeOnCheck:
+		out.Mode = ""
+	case schedule_engine.MaterializeNever, schedule_engine.MaterializeAhead:
+	default:
+		return nil, fmt.Errorf("unknown materialisation mode %q", out.Mode)
+	}
+	if out.Column != "" {
+		config, err := m.getAccessBoardConfig()
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		if !slices.ContainsFunc(config.ColumnDefinitions, func(c access.ColumnDefinition) bool { return c.Name == out.Column }) {
+			return nil, fmt.Errorf("column %q not found on the board", out.Column)
+		}
+	}
+	for _, tag := range mat.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(out.Tags, tag) {
+			out.Tags = append(out.Tags, tag)
+		}
+	}
+	if err := validateTagNames(out.Tags); err != nil {
+		return nil, err
+	}
+	if len(out.TitleTemplate) > maxTitleTemplateLength {
+		return nil, fmt.Errorf("title template cannot exceed %d characters", maxTitleTemplateLength)
+	}
+	if out.Mode == "" && out.Column == "" && len(out.Tags) == 0 && out.TitleTemplate == "" {
+		return nil, nil
+	}
+	return &out, nil
+}
+
+// materializedAhead returns the IDs among ids of routines materialised
+// ahead of time.
+func materializedAhead(routines []access.Routine, ids []string) []string {
+	var out []string
+	for _, r := range routines {
+		if r.Materialization != nil && r.Materialization.Mode == schedule_engine.MaterializeAhead && slices.Contains(ids, r.ID) {
+			out = append(out, r.ID)
+		}
+	}
+	return out
+}
+
+// routineTaskStatuses returns every status a routine task can be in: the
+// board's columns and archived.
+func (m *PlanningManager) routineTaskStatuses() ([]string, error) {
+	config, err := m.getAccessBoardConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load board config: %w", err)
+	}
+	statuses := make([]string, 0, len(config.ColumnDefinitions)+1)
+	for _, col := range config.ColumnDefinitions {
+		statuses = append(statuses, col.Name)
+	}
+	return append(statuses, string(access.TaskStatusArchived)), nil
+}
+
+// MaterializeDueRoutines creates, in one commit, the tasks of routines
+// materialised ahead of time whose occurrence falls due today, and returns
+// them. Occurrences already checked or holding a task are left alone, so
+// the frontend may call it on every start and day change.
+func (m *PlanningManager) MaterializeDueRoutines() ([]Task, error) {
+	routines, err := m.routineAccess.GetRoutines()
+	if err != nil {
+		return nil, fmt.Errorf("MaterializeDueRoutines: failed to load routines: %w", err)
+	}
+	var ahead []access.Routine
+	for _, r := range routines {
+		if r.Materialization != nil && r.Materialization.Mode == schedule_engine.MaterializeAhead {
+			ahead = append(ahead, r)
+		}
+	}
+	if len(ahead) == 0 {
+		return nil, nil
+	}
+	if hasRoutineLinks(ahead) {
+		themes, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return nil, fmt.Errorf("MaterializeDueRoutines: failed to load themes: %w", err)
+		}
+		ahead = dropDanglingRoutineLinks(ahead, themes)
+	}
+
+	statuses, err := m.routineTaskStatuses()
+	if err != nil {
+		return nil, fmt.Errorf("MaterializeDueRoutines: %w", err)
+	}
+	var existing []schedule_engine.ExistingTaskRef
+	for _, status := range statuses {
+		tasks, err := m.taskAccess.Find(access.TaskFilter{Status: &status})
+		if err != nil {
+			return nil, fmt.Errorf("MaterializeDueRoutines: %w", err)
+		}
+		for _, t := range tasks {
+			if t.RoutineRef != nil {
+				existing = append(existing, schedule_engine.ExistingTaskRef{
+					TaskID:    t.ID,
+					RoutineID: t.RoutineRef.RoutineID,
+					Date:      t.RoutineRef.Date,
+					Status:    status,
+				})
+			}
+		}
+	}
+
+	inputs, err := m.toEngineRoutineInputs(ahead)
+	if err != nil {
+		return nil, fmt.Errorf("MaterializeDueRoutines: %w", err)
+	}
+	plan := m.scheduleEngine.PlanDue(inputs, existing, m.clock.Today())
+	creates, err := m.buildRoutineTaskCreates(plan.Creates)
+	if err != nil {
+		return nil, fmt.Errorf("MaterializeDueRoutines: %w", err)
+	}
+	if len(creates) == 0 {
+		return nil, nil
+	}
+
+	outcome, err := m.taskAccess.Commit(access.BatchRequest{Creates: creates})
+	if err != nil {
+		return nil, fmt.Errorf("MaterializeDueRoutines: %w", err)
+	}
+	created := make([]Task, len(outcome.CreatedIDs))
+	for i, id := range outcome.CreatedIDs {
+		created[i] = toManagerTask(creates[i].Task)
+		created[i].ID = id
+	}
+	slog.Info("MaterializeDueRoutines: applied", "created", len(created))
+	return created, nil
+}
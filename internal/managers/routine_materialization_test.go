@@ -0,0 +1,148 @@
+package managers
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_RoutineMaterialization_EstablishAndRevise(t *testing.T) {
+	pm, _, _ := newRoutineCheckTestManager(t)
+
+	res, err := pm.Establish(EstablishRequest{
+		GoalType:        GoalTypeRoutine,
+		Description:     "Run",
+		Materialization: &RoutineMaterialization{Mode: "ahead", Column: " doing ", Tags: []string{"Health", " Health "}},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	got := res.Routine.Materialization
+	if got == nil || got.Mode != "ahead" || got.Column != "doing" || !slices.Equal(got.Tags, []string{"Health"}) {
+		t.Errorf("materialization = %+v, want ahead/doing/[Health]", got)
+	}
+
+	// The defaults normalise away.
+	if err := pm.Revise(ReviseRequest{GoalID: res.Routine.ID, Materialization: &RoutineMaterialization{Mode: "on-check"}}); err != nil {
+		t.Fatalf("Revise: %v", err)
+	}
+	routines, err := pm.GetRoutines()
+	if err != nil {
+		t.Fatalf("GetRoutines: %v", err)
+	}
+	if routines[0].Materialization != nil {
+		t.Errorf("materialization = %+v, want nil", routines[0].Materialization)
+	}
+
+	tests := []struct {
+		name    string
+		mat     RoutineMaterialization
+		wantErr string
+	}{
+		{"unknown mode", RoutineMaterialization{Mode: "later"}, "unknown materialisation mode"},
+		{"unknown column", RoutineMaterialization{Column: "review"}, "not found on the board"},
+		{"long template", RoutineMaterialization{TitleTemplate: strings.Repeat("x", maxTitleTemplateLength+1)}, "cannot exceed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := pm.Revise(ReviseRequest{GoalID: res.Routine.ID, Materialization: &tt.mat}); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Revise = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnit_RoutineMaterialization_RecordCompletions(t *testing.T) {
+	pm, ka, _ := newRoutineCheckTestManager(t)
+	never, err := pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Meditate", Materialization: &RoutineMaterialization{Mode: "never"}})
+	if err != nil {
+		t.Fatalf("Establish never: %v", err)
+	}
+	custom, err := pm.Establish(EstablishRequest{
+		GoalType:        GoalTypeRoutine,
+		Description:     "Run",
+		Materialization: &RoutineMaterialization{Column: "doing", Tags: []string{"Health"}, TitleTemplate: "{description} on {date}"},
+	})
+	if err != nil {
+		t.Fatalf("Establish custom: %v", err)
+	}
+
+	day := DayFocus{Date: utilities.MustParseCalendarDate("2026-03-19"), RoutineChecks: []string{never.Routine.ID, custom.Routine.ID}}
+	if err := pm.RecordRoutineCompletions(day, nil); err != nil {
+		t.Fatalf("RecordRoutineCompletions: %v", err)
+	}
+	if got := len(ka.tasks["todo"]); got != 0 {
+		t.Errorf("todo tasks = %d, want 0", got)
+	}
+	doing := ka.tasks["doing"]
+	if len(doing) != 1 {
+		t.Fatalf("doing = %+v, want one task", doing)
+	}
+	if task := doing[0]; task.Title != "Run on 2026-03-19" || !slices.Equal(task.Tags, []string{"Health"}) || task.RoutineRef.RoutineID != custom.Routine.ID {
+		t.Errorf("task = %+v, want custom title and tags", task)
+	}
+
+	// Unchecking deletes the doing task as before.
+	day.RoutineChecks = nil
+	if err := pm.RecordRoutineCompletions(day, []string{never.Routine.ID, custom.Routine.ID}); err != nil {
+		t.Fatalf("RecordRoutineCompletions unchecked: %v", err)
+	}
+	if got := len(ka.tasks["doing"]); got != 0 {
+		t.Errorf("doing tasks = %d, want 0", got)
+	}
+}
+
+func TestUnit_RoutineMaterialization_Ahead(t *testing.T) {
+	pm, ka, _ := newRoutineCheckTestManager(t)
+	res, err := pm.Establish(EstablishRequest{
+		GoalType:        GoalTypeRoutine,
+		Description:     "Run",
+		RepeatPattern:   &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-03-19")},
+		Materialization: &RoutineMaterialization{Mode: "ahead"},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+
+	created, err := pm.MaterializeDueRoutines()
+	if err != nil {
+		t.Fatalf("MaterializeDueRoutines: %v", err)
+	}
+	if len(created) != 1 || created[0].ID == "" || created[0].Title != "Run" {
+		t.Fatalf("created = %+v, want one Run task", created)
+	}
+
+	// Running again, and then checking the routine, adds no second task.
+	if created, err := pm.MaterializeDueRoutines(); err != nil || len(created) != 0 {
+		t.Errorf("second MaterializeDueRoutines = %+v, %v, want nothing", created, err)
+	}
+	day := DayFocus{Date: utilities.MustParseCalendarDate("2026-03-19"), RoutineChecks: []string{id}}
+	if err := pm.RecordRoutineCompletions(day, nil); err != nil {
+		t.Fatalf("RecordRoutineCompletions: %v", err)
+	}
+	if got := ka.tasks["todo"]; len(got) != 1 || got[0].RoutineRef == nil || got[0].RoutineRef.RoutineID != id {
+		t.Errorf("todo = %+v, want the one ahead task", got)
+	}
+
+	// Unchecking deletes the todo task.
+	day.RoutineChecks = nil
+	if err := pm.RecordRoutineCompletions(day, []string{id}); err != nil {
+		t.Fatalf("RecordRoutineCompletions unchecked: %v", err)
+	}
+	if got := len(ka.tasks["todo"]); got != 0 {
+		t.Errorf("todo tasks = %d, want 0", got)
+	}
+
+	// A routine without ahead materialisation is left alone.
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Walk", RepeatPattern: dailyPattern()}); err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	ka.tasks["done"] = append(ka.tasks["done"], access.Task{ID: "T-T9", RoutineRef: &access.RoutineRef{RoutineID: id, Date: utilities.MustParseCalendarDate("2026-03-19")}})
+	if created, err := pm.MaterializeDueRoutines(); err != nil || len(created) != 0 {
+		t.Errorf("MaterializeDueRoutines = %+v, %v, want nothing", created, err)
+	}
+}
@@ -0,0 +1,91 @@
+package managers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rkn/bearing/internal/engines/schedule_engine"
+)
+
+// ExportRoutineRRule returns a routine's schedule as iCalendar DTSTART and
+// RRULE lines for calendar apps. Routines with a structured repeat pattern
+// are converted to an RRULE with the same occurrences.
+func (m *PlanningManager) ExportRoutineRRule(routineId string) (string, error) {
+	if routineId == "" {
+		return "", fmt.Errorf("routineId cannot be empty")
+	}
+	routines, err := m.routineAccess.GetRoutines()
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+	for _, r := range routines {
+		if r.ID != routineId {
+			continue
+		}
+		if r.RepeatPattern == nil {
+			return "", fmt.Errorf("routine %s is sporadic and has no schedule", routineId)
+		}
+		rule, err := schedule_engine.PatternToRRule(*toEngineRepeatPattern(r.RepeatPattern))
+		if err != nil {
+			return "", fmt.Errorf("routine %s: %w", routineId, err)
+		}
+		return schedule_engine.FormatRecurrence(rule, r.RepeatPattern.StartDate), nil
+	}
+	return "", fmt.Errorf("routine with ID %s not found", routineId)
+}
+
+// normalizeRepeatPattern validates a pattern given as an RRULE and returns
+// it with the rule in canonical form. A DTSTART line in the rule supplies
+// the start date when the pattern has none. Frequency and Interval are
+// copied from the rule for views that label routines by them; the other
+// structured fields are dropped since the rule replaces them, and an end
+// date or count must be given in the rule itself. An after-completion
+// pattern must name a plain frequency unit and cannot carry a rule. Other
+// patterns without an RRULE are returned unchanged once their end and pauses
+// are valid.
+func normalizeRepeatPattern(p *RepeatPattern) (*RepeatPattern, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if err := validateScheduleBounds(p); err != nil {
+		return nil, err
+	}
+	if p.AfterCompletion {
+		if strings.TrimSpace(p.RRule) != "" {
+			return nil, fmt.Errorf("an after-completion schedule cannot also have a recurrence rule")
+		}
+		switch p.Frequency {
+		case "daily", "weekly", "monthly", "yearly":
+		default:
+			return nil, fmt.Errorf("invalid frequency %q for an after-completion schedule", p.Frequency)
+		}
+		if p.StartDate.IsZero() {
+			return nil, fmt.Errorf("after-completion schedule needs a start date")
+		}
+		return p, nil
+	}
+	if strings.TrimSpace(p.RRule) == "" {
+		return p, nil
+	}
+	if !p.EndDate.IsZero() || p.Count != 0 {
+		return nil, fmt.Errorf("a recurrence rule sets its end with UNTIL or COUNT")
+	}
+	rule, dtstart, err := schedule_engine.ParseRecurrence(p.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	start := p.StartDate
+	if start.IsZero() {
+		start = dtstart
+	}
+	if start.IsZero() {
+		return nil, fmt.Errorf("recurrence rule needs a start date")
+	}
+	return &RepeatPattern{
+		Frequency: strings.ToLower(rule.Freq),
+		Interval:  rule.Interval,
+		RRule:     rule.String(),
+		StartDate: start,
+		Pauses:    p.Pauses,
+	}, nil
+}
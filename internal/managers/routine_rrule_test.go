@@ -0,0 +1,117 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_Establish_RoutineWithRRule(t *testing.T) {
+	manager, _, _ := newMockManager()
+
+	res, err := manager.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Pay rent",
+		RepeatPattern: &RepeatPattern{RRule: "DTSTART;VALUE=DATE:20260101\nRRULE:freq=monthly;byday=mo,tu,we,th,fr;bysetpos=-1"},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	want := RepeatPattern{
+		Frequency: "monthly",
+		Interval:  1,
+		RRule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		StartDate: utilities.MustParseCalendarDate("2026-01-01"),
+	}
+	if p := res.Routine.RepeatPattern; p == nil || p.Frequency != want.Frequency || p.Interval != want.Interval || p.RRule != want.RRule || p.StartDate != want.StartDate {
+		t.Fatalf("pattern = %+v, want %+v", p, want)
+	}
+
+	// January 31, 2026 is a Saturday, so the last weekday is the 30th.
+	for date, scheduled := range map[string]bool{"2026-01-30": true, "2026-01-31": false} {
+		occurrences, err := manager.GetRoutinesForDate(date)
+		if err != nil {
+			t.Fatalf("GetRoutinesForDate(%s): %v", date, err)
+		}
+		found := false
+		for _, o := range occurrences {
+			found = found || (o.RoutineID == res.Routine.ID && o.Status == "scheduled")
+		}
+		if found != scheduled {
+			t.Errorf("scheduled on %s = %v, want %v", date, found, scheduled)
+		}
+	}
+
+	exported, err := manager.ExportRoutineRRule(res.Routine.ID)
+	if err != nil || exported != "DTSTART;VALUE=DATE:20260101\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1" {
+		t.Errorf("ExportRoutineRRule = %q, %v", exported, err)
+	}
+}
+
+func TestUnit_RoutineRRule_Validation(t *testing.T) {
+	manager, _, _ := newMockManager()
+
+	for _, tt := range []struct {
+		name, rule, wantErr string
+	}{
+		{"unsupported part", "FREQ=DAILY;BYHOUR=8", "invalid recurrence rule"},
+		{"no start date", "FREQ=DAILY", "needs a start date"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manager.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Walk", RepeatPattern: &RepeatPattern{RRule: tt.rule}})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	res, err := manager.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Walk",
+		RepeatPattern: &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-01-01")},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	if err := manager.Revise(ReviseRequest{GoalID: res.Routine.ID, RepeatPattern: &RepeatPattern{RRule: "FREQ=MONTHLY;BYDAY=9MO", StartDate: "2026-01-01"}}); err == nil {
+		t.Error("expected Revise to reject an invalid rule")
+	}
+	routines, _ := manager.GetRoutines()
+	if p := routines[0].RepeatPattern; p == nil || p.Frequency != "daily" || p.RRule != "" {
+		t.Errorf("pattern after rejected revise = %+v, want it unchanged", p)
+	}
+}
+
+func TestUnit_ExportRoutineRRule(t *testing.T) {
+	manager, _, _ := newMockManager()
+
+	structured, err := manager.Establish(EstablishRequest{
+		GoalType:    GoalTypeRoutine,
+		Description: "Review finances",
+		RepeatPattern: &RepeatPattern{
+			Frequency:   "monthly",
+			Interval:    1,
+			WeekOfMonth: 2,
+			Weekdays:    []int{2},
+			StartDate:   utilities.MustParseCalendarDate("2026-04-01"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	sporadic, err := testAddRoutine(manager, "Call grandma")
+	if err != nil {
+		t.Fatalf("testAddRoutine: %v", err)
+	}
+
+	if got, err := manager.ExportRoutineRRule(structured.Routine.ID); err != nil || got != "DTSTART;VALUE=DATE:20260401\nRRULE:FREQ=MONTHLY;BYDAY=2TU" {
+		t.Errorf("ExportRoutineRRule = %q, %v", got, err)
+	}
+	if _, err := manager.ExportRoutineRRule(sporadic.ID); err == nil || !strings.Contains(err.Error(), "sporadic") {
+		t.Errorf("sporadic routine = %v, want error", err)
+	}
+	if _, err := manager.ExportRoutineRRule("R99"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("unknown routine = %v, want not found", err)
+	}
+}
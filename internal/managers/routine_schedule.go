@@ -0,0 +1,153 @@
+package managers
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// SkipRoutineOccurrence adds a schedule exception that drops a routine
+// occurrence without moving it, so it is neither scheduled nor overdue. The
+// date must be one the schedule produces, for an after-completion routine
+// its current due date; skipping a date that already has an exception
+// changes nothing.
+func (m *PlanningManager) SkipRoutineOccurrence(routineID, date string) error {
+	if routineID == "" || date == "" {
+		return fmt.Errorf("routineID and date cannot be empty")
+	}
+	skipped, err := utilities.ParseCalendarDate(date)
+	if err != nil {
+		return fmt.Errorf("SkipRoutineOccurrence: invalid date: %w", err)
+	}
+	return m.updateRoutineSchedule("SkipRoutineOccurrence", routineID, func(routine *access.Routine) (bool, error) {
+		if routine.RepeatPattern == nil {
+			return false, fmt.Errorf("routine %s is sporadic and has no schedule", routineID)
+		}
+		for _, e := range routine.Exceptions {
+			if e.OriginalDate == skipped {
+				return false, nil
+			}
+		}
+		scheduled, err := m.isScheduledOccurrence(*routine, skipped.String())
+		if err != nil {
+			return false, err
+		}
+		if !scheduled {
+			return false, fmt.Errorf("%s is not a scheduled occurrence of routine %s", skipped, routineID)
+		}
+		routine.Exceptions = append(routine.Exceptions, access.ScheduleException{OriginalDate: skipped})
+		return true, nil
+	})
+}
+
+// isScheduledOccurrence reports whether the periodic routine falls due on
+// date: an occurrence of a fixed schedule, or the due date of an
+// after-completion one given its completions before date.
+func (m *PlanningManager) isScheduledOccurrence(routine access.Routine, date string) (bool, error) {
+	pattern := *toEngineRepeatPattern(routine.RepeatPattern)
+	exceptions := toEngineExceptions(routine.Exceptions)
+	if !pattern.AfterCompletion {
+		return slices.Contains(m.scheduleEngine.ComputeOccurrences(pattern, exceptions, date, date), date), nil
+	}
+	completedDates, err := m.calendarAccess.GetRoutineCompletions(routine.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get completions for routine %s: %w", routine.ID, err)
+	}
+	return m.scheduleEngine.NextDue(pattern, exceptions, completedDates, date) == date, nil
+}
+
+// PauseRoutine adds a pause from `from` to `to` (inclusive) to a periodic
+// routine. No occurrences fall within the pause, so its dates are neither
+// scheduled nor overdue and do not count towards period completion.
+func (m *PlanningManager) PauseRoutine(routineID, from, to string) error {
+	if routineID == "" || from == "" || to == "" {
+		return fmt.Errorf("routineID, from, and to cannot be empty")
+	}
+	pause, err := parseSchedulePause(from, to)
+	if err != nil {
+		return fmt.Errorf("PauseRoutine: %w", err)
+	}
+	return m.updateRoutineSchedule("PauseRoutine", routineID, func(routine *access.Routine) (bool, error) {
+		if routine.RepeatPattern == nil {
+			return false, fmt.Errorf("routine %s is sporadic and has no schedule", routineID)
+		}
+		routine.RepeatPattern.Pauses = append(routine.RepeatPattern.Pauses, access.SchedulePause{From: pause.From, To: pause.To})
+		return true, nil
+	})
+}
+
+// updateRoutineSchedule applies change to the routine with routineID and
+// saves it unless change reports that nothing changed. op prefixes the
+// returned errors.
+func (m *PlanningManager) updateRoutineSchedule(op, routineID string, change func(routine *access.Routine) (bool, error)) error {
+	routines, err := m.routineAccess.GetRoutines()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get routines: %w", op, err)
+	}
+	for _, routine := range routines {
+		if routine.ID != routineID {
+			continue
+		}
+		changed, err := change(&routine)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if !changed {
+			return nil
+		}
+		if err := m.routineAccess.SaveRoutine(routine); err != nil {
+			return fmt.Errorf("%s: failed to save routine: %w", op, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: routine %s not found", op, routineID)
+}
+
+// parseSchedulePause parses and validates a pause's bounds.
+func parseSchedulePause(from, to string) (SchedulePause, error) {
+	fromDate, err := utilities.ParseCalendarDate(from)
+	if err != nil {
+		return SchedulePause{}, fmt.Errorf("invalid pause start: %w", err)
+	}
+	toDate, err := utilities.ParseCalendarDate(to)
+	if err != nil {
+		return SchedulePause{}, fmt.Errorf("invalid pause end: %w", err)
+	}
+	pause := SchedulePause{From: fromDate, To: toDate}
+	return pause, validateSchedulePause(pause)
+}
+
+// validateSchedulePause checks that a pause has both bounds in order.
+func validateSchedulePause(p SchedulePause) error {
+	if p.From.IsZero() || p.To.IsZero() {
+		return fmt.Errorf("a pause needs a start and an end date")
+	}
+	// Lexicographic compare on YYYY-MM-DD is equivalent to chronological order.
+	if p.To < p.From {
+		return fmt.Errorf("pause ends (%s) before it starts (%s)", p.To, p.From)
+	}
+	return nil
+}
+
+// validateScheduleBounds checks a pattern's end date, count and pauses.
+// A pattern ends either on a date or after a number of occurrences, not
+// both.
+func validateScheduleBounds(p *RepeatPattern) error {
+	if p.Count < 0 {
+		return fmt.Errorf("occurrence count cannot be negative")
+	}
+	if p.Count > 0 && !p.EndDate.IsZero() {
+		return fmt.Errorf("a schedule ends either on a date or after a count, not both")
+	}
+	if !p.EndDate.IsZero() && !p.StartDate.IsZero() && p.EndDate < p.StartDate {
+		return fmt.Errorf("end date %s is before start date %s", p.EndDate, p.StartDate)
+	}
+	for _, pause := range p.Pauses {
+		if err := validateSchedulePause(pause); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_SkipAndPauseRoutine(t *testing.T) {
+	ca := newMockCalendarAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 8, 17, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	manager := newTestManager(t, testManagerDeps{calendar: ca, clock: clock})
+	res, err := manager.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Stretch",
+		RepeatPattern: &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2026-07-27")},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+	ca.days["2026-07-29"] = access.DayFocus{Date: utilities.MustParseCalendarDate("2026-07-29"), RoutineChecks: []string{id}}
+
+	if err := manager.SkipRoutineOccurrence(id, "2026-07-30"); err != nil {
+		t.Fatalf("SkipRoutineOccurrence: %v", err)
+	}
+	// Skipping the same date again changes nothing.
+	if err := manager.SkipRoutineOccurrence(id, "2026-07-30"); err != nil {
+		t.Fatalf("SkipRoutineOccurrence again: %v", err)
+	}
+	if err := manager.PauseRoutine(id, "2026-07-31", "2026-08-16"); err != nil {
+		t.Fatalf("PauseRoutine: %v", err)
+	}
+
+	occurrences, err := manager.GetRoutinesForDate("2026-08-17")
+	if err != nil {
+		t.Fatalf("GetRoutinesForDate: %v", err)
+	}
+	for _, o := range occurrences {
+		if o.Status == "overdue" {
+			t.Errorf("skipped and paused dates reported overdue: %+v", o)
+		}
+	}
+	if occurrences, _ := manager.GetRoutinesForDate("2026-08-05"); len(occurrences) != 0 {
+		t.Errorf("paused day = %+v, want nothing scheduled", occurrences)
+	}
+
+	routines, _ := manager.GetRoutines()
+	got := routines[0]
+	if len(got.Exceptions) != 1 || got.Exceptions[0].OriginalDate != "2026-07-30" || !got.Exceptions[0].NewDate.IsZero() {
+		t.Errorf("exceptions = %+v, want one skip", got.Exceptions)
+	}
+	if p := got.RepeatPattern.Pauses; len(p) != 1 || p[0].From != "2026-07-31" || p[0].To != "2026-08-16" {
+		t.Errorf("pauses = %+v", p)
+	}
+
+	sporadic, err := testAddRoutine(manager, "Call grandma")
+	if err != nil {
+		t.Fatalf("testAddRoutine: %v", err)
+	}
+	for _, tt := range []struct {
+		name    string
+		err     error
+		wantErr string
+	}{
+		{"pause backwards", manager.PauseRoutine(id, "2026-09-10", "2026-09-01"), "before it starts"},
+		{"pause sporadic", manager.PauseRoutine(sporadic.ID, "2026-09-01", "2026-09-10"), "sporadic"},
+		{"skip unknown routine", manager.SkipRoutineOccurrence("R99", "2026-09-01"), "not found"},
+		{"skip invalid date", manager.SkipRoutineOccurrence(id, "2026-13-01"), "invalid date"},
+		{"skip sporadic", manager.SkipRoutineOccurrence(sporadic.ID, "2026-09-01"), "sporadic"},
+		{"skip before start", manager.SkipRoutineOccurrence(id, "2026-07-20"), "not a scheduled occurrence"},
+		{"skip paused date", manager.SkipRoutineOccurrence(id, "2026-08-05"), "not a scheduled occurrence"},
+	} {
+		if tt.err == nil || !strings.Contains(tt.err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, tt.err)
+		}
+	}
+}
+
+func TestUnit_SkipFloatingRoutine(t *testing.T) {
+	ca := newMockCalendarAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 3, 19, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	manager := newTestManager(t, testManagerDeps{calendar: ca, clock: clock})
+	res, err := manager.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Water the plants",
+		RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, AfterCompletion: true, StartDate: utilities.MustParseCalendarDate("2026-03-01")},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+	ca.days["2026-03-10"] = access.DayFocus{Date: utilities.MustParseCalendarDate("2026-03-10"), RoutineChecks: []string{id}}
+
+	// Due a week after the last completion, not on the old weekly grid.
+	if err := manager.SkipRoutineOccurrence(id, "2026-03-15"); err == nil || !strings.Contains(err.Error(), "not a scheduled occurrence") {
+		t.Errorf("SkipRoutineOccurrence off the due date = %v, want rejection", err)
+	}
+	if err := manager.SkipRoutineOccurrence(id, "2026-03-17"); err != nil {
+		t.Fatalf("SkipRoutineOccurrence: %v", err)
+	}
+	routines, _ := manager.GetRoutines()
+	if got := routines[0].Exceptions; len(got) != 1 || got[0].OriginalDate != "2026-03-17" {
+		t.Errorf("exceptions = %+v, want one skip on 17 March", got)
+	}
+}
+
+func TestUnit_RoutineScheduleBounds_Validation(t *testing.T) {
+	manager, _, _ := newMockManager()
+	start := utilities.MustParseCalendarDate("2026-01-01")
+
+	for _, tt := range []struct {
+		name    string
+		pattern RepeatPattern
+		wantErr string
+	}{
+		{"end and count", RepeatPattern{Frequency: "daily", StartDate: start, EndDate: "2026-02-01", Count: 3}, "not both"},
+		{"end before start", RepeatPattern{Frequency: "daily", StartDate: start, EndDate: "2025-12-31"}, "before start date"},
+		{"negative count", RepeatPattern{Frequency: "daily", StartDate: start, Count: -1}, "cannot be negative"},
+		{"open pause", RepeatPattern{Frequency: "daily", StartDate: start, Pauses: []SchedulePause{{From: "2026-03-01"}}}, "start and an end"},
+		{"rule with end date", RepeatPattern{RRule: "FREQ=DAILY", StartDate: start, EndDate: "2026-02-01"}, "UNTIL or COUNT"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := tt.pattern
+			_, err := manager.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Walk", RepeatPattern: &pattern})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	res, err := manager.Establish(EstablishRequest{
+		GoalType:    GoalTypeRoutine,
+		Description: "Physio exercises",
+		RepeatPattern: &RepeatPattern{
+			RRule:     "FREQ=DAILY;COUNT=10",
+			StartDate: start,
+			Pauses:    []SchedulePause{{From: "2026-01-03", To: "2026-01-04"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	if p := res.Routine.RepeatPattern; p == nil || len(p.Pauses) != 1 {
+		t.Errorf("pattern = %+v, want the pause kept", p)
+	}
+}
@@ -0,0 +1,92 @@
+package managers
+
+import (
+	"fmt"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/engines/schedule_engine"
+)
+
+// RoutineStats holds streak and adherence statistics for a routine.
+type RoutineStats struct {
+	RoutineID     string              `json:"routineId"`
+	CurrentStreak int                 `json:"currentStreak"`
+	LongestStreak int                 `json:"longestStreak"`
+	Adherence     []RoutineAdherence  `json:"adherence"` // last 4, 12 and 52 weeks
+	ByWeekday     []int               `json:"byWeekday"` // completions per weekday, 0=Sun..6=Sat
+	Year          int                 `json:"year"`
+	Heatmap       []RoutineHeatmapDay `json:"heatmap"` // every day of Year
+}
+
+// RoutineAdherence is the share of a routine's occurrences met over a trailing window.
+type RoutineAdherence struct {
+	Weeks     int     `json:"weeks"`
+	Completed int     `json:"completed"`
+	Expected  int     `json:"expected"`
+	Rate      float64 `json:"rate"` // 0 when nothing was expected
+}
+
+// RoutineHeatmapDay is one day of a routine's year heatmap.
+type RoutineHeatmapDay struct {
+	Date      string `json:"date"`
+	Scheduled bool   `json:"scheduled"`
+	Completed bool   `json:"completed"`
+}
+
+// GetRoutineStats returns a routine's current and longest streak, its
+// adherence over the last 4, 12 and 52 weeks, its completions by weekday
+// and a heatmap of year (the current year when 0). Completions are read
+// once across all calendar years. Sporadic routines have no occurrences, so
+// their streaks and adherence are zero.
+func (m *PlanningManager) GetRoutineStats(routineID string, year int) (*RoutineStats, error) {
+	if routineID == "" {
+		return nil, fmt.Errorf("routineID cannot be empty")
+	}
+
+	routines, err := m.routineAccess.GetRoutines()
+	if err != nil {
+		return nil, fmt.Errorf("GetRoutineStats: failed to get routines: %w", err)
+	}
+	var routine *access.Routine
+	for i := range routines {
+		if routines[i].ID == routineID {
+			routine = &routines[i]
+			break
+		}
+	}
+	if routine == nil {
+		return nil, fmt.Errorf("GetRoutineStats: routine %s not found", routineID)
+	}
+
+	completedDates, err := m.calendarAccess.GetRoutineCompletions(routineID)
+	if err != nil {
+		return nil, fmt.Errorf("GetRoutineStats: failed to get completions: %w", err)
+	}
+
+	today := m.clock.Today()
+	if year == 0 {
+		year = today.Time().Year()
+	}
+	var pattern schedule_engine.RepeatPattern
+	if p := toEngineRepeatPattern(routine.RepeatPattern); p != nil {
+		pattern = *p
+	}
+	stats := m.scheduleEngine.ComputeStats(pattern, toEngineExceptions(routine.Exceptions), completedDates, today.String(), year)
+
+	result := &RoutineStats{
+		RoutineID:     routineID,
+		CurrentStreak: stats.CurrentStreak,
+		LongestStreak: stats.LongestStreak,
+		Adherence:     make([]RoutineAdherence, len(stats.Adherence)),
+		ByWeekday:     stats.ByWeekday[:],
+		Year:          year,
+		Heatmap:       make([]RoutineHeatmapDay, len(stats.Heatmap)),
+	}
+	for i, a := range stats.Adherence {
+		result.Adherence[i] = RoutineAdherence{Weeks: a.Weeks, Completed: a.Completed, Expected: a.Expected, Rate: a.Rate}
+	}
+	for i, d := range stats.Heatmap {
+		result.Heatmap[i] = RoutineHeatmapDay{Date: d.Date, Scheduled: d.Scheduled, Completed: d.Completed}
+	}
+	return result, nil
+}
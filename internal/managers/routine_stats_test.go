@@ -0,0 +1,55 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+func TestUnit_GetRoutineStats(t *testing.T) {
+	ca := newMockCalendarAccess()
+	clock := utilities.NewFrozenClock(time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC), time.UTC, 0)
+	manager := newTestManager(t, testManagerDeps{calendar: ca, clock: clock})
+	res, err := manager.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Read",
+		RepeatPattern: &RepeatPattern{Frequency: "daily", Interval: 1, StartDate: utilities.MustParseCalendarDate("2025-12-28")},
+	})
+	if err != nil {
+		t.Fatalf("Establish: %v", err)
+	}
+	id := res.Routine.ID
+	// A streak across the year boundary, read from both year files.
+	for _, d := range []string{"2025-12-28", "2025-12-30", "2025-12-31", "2026-01-01", "2026-01-02"} {
+		ca.days[d] = access.DayFocus{Date: utilities.MustParseCalendarDate(d), RoutineChecks: []string{id}}
+	}
+
+	stats, err := manager.GetRoutineStats(id, 0)
+	if err != nil {
+		t.Fatalf("GetRoutineStats: %v", err)
+	}
+	if stats.CurrentStreak != 4 || stats.LongestStreak != 4 {
+		t.Errorf("streaks = %d, %d; want 4, 4", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if a := stats.Adherence[0]; a.Weeks != 4 || a.Completed != 5 || a.Expected != 6 {
+		t.Errorf("4-week adherence = %+v, want 5 of 6", a)
+	}
+	if stats.Year != 2026 || len(stats.Heatmap) != 365 || len(stats.ByWeekday) != 7 {
+		t.Errorf("year %d with %d heatmap days and %d weekdays", stats.Year, len(stats.Heatmap), len(stats.ByWeekday))
+	}
+
+	previous, err := manager.GetRoutineStats(id, 2025)
+	if err != nil {
+		t.Fatalf("GetRoutineStats(2025): %v", err)
+	}
+	if last := previous.Heatmap[364]; last.Date != "2025-12-31" || !last.Scheduled || !last.Completed {
+		t.Errorf("2025-12-31 = %+v, want scheduled and completed", last)
+	}
+
+	if _, err := manager.GetRoutineStats("R99", 0); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("unknown routine = %v, want not found", err)
+	}
+}
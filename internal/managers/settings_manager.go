@@ -0,0 +1,151 @@
+package managers
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// Model timeout bounds accepted by UpdateSettings, in seconds.
+const (
+	minModelTimeoutSeconds = 5
+	maxModelTimeoutSeconds = 600
+)
+
+// localePattern accepts BCP 47 tags of the shape Bearing produces and the
+// frontend's Intl APIs accept, e.g. "en", "de-CH", "zh-Hant-TW".
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// Settings is the manager-layer view of the application settings. It is
+// flattened for the frontend; nesting is an on-disk concern.
+type Settings struct {
+	GitAuthorName       string `json:"gitAuthorName"`
+	GitAuthorEmail      string `json:"gitAuthorEmail"`
+	WeekStart           string `json:"weekStart"`
+	Timezone            string `json:"timezone"`
+	DayStartHour        int    `json:"dayStartHour"`
+	Locale              string `json:"locale"`
+	DefaultThemeID      string `json:"defaultThemeId"`
+	AdvisorEnabled      bool   `json:"advisorEnabled"`
+	ModelProvider       string `json:"modelProvider"`
+	ModelTimeoutSeconds int    `json:"modelTimeoutSeconds"`
+	AutoArchiveMode     string `json:"autoArchiveMode"`
+	AutoArchiveDays     int    `json:"autoArchiveDays"`
+}
+
+// weekStartDay maps a settings week start to the weekday weekly routine
+// periods begin on. Anything but WeekStartSunday means Monday.
+func weekStartDay(weekStart string) time.Weekday {
+	if weekStart == access.WeekStartSunday {
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+// ISettingsManager defines operations on the application settings.
+type ISettingsManager interface {
+	GetSettings() (*Settings, error)
+	UpdateSettings(settings Settings) (*Settings, error)
+}
+
+// SettingsManager implements ISettingsManager. It owns validation; the git
+// author, timezone, day boundary, model provider and model timeout are read
+// by bootstrap when a profile is opened, so changes to them apply from the
+// next open.
+type SettingsManager struct {
+	settingsAccess access.ISettingsAccess
+	themeAccess    access.IThemeAccess
+}
+
+// NewSettingsManager creates a new SettingsManager instance.
+func NewSettingsManager(settingsAccess access.ISettingsAccess, themeAccess access.IThemeAccess) (*SettingsManager, error) {
+	if settingsAccess == nil {
+		return nil, fmt.Errorf("settingsAccess cannot be nil")
+	}
+	if themeAccess == nil {
+		return nil, fmt.Errorf("themeAccess cannot be nil")
+	}
+	return &SettingsManager{settingsAccess: settingsAccess, themeAccess: themeAccess}, nil
+}
+
+// GetSettings returns the current settings with defaults applied.
+func (m *SettingsManager) GetSettings() (*Settings, error) {
+	settings, err := m.settingsAccess.LoadSettings()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	result := toManagerSettings(*settings)
+	return &result, nil
+}
+
+// UpdateSettings validates and persists the complete settings, returning the
+// stored values. Nothing is written when validation fails.
+func (m *SettingsManager) UpdateSettings(settings Settings) (*Settings, error) {
+	settings.GitAuthorName = strings.TrimSpace(settings.GitAuthorName)
+	settings.GitAuthorEmail = strings.TrimSpace(settings.GitAuthorEmail)
+	settings.Timezone = strings.TrimSpace(settings.Timezone)
+	settings.Locale = strings.TrimSpace(settings.Locale)
+
+	if err := m.validateSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := m.settingsAccess.SaveSettings(toAccessSettings(settings)); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return m.GetSettings()
+}
+
+// validateSettings checks every field; the first violation is returned.
+func (m *SettingsManager) validateSettings(s Settings) error {
+	if s.GitAuthorName == "" {
+		return fmt.Errorf("git author name cannot be empty")
+	}
+	if _, err := mail.ParseAddress(s.GitAuthorEmail); err != nil {
+		return fmt.Errorf("invalid git author email %q", s.GitAuthorEmail)
+	}
+	if s.WeekStart != access.WeekStartMonday && s.WeekStart != access.WeekStartSunday {
+		return fmt.Errorf("invalid week start %q (must be %s or %s)", s.WeekStart, access.WeekStartMonday, access.WeekStartSunday)
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", s.Timezone)
+		}
+	}
+	if s.DayStartHour < 0 || s.DayStartHour > utilities.MaxDayStartHour {
+		return fmt.Errorf("day start hour must be between 0 and %d", utilities.MaxDayStartHour)
+	}
+	if s.Locale != "" && !localePattern.MatchString(s.Locale) {
+		return fmt.Errorf("invalid locale %q", s.Locale)
+	}
+	if s.DefaultThemeID != "" {
+		themes, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return fmt.Errorf("failed to get themes: %w", err)
+		}
+		if !slices.ContainsFunc(themes, func(t access.LifeTheme) bool { return t.ID == s.DefaultThemeID }) {
+			return fmt.Errorf("default theme %s not found", s.DefaultThemeID)
+		}
+	}
+	if !slices.Contains(access.ModelProviders(), s.ModelProvider) {
+		return fmt.Errorf("unknown model provider %q", s.ModelProvider)
+	}
+	if s.ModelTimeoutSeconds < minModelTimeoutSeconds || s.ModelTimeoutSeconds > maxModelTimeoutSeconds {
+		return fmt.Errorf("model timeout must be between %d and %d seconds", minModelTimeoutSeconds, maxModelTimeoutSeconds)
+	}
+	switch access.AutoArchiveMode(s.AutoArchiveMode) {
+	case access.AutoArchiveManual:
+	case access.AutoArchiveAfterDays:
+		if s.AutoArchiveDays < 1 {
+			return fmt.Errorf("auto-archive days must be at least 1")
+		}
+	default:
+		return fmt.Errorf("invalid auto-archive mode %q", s.AutoArchiveMode)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// mockSettingsAccess implements access.ISettingsAccess in memory.
+type mockSettingsAccess struct {
+	settings  access.Settings
+	saveCount int
+}
+
+func newMockSettingsAccess() *mockSettingsAccess {
+	return &mockSettingsAccess{settings: access.DefaultSettings()}
+}
+
+func (m *mockSettingsAccess) LoadSettings() (*access.Settings, error) {
+	settings := m.settings
+	return &settings, nil
+}
+
+func (m *mockSettingsAccess) SaveSettings(settings access.Settings) error {
+	m.settings = settings
+	m.saveCount++
+	return nil
+}
+
+func (m *mockSettingsAccess) WriteSettings(settings access.Settings) error {
+	m.settings = settings
+	return nil
+}
+
+func TestUnit_SettingsManager_GetDefaults(t *testing.T) {
+	sm, err := NewSettingsManager(newMockSettingsAccess(), newMockThemeAccess())
+	if err != nil {
+		t.Fatalf("NewSettingsManager: %v", err)
+	}
+
+	settings, err := sm.GetSettings()
+	if err != nil {
+		t.Fatalf("GetSettings: %v", err)
+	}
+	if settings.WeekStart != access.WeekStartMonday || settings.ModelProvider != access.ModelProviderClaudeCLI ||
+		settings.ModelTimeoutSeconds != 60 || settings.AutoArchiveMode != string(access.AutoArchiveManual) {
+		t.Errorf("unexpected defaults: %+v", settings)
+	}
+}
+
+func TestUnit_SettingsManager_UpdateValid(t *testing.T) {
+	sa := newMockSettingsAccess()
+	sm, _ := NewSettingsManager(sa, newMockThemeAccess())
+
+	settings, _ := sm.GetSettings()
+	settings.GitAuthorName = "  Ada  "
+	settings.GitAuthorEmail = "ada@example.com"
+	settings.WeekStart = access.WeekStartSunday
+	settings.Timezone = "America/New_York"
+	settings.DayStartHour = 4
+	settings.Locale = "de-CH"
+	settings.DefaultThemeID = "T"
+	settings.ModelTimeoutSeconds = 120
+	settings.AutoArchiveMode = string(access.AutoArchiveAfterDays)
+	settings.AutoArchiveDays = 7
+
+	updated, err := sm.UpdateSettings(*settings)
+	if err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+	if sa.saveCount != 1 {
+		t.Errorf("saveCount = %d, want 1", sa.saveCount)
+	}
+	if updated.GitAuthorName != "Ada" || sa.settings.GitAuthor.Name != "Ada" {
+		t.Errorf("author name not trimmed: %q", updated.GitAuthorName)
+	}
+	if sa.settings.AutoArchive.Days != 7 || sa.settings.Advisor.TimeoutSeconds != 120 {
+		t.Errorf("stored settings = %+v", sa.settings)
+	}
+}
+
+func TestUnit_SettingsManager_UpdateRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Settings)
+		want   string
+	}{
+		{"empty author", func(s *Settings) { s.GitAuthorName = " " }, "author name"},
+		{"bad email", func(s *Settings) { s.GitAuthorEmail = "nobody" }, "email"},
+		{"week start", func(s *Settings) { s.WeekStart = "friday" }, "week start"},
+		{"timezone", func(s *Settings) { s.Timezone = "Mars/Olympus" }, "timezone"},
+		{"day start", func(s *Settings) { s.DayStartHour = 13 }, "day start"},
+		{"locale", func(s *Settings) { s.Locale = "en_US.UTF-8" }, "locale"},
+		{"default theme", func(s *Settings) { s.DefaultThemeID = "NOPE" }, "default theme"},
+		{"provider", func(s *Settings) { s.ModelProvider = "carrier-pigeon" }, "provider"},
+		{"timeout", func(s *Settings) { s.ModelTimeoutSeconds = 1 }, "timeout"},
+		{"archive mode", func(s *Settings) { s.AutoArchiveMode = "weekly" }, "auto-archive mode"},
+		{"archive days", func(s *Settings) {
+			s.AutoArchiveMode = string(access.AutoArchiveAfterDays)
+			s.AutoArchiveDays = 0
+		}, "days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sa := newMockSettingsAccess()
+			sm, _ := NewSettingsManager(sa, newMockThemeAccess())
+			settings, _ := sm.GetSettings()
+			tt.mutate(settings)
+
+			_, err := sm.UpdateSettings(*settings)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+			if sa.saveCount != 0 {
+				t.Error("invalid settings must not be saved")
+			}
+		})
+	}
+}
+
+func TestUnit_ArchiveDoneTasksOlderThan(t *testing.T) {
+	pm, _, ka := newMockManager()
+	old := utilities.NewTimestamp(time.Now().AddDate(0, 0, -10))
+	recent := utilities.NewTimestamp(time.Now().AddDate(0, 0, -1))
+	ka.tasks["done"] = []access.Task{
+		{ID: "T-T1", ThemeID: "T", UpdatedAt: old},
+		{ID: "T-T2", ThemeID: "T", UpdatedAt: recent},
+		{ID: "T-T3", ThemeID: "T", CreatedAt: old},
+		{ID: "T-T4", ThemeID: "T"},
+	}
+
+	archived, err := pm.ArchiveDoneTasksOlderThan(7)
+	if err != nil {
+		t.Fatalf("ArchiveDoneTasksOlderThan: %v", err)
+	}
+	if archived != 2 {
+		t.Errorf("archived = %d, want 2", archived)
+	}
+	remaining := map[string]bool{}
+	for _, task := range ka.tasks["done"] {
+		remaining[task.ID] = true
+	}
+	if remaining["T-T1"] || remaining["T-T3"] || !remaining["T-T2"] || !remaining["T-T4"] {
+		t.Errorf("remaining done tasks = %v, want T-T2 and T-T4", remaining)
+	}
+}
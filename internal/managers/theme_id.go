@@ -0,0 +1,150 @@
+package managers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// ThemeIDChangeResult reports the IDs rewritten by ChangeThemeID. IDMapping
+// maps the old ID of the theme and of every objective, key result and task
+// carrying its abbreviation to the new ID.
+type ThemeIDChangeResult struct {
+	IDMapping map[string]string `json:"idMapping"`
+}
+
+// ChangeThemeID changes a theme's abbreviation, which is baked into its own
+// ID and into the IDs of its objectives, key results and tasks ("H-T12").
+// themes.json, the task files in every status directory (archived included),
+// both task order files, the calendar's theme and OKR references and the
+// navigation context are rewritten in a single commit.
+//
+// The new abbreviation must be 1-3 uppercase letters and must not be used by
+// another theme. Changing a theme to its current ID is a no-op.
+func (m *PlanningManager) ChangeThemeID(themeId, newThemeId string) (*ThemeIDChangeResult, error) {
+	if themeId == "" {
+		return nil, fmt.Errorf("themeId cannot be empty")
+	}
+	if !access.IsValidThemeID(newThemeId) {
+		return nil, fmt.Errorf("theme ID %q must be 1-3 uppercase letters", newThemeId)
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	idx := -1
+	for i := range themes {
+		if themes[i].ID == themeId {
+			idx = i
+		} else if themes[i].ID == newThemeId {
+			return nil, fmt.Errorf("theme ID %s is already used by %q", newThemeId, themes[i].Name)
+		}
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("theme with ID %s not found", themeId)
+	}
+	if newThemeId == themeId {
+		return &ThemeIDChangeResult{IDMapping: map[string]string{themeId: themeId}}, nil
+	}
+
+	mapping := map[string]string{themeId: newThemeId}
+	theme := themes[idx]
+	theme.ID = newThemeId
+	theme.Objectives = rekeyObjectives(theme.Objectives, themeId, newThemeId, mapping)
+
+	commitMsg := fmt.Sprintf("Change theme ID %s to %s", themeId, newThemeId)
+	if err := utilities.RunTransaction(m.repo, commitMsg, func() error {
+		if err := m.themeAccess.WriteRenameTheme(themeId, theme); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		taskIDs, err := m.taskAccess.WriteRenameTheme(themeId, newThemeId)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		for oldID, newID := range taskIDs {
+			mapping[oldID] = newID
+		}
+
+		days, err := m.remapDayFocusIDs(mapping)
+		if err != nil {
+			return err
+		}
+		for _, day := range days {
+			if err := m.calendarAccess.WriteDayFocus(day); err != nil {
+				return fmt.Errorf("write day %s: %w", day.Date, err)
+			}
+		}
+		weeks, err := m.remapWeekPlanIDs(mapping)
+		if err != nil {
+			return err
+		}
+		for _, week := range weeks {
+			if err := m.weekAccess.WriteWeekPlan(week); err != nil {
+				return fmt.Errorf("write week %s: %w", week.Week, err)
+			}
+		}
+		routines, changed, err := m.remapRoutineLinks(mapping)
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := m.routineAccess.WriteSaveRoutines(routines); err != nil {
+				return fmt.Errorf("%w", err)
+			}
+		}
+		settings, err := m.settingsAccess.LoadSettings()
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		if settings.DefaultThemeID == themeId {
+			settings.DefaultThemeID = newThemeId
+			if err := m.settingsAccess.WriteSettings(*settings); err != nil {
+				return fmt.Errorf("%w", err)
+			}
+		}
+		return m.remapNavigationContext(mapping)
+	}); err != nil {
+		return nil, fmt.Errorf("ChangeThemeID: %w", err)
+	}
+
+	return &ThemeIDChangeResult{IDMapping: mapping}, nil
+}
+
+// rekeyObjectives returns a copy of objectives with the oldAbbr prefix of
+// every objective and key result ID replaced by newAbbr, recording each
+// change in mapping. Parent links are re-derived by ThemeAccess on write.
+func rekeyObjectives(objectives []access.Objective, oldAbbr, newAbbr string, mapping map[string]string) []access.Objective {
+	if objectives == nil {
+		return nil
+	}
+	out := make([]access.Objective, len(objectives))
+	for i, obj := range objectives {
+		obj.ID = rekeyID(obj.ID, oldAbbr, newAbbr, mapping)
+		if obj.KeyResults != nil {
+			krs := make([]access.KeyResult, len(obj.KeyResults))
+			for j, kr := range obj.KeyResults {
+				kr.ID = rekeyID(kr.ID, oldAbbr, newAbbr, mapping)
+				krs[j] = kr
+			}
+			obj.KeyResults = krs
+		}
+		obj.Objectives = rekeyObjectives(obj.Objectives, oldAbbr, newAbbr, mapping)
+		out[i] = obj
+	}
+	return out
+}
+
+// rekeyID swaps the theme abbreviation of a theme-scoped ID ("H-O1" to
+// "WB-O1"). IDs from another namespace are returned unchanged.
+func rekeyID(id, oldAbbr, newAbbr string, mapping map[string]string) string {
+	rest, ok := strings.CutPrefix(id, oldAbbr+"-")
+	if !ok {
+		return id
+	}
+	newID := newAbbr + "-" + rest
+	mapping[id] = newID
+	return newID
+}
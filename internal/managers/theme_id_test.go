@@ -0,0 +1,148 @@
+package managers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+)
+
+// newThemeIDTestManager returns a manager with theme T holding T-O1 (with
+// T-KR1 and child T-O2), an active task T-T1, an archived task T-T2, and a
+// second theme "Career".
+func newThemeIDTestManager(t *testing.T) (*PlanningManager, *mockTaskAccess, *mockCalendarAccess, *recordingUIStateAccess, *stubRepo, string) {
+	t.Helper()
+	ka := newMockTaskAccess()
+	ca := newMockCalendarAccess()
+	ua := &recordingUIStateAccess{}
+	repo := newStubRepo()
+	pm := newTestManager(t, testManagerDeps{tasks: ka, calendar: ca, uiState: ua, repo: repo})
+	res, err := pm.Establish(EstablishRequest{GoalType: GoalTypeTheme, Name: "Career", Color: "#ff0000"})
+	if err != nil {
+		t.Fatalf("Establish theme: %v", err)
+	}
+	for _, step := range []func() error{
+		func() error { _, err := testCreateObjective(pm, "T", "Get fit"); return err },
+		func() error { _, err := testCreateKeyResult(pm, "T-O1", "Run km", 0, 100); return err },
+		func() error { _, err := testCreateObjective(pm, "T-O1", "Strength"); return err },
+	} {
+		if err := step(); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+	ka.tasks["todo"] = []access.Task{{ID: "T-T1", ThemeID: "T", Title: "Run"}, {ID: "C-T1", ThemeID: res.Theme.ID, Title: "Apply"}}
+	ka.tasks["archived"] = []access.Task{{ID: "T-T2", ThemeID: "T", Title: "Swim"}}
+	ka.taskOrder = map[string][]string{"important-urgent": {"C-T1", "T-T1"}}
+	ka.archivedOrder = []string{"T-T2"}
+	repo.tx.commits = nil
+	return pm, ka, ca, ua, repo, res.Theme.ID
+}
+
+func TestUnit_ChangeThemeID_RewritesEverything(t *testing.T) {
+	pm, ka, ca, ua, repo, career := newThemeIDTestManager(t)
+	ca.days["2026-03-01"] = access.DayFocus{Date: "2026-03-01", ThemeIDs: []string{career, "T"}, OkrIDs: []string{"T-KR1"}}
+	ca.days["2026-03-02"] = access.DayFocus{Date: "2026-03-02", ThemeIDs: []string{career}}
+	ua.ctx = &access.NavigationContext{FilterThemeID: "T", FilterThemeIDs: []string{"T", career}, ExpandedOkrIds: []string{"T", "T-O1"}, CurrentItem: "T-T1"}
+
+	result, err := pm.ChangeThemeID("T", "FIT")
+	if err != nil {
+		t.Fatalf("ChangeThemeID: %v", err)
+	}
+
+	want := map[string]string{
+		"T": "FIT", "T-O1": "FIT-O1", "T-KR1": "FIT-KR1", "T-O2": "FIT-O2", "T-T1": "FIT-T1", "T-T2": "FIT-T2",
+	}
+	if len(result.IDMapping) != len(want) {
+		t.Fatalf("IDMapping = %v, want %v", result.IDMapping, want)
+	}
+	for old, id := range want {
+		if result.IDMapping[old] != id {
+			t.Errorf("IDMapping[%s] = %q, want %q", old, result.IDMapping[old], id)
+		}
+	}
+	if len(repo.tx.commits) != 1 || repo.tx.commits[0] != "Change theme ID T to FIT" {
+		t.Errorf("commits = %q, want a single commit", repo.tx.commits)
+	}
+
+	themes, _ := pm.GetHierarchy()
+	if themes[0].ID != "FIT" || themes[1].ID != career {
+		t.Fatalf("themes = %s, %s; want FIT first", themes[0].ID, themes[1].ID)
+	}
+	obj := themes[0].Objectives[0]
+	if obj.ID != "FIT-O1" || obj.ParentID != "FIT" || obj.KeyResults[0].ID != "FIT-KR1" || obj.KeyResults[0].ParentID != "FIT-O1" || obj.Objectives[0].ParentID != "FIT-O1" {
+		t.Errorf("objective = %+v, want IDs and parents rewritten", obj)
+	}
+
+	if task := ka.tasks["archived"][0]; task.ID != "FIT-T2" || task.ThemeID != "FIT" {
+		t.Errorf("archived task = %+v, want FIT-T2", task)
+	}
+	if got := strings.Join(ka.taskOrder["important-urgent"], ","); got != "C-T1,FIT-T1" {
+		t.Errorf("task order = %s", got)
+	}
+	if ka.archivedOrder[0] != "FIT-T2" {
+		t.Errorf("archived order = %v", ka.archivedOrder)
+	}
+
+	day := ca.days["2026-03-01"]
+	if strings.Join(day.ThemeIDs, ",") != career+",FIT" || day.OkrIDs[0] != "FIT-KR1" {
+		t.Errorf("day focus = %+v, want references rewritten", day)
+	}
+	if ua.ctx.FilterThemeID != "FIT" || ua.ctx.FilterThemeIDs[0] != "FIT" || ua.ctx.ExpandedOkrIds[1] != "FIT-O1" || ua.ctx.CurrentItem != "FIT-T1" {
+		t.Errorf("navigation context = %+v, want remapped", ua.ctx)
+	}
+}
+
+func TestUnit_ChangeThemeID_RemapsDefaultTheme(t *testing.T) {
+	pm, _, _, _, _, _ := newThemeIDTestManager(t)
+	sa := pm.settingsAccess.(*mockSettingsAccess)
+	sa.settings.DefaultThemeID = "T"
+
+	if _, err := pm.ChangeThemeID("T", "FIT"); err != nil {
+		t.Fatalf("ChangeThemeID: %v", err)
+	}
+	if sa.settings.DefaultThemeID != "FIT" {
+		t.Errorf("DefaultThemeID = %q, want FIT", sa.settings.DefaultThemeID)
+	}
+}
+
+func TestUnit_ChangeThemeID_NavigationContextFailureAborts(t *testing.T) {
+	pm, _, _, ua, repo, _ := newThemeIDTestManager(t)
+	ua.ctx = &access.NavigationContext{CurrentItem: "T-T1"}
+	ua.saveErr = errors.New("disk full")
+
+	if _, err := pm.ChangeThemeID("T", "FIT"); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("ChangeThemeID = %v, want the save error", err)
+	}
+	if len(repo.tx.commits) != 0 {
+		t.Errorf("commits = %v, want none", repo.tx.commits)
+	}
+}
+
+func TestUnit_ChangeThemeID_Validation(t *testing.T) {
+	pm, _, _, _, repo, career := newThemeIDTestManager(t)
+
+	tests := []struct {
+		name, theme, newID, wantErr string
+	}{
+		{"empty theme", "", "FIT", "themeId cannot be empty"},
+		{"lowercase", "T", "fit", "1-3 uppercase letters"},
+		{"too long", "T", "FITS", "1-3 uppercase letters"},
+		{"unknown theme", "X", "FIT", "not found"},
+		{"taken", "T", career, "already used by \"Career\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pm.ChangeThemeID(tt.theme, tt.newID); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if result, err := pm.ChangeThemeID("T", "T"); err != nil || result.IDMapping["T"] != "T" {
+		t.Errorf("unchanged ID = %v, %v; want identity mapping", result, err)
+	}
+	if len(repo.tx.commits) != 0 {
+		t.Errorf("rejected changes committed: %q", repo.tx.commits)
+	}
+}
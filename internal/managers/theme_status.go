@@ -0,0 +1,86 @@
+package managers
+
+import (
+	"fmt"
+
+	"github.com/rkn/bearing/internal/access"
+)
+
+// SetThemeStatus moves a theme between active, paused and archived. Paused
+// themes accept no new tasks; archived themes are left out of the hierarchy,
+// progress and advisor context. Nothing else about the theme changes, so
+// setting it back to active restores it exactly as it was.
+func (m *PlanningManager) SetThemeStatus(themeId, status string) error {
+	if themeId == "" {
+		return fmt.Errorf("themeId cannot be empty")
+	}
+	if !IsValidThemeStatus(status) {
+		return fmt.Errorf("invalid theme status %q", status)
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	for i := range themes {
+		if themes[i].ID != themeId {
+			continue
+		}
+		// Active is stored as the empty status, like themes that never
+		// left it.
+		if status == string(access.ThemeStatusActive) {
+			status = ""
+		}
+		if themes[i].Status == status {
+			return nil
+		}
+		themes[i].Status = status
+		if err := m.themeAccess.SaveTheme(themes[i]); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("theme with ID %s not found", themeId)
+}
+
+// GetArchivedThemes returns the archived themes, which GetHierarchy leaves
+// out, so they can be reviewed and restored.
+func (m *PlanningManager) GetArchivedThemes() ([]LifeTheme, error) {
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	result := []LifeTheme{}
+	for _, t := range themes {
+		if t.Status == string(access.ThemeStatusArchived) {
+			result = append(result, toManagerLifeTheme(t))
+		}
+	}
+	return result, nil
+}
+
+// visibleThemes returns themes without the archived ones.
+func visibleThemes(themes []access.LifeTheme) []access.LifeTheme {
+	result := make([]access.LifeTheme, 0, len(themes))
+	for _, t := range themes {
+		if t.Status != string(access.ThemeStatusArchived) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// checkThemeAcceptsTasks rejects new tasks for paused and archived themes.
+// Unknown theme IDs are left to the caller.
+func (m *PlanningManager) checkThemeAcceptsTasks(themeId string) error {
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	for _, t := range themes {
+		if t.ID == themeId && t.Status != "" && t.Status != string(access.ThemeStatusActive) {
+			return fmt.Errorf("theme %s is %s and does not accept new tasks", t.Name, t.Status)
+		}
+	}
+	return nil
+}
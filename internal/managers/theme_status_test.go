@@ -0,0 +1,102 @@
+package managers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+)
+
+func TestUnit_SetThemeStatus_ArchiveHidesAndRestoreBringsBack(t *testing.T) {
+	pm, ta, _ := newMockManager()
+	if _, err := pm.Establish(EstablishRequest{GoalType: GoalTypeTheme, Name: "Career", Color: "#ff0000"}); err != nil {
+		t.Fatalf("Establish theme: %v", err)
+	}
+	if _, err := testCreateObjective(pm, "T", "Get fit"); err != nil {
+		t.Fatalf("create objective: %v", err)
+	}
+	if _, err := testCreateKeyResult(pm, "T-O1", "Run km", 0, 100); err != nil {
+		t.Fatalf("create key result: %v", err)
+	}
+	before := ta.themes[0]
+
+	if err := pm.SetThemeStatus("T", "archived"); err != nil {
+		t.Fatalf("SetThemeStatus archived: %v", err)
+	}
+	themes, _ := pm.GetHierarchy()
+	if len(themes) != 1 || themes[0].ID != "C" {
+		t.Errorf("hierarchy = %+v, want only C", themes)
+	}
+	progress, _ := pm.GetAllThemeProgress()
+	if len(progress) != 1 || progress[0].ThemeID != "C" {
+		t.Errorf("progress = %+v, want only C", progress)
+	}
+	archived, _ := pm.GetArchivedThemes()
+	if len(archived) != 1 || archived[0].ID != "T" || archived[0].Status != "archived" || len(archived[0].Objectives) != 1 {
+		t.Errorf("archived themes = %+v, want T with its objective", archived)
+	}
+
+	if err := pm.SetThemeStatus("T", "active"); err != nil {
+		t.Fatalf("SetThemeStatus active: %v", err)
+	}
+	if !reflect.DeepEqual(ta.themes[0], before) {
+		t.Errorf("restored theme = %+v, want %+v", ta.themes[0], before)
+	}
+	if themes, _ := pm.GetHierarchy(); len(themes) != 2 {
+		t.Errorf("hierarchy after restore = %+v, want both themes", themes)
+	}
+}
+
+func TestUnit_SetThemeStatus_PausedThemeRejectsTasks(t *testing.T) {
+	pm, _, _ := newMockManager()
+
+	if err := pm.SetThemeStatus("T", "paused"); err != nil {
+		t.Fatalf("SetThemeStatus paused: %v", err)
+	}
+	if _, err := pm.CreateTask("Run", "T", "important-urgent", "", "", ""); err == nil || !strings.Contains(err.Error(), "paused") {
+		t.Errorf("CreateTask on paused theme = %v, want rejection", err)
+	}
+	themes, _ := pm.GetHierarchy()
+	if len(themes) != 1 || themes[0].Status != "paused" {
+		t.Errorf("hierarchy = %+v, want the paused theme shown", themes)
+	}
+
+	if err := pm.SetThemeStatus("T", "active"); err != nil {
+		t.Fatalf("SetThemeStatus active: %v", err)
+	}
+	if _, err := pm.CreateTask("Run", "T", "important-urgent", "", "", ""); err != nil {
+		t.Errorf("CreateTask after resume: %v", err)
+	}
+}
+
+func TestUnit_SetThemeStatus_Validation(t *testing.T) {
+	pm, _, _ := newMockManager()
+
+	tests := []struct {
+		name, theme, status, wantErr string
+	}{
+		{"empty theme", "", "paused", "themeId cannot be empty"},
+		{"invalid status", "T", "completed", "invalid theme status"},
+		{"unknown theme", "X", "paused", "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := pm.SetThemeStatus(tt.theme, tt.status); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUnit_VisibleThemes_SkipsArchivedOnly(t *testing.T) {
+	themes := []access.LifeTheme{
+		{ID: "A"},
+		{ID: "P", Status: string(access.ThemeStatusPaused)},
+		{ID: "X", Status: string(access.ThemeStatusArchived)},
+	}
+	got := visibleThemes(themes)
+	if len(got) != 2 || got[0].ID != "A" || got[1].ID != "P" {
+		t.Errorf("visibleThemes = %+v, want A and P", got)
+	}
+}
@@ -2,6 +2,7 @@ package managers
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/rkn/bearing/internal/access"
@@ -59,6 +60,15 @@ func IsValidOKRStatus(status string) bool {
 	return false
 }
 
+// IsValidThemeStatus checks if a theme status string is valid.
+func IsValidThemeStatus(status string) bool {
+	switch access.ThemeStatus(status) {
+	case access.ThemeStatusActive, access.ThemeStatusPaused, access.ThemeStatusArchived:
+		return true
+	}
+	return false
+}
+
 // EffectiveOKRStatus returns "active" if status is empty, otherwise the status as-is.
 func EffectiveOKRStatus(status string) string {
 	if status == "" {
@@ -80,8 +90,104 @@ func IsValidClosingStatus(status string) bool {
 // Empty string is treated as valid (equivalent to "metric").
 func IsValidKRType(krType string) bool {
 	switch krType {
-	case "", access.KRTypeMetric, access.KRTypeBinary:
+	case "", access.KRTypeMetric, access.KRTypeBinary, access.KRTypePercentage, access.KRTypeMilestone:
 		return true
 	}
 	return false
 }
+
+// maxGoalWeight bounds the relative weight of an objective or key result.
+const maxGoalWeight = 100
+
+// validateWeight accepts 0 (unset, counted as 1) or a finite positive weight
+// up to maxGoalWeight.
+func validateWeight(w float64) error {
+	if math.IsNaN(w) || w < 0 || w > maxGoalWeight {
+		return fmt.Errorf("weight must be between 0 and %d", maxGoalWeight)
+	}
+	return nil
+}
+
+// maxKRUnitLength bounds the free-text unit label of a key result.
+const maxKRUnitLength = 20
+
+// normalizeKeyResult validates the type, direction, unit and values of kr and
+// fills in their canonical form: an explicit direction, a trimmed unit and
+// "%" as the unit of percentage key results. Binary key results always run
+// upwards from 0 to 1, milestone key results from 0 to 100 percent of their
+// milestones.
+func normalizeKeyResult(kr *access.KeyResult) error {
+	if !IsValidKRType(kr.Type) {
+		return fmt.Errorf("invalid key result type: %s", kr.Type)
+	}
+	switch kr.Direction {
+	case "":
+		kr.Direction = access.KRDirectionIncrease
+	case access.KRDirectionIncrease, access.KRDirectionDecrease:
+	default:
+		return fmt.Errorf("invalid key result direction: %s", kr.Direction)
+	}
+	kr.Unit = strings.TrimSpace(kr.Unit)
+	if len(kr.Unit) > maxKRUnitLength {
+		return fmt.Errorf("unit cannot exceed %d characters", maxKRUnitLength)
+	}
+	if math.IsNaN(kr.StartValue) || math.IsInf(kr.StartValue, 0) || math.IsNaN(kr.TargetValue) || math.IsInf(kr.TargetValue, 0) {
+		return fmt.Errorf("key result values must be finite numbers")
+	}
+
+	switch kr.Type {
+	case access.KRTypeBinary:
+		if kr.StartValue != 0 || (kr.TargetValue != 0 && kr.TargetValue != 1) || kr.Direction != access.KRDirectionIncrease {
+			return fmt.Errorf("binary key results run from 0 to 1")
+		}
+		kr.TargetValue = 1
+		kr.Unit = ""
+	case access.KRTypePercentage:
+		if kr.StartValue < 0 || kr.StartValue > 100 || kr.TargetValue < 0 || kr.TargetValue > 100 {
+			return fmt.Errorf("percentage values must be between 0 and 100")
+		}
+		kr.Unit = "%"
+	case access.KRTypeMilestone:
+		if kr.StartValue != 0 || (kr.TargetValue != 0 && kr.TargetValue != 100) || kr.Direction != access.KRDirectionIncrease {
+			return fmt.Errorf("milestone key results run from 0 to 100 percent")
+		}
+		if err := validateMilestones(kr.Milestones); err != nil {
+			return err
+		}
+		kr.TargetValue = 100
+		kr.Unit = "%"
+	}
+	if kr.Type != access.KRTypeMilestone && len(kr.Milestones) > 0 {
+		return fmt.Errorf("only milestone key results can have milestones")
+	}
+
+	if kr.Direction == access.KRDirectionIncrease && kr.StartValue > kr.TargetValue {
+		return fmt.Errorf("startValue (%g) cannot exceed targetValue (%g) for an increasing key result", kr.StartValue, kr.TargetValue)
+	}
+	if kr.Direction == access.KRDirectionDecrease && kr.StartValue < kr.TargetValue {
+		return fmt.Errorf("startValue (%g) cannot be below targetValue (%g) for a decreasing key result", kr.StartValue, kr.TargetValue)
+	}
+	return nil
+}
+
+// validateKeyResultValue checks a recorded value against the key result's
+// type: binary key results accept only 0 and 1, percentages 0-100, and
+// milestone key results take no check-ins at all.
+func validateKeyResultValue(kr access.KeyResult, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("value must be a finite number")
+	}
+	switch kr.Type {
+	case access.KRTypeBinary:
+		if value != 0 && value != 1 {
+			return fmt.Errorf("binary key result value must be 0 or 1, got %g", value)
+		}
+	case access.KRTypePercentage:
+		if value < 0 || value > 100 {
+			return fmt.Errorf("percentage value must be between 0 and 100, got %g", value)
+		}
+	case access.KRTypeMilestone:
+		return fmt.Errorf("milestone key results record progress by completing milestones")
+	}
+	return nil
+}
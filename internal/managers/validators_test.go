@@ -60,13 +60,13 @@ func TestUnit_IsValidClosingStatus(t *testing.T) {
 }
 
 func TestUnit_IsValidKRType(t *testing.T) {
-	valid := []string{"", "metric", "binary"}
+	valid := []string{"", "metric", "binary", "percentage", "milestone"}
 	for _, s := range valid {
 		if !IsValidKRType(s) {
 			t.Errorf("IsValidKRType(%q) = false, want true", s)
 		}
 	}
-	invalid := []string{"numeric", "percentage", "boolean"}
+	invalid := []string{"numeric", "percent", "boolean"}
 	for _, s := range invalid {
 		if IsValidKRType(s) {
 			t.Errorf("IsValidKRType(%q) = true, want false", s)
@@ -0,0 +1,368 @@
+package managers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// Bounds on the intentions of a week plan.
+const (
+	maxWeekIntentions      = 10
+	maxWeekIntentionLength = 200
+)
+
+// WeekPlan is the plan for one ISO week in the Manager layer's public
+// interface: what the week is for, the objectives, key results and themes it
+// works on and the tasks committed to it. StartDate and EndDate are the
+// week's Monday and Sunday, derived from Week.
+type WeekPlan struct {
+	Week             string                 `json:"week"`
+	StartDate        utilities.CalendarDate `json:"startDate"`
+	EndDate          utilities.CalendarDate `json:"endDate"`
+	Intentions       []string               `json:"intentions,omitempty"`
+	ObjectiveIDs     []string               `json:"objectiveIds,omitempty"`
+	KeyResultIDs     []string               `json:"keyResultIds,omitempty"`
+	ThemeIDs         []string               `json:"themeIds,omitempty"`
+	CommittedTaskIDs []string               `json:"committedTaskIds,omitempty"`
+	UpdatedAt        utilities.Timestamp    `json:"updatedAt,omitempty"`
+}
+
+// WeekTask is a committed task as reported by ReviewWeek.
+type WeekTask struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	ThemeID string `json:"themeId,omitempty"`
+	Status  string `json:"status"`
+}
+
+// MissedRoutine lists the dates of a reviewed week on which a routine was
+// due but not checked.
+type MissedRoutine struct {
+	RoutineID   string   `json:"routineId"`
+	Description string   `json:"description"`
+	ThemeID     string   `json:"themeId,omitempty"`
+	Dates       []string `json:"dates"`
+}
+
+// WeekReview reports how a week went against its plan. ReviewedThrough is
+// the last day whose routines count: the week's Sunday once it is over,
+// yesterday while it runs, and empty for a week not yet started.
+type WeekReview struct {
+	Week            string                 `json:"week"`
+	StartDate       utilities.CalendarDate `json:"startDate"`
+	EndDate         utilities.CalendarDate `json:"endDate"`
+	ReviewedThrough utilities.CalendarDate `json:"reviewedThrough,omitempty"`
+	CompletedTasks  []WeekTask             `json:"completedTasks"`
+	OpenTasks       []WeekTask             `json:"openTasks"`
+	MissingTaskIDs  []string               `json:"missingTaskIds,omitempty"` // committed tasks since deleted
+	MissedRoutines  []MissedRoutine        `json:"missedRoutines"`
+}
+
+// GetCurrentWeek returns today's ISO week, e.g. "2026-W12".
+func (m *PlanningManager) GetCurrentWeek() string {
+	return m.clock.Today().ISOWeek()
+}
+
+// GetWeekPlan returns the plan of an ISO week, or an empty plan for the week
+// when none is saved. References to themes and goals deleted since are
+// left out.
+func (m *PlanningManager) GetWeekPlan(week string) (*WeekPlan, error) {
+	monday, sunday, err := utilities.ParseISOWeek(week)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	stored, err := m.weekAccess.GetWeekPlan(week)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if stored == nil {
+		return &WeekPlan{Week: week, StartDate: monday, EndDate: sunday}, nil
+	}
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	plan := toManagerWeekPlan(dropDanglingWeekRefs(*stored, themes))
+	return &plan, nil
+}
+
+// GetWeekPlans returns every saved week plan, oldest week first.
+func (m *PlanningManager) GetWeekPlans() ([]WeekPlan, error) {
+	stored, err := m.weekAccess.GetWeekPlans()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	result := make([]WeekPlan, len(stored))
+	for i, p := range stored {
+		result[i] = toManagerWeekPlan(dropDanglingWeekRefs(p, themes))
+	}
+	return result, nil
+}
+
+// SaveWeekPlan validates and saves the plan of plan.Week, replacing any
+// saved one, and returns it as stored. Intentions are trimmed and empty ones
+// dropped; every ID must name an existing theme, objective, key result or
+// task of its list. Committed tasks already in the saved plan may have been
+// deleted since.
+func (m *PlanningManager) SaveWeekPlan(plan WeekPlan) (*WeekPlan, error) {
+	if _, _, err := utilities.ParseISOWeek(plan.Week); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	stored := access.WeekPlan{Week: plan.Week}
+	for _, intention := range plan.Intentions {
+		intention = strings.TrimSpace(intention)
+		if intention == "" {
+			continue
+		}
+		if len(intention) > maxWeekIntentionLength {
+			return nil, fmt.Errorf("intention cannot exceed %d characters", maxWeekIntentionLength)
+		}
+		stored.Intentions = append(stored.Intentions, intention)
+	}
+	if len(stored.Intentions) > maxWeekIntentions {
+		return nil, fmt.Errorf("a week holds at most %d intentions", maxWeekIntentions)
+	}
+
+	themes, err := m.themeAccess.GetThemes()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if stored.ThemeIDs, err = requireWeekRefs(plan.ThemeIDs, "theme", func(id string) bool { return themeExists(themes, id) }); err != nil {
+		return nil, err
+	}
+	if stored.ObjectiveIDs, err = requireWeekRefs(plan.ObjectiveIDs, "objective", func(id string) bool { return weekObjectiveExists(themes, id) }); err != nil {
+		return nil, err
+	}
+	if stored.KeyResultIDs, err = requireWeekRefs(plan.KeyResultIDs, "key result", func(id string) bool { return weekKeyResultExists(themes, id) }); err != nil {
+		return nil, err
+	}
+
+	if len(plan.CommittedTaskIDs) > 0 {
+		tasks, err := m.GetTasks()
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		taskIDs := make(map[string]bool, len(tasks))
+		for _, t := range tasks {
+			taskIDs[t.ID] = true
+		}
+		previous, err := m.weekAccess.GetWeekPlan(plan.Week)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		var committed []string
+		if previous != nil {
+			committed = previous.CommittedTaskIDs
+		}
+		if stored.CommittedTaskIDs, err = requireWeekRefs(plan.CommittedTaskIDs, "task", func(id string) bool {
+			return taskIDs[id] || slices.Contains(committed, id)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	stored.UpdatedAt = m.clock.Now()
+
+	if err := m.weekAccess.SaveWeekPlan(stored); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	result := toManagerWeekPlan(stored)
+	return &result, nil
+}
+
+// DeleteWeekPlan deletes the saved plan of an ISO week.
+func (m *PlanningManager) DeleteWeekPlan(week string) error {
+	if err := m.weekAccess.DeleteWeekPlan(week); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// ReviewWeek reports which of a week's committed tasks reached a done-type
+// column or the archive, which are still open and which were deleted, and
+// the routine occurrences of the week that went unchecked. A fixed-schedule
+// routine misses each scheduled date without a check on that date; an
+// after-completion routine misses its due date when that falls on or before
+// the last reviewed day without a completion since. A week without a saved
+// plan has no committed tasks but still reports missed routines.
+func (m *PlanningManager) ReviewWeek(week string) (*WeekReview, error) {
+	plan, err := m.GetWeekPlan(week)
+	if err != nil {
+		return nil, err
+	}
+	review := &WeekReview{
+		Week:           plan.Week,
+		StartDate:      plan.StartDate,
+		EndDate:        plan.EndDate,
+		CompletedTasks: []WeekTask{},
+		OpenTasks:      []WeekTask{},
+		MissedRoutines: []MissedRoutine{},
+	}
+
+	if len(plan.CommittedTaskIDs) > 0 {
+		if err := m.reviewCommittedTasks(review, plan.CommittedTaskIDs); err != nil {
+			return nil, fmt.Errorf("ReviewWeek: %w", err)
+		}
+	}
+
+	today := m.clock.Today()
+	through := plan.EndDate
+	if today.String() <= plan.EndDate.String() {
+		through = utilities.NewCalendarDate(today.Time().AddDate(0, 0, -1))
+	}
+	if through.String() < plan.StartDate.String() {
+		return review, nil
+	}
+	review.ReviewedThrough = through
+	if err := m.reviewMissedRoutines(review, plan.StartDate, through); err != nil {
+		return nil, fmt.Errorf("ReviewWeek: %w", err)
+	}
+	return review, nil
+}
+
+// reviewCommittedTasks sorts the committed tasks of a review into completed,
+// open and missing, keeping the plan's order.
+func (m *PlanningManager) reviewCommittedTasks(review *WeekReview, taskIDs []string) error {
+	config, err := m.getAccessBoardConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get board config: %w", err)
+	}
+	done := doneStatuses(config)
+	tasks, err := m.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+	byID := make(map[string]TaskWithStatus, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	for _, id := range taskIDs {
+		t, ok := byID[id]
+		if !ok {
+			review.MissingTaskIDs = append(review.MissingTaskIDs, id)
+			continue
+		}
+		entry := WeekTask{ID: t.ID, Title: t.Title, ThemeID: t.ThemeID, Status: t.Status}
+		if slices.Contains(done, t.Status) {
+			review.CompletedTasks = append(review.CompletedTasks, entry)
+		} else {
+			review.OpenTasks = append(review.OpenTasks, entry)
+		}
+	}
+	return nil
+}
+
+// reviewMissedRoutines adds to review the routines missed between start
+// and through (inclusive), in catalogue order. A check made late, up to
+// today, still meets the occurrence before it.
+func (m *PlanningManager) reviewMissedRoutines(review *WeekReview, start, through utilities.CalendarDate) error {
+	routines, err := m.routineAccess.GetRoutines()
+	if err != nil {
+		return fmt.Errorf("failed to load routines: %w", err)
+	}
+	if hasRoutineLinks(routines) {
+		themes, err := m.themeAccess.GetThemes()
+		if err != nil {
+			return fmt.Errorf("failed to load themes: %w", err)
+		}
+		routines = dropDanglingRoutineLinks(routines, themes)
+	}
+
+	dayAfter := through.Time().AddDate(0, 0, 1).Format(time.DateOnly)
+	for _, r := range routines {
+		if r.RepeatPattern == nil {
+			continue
+		}
+		completed, err := m.calendarAccess.GetRoutineCompletions(r.ID)
+		if err != nil {
+			return fmt.Errorf("load completions for routine %s: %w", r.ID, err)
+		}
+		pattern := *toEngineRepeatPattern(r.RepeatPattern)
+		exceptions := toEngineExceptions(r.Exceptions)
+
+		var dates []string
+		if pattern.AfterCompletion {
+			due := m.scheduleEngine.NextDue(pattern, exceptions, completed, dayAfter)
+			if due != "" && due <= through.String() {
+				dates = []string{due}
+			}
+		} else {
+			dates = m.scheduleEngine.MissedOccurrences(pattern, exceptions, completed, start.String(), through.String(), m.clock.Today().String())
+		}
+		if len(dates) > 0 {
+			review.MissedRoutines = append(review.MissedRoutines, MissedRoutine{
+				RoutineID:   r.ID,
+				Description: r.Description,
+				ThemeID:     r.ThemeID,
+				Dates:       dates,
+			})
+		}
+	}
+	return nil
+}
+
+// weekRefs trims and de-duplicates the IDs of one list of a week plan and
+// splits them into those for which exists holds and those for which it
+// does not.
+func weekRefs(ids []string, exists func(string) bool) (kept, missing []string) {
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || slices.Contains(kept, id) || slices.Contains(missing, id) {
+			continue
+		}
+		if exists(id) {
+			kept = append(kept, id)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return kept, missing
+}
+
+// requireWeekRefs returns the trimmed, de-duplicated IDs of one list of a
+// week plan, rejecting the first for which exists is false.
+func requireWeekRefs(ids []string, kind string, exists func(string) bool) ([]string, error) {
+	kept, missing := weekRefs(ids, exists)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s %s not found", kind, missing[0])
+	}
+	return kept, nil
+}
+
+// weekObjectiveExists reports whether any theme holds an objective with the
+// given ID.
+func weekObjectiveExists(themes []access.LifeTheme, id string) bool {
+	for _, theme := range themes {
+		if findObjectiveByID(theme.Objectives, id) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// weekKeyResultExists reports whether any theme holds a key result with the
+// given ID.
+func weekKeyResultExists(themes []access.LifeTheme, id string) bool {
+	_, kr := findKeyResultTheme(themes, id)
+	return kr != nil
+}
+
+// dropDanglingWeekRefs returns plan without the theme, objective and key
+// result IDs that no longer exist in themes. Committed task IDs are kept so
+// ReviewWeek can report deleted tasks.
+func dropDanglingWeekRefs(plan access.WeekPlan, themes []access.LifeTheme) access.WeekPlan {
+	plan.ThemeIDs, _ = weekRefs(plan.ThemeIDs, func(id string) bool { return themeExists(themes, id) })
+	plan.ObjectiveIDs, _ = weekRefs(plan.ObjectiveIDs, func(id string) bool { return weekObjectiveExists(themes, id) })
+	plan.KeyResultIDs, _ = weekRefs(plan.KeyResultIDs, func(id string) bool { return weekKeyResultExists(themes, id) })
+	return plan
+}
@@ -0,0 +1,199 @@
+package managers
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rkn/bearing/internal/access"
+	"github.com/rkn/bearing/internal/utilities"
+)
+
+// The frozen clock of newRoutineCheckTestManager reads Thursday 2026-03-19,
+// which falls in 2026-W12 (16 to 22 March).
+
+func TestUnit_WeekPlan_SaveAndGet(t *testing.T) {
+	pm, ka, _ := newRoutineCheckTestManager(t)
+	if _, err := testCreateObjective(pm, "T", "Get fit"); err != nil {
+		t.Fatalf("CreateObjective: %v", err)
+	}
+	if _, err := testCreateKeyResult(pm, "T-O1", "Run km", 0, 100); err != nil {
+		t.Fatalf("CreateKeyResult: %v", err)
+	}
+	ka.tasks["todo"] = []access.Task{{ID: "T-T1", ThemeID: "T", Title: "Run"}}
+
+	if got := pm.GetCurrentWeek(); got != "2026-W12" {
+		t.Errorf("GetCurrentWeek = %q, want 2026-W12", got)
+	}
+
+	empty, err := pm.GetWeekPlan("2026-W12")
+	if err != nil {
+		t.Fatalf("GetWeekPlan: %v", err)
+	}
+	if empty.StartDate != "2026-03-16" || empty.EndDate != "2026-03-22" || len(empty.Intentions) != 0 {
+		t.Errorf("empty plan = %+v, want 16 to 22 March with nothing planned", empty)
+	}
+
+	saved, err := pm.SaveWeekPlan(WeekPlan{
+		Week:             "2026-W12",
+		Intentions:       []string{" Rest well ", "", "Ship the draft"},
+		ThemeIDs:         []string{"T", " T "},
+		ObjectiveIDs:     []string{"T-O1"},
+		KeyResultIDs:     []string{"T-KR1"},
+		CommittedTaskIDs: []string{"T-T1"},
+	})
+	if err != nil {
+		t.Fatalf("SaveWeekPlan: %v", err)
+	}
+	if !slices.Equal(saved.Intentions, []string{"Rest well", "Ship the draft"}) || !slices.Equal(saved.ThemeIDs, []string{"T"}) || saved.UpdatedAt == "" {
+		t.Errorf("saved = %+v, want trimmed intentions and one theme", saved)
+	}
+
+	tests := []struct {
+		name    string
+		plan    WeekPlan
+		wantErr string
+	}{
+		{"bad week", WeekPlan{Week: "2026-W54"}, "week"},
+		{"unknown theme", WeekPlan{Week: "2026-W12", ThemeIDs: []string{"X"}}, "theme X not found"},
+		{"key result as objective", WeekPlan{Week: "2026-W12", ObjectiveIDs: []string{"T-KR1"}}, "objective T-KR1 not found"},
+		{"unknown task", WeekPlan{Week: "2026-W12", CommittedTaskIDs: []string{"T-T9"}}, "task T-T9 not found"},
+		{"long intention", WeekPlan{Week: "2026-W12", Intentions: []string{strings.Repeat("x", maxWeekIntentionLength+1)}}, "cannot exceed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pm.SaveWeekPlan(tt.plan); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SaveWeekPlan = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A committed task deleted since may stay in the plan.
+	ka.tasks["todo"] = nil
+	if _, err := pm.SaveWeekPlan(WeekPlan{Week: "2026-W12", CommittedTaskIDs: []string{"T-T1"}}); err != nil {
+		t.Errorf("SaveWeekPlan with deleted task: %v", err)
+	}
+
+	plans, err := pm.GetWeekPlans()
+	if err != nil || len(plans) != 1 || plans[0].Week != "2026-W12" {
+		t.Errorf("GetWeekPlans = %+v, %v, want the one week", plans, err)
+	}
+	if err := pm.DeleteWeekPlan("2026-W12"); err != nil {
+		t.Fatalf("DeleteWeekPlan: %v", err)
+	}
+	if plans, _ := pm.GetWeekPlans(); len(plans) != 0 {
+		t.Errorf("GetWeekPlans after delete = %+v, want none", plans)
+	}
+}
+
+func TestUnit_WeekPlan_DropsDanglingRefs(t *testing.T) {
+	pm, _, _ := newRoutineCheckTestManager(t)
+	pm.weekAccess.(*mockWeekAccess).weeks["2026-W12"] = access.WeekPlan{
+		Week:         "2026-W12",
+		ThemeIDs:     []string{"T", "GONE"},
+		ObjectiveIDs: []string{"GONE-O1"},
+		KeyResultIDs: []string{"GONE-KR1"},
+	}
+
+	plan, err := pm.GetWeekPlan("2026-W12")
+	if err != nil {
+		t.Fatalf("GetWeekPlan: %v", err)
+	}
+	if !slices.Equal(plan.ThemeIDs, []string{"T"}) || len(plan.ObjectiveIDs) != 0 || len(plan.KeyResultIDs) != 0 {
+		t.Errorf("plan = %+v, want only theme T", plan)
+	}
+}
+
+func TestUnit_ReviewWeek(t *testing.T) {
+	pm, ka, ca := newRoutineCheckTestManager(t)
+	daily, err := pm.Establish(EstablishRequest{GoalType: GoalTypeRoutine, Description: "Stretch", RepeatPattern: dailyPattern()})
+	if err != nil {
+		t.Fatalf("Establish daily: %v", err)
+	}
+	floating, err := pm.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Call home",
+		RepeatPattern: &RepeatPattern{Frequency: "daily", Interval: 3, StartDate: utilities.MustParseCalendarDate("2026-03-01"), AfterCompletion: true},
+	})
+	if err != nil {
+		t.Fatalf("Establish floating: %v", err)
+	}
+	alternate, err := pm.Establish(EstablishRequest{
+		GoalType:      GoalTypeRoutine,
+		Description:   "Swim",
+		RepeatPattern: &RepeatPattern{Frequency: "weekly", Interval: 1, Weekdays: []int{1, 3, 5}, StartDate: utilities.MustParseCalendarDate("2026-03-02")},
+	})
+	if err != nil {
+		t.Fatalf("Establish alternate: %v", err)
+	}
+	ca.days["2026-03-12"] = access.DayFocus{Date: "2026-03-12", RoutineChecks: []string{floating.Routine.ID}}
+	ca.days["2026-03-16"] = access.DayFocus{Date: "2026-03-16", RoutineChecks: []string{daily.Routine.ID}}
+	// Swim is checked a day late for Monday and Wednesday.
+	ca.days["2026-03-17"] = access.DayFocus{Date: "2026-03-17", RoutineChecks: []string{alternate.Routine.ID}}
+	ca.days["2026-03-19"] = access.DayFocus{Date: "2026-03-19", RoutineChecks: []string{alternate.Routine.ID}}
+
+	ka.tasks["todo"] = []access.Task{{ID: "T-T1", ThemeID: "T", Title: "Write"}}
+	ka.tasks["done"] = []access.Task{{ID: "T-T2", ThemeID: "T", Title: "Read"}}
+	ka.tasks["archived"] = []access.Task{{ID: "T-T3", ThemeID: "T", Title: "Plan"}}
+	if _, err := pm.SaveWeekPlan(WeekPlan{Week: "2026-W12", CommittedTaskIDs: []string{"T-T1", "T-T2", "T-T3"}}); err != nil {
+		t.Fatalf("SaveWeekPlan: %v", err)
+	}
+	ka.tasks["todo"] = nil
+
+	review, err := pm.ReviewWeek("2026-W12")
+	if err != nil {
+		t.Fatalf("ReviewWeek: %v", err)
+	}
+	if review.ReviewedThrough != "2026-03-18" {
+		t.Errorf("ReviewedThrough = %q, want yesterday", review.ReviewedThrough)
+	}
+	var completed []string
+	for _, task := range review.CompletedTasks {
+		completed = append(completed, task.ID)
+	}
+	if !slices.Equal(completed, []string{"T-T2", "T-T3"}) || len(review.OpenTasks) != 0 || !slices.Equal(review.MissingTaskIDs, []string{"T-T1"}) {
+		t.Errorf("tasks = completed %v, open %+v, missing %v, want T-T2 and T-T3 done and T-T1 missing", completed, review.OpenTasks, review.MissingTaskIDs)
+	}
+
+	// Stretch was checked on Monday only; Call home fell due on the 15th;
+	// Swim's late checks meet its occurrences.
+	if len(review.MissedRoutines) != 2 {
+		t.Fatalf("MissedRoutines = %+v, want two", review.MissedRoutines)
+	}
+	if got := review.MissedRoutines[0]; got.RoutineID != daily.Routine.ID || !slices.Equal(got.Dates, []string{"2026-03-17", "2026-03-18"}) {
+		t.Errorf("daily missed = %+v, want 17 and 18 March", got)
+	}
+	if got := review.MissedRoutines[1]; got.RoutineID != floating.Routine.ID || !slices.Equal(got.Dates, []string{"2026-03-15"}) {
+		t.Errorf("floating missed = %+v, want 15 March", got)
+	}
+
+	// A week not yet started reports no routines.
+	future, err := pm.ReviewWeek("2026-W13")
+	if err != nil {
+		t.Fatalf("ReviewWeek future: %v", err)
+	}
+	if future.ReviewedThrough != "" || len(future.MissedRoutines) != 0 {
+		t.Errorf("future review = %+v, want nothing reviewed", future)
+	}
+}
+
+func TestUnit_ChangeThemeID_RemapsWeekPlans(t *testing.T) {
+	pm, _, _, _, _, _ := newThemeIDTestManager(t)
+	weeks := pm.weekAccess.(*mockWeekAccess).weeks
+	weeks["2026-W12"] = access.WeekPlan{
+		Week:             "2026-W12",
+		ThemeIDs:         []string{"T"},
+		ObjectiveIDs:     []string{"T-O1"},
+		KeyResultIDs:     []string{"T-KR1"},
+		CommittedTaskIDs: []string{"T-T1"},
+	}
+
+	if _, err := pm.ChangeThemeID("T", "FIT"); err != nil {
+		t.Fatalf("ChangeThemeID: %v", err)
+	}
+	got := weeks["2026-W12"]
+	if !slices.Equal(got.ThemeIDs, []string{"FIT"}) || !slices.Equal(got.ObjectiveIDs, []string{"FIT-O1"}) ||
+		!slices.Equal(got.KeyResultIDs, []string{"FIT-KR1"}) || !slices.Equal(got.CommittedTaskIDs, []string{"FIT-T1"}) {
+		t.Errorf("week = %+v, want every ID rekeyed", got)
+	}
+}
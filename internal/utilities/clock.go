@@ -0,0 +1,118 @@
+package utilities
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaxDayStartHour is the latest hour a day may be configured to start at.
+// Later boundaries would make "today" lag behind the calendar for most of
+// the waking day.
+const MaxDayStartHour = 12
+
+// Clock supplies the current time to managers. Unlike the package-level
+// Now and Today it is configured with the user's timezone and day boundary,
+// and tests can freeze it.
+type Clock interface {
+	// Now returns the current instant for persisted timestamps (UTC).
+	Now() Timestamp
+	// Today returns the current logical date: the calendar date in the
+	// configured timezone, where times before the day-start hour still
+	// belong to the previous day.
+	Today() CalendarDate
+}
+
+// zonedClock implements Clock on top of an arbitrary time source.
+type zonedClock struct {
+	location *time.Location
+	dayStart time.Duration
+	now      func() time.Time
+}
+
+func (c *zonedClock) Now() Timestamp {
+	return NewTimestamp(c.now().UTC())
+}
+
+func (c *zonedClock) Today() CalendarDate {
+	return LogicalDate(c.now(), c.location, c.dayStart)
+}
+
+// LogicalDate returns the date t falls on in location when days start
+// dayStart after midnight.
+func LogicalDate(t time.Time, location *time.Location, dayStart time.Duration) CalendarDate {
+	return NewCalendarDate(t.In(location).Add(-dayStart))
+}
+
+// validateClockConfig checks the shared clock configuration.
+func validateClockConfig(location *time.Location, dayStartHour int) error {
+	if location == nil {
+		return fmt.Errorf("location cannot be nil")
+	}
+	if dayStartHour < 0 || dayStartHour > MaxDayStartHour {
+		return fmt.Errorf("day start hour must be between 0 and %d, got %d", MaxDayStartHour, dayStartHour)
+	}
+	return nil
+}
+
+// NewSystemClock returns a Clock backed by the wall clock, reporting dates in
+// location with days starting at dayStartHour:00.
+func NewSystemClock(location *time.Location, dayStartHour int) (Clock, error) {
+	if err := validateClockConfig(location, dayStartHour); err != nil {
+		return nil, fmt.Errorf("NewSystemClock: %w", err)
+	}
+	return &zonedClock{
+		location: location,
+		dayStart: time.Duration(dayStartHour) * time.Hour,
+		now:      time.Now,
+	}, nil
+}
+
+// DefaultClock returns a wall clock in the process-local timezone with days
+// starting at midnight; it matches the package-level Now and Today.
+func DefaultClock() Clock {
+	return &zonedClock{location: time.Local, now: time.Now}
+}
+
+// FrozenClock is a Clock that only moves when told to. Intended for tests.
+type FrozenClock struct {
+	zonedClock
+	mu sync.Mutex
+	t  time.Time
+}
+
+// NewFrozenClock returns a FrozenClock stopped at t, reporting dates in
+// location with days starting at dayStartHour:00. It panics on an invalid
+// configuration, like the Must* helpers.
+func NewFrozenClock(t time.Time, location *time.Location, dayStartHour int) *FrozenClock {
+	if err := validateClockConfig(location, dayStartHour); err != nil {
+		panic(fmt.Sprintf("NewFrozenClock: %v", err))
+	}
+	c := &FrozenClock{t: t}
+	c.zonedClock = zonedClock{
+		location: location,
+		dayStart: time.Duration(dayStartHour) * time.Hour,
+		now:      c.current,
+	}
+	return c
+}
+
+func (c *FrozenClock) current() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Set moves the clock to t.
+func (c *FrozenClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
+
+// Advance moves the clock forward by d.
+func (c *FrozenClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
@@ -0,0 +1,53 @@
+package utilities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnit_FrozenClock_DayBoundary(t *testing.T) {
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	// 2026-03-02 02:30 in Zurich (UTC+1).
+	clock := NewFrozenClock(time.Date(2026, 3, 2, 1, 30, 0, 0, time.UTC), zurich, 4)
+
+	if got := clock.Today(); got != "2026-03-01" {
+		t.Errorf("Today before 04:00 = %s, want 2026-03-01", got)
+	}
+	clock.Advance(2 * time.Hour) // 04:30 local
+	if got := clock.Today(); got != "2026-03-02" {
+		t.Errorf("Today after 04:00 = %s, want 2026-03-02", got)
+	}
+	if got := clock.Now().String(); got != "2026-03-02T03:30:00Z" {
+		t.Errorf("Now = %s, want UTC instant", got)
+	}
+}
+
+func TestUnit_FrozenClock_TimezoneDecidesDate(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	instant := time.Date(2026, 6, 30, 20, 0, 0, 0, time.UTC)
+
+	if got := NewFrozenClock(instant, time.UTC, 0).Today(); got != "2026-06-30" {
+		t.Errorf("UTC Today = %s, want 2026-06-30", got)
+	}
+	if got := NewFrozenClock(instant, tokyo, 0).Today(); got != "2026-07-01" {
+		t.Errorf("Tokyo Today = %s, want 2026-07-01", got)
+	}
+}
+
+func TestUnit_NewSystemClock_RejectsInvalidConfig(t *testing.T) {
+	if _, err := NewSystemClock(nil, 0); err == nil {
+		t.Error("expected error for nil location")
+	}
+	if _, err := NewSystemClock(time.UTC, MaxDayStartHour+1); err == nil {
+		t.Error("expected error for day start hour beyond the maximum")
+	}
+	if _, err := NewSystemClock(time.UTC, -1); err == nil {
+		t.Error("expected error for negative day start hour")
+	}
+}
@@ -46,9 +46,9 @@ func MustParseCalendarDate(s string) CalendarDate {
 	return d
 }
 
-// Today returns the current date in the user's local timezone.
-// This is correct for a local-first desktop app: the user's wall-clock
-// date is what matters.
+// Today returns the current date in the process-local timezone with days
+// starting at midnight. Managers use a Clock instead, which honours the
+// configured timezone and day boundary.
 func Today() CalendarDate {
 	return NewCalendarDate(time.Now())
 }
@@ -73,6 +73,37 @@ func (d CalendarDate) IsZero() bool {
 	return d == ""
 }
 
+// ISOWeek returns the ISO 8601 week containing d as "YYYY-Www", for
+// example "2026-W01" for 2025-12-29. Returns "" for the zero value.
+func (d CalendarDate) ISOWeek() string {
+	if d == "" {
+		return ""
+	}
+	year, week := d.Time().ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// ParseISOWeek parses a "YYYY-Www" ISO 8601 week and returns its Monday
+// and Sunday. It rejects weeks the year does not have, such as W53 of a
+// 52-week year.
+func ParseISOWeek(s string) (monday, sunday CalendarDate, err error) {
+	var year, week int
+	if len(s) != 8 || s[4:6] != "-W" {
+		return "", "", fmt.Errorf("invalid ISO week %q: want YYYY-Www", s)
+	}
+	if _, err := fmt.Sscanf(s, "%4d-W%2d", &year, &week); err != nil || week < 1 {
+		return "", "", fmt.Errorf("invalid ISO week %q: want YYYY-Www", s)
+	}
+	// January 4th always falls in week 1.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	start := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7+(week-1)*7)
+	monday = NewCalendarDate(start)
+	if monday.ISOWeek() != s {
+		return "", "", fmt.Errorf("invalid ISO week %q: week does not exist", s)
+	}
+	return monday, NewCalendarDate(start.AddDate(0, 0, 6)), nil
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 // Accepts null, "", and valid "YYYY-MM-DD" strings.
 func (d *CalendarDate) UnmarshalJSON(b []byte) error {
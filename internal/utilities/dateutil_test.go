@@ -383,6 +383,53 @@ func TestUnit_CalendarDate_MustParsePanics(t *testing.T) {
 	MustParseCalendarDate("invalid")
 }
 
+// ---------------------------------------------------------------------------
+// CalendarDate — ISO weeks
+// ---------------------------------------------------------------------------
+
+func TestUnit_CalendarDate_ISOWeek(t *testing.T) {
+	tests := map[string]string{
+		"2026-03-19": "2026-W12",
+		"2025-12-29": "2026-W01", // Monday of the next year's first week
+		"2027-01-01": "2026-W53",
+		"2021-01-03": "2020-W53",
+	}
+	for date, want := range tests {
+		if got := MustParseCalendarDate(date).ISOWeek(); got != want {
+			t.Errorf("%s.ISOWeek() = %q, want %q", date, got, want)
+		}
+	}
+	if got := CalendarDate("").ISOWeek(); got != "" {
+		t.Errorf("zero ISOWeek() = %q, want empty", got)
+	}
+}
+
+func TestUnit_ParseISOWeek(t *testing.T) {
+	tests := []struct {
+		week, monday, sunday string
+	}{
+		{"2026-W12", "2026-03-16", "2026-03-22"},
+		{"2026-W01", "2025-12-29", "2026-01-04"},
+		{"2026-W53", "2026-12-28", "2027-01-03"},
+	}
+	for _, tt := range tests {
+		monday, sunday, err := ParseISOWeek(tt.week)
+		if err != nil {
+			t.Errorf("ParseISOWeek(%q): %v", tt.week, err)
+			continue
+		}
+		if monday.String() != tt.monday || sunday.String() != tt.sunday {
+			t.Errorf("ParseISOWeek(%q) = %s..%s, want %s..%s", tt.week, monday, sunday, tt.monday, tt.sunday)
+		}
+	}
+
+	for _, week := range []string{"", "2026-12", "2026-W00", "2025-W53", "2026-W1", "2026W12x", "2026-W+1"} {
+		if _, _, err := ParseISOWeek(week); err == nil {
+			t.Errorf("ParseISOWeek(%q): expected error", week)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Timestamp — Construction
 // ---------------------------------------------------------------------------
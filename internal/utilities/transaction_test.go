@@ -194,10 +194,12 @@ func (s *stubRepo) GetFileHistory(_ string, _ int) ([]CommitInfo, error) {
 }
 func (s *stubRepo) GetFileHistoryStream(_ string) <-chan CommitInfo { panic("unused") }
 func (s *stubRepo) GetFileDifferences(_, _ string) ([]byte, error)  { panic("unused") }
+func (s *stubRepo) GetFileAtCommit(_, _ string) ([]byte, error)     { panic("unused") }
 func (s *stubRepo) ValidateRepositoryAndPaths(_ RepositoryValidationRequest) (*RepositoryValidationResult, error) {
 	panic("unused")
 }
-func (s *stubRepo) Close() error { return nil }
+func (s *stubRepo) RestoreWorkTree() error { panic("unused") }
+func (s *stubRepo) Close() error           { return nil }
 
 // TestUnit_RunTransaction_CommitError_Surfaced verifies that an error from
 // the underlying Commit call is wrapped and returned to the caller.
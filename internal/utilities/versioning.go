@@ -145,6 +145,32 @@ func InitializeRepositoryWithConfig(path string, gitConfig *AuthorConfiguration)
 	return repo, nil
 }
 
+// ErrNoRepository is returned by OpenRepository when path holds no git
+// repository.
+var ErrNoRepository = errors.New("no git repository found")
+
+// OpenRepository opens the existing repository at path without creating a
+// directory or repository and without author configuration, so the handle
+// can read history but every commit through it fails.
+func OpenRepository(path string) (IRepository, error) {
+	gitRepo, err := git.PlainOpen(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, fmt.Errorf("OpenRepository %s: %w", path, ErrNoRepository)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository failed to open %s: %w", path, err)
+	}
+
+	canon, _ := canonicalizePath(path)
+	return &repository{
+		path:          path,
+		canonicalPath: canon,
+		gitRepo:       gitRepo,
+		mutex:         &sync.RWMutex{},
+		logger:        slog.Default(),
+	}, nil
+}
+
 // ValidateRepositoryAndPaths validates a directory as a git repository and optionally checks file/directory existence
 func ValidateRepositoryAndPaths(request RepositoryValidationRequest) (*RepositoryValidationResult, error) {
 	logger := slog.Default()
@@ -292,10 +318,18 @@ type IRepository interface {
 	GetFileHistoryStream(filePath string) <-chan CommitInfo
 
 	GetFileDifferences(hash1, hash2 string) ([]byte, error)
+	// GetFileAtCommit returns the content of filePath (relative to the
+	// repository root) as it was at the given commit.
+	GetFileAtCommit(filePath, hash string) ([]byte, error)
 
 	// Repository validation
 	ValidateRepositoryAndPaths(request RepositoryValidationRequest) (*RepositoryValidationResult, error)
 
+	// RestoreWorkTree discards uncommitted work: the index and tracked files
+	// are reset to HEAD and files that are neither in HEAD nor ignored are
+	// removed.
+	RestoreWorkTree() error
+
 	Close() error
 }
 
@@ -806,6 +840,29 @@ func (r *repository) GetFileDifferences(hash1, hash2 string) ([]byte, error) {
 	return []byte(patch.String()), nil
 }
 
+// GetFileAtCommit returns the content of a file as of a commit
+func (r *repository) GetFileAtCommit(filePath, hash string) ([]byte, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	commit, err := r.gitRepo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("VersioningUtility.Repository.GetFileAtCommit failed to get commit %s: %w", hash, err)
+	}
+
+	file, err := commit.File(filepath.ToSlash(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("VersioningUtility.Repository.GetFileAtCommit failed to get %s at commit %s: %w", filePath, hash, err)
+	}
+
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("VersioningUtility.Repository.GetFileAtCommit failed to read %s at commit %s: %w", filePath, hash, err)
+	}
+
+	return []byte(contents), nil
+}
+
 // ValidateRepositoryAndPaths validates the repository and optionally checks file/directory existence
 func (r *repository) ValidateRepositoryAndPaths(request RepositoryValidationRequest) (*RepositoryValidationResult, error) {
 	// Use the repository's path if no directory path specified
@@ -817,6 +874,72 @@ func (r *repository) ValidateRepositoryAndPaths(request RepositoryValidationRequ
 	return ValidateRepositoryAndPaths(request)
 }
 
+// RestoreWorkTree resets the index to HEAD, rewrites every changed tracked
+// file from HEAD and removes files HEAD does not have. Ignored files are left
+// alone. It refuses to run before the first commit, where every file is
+// untracked and restoring would remove all data.
+func (r *repository) RestoreWorkTree() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	head, err := r.gitRepo.Head()
+	if err != nil {
+		return fmt.Errorf("repository.RestoreWorkTree no commit to restore in %s: %w", r.path, err)
+	}
+	commit, err := r.gitRepo.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("repository.RestoreWorkTree failed to read HEAD in %s: %w", r.path, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return fmt.Errorf("repository.RestoreWorkTree failed to read HEAD tree in %s: %w", r.path, err)
+	}
+
+	workTree, err := r.gitRepo.Worktree()
+	if err != nil {
+		return fmt.Errorf("repository.RestoreWorkTree failed to get worktree for %s: %w", r.path, err)
+	}
+	// Status leaves out ignored files, so they are never touched below.
+	// A hard reset is not used because go-git removes ignored files too.
+	status, err := workTree.Status()
+	if err != nil {
+		return fmt.Errorf("repository.RestoreWorkTree failed to get status for %s: %w", r.path, err)
+	}
+	if err := workTree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.MixedReset}); err != nil {
+		return fmt.Errorf("repository.RestoreWorkTree failed to reset index in %s: %w", r.path, err)
+	}
+
+	for path, fileStatus := range status {
+		if fileStatus.Staging == git.Unmodified && fileStatus.Worktree == git.Unmodified {
+			continue
+		}
+		fullPath := filepath.Join(r.path, filepath.FromSlash(path))
+		file, err := tree.File(path)
+		if errors.Is(err, object.ErrFileNotFound) {
+			if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("repository.RestoreWorkTree failed to remove %s: %w", path, err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("repository.RestoreWorkTree failed to read %s at HEAD: %w", path, err)
+		}
+		contents, err := file.Contents()
+		if err != nil {
+			return fmt.Errorf("repository.RestoreWorkTree failed to read %s at HEAD: %w", path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return fmt.Errorf("repository.RestoreWorkTree failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(fullPath, []byte(contents), 0644); err != nil {
+			return fmt.Errorf("repository.RestoreWorkTree failed to restore %s: %w", path, err)
+		}
+	}
+
+	r.logger.Info("Working tree restored", "path", r.path, "head", head.Hash().String())
+	return nil
+}
+
 // Close releases resources associated with the repository handle
 func (r *repository) Close() error {
 	// go-git repositories don't need explicit closing
@@ -2,6 +2,7 @@
 package utilities
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -80,6 +81,91 @@ func TestUnit_VersioningUtility_ExistingRepository(t *testing.T) {
 	}
 }
 
+// TestVersioningUtility_OpenRepository tests opening without initialising
+func TestUnit_VersioningUtility_OpenRepository(t *testing.T) {
+	tempDir := t.TempDir()
+	repoPath := filepath.Join(tempDir, "open_repo")
+
+	if _, err := OpenRepository(repoPath); !errors.Is(err, ErrNoRepository) {
+		t.Fatalf("Expected ErrNoRepository, got %v", err)
+	}
+	if _, err := os.Stat(repoPath); !os.IsNotExist(err) {
+		t.Error("Expected OpenRepository not to create the directory")
+	}
+
+	repo1, err := InitializeRepositoryWithConfig(repoPath, testAuthorConfig())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	repo1.Close()
+
+	repo2, err := OpenRepository(repoPath)
+	if err != nil {
+		t.Fatalf("Expected successful opening, got error: %v", err)
+	}
+	defer repo2.Close()
+	if err := RunTransaction(repo2, "read-only", func() error { return nil }); err == nil {
+		t.Error("Expected commit through an opened repository to fail")
+	}
+}
+
+// TestVersioningUtility_RestoreWorkTree tests discarding uncommitted work
+func TestUnit_VersioningUtility_RestoreWorkTree(t *testing.T) {
+	tempDir := t.TempDir()
+	repoPath := filepath.Join(tempDir, "restore_repo")
+
+	repo, err := InitializeRepositoryWithConfig(repoPath, testAuthorConfig())
+	if err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+	defer repo.Close()
+
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(repoPath, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", rel, err)
+		}
+	}
+
+	write("tracked.txt", "committed")
+	if err := repo.RestoreWorkTree(); err == nil {
+		t.Error("Expected restore before the first commit to fail")
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "tracked.txt")); err != nil {
+		t.Errorf("Expected failed restore to keep files: %v", err)
+	}
+
+	write(".gitignore", "ignored.txt\n")
+	if err := RunTransaction(repo, "Initial", func() error { return nil }); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	write("tracked.txt", "half-written")
+	write("untracked.txt", "new")
+	write("sub/untracked.txt", "new")
+	write("ignored.txt", "local")
+
+	if err := repo.RestoreWorkTree(); err != nil {
+		t.Fatalf("RestoreWorkTree failed: %v", err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(repoPath, "tracked.txt")); string(data) != "committed" {
+		t.Errorf("Expected tracked.txt to be reset, got %q", data)
+	}
+	for _, rel := range []string{"untracked.txt", "sub/untracked.txt"} {
+		if _, err := os.Stat(filepath.Join(repoPath, rel)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", rel)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "ignored.txt")); err != nil {
+		t.Errorf("Expected ignored.txt to be kept: %v", err)
+	}
+}
+
 // TestVersioningUtility_InitializeRepository_InvalidPath tests invalid path handling
 func TestUnit_VersioningUtility_InvalidPath(t *testing.T) {
 	// Test with read-only parent directory (simulated)
@@ -620,6 +706,57 @@ func TestUnit_VersioningUtility_FileDifferences(t *testing.T) {
 	}
 }
 
+// TestVersioningUtility_FileAtCommit tests reading a file as of an earlier commit
+func TestUnit_VersioningUtility_FileAtCommit(t *testing.T) {
+	tempDir := t.TempDir()
+	repoPath := filepath.Join(tempDir, "file_at_commit_test")
+
+	repo, err := InitializeRepositoryWithConfig(repoPath, testAuthorConfig())
+	if err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+	defer repo.Close()
+
+	if err := os.MkdirAll(filepath.Join(repoPath, "sub"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	relPath := filepath.Join("sub", "file.txt")
+	commitVersion := func(content, message string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoPath, relPath), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+		tx, err := repo.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		if err := tx.Stage([]string{"."}); err != nil {
+			_ = tx.Cancel()
+			t.Fatalf("Failed to stage: %v", err)
+		}
+		hash, err := tx.Commit(message)
+		if err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+		return hash
+	}
+
+	hash1 := commitVersion("first\n", "First version")
+	commitVersion("second\n", "Second version")
+
+	content, err := repo.GetFileAtCommit(relPath, hash1)
+	if err != nil {
+		t.Fatalf("Failed to get file at commit: %v", err)
+	}
+	if string(content) != "first\n" {
+		t.Errorf("Expected first version, got %q", content)
+	}
+
+	if _, err := repo.GetFileAtCommit("missing.txt", hash1); err == nil {
+		t.Error("Expected error for file missing at commit, got nil")
+	}
+}
+
 // TestVersioningUtility_InvalidCommitHash tests error handling for invalid commit hashes
 func TestUnit_VersioningUtility_InvalidCommitHash(t *testing.T) {
 	tempDir := t.TempDir()
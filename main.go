@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"io/fs"
 	"log/slog"
-	"os"
+	"sync"
 
 	"github.com/rkn/bearing/internal/access"
 	"github.com/rkn/bearing/internal/bootstrap"
@@ -26,13 +26,20 @@ var version = "dev"
 //go:embed all:frontend/dist
 var assets embed.FS
 
-// App struct holds the application state
+// App struct holds the application state.
+//
+// mu guards the managers of the open profile, which are replaced as a whole
+// when the user switches profiles. Bindings hold the read lock for the whole
+// manager call, so the old profile is only closed once they have returned.
 type App struct {
 	ctx              context.Context
+	mu               sync.RWMutex
 	planningManager  *managers.PlanningManager
 	workspaceManager *managers.WorkspaceManager
 	adviceManager    *managers.AdviceManager
-	logFile          *os.File
+	settingsManager  *managers.SettingsManager
+	profileManager   *managers.ProfileManager
+	current          *bootstrap.Result
 }
 
 // NewApp creates a new App application struct
@@ -51,21 +58,49 @@ func (a *App) startup(ctx context.Context) {
 		return
 	}
 
-	a.planningManager = result.PlanningManager
-	a.workspaceManager = result.WorkspaceManager
-	a.adviceManager = result.AdviceManager
-	a.logFile = result.LogFile
-	slog.Info("Bearing started", "version", version)
+	a.use(result)
+	slog.Info("Bearing started", "version", version, "profile", result.Profile.ID)
 }
 
 // shutdown is called when the app is closing
 func (a *App) shutdown(ctx context.Context) {
 	slog.Info("Bearing shutting down")
-	if a.logFile != nil {
-		a.logFile.Close()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.current != nil {
+		_ = a.current.Close()
 	}
 }
 
+// use installs the managers of an opened profile and returns the previously
+// installed result, if any.
+func (a *App) use(result *bootstrap.Result) *bootstrap.Result {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	previous := a.current
+	a.current = result
+	a.planningManager = result.PlanningManager
+	a.workspaceManager = result.WorkspaceManager
+	a.adviceManager = result.AdviceManager
+	a.settingsManager = result.SettingsManager
+	a.profileManager = result.ProfileManager
+	return previous
+}
+
+// hold read-locks the managers of the open profile until the returned
+// function is called. Bindings defer it around their manager call so that
+// SwitchProfile cannot close the profile while the call is still running.
+func (a *App) hold() func() {
+	a.mu.RLock()
+	return a.mu.RUnlock
+}
+
+func (a *App) profiles() *managers.ProfileManager {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.profileManager
+}
+
 // LogFrontend receives a log entry from the frontend and writes it via slog
 func (a *App) LogFrontend(level, message, source string) {
 	attrs := []any{"source", source, "origin", "frontend"}
@@ -84,160 +119,359 @@ func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, Welcome to Bearing!", name)
 }
 
-// GetLocale detects the system locale and returns a BCP 47 locale tag.
+// GetLocale returns the locale from settings, falling back to the detected
+// system locale, as a BCP 47 locale tag.
 func (a *App) GetLocale() string {
+	defer a.hold()()
+	if sm := a.settingsManager; sm != nil {
+		if settings, err := sm.GetSettings(); err == nil && settings.Locale != "" {
+			return settings.Locale
+		}
+	}
 	return utilities.DetectLocale()
 }
 
 // --- OKR lifecycle operations ---
 
+func (a *App) SetThemeStatus(themeId, status string) error {
+	defer a.hold()()
+	return a.planningManager.SetThemeStatus(themeId, status)
+}
+
 func (a *App) SetObjectiveStatus(objectiveId, status string) error {
+	defer a.hold()()
 	return a.planningManager.SetObjectiveStatus(objectiveId, status)
 }
 
 func (a *App) SetKeyResultStatus(keyResultId, status string) error {
+	defer a.hold()()
 	return a.planningManager.SetKeyResultStatus(keyResultId, status)
 }
 
 func (a *App) CloseObjective(objectiveId, closingStatus, closingNotes string) error {
+	defer a.hold()()
 	return a.planningManager.CloseObjective(objectiveId, closingStatus, closingNotes)
 }
 
 func (a *App) ReopenObjective(objectiveId string) error {
+	defer a.hold()()
 	return a.planningManager.ReopenObjective(objectiveId)
 }
 
+func (a *App) GetRetrospective(objectiveId string) (*managers.Retrospective, error) {
+	defer a.hold()()
+	return a.planningManager.GetRetrospective(objectiveId)
+}
+
+func (a *App) ExportRetrospectiveMarkdown(objectiveId string) (string, error) {
+	defer a.hold()()
+	return a.planningManager.ExportRetrospectiveMarkdown(objectiveId)
+}
+
+// --- OKR cycle operations ---
+
+func (a *App) GetCycles() ([]managers.Cycle, error) {
+	defer a.hold()()
+	return a.planningManager.GetCycles()
+}
+
+func (a *App) CreateCycle(name, startDate, endDate string) (*managers.Cycle, error) {
+	defer a.hold()()
+	return a.planningManager.CreateCycle(name, startDate, endDate)
+}
+
+func (a *App) UpdateCycle(cycle managers.Cycle) error {
+	defer a.hold()()
+	return a.planningManager.UpdateCycle(cycle)
+}
+
+func (a *App) DeleteCycle(cycleId string) error {
+	defer a.hold()()
+	return a.planningManager.DeleteCycle(cycleId)
+}
+
+func (a *App) GetHierarchyForCycle(cycleId string) ([]managers.LifeTheme, error) {
+	defer a.hold()()
+	return a.planningManager.GetHierarchyForCycle(cycleId)
+}
+
+func (a *App) GetThemeProgressForCycle(cycleId string) ([]managers.ThemeProgress, error) {
+	defer a.hold()()
+	return a.planningManager.GetThemeProgressForCycle(cycleId)
+}
+
+func (a *App) RolloverCycle(req managers.RolloverRequest) (*managers.RolloverResult, error) {
+	defer a.hold()()
+	return a.planningManager.RolloverCycle(req)
+}
+
 // --- Behavioral goal operations ---
 
 func (a *App) GetHierarchy() ([]managers.LifeTheme, error) {
+	defer a.hold()()
 	return a.planningManager.GetHierarchy()
 }
 
+func (a *App) GetArchivedThemes() ([]managers.LifeTheme, error) {
+	defer a.hold()()
+	return a.planningManager.GetArchivedThemes()
+}
+
 func (a *App) Establish(req managers.EstablishRequest) (*managers.EstablishResult, error) {
+	defer a.hold()()
 	return a.planningManager.Establish(req)
 }
 
 func (a *App) Revise(req managers.ReviseRequest) error {
+	defer a.hold()()
 	return a.planningManager.Revise(req)
 }
 
-func (a *App) RecordProgress(goalId string, value int) error {
+func (a *App) RecordProgress(goalId string, value float64) error {
+	defer a.hold()()
 	return a.planningManager.RecordProgress(goalId, value)
 }
 
 func (a *App) Dismiss(goalId string) error {
+	defer a.hold()()
 	return a.planningManager.Dismiss(goalId)
 }
 
+func (a *App) Reparent(goalId, newParentId string) (*managers.ReparentResult, error) {
+	defer a.hold()()
+	return a.planningManager.Reparent(goalId, newParentId)
+}
+
+func (a *App) ChangeThemeID(themeId, newThemeId string) (*managers.ThemeIDChangeResult, error) {
+	defer a.hold()()
+	return a.planningManager.ChangeThemeID(themeId, newThemeId)
+}
+
+// --- Key result check-in operations ---
+
+func (a *App) ListCheckIns(keyResultId string) ([]managers.CheckIn, error) {
+	defer a.hold()()
+	return a.planningManager.ListCheckIns(keyResultId)
+}
+
+func (a *App) RecordCheckIn(keyResultId string, value float64, note string, confidence int) (*managers.CheckIn, error) {
+	defer a.hold()()
+	return a.planningManager.RecordCheckIn(keyResultId, value, note, confidence)
+}
+
+func (a *App) UpdateCheckIn(keyResultId string, checkIn managers.CheckIn) error {
+	defer a.hold()()
+	return a.planningManager.UpdateCheckIn(keyResultId, checkIn)
+}
+
+func (a *App) DeleteCheckIn(keyResultId, checkInId string) error {
+	defer a.hold()()
+	return a.planningManager.DeleteCheckIn(keyResultId, checkInId)
+}
+
+func (a *App) SetMilestoneDone(keyResultId, milestoneId string, done bool) (*managers.Milestone, error) {
+	defer a.hold()()
+	return a.planningManager.SetMilestoneDone(keyResultId, milestoneId, done)
+}
+
 func (a *App) GetRoutineProgress(routineId string) (*managers.RoutinePeriodProgress, error) {
+	defer a.hold()()
 	return a.planningManager.GetRoutineProgress(routineId)
 }
 
 func (a *App) SuggestAbbreviation(name string) (string, error) {
+	defer a.hold()()
 	return a.planningManager.SuggestAbbreviation(name)
 }
 
 // --- Calendar operations ---
 
+// GetToday returns the current logical date (YYYY-MM-DD).
+func (a *App) GetToday() string {
+	defer a.hold()()
+	return a.planningManager.GetToday()
+}
+
 func (a *App) GetYearFocus(year int) ([]managers.DayFocus, error) {
+	defer a.hold()()
 	return a.planningManager.GetYearFocus(year)
 }
 
 func (a *App) SaveDayFocus(day managers.DayFocus) error {
+	defer a.hold()()
 	return a.planningManager.SaveDayFocus(day)
 }
 
 func (a *App) RecordRoutineCompletions(day managers.DayFocus, previousChecks []string) error {
+	defer a.hold()()
 	return a.planningManager.RecordRoutineCompletions(day, previousChecks)
 }
 
 func (a *App) ClearDayFocus(date string) error {
+	defer a.hold()()
 	return a.planningManager.ClearDayFocus(date)
 }
 
 func (a *App) GetRoutines() ([]managers.Routine, error) {
+	defer a.hold()()
 	return a.planningManager.GetRoutines()
 }
 
 func (a *App) GetRoutinesForDate(date string) ([]managers.RoutineOccurrence, error) {
+	defer a.hold()()
 	return a.planningManager.GetRoutinesForDate(date)
 }
 
 func (a *App) RescheduleRoutineOccurrence(routineID, originalDate, newDate string) error {
+	defer a.hold()()
 	return a.planningManager.RescheduleRoutineOccurrence(routineID, originalDate, newDate)
 }
 
+func (a *App) ExportRoutineRRule(routineId string) (string, error) {
+	defer a.hold()()
+	return a.planningManager.ExportRoutineRRule(routineId)
+}
+
+func (a *App) SkipRoutineOccurrence(routineID, date string) error {
+	defer a.hold()()
+	return a.planningManager.SkipRoutineOccurrence(routineID, date)
+}
+
+func (a *App) PauseRoutine(routineID, from, to string) error {
+	defer a.hold()()
+	return a.planningManager.PauseRoutine(routineID, from, to)
+}
+
+func (a *App) GetRoutineStats(routineID string, year int) (*managers.RoutineStats, error) {
+	defer a.hold()()
+	return a.planningManager.GetRoutineStats(routineID, year)
+}
+
+func (a *App) MaterializeDueRoutines() ([]managers.Task, error) {
+	defer a.hold()()
+	return a.planningManager.MaterializeDueRoutines()
+}
+
+// --- Weekly planning operations ---
+
+func (a *App) GetCurrentWeek() string {
+	defer a.hold()()
+	return a.planningManager.GetCurrentWeek()
+}
+
+func (a *App) GetWeekPlan(week string) (*managers.WeekPlan, error) {
+	defer a.hold()()
+	return a.planningManager.GetWeekPlan(week)
+}
+
+func (a *App) GetWeekPlans() ([]managers.WeekPlan, error) {
+	defer a.hold()()
+	return a.planningManager.GetWeekPlans()
+}
+
+func (a *App) SaveWeekPlan(plan managers.WeekPlan) (*managers.WeekPlan, error) {
+	defer a.hold()()
+	return a.planningManager.SaveWeekPlan(plan)
+}
+
+func (a *App) DeleteWeekPlan(week string) error {
+	defer a.hold()()
+	return a.planningManager.DeleteWeekPlan(week)
+}
+
+func (a *App) ReviewWeek(week string) (*managers.WeekReview, error) {
+	defer a.hold()()
+	return a.planningManager.ReviewWeek(week)
+}
+
 // --- Task operations ---
 
 func (a *App) GetTasks() ([]managers.TaskWithStatus, error) {
+	defer a.hold()()
 	return a.planningManager.GetTasks()
 }
 
 func (a *App) CreateTask(title, themeId, priority, description, tags, promotionDate string) (*managers.Task, error) {
+	defer a.hold()()
 	return a.planningManager.CreateTask(title, themeId, priority, description, tags, promotionDate)
 }
 
 func (a *App) UpdateTask(task managers.Task) error {
+	defer a.hold()()
 	return a.planningManager.UpdateTask(task)
 }
 
 func (a *App) MoveTask(taskId, newStatus, newPriority string, positions map[string][]string) (*managers.MoveTaskResult, error) {
+	defer a.hold()()
 	return a.planningManager.MoveTask(taskId, newStatus, newPriority, positions)
 }
 
 func (a *App) DeleteTask(taskId string) error {
+	defer a.hold()()
 	return a.planningManager.DeleteTask(taskId)
 }
 
 func (a *App) ArchiveTask(taskId string) error {
+	defer a.hold()()
 	return a.planningManager.ArchiveTask(taskId)
 }
 
 func (a *App) ArchiveAllDoneTasks() error {
+	defer a.hold()()
 	return a.planningManager.ArchiveAllDoneTasks()
 }
 
 func (a *App) RestoreTask(taskId string) error {
+	defer a.hold()()
 	return a.planningManager.RestoreTask(taskId)
 }
 
 func (a *App) ReorderTasks(positions map[string][]string) (*managers.ReorderResult, error) {
+	defer a.hold()()
 	return a.planningManager.ReorderTasks(positions)
 }
 
 func (a *App) ProcessPriorityPromotions() ([]managers.PromotedTask, error) {
+	defer a.hold()()
 	return a.planningManager.ProcessPriorityPromotions()
 }
 
 // --- Board configuration operations ---
 
 func (a *App) GetBoardConfiguration() (*managers.BoardConfiguration, error) {
+	defer a.hold()()
 	return a.workspaceManager.GetBoardConfiguration()
 }
 
 func (a *App) AddColumn(title, insertAfterSlug string) (*managers.BoardConfiguration, error) {
+	defer a.hold()()
 	return a.workspaceManager.AddColumn(title, insertAfterSlug)
 }
 
 func (a *App) RemoveColumn(slug string) (*managers.BoardConfiguration, error) {
+	defer a.hold()()
 	return a.workspaceManager.RemoveColumn(slug)
 }
 
 func (a *App) RenameColumn(oldSlug, newTitle string) (*managers.BoardConfiguration, error) {
+	defer a.hold()()
 	return a.workspaceManager.RenameColumn(oldSlug, newTitle)
 }
 
 func (a *App) ReorderColumns(slugs []string) (*managers.BoardConfiguration, error) {
+	defer a.hold()()
 	return a.workspaceManager.ReorderColumns(slugs)
 }
 
 // --- Navigation context operations ---
 
 func (a *App) LoadNavigationContext() (*managers.NavigationContext, error) {
+	defer a.hold()()
 	return a.planningManager.LoadNavigationContext()
 }
 
 func (a *App) SaveNavigationContext(ctx managers.NavigationContext) error {
+	defer a.hold()()
 	return a.planningManager.SaveNavigationContext(ctx)
 }
 
@@ -245,6 +479,7 @@ func (a *App) SaveNavigationContext(ctx managers.NavigationContext) error {
 
 // LoadTaskDrafts retrieves saved task drafts as a JSON string.
 func (a *App) LoadTaskDrafts() string {
+	defer a.hold()()
 	data, err := a.planningManager.LoadTaskDrafts()
 	if err != nil {
 		slog.Error("LoadTaskDrafts failed", "error", err)
@@ -258,30 +493,131 @@ func (a *App) LoadTaskDrafts() string {
 
 // SaveTaskDrafts persists task drafts from a JSON string.
 func (a *App) SaveTaskDrafts(data string) error {
+	defer a.hold()()
 	return a.planningManager.SaveTaskDrafts(json.RawMessage(data))
 }
 
 // --- Progress operations ---
 
 func (a *App) GetAllThemeProgress() ([]managers.ThemeProgress, error) {
+	defer a.hold()()
 	return a.planningManager.GetAllThemeProgress()
 }
 
+func (a *App) GetProgressForecast() ([]managers.ThemeForecast, error) {
+	defer a.hold()()
+	return a.planningManager.GetProgressForecast()
+}
+
+// --- Data integrity operations ---
+
+// CheckDataIntegrity reports drifted references without changing anything.
+func (a *App) CheckDataIntegrity() (*managers.IntegrityReport, error) {
+	defer a.hold()()
+	return a.planningManager.CheckIntegrity(false)
+}
+
+// RepairDataIntegrity fixes what can be fixed safely in a single commit and
+// reports the remaining issues for manual action.
+func (a *App) RepairDataIntegrity() (*managers.IntegrityReport, error) {
+	defer a.hold()()
+	return a.planningManager.CheckIntegrity(true)
+}
+
 // --- Personal vision operations ---
 
 func (a *App) GetPersonalVision() (*managers.PersonalVision, error) {
+	defer a.hold()()
 	return a.planningManager.GetPersonalVision()
 }
 
 func (a *App) SavePersonalVision(mission, vision string) error {
+	defer a.hold()()
 	return a.planningManager.SavePersonalVision(mission, vision)
 }
 
+// --- Settings operations ---
+
+func (a *App) GetSettings() (*managers.Settings, error) {
+	defer a.hold()()
+	return a.settingsManager.GetSettings()
+}
+
+// UpdateSettings validates and stores the settings. The git author, clock and
+// model settings take effect the next time a profile is opened.
+func (a *App) UpdateSettings(settings managers.Settings) (*managers.Settings, error) {
+	defer a.hold()()
+	return a.settingsManager.UpdateSettings(settings)
+}
+
+// --- Profile operations ---
+
+func (a *App) ListProfiles() ([]managers.Profile, error) {
+	return a.profiles().ListProfiles()
+}
+
+// GetActiveProfile returns the profile whose data is currently open.
+func (a *App) GetActiveProfile() (*managers.Profile, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.current == nil || a.current.Profile == nil {
+		return nil, fmt.Errorf("no profile is open")
+	}
+	p := *a.current.Profile
+	return &p, nil
+}
+
+// CreateProfile registers a new profile with its own data directory. The
+// directory and repository are created when the profile is first opened.
+func (a *App) CreateProfile(name string) (*managers.Profile, error) {
+	return a.profiles().CreateProfile(name, "")
+}
+
+func (a *App) RenameProfile(profileId, name string) error {
+	if err := a.profiles().RenameProfile(profileId, name); err != nil {
+		return err
+	}
+	renamed, err := a.profiles().GetProfile(profileId)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.current != nil && a.current.Profile != nil && a.current.Profile.ID == profileId {
+		a.current.Profile.Name = renamed.Name
+	}
+	return nil
+}
+
+func (a *App) RemoveProfile(profileId string) error {
+	return a.profiles().RemoveProfile(profileId)
+}
+
+// SwitchProfile opens another profile and replaces all managers with ones
+// bound to its data directory. The previous profile stays open until the new
+// one initialised successfully, so a failed switch leaves the app usable,
+// and is closed only after calls already running against it have returned.
+func (a *App) SwitchProfile(profileId string) (*managers.Profile, error) {
+	result, err := bootstrap.OpenProfile(a.profiles(), profileId)
+	if err != nil {
+		slog.Error("SwitchProfile failed", "profile", profileId, "error", err)
+		return nil, err
+	}
+	if previous := a.use(result); previous != nil {
+		if err := previous.Close(); err != nil {
+			slog.Warn("SwitchProfile: failed to close previous profile", "error", err)
+		}
+	}
+	p := *result.Profile
+	return &p, nil
+}
+
 // --- Advisor operations ---
 
 // RequestAdvice sends a user message to the AI advisor with conversation
 // history and optional OKR selection.
 func (a *App) RequestAdvice(message string, historyJSON string, selectedOKRIds []string) (*chat_engine.AdviceResponse, error) {
+	defer a.hold()()
 	var history []chat_engine.ChatMessage
 	if historyJSON != "" {
 		if err := json.Unmarshal([]byte(historyJSON), &history); err != nil {
@@ -295,21 +631,25 @@ func (a *App) RequestAdvice(message string, historyJSON string, selectedOKRIds [
 
 // GetAvailableModels returns the list of available AI model providers.
 func (a *App) GetAvailableModels() []access.ModelInfo {
+	defer a.hold()()
 	return a.adviceManager.GetAvailableModels()
 }
 
 // GetAdviceSetting returns whether the advisor feature is enabled.
 func (a *App) GetAdviceSetting() (bool, error) {
+	defer a.hold()()
 	return a.adviceManager.GetEnabled()
 }
 
 // SetAdviceSetting enables or disables the advisor feature.
 func (a *App) SetAdviceSetting(enabled bool) error {
+	defer a.hold()()
 	return a.adviceManager.SetEnabled(enabled)
 }
 
 // AcceptSuggestion applies a structured suggestion from the advisor.
 func (a *App) AcceptSuggestion(suggestionJSON string, parentContext string) error {
+	defer a.hold()()
 	var suggestion chat_engine.Suggestion
 	if err := json.Unmarshal([]byte(suggestionJSON), &suggestion); err != nil {
 		slog.Error("AcceptSuggestion: failed to parse suggestion JSON", "error", err)
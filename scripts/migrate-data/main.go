@@ -0,0 +1,59 @@
+// Command migrate-data runs the registered data-directory schema migrations.
+// Bearing applies the same migrations automatically on startup; this command
+// exists to preview them (-dry-run) or to migrate a directory without
+// launching the app.
+//
+// Usage: go run ./scripts/migrate-data [-data-dir DIR] [-dry-run]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rkn/bearing/internal/bootstrap"
+)
+
+func main() {
+	dataDir := flag.String("data-dir", "", "data directory (default $BEARING_DATA_DIR or ~/.bearing)")
+	dryRun := flag.Bool("dry-run", false, "report pending changes without writing or committing")
+	flag.Parse()
+
+	if err := run(*dataDir, *dryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "migration failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dataDir string, dryRun bool) error {
+	if dataDir == "" {
+		dataDir = os.Getenv("BEARING_DATA_DIR")
+	}
+	if dataDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		dataDir = filepath.Join(homeDir, ".bearing")
+	}
+
+	results, err := bootstrap.MigrateDataDir(dataDir, dryRun)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Printf("Data directory is at schema v%d, nothing to migrate.\n", bootstrap.LatestSchemaVersion())
+		return nil
+	}
+	for _, r := range results {
+		fmt.Printf("v%d %s: %d change(s)\n", r.Version, r.Name, len(r.Changes))
+		for _, c := range r.Changes {
+			fmt.Printf("  - %s\n", c)
+		}
+	}
+	if dryRun {
+		fmt.Println("Dry run: no files were written.")
+	}
+	return nil
+}